@@ -1,17 +1,33 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/roster"
+	"github.com/igorilic/fof9editor/internal/state"
 	"github.com/igorilic/fof9editor/internal/ui"
+	"github.com/igorilic/fof9editor/internal/validation"
 	"github.com/igorilic/fof9editor/internal/version"
 
 	"fyne.io/fyne/v2/app"
 )
 
-var showVersion = flag.Bool("version", false, "Show version information")
+var (
+	showVersion  = flag.Bool("version", false, "Show version information")
+	ratingsFile  = flag.String("ratings", "", "Print the OVERALLRATING distribution of a player CSV file as JSON")
+	baselineFile = flag.String("baseline", "default_data/2024_players.csv", "Player CSV file to compare -ratings with")
+	validateFile = flag.String("validate", "", "Validate a .fof9proj project and print a report; exits with 2 if it has errors")
+	reportFormat = flag.String("report", "markdown", "Report format for -validate: json, markdown, html or junit")
+	refsDir      = flag.String("refs", "default_data", "Reference data folder for -validate (cities.csv, colleges.csv, ...)")
+	fixCodes     = flag.String("fix", "", "With -validate, fix the findings with these comma-separated codes, or all errors, and save the project")
+	dryRun       = flag.Bool("dry-run", false, "With -fix, list the changes without applying them")
+)
 
 func main() {
 	flag.Parse()
@@ -21,8 +37,121 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *ratingsFile != "" {
+		if err := writeRatingReport(os.Stdout, *ratingsFile, *baselineFile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if *validateFile != "" {
+		valid, err := runValidation(os.Stdout, os.Stderr, *validateFile, *refsDir, *reportFormat, *fixCodes, *dryRun)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if !valid {
+			os.Exit(2)
+		}
+		os.Exit(0)
+	}
+
 	myApp := app.New()
 	mainWindow := ui.NewMainWindow(myApp)
 
 	mainWindow.ShowAndRun()
 }
+
+// writeRatingReport compares the overall ratings of a player file with a
+// baseline player file and writes the report as indented JSON
+func writeRatingReport(w io.Writer, playersPath, baselinePath string) error {
+	players, err := data.LoadPlayers(playersPath)
+	if err != nil {
+		return fmt.Errorf("failed to load players: %w", err)
+	}
+	baseline, err := data.LoadPlayers(baselinePath)
+	if err != nil {
+		return fmt.Errorf("failed to load baseline: %w", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(roster.AnalyzeRatings(players, baseline))
+}
+
+// runValidation validates a project with the reference data of a folder and
+// writes the report to w. With fixCodes it first fixes those findings,
+// listing the changes to log. It returns whether the project has no errors.
+func runValidation(w, log io.Writer, projectPath, refsPath, formatName, fixCodes string, dryRun bool) (bool, error) {
+	format, err := validation.ParseReportFormat(formatName)
+	if err != nil {
+		return false, err
+	}
+	appState, err := loadValidationProject(projectPath, refsPath)
+	if err != nil {
+		return false, err
+	}
+	if fixCodes != "" {
+		if err := fixValidationFindings(log, appState, fixCodes, dryRun); err != nil {
+			return false, err
+		}
+	}
+	return writeValidationReport(w, appState, format)
+}
+
+// loadValidationProject loads a project and the reference data of a folder
+func loadValidationProject(projectPath, refsPath string) (*state.AppState, error) {
+	appState := state.GetInstance()
+	if err := appState.LoadProject(projectPath); err != nil {
+		return nil, err
+	}
+	if err := appState.LoadReferenceData(refsPath); err != nil {
+		return nil, err
+	}
+	return appState, nil
+}
+
+// fixValidationFindings lists the changes fixing the findings with the given
+// comma-separated codes, of any severity, or every error offering a fix for
+// "all", then applies them and saves the project unless dryRun is set
+func fixValidationFindings(w io.Writer, appState *state.AppState, codes string, dryRun bool) error {
+	wanted := make(map[string]bool)
+	for _, code := range strings.Split(codes, ",") {
+		wanted[strings.ToUpper(strings.TrimSpace(code))] = true
+	}
+
+	var findings []validation.ValidationError
+	for _, finding := range validation.ValidateProject(appState, appState.ReferenceData).Errors {
+		if wanted[finding.Code] || (wanted["ALL"] && validation.HasFix(finding)) {
+			findings = append(findings, finding)
+		}
+	}
+
+	changes := validation.ProposeFixes(appState, findings)
+	for _, change := range changes {
+		fmt.Fprintf(w, "fix %s: %s\n", change.Code, change)
+	}
+	if dryRun || len(changes) == 0 {
+		fmt.Fprintf(w, "%d changes proposed; nothing was changed\n", len(changes))
+		return nil
+	}
+
+	applied := validation.ApplyFixes(appState, changes)
+	if err := appState.SaveProject(); err != nil {
+		return fmt.Errorf("failed to save fixes: %w", err)
+	}
+	fmt.Fprintf(w, "%d changes applied and saved\n", applied)
+	return nil
+}
+
+// writeValidationReport validates a loaded project and writes the report. It
+// returns whether the project has no errors.
+func writeValidationReport(w io.Writer, appState *state.AppState, format validation.ReportFormat) (bool, error) {
+	result := validation.ValidateProject(appState, appState.ReferenceData)
+	title := appState.GetProject().LeagueName
+	if err := validation.WriteReport(w, format, title, result); err != nil {
+		return false, fmt.Errorf("failed to write report: %w", err)
+	}
+	return result.Valid, nil
+}
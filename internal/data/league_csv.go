@@ -0,0 +1,94 @@
+// ABOUTME: League structure CSV loading and saving for FOF9 Editor
+// ABOUTME: Handles league_info.csv, default_teams.csv, schedule templates and xxxx_info.csv
+
+package data
+
+import (
+	"fmt"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// LoadLeagueStructures reads league_info.csv and returns all league formats
+func LoadLeagueStructures(filepath string) ([]models.LeagueStructure, error) {
+	reader := NewCSVReader(filepath)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read league info CSV: %w", err)
+	}
+
+	structures := make([]models.LeagueStructure, 0, len(records))
+	for i, record := range records {
+		var structure models.LeagueStructure
+		if err := mapRowToStruct(record, &structure); err != nil {
+			return nil, fmt.Errorf("error parsing league structure at row %d: %w", i+2, err)
+		}
+		structures = append(structures, structure)
+	}
+
+	return structures, nil
+}
+
+// SaveLeagueStructures writes league formats to league_info.csv
+func SaveLeagueStructures(filepath string, structures []models.LeagueStructure) error {
+	return saveStructs(filepath, "league structure", structures)
+}
+
+// LoadDefaultTeams reads default_teams.csv and returns the teams of every league format
+func LoadDefaultTeams(filepath string) ([]models.DefaultTeam, error) {
+	reader := NewCSVReader(filepath)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read default teams CSV: %w", err)
+	}
+
+	teams := make([]models.DefaultTeam, 0, len(records))
+	for i, record := range records {
+		var team models.DefaultTeam
+		if err := mapRowToStruct(record, &team); err != nil {
+			return nil, fmt.Errorf("error parsing default team at row %d: %w", i+2, err)
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
+// SaveDefaultTeams writes default teams to default_teams.csv
+func SaveDefaultTeams(filepath string, teams []models.DefaultTeam) error {
+	return saveStructs(filepath, "default team", teams)
+}
+
+// SaveScheduleTemplate writes a schedule template file (e.g. 32_8_18_schedule.csv)
+func SaveScheduleTemplate(filepath string, games []models.ScheduleTemplateGame) error {
+	return saveStructs(filepath, "schedule game", games)
+}
+
+// SaveSeasonSchedule writes a dated season schedule file (e.g. 2024_schedule.csv)
+func SaveSeasonSchedule(filepath string, games []models.ScheduledGame) error {
+	return saveStructs(filepath, "scheduled game", games)
+}
+
+// LoadLeagueInfo reads a custom league's xxxx_info.csv file
+func LoadLeagueInfo(filepath string) (*models.LeagueInfo, error) {
+	reader := NewCSVReader(filepath)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read league info CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("league info CSV has no data rows")
+	}
+
+	info := &models.LeagueInfo{}
+	if err := mapRowToStruct(records[0], info); err != nil {
+		return nil, fmt.Errorf("error parsing league info at row 2: %w", err)
+	}
+
+	return info, nil
+}
+
+// SaveLeagueInfo writes a custom league's xxxx_info.csv file
+func SaveLeagueInfo(filepath string, info *models.LeagueInfo) error {
+	return saveStructs(filepath, "league info", []models.LeagueInfo{*info})
+}
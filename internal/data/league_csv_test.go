@@ -0,0 +1,139 @@
+// ABOUTME: Tests for league structure CSV loading and saving
+// ABOUTME: Validates league_info, default_teams, schedule template, season schedule and xxxx_info round trips
+
+package data
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestLoadLeagueStructures_DefaultData(t *testing.T) {
+	structures, err := LoadLeagueStructures("../../default_data/league_info.csv")
+	if err != nil {
+		t.Fatalf("LoadLeagueStructures failed: %v", err)
+	}
+
+	if len(structures) == 0 {
+		t.Fatal("Expected league structures")
+	}
+
+	first := structures[0]
+	if first.ScheduleID != "32_8_18" || first.Teams != 32 || first.Divisions != 8 {
+		t.Errorf("Expected 32_8_18 with 32 teams and 8 divisions, got %s/%d/%d", first.ScheduleID, first.Teams, first.Divisions)
+	}
+	if first.Conf1 != "AFC" || first.Div5 != "East" || first.Div8Teams != 4 {
+		t.Errorf("Unexpected conference/division data: %s %s %d", first.Conf1, first.Div5, first.Div8Teams)
+	}
+	if first.Rotations != 12 || first.RotationBase != 2002 {
+		t.Errorf("Expected 12 rotations from 2002, got %d/%d", first.Rotations, first.RotationBase)
+	}
+}
+
+func TestLeagueStructures_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "league_info.csv")
+	original := []models.LeagueStructure{*models.NewLeagueStructure(16, 4, 14)}
+
+	if err := SaveLeagueStructures(path, original); err != nil {
+		t.Fatalf("SaveLeagueStructures failed: %v", err)
+	}
+
+	loaded, err := LoadLeagueStructures(path)
+	if err != nil {
+		t.Fatalf("LoadLeagueStructures failed: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0] != original[0] {
+		t.Errorf("Round trip mismatch: expected %+v, got %+v", original, loaded)
+	}
+}
+
+func TestDefaultTeams_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default_teams.csv")
+	original := []models.DefaultTeam{
+		{League: "4_2_6", TeamID: 1, TeamName: "Boston", NickName: "Minutemen", Abbreviation: "BOS", Conference: 1, Division: 1, City: 7215, PrimaryBlue: 128},
+	}
+
+	if err := SaveDefaultTeams(path, original); err != nil {
+		t.Fatalf("SaveDefaultTeams failed: %v", err)
+	}
+
+	loaded, err := LoadDefaultTeams(path)
+	if err != nil {
+		t.Fatalf("LoadDefaultTeams failed: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0] != original[0] {
+		t.Errorf("Round trip mismatch: expected %+v, got %+v", original, loaded)
+	}
+}
+
+func TestSeasonSchedule_RoundTrip(t *testing.T) {
+	original, err := LoadSeasonSchedule("../../default_data/2024_schedule.csv")
+	if err != nil {
+		t.Fatalf("LoadSeasonSchedule failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "2024_schedule.csv")
+	if err := SaveSeasonSchedule(path, original); err != nil {
+		t.Fatalf("SaveSeasonSchedule failed: %v", err)
+	}
+
+	loaded, err := LoadSeasonSchedule(path)
+	if err != nil {
+		t.Fatalf("LoadSeasonSchedule failed: %v", err)
+	}
+	if !slices.Equal(loaded, original) {
+		t.Errorf("Expected %d games to round trip, got %d", len(original), len(loaded))
+	}
+}
+
+func TestScheduleTemplate_RoundTrip(t *testing.T) {
+	original, err := LoadScheduleTemplate("../../testdata/fixtures/csv/4_2_3_schedule.csv")
+	if err != nil {
+		t.Fatalf("LoadScheduleTemplate failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "4_2_3_schedule.csv")
+	if err := SaveScheduleTemplate(path, original); err != nil {
+		t.Fatalf("SaveScheduleTemplate failed: %v", err)
+	}
+
+	headers, _, err := NewCSVReader(path).ReadAllWithHeaders()
+	if err != nil {
+		t.Fatalf("ReadAllWithHeaders failed: %v", err)
+	}
+	if len(headers) != 8 || headers[0] != "ROTATION" || headers[7] != "VISTEAM" {
+		t.Errorf("Unexpected schedule headers: %v", headers)
+	}
+
+	loaded, err := LoadScheduleTemplate(path)
+	if err != nil {
+		t.Fatalf("LoadScheduleTemplate failed: %v", err)
+	}
+	if len(loaded) != len(original) || loaded[5] != original[5] {
+		t.Errorf("Round trip mismatch at game 5: expected %+v, got %+v", original[5], loaded[5])
+	}
+}
+
+func TestLeagueInfo_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mylg_info.csv")
+	original := models.NewDefaultLeagueInfo(2025)
+
+	if err := SaveLeagueInfo(path, original); err != nil {
+		t.Fatalf("SaveLeagueInfo failed: %v", err)
+	}
+
+	loaded, err := LoadLeagueInfo(path)
+	if err != nil {
+		t.Fatalf("LoadLeagueInfo failed: %v", err)
+	}
+	if *loaded != *original {
+		t.Errorf("Round trip mismatch: expected %+v, got %+v", original, loaded)
+	}
+
+	if _, err := LoadLeagueInfo("../../testdata/fixtures/csv/empty.csv"); err == nil {
+		t.Error("Expected error for league info file without data rows")
+	}
+}
@@ -1,5 +1,5 @@
-// ABOUTME: Player CSV loading functionality for FOF9 Editor
-// ABOUTME: Maps CSV records to Player structs with proper type conversions
+// ABOUTME: Player and quarterback CSV loading functionality for FOF9 Editor
+// ABOUTME: Maps CSV records to Player and Quarterback structs with proper type conversions
 
 package data
 
@@ -31,6 +31,27 @@ func LoadPlayers(filepath string) ([]models.Player, error) {
 	return players, nil
 }
 
+// LoadQuarterbacks reads a quarterback CSV file (e.g. 2024_quarterbacks.csv)
+// and returns a slice of Quarterback structs
+func LoadQuarterbacks(filepath string) ([]models.Quarterback, error) {
+	reader := NewCSVReader(filepath)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quarterback CSV: %w", err)
+	}
+
+	quarterbacks := make([]models.Quarterback, 0, len(records))
+	for i, record := range records {
+		var quarterback models.Quarterback
+		if err := mapRowToStruct(record, &quarterback); err != nil {
+			return nil, fmt.Errorf("error parsing quarterback at row %d: %w", i+2, err)
+		}
+		quarterbacks = append(quarterbacks, quarterback)
+	}
+
+	return quarterbacks, nil
+}
+
 // mapRowToPlayer converts a CSV row (map of column->value) to a Player struct
 func mapRowToPlayer(row map[string]string) (models.Player, error) {
 	player := models.Player{}
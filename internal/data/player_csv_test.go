@@ -1,5 +1,5 @@
-// ABOUTME: Tests for player CSV loading functionality
-// ABOUTME: Validates CSV parsing and field mapping for Player structs
+// ABOUTME: Tests for player and quarterback CSV loading functionality
+// ABOUTME: Validates CSV parsing and field mapping for Player and Quarterback structs
 
 package data
 
@@ -222,3 +222,25 @@ func TestLoadPlayers_IntegrationWithRealStructure(t *testing.T) {
 		t.Errorf("Expected BonusYear1 0 (not in CSV), got %d", p.BonusYear1)
 	}
 }
+
+func TestLoadQuarterbacks_DefaultData(t *testing.T) {
+	quarterbacks, err := LoadQuarterbacks("../../default_data/2024_quarterbacks.csv")
+	if err != nil {
+		t.Fatalf("LoadQuarterbacks failed: %v", err)
+	}
+
+	if len(quarterbacks) != 140 {
+		t.Fatalf("Expected 140 quarterbacks, got %d", len(quarterbacks))
+	}
+
+	qb := quarterbacks[0]
+	if qb.PlayerID != 501 || qb.GetDisplayName() != "Aaron Rodgers" {
+		t.Errorf("Expected 501 Aaron Rodgers, got %d %s", qb.PlayerID, qb.GetDisplayName())
+	}
+	if qb.Height != 740 || qb.HandSize != 101 || qb.ArmLength != 322 {
+		t.Errorf("Expected measurements 740/101/322, got %d/%d/%d", qb.Height, qb.HandSize, qb.ArmLength)
+	}
+	if qb.Touch != 153 || qb.SecureHandling != 145 {
+		t.Errorf("Expected TOUCH 153 and SECURE_HANDLING 145, got %d and %d", qb.Touch, qb.SecureHandling)
+	}
+}
@@ -13,22 +13,25 @@ import (
 
 // SavePlayers writes a slice of Player structs to a CSV file
 func SavePlayers(filepath string, players []models.Player) error {
-	if len(players) == 0 {
-		// Write empty file with headers only
-		headers := getPlayerHeaders()
-		writer := NewCSVWriter(filepath)
-		return writer.WriteAll(headers, []map[string]string{})
-	}
+	return saveStructs(filepath, "player", players)
+}
 
-	// Get headers from first player struct
-	headers := getPlayerHeaders()
+// SaveQuarterbacks writes a slice of Quarterback structs to a CSV file
+func SaveQuarterbacks(filepath string, quarterbacks []models.Quarterback) error {
+	return saveStructs(filepath, "quarterback", quarterbacks)
+}
 
-	// Convert players to records
-	records := make([]map[string]string, 0, len(players))
-	for i, player := range players {
-		record, err := playerToMap(player)
+// saveStructs writes a slice of structs to a CSV file, using csv struct tags
+// for the headers. kind names the records in conversion errors.
+func saveStructs[T any](filepath string, kind string, items []T) error {
+	headers := getStructHeaders[T]()
+
+	// Convert items to records
+	records := make([]map[string]string, 0, len(items))
+	for i, item := range items {
+		record, err := structToMap(item)
 		if err != nil {
-			return fmt.Errorf("error converting player %d to CSV: %w", i, err)
+			return fmt.Errorf("error converting %s %d to CSV: %w", kind, i, err)
 		}
 		records = append(records, record)
 	}
@@ -38,16 +41,13 @@ func SavePlayers(filepath string, players []models.Player) error {
 	return writer.WriteAll(headers, records)
 }
 
-// getPlayerHeaders returns the CSV headers for Player struct
-func getPlayerHeaders() []string {
-	player := models.Player{}
-	playerType := reflect.TypeOf(player)
+// getStructHeaders returns the csv tags of a struct type in field order
+func getStructHeaders[T any]() []string {
+	structType := reflect.TypeOf((*T)(nil)).Elem()
 
-	headers := make([]string, 0, playerType.NumField())
-	for i := 0; i < playerType.NumField(); i++ {
-		field := playerType.Field(i)
-		csvTag := field.Tag.Get("csv")
-		if csvTag != "" {
+	headers := make([]string, 0, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		if csvTag := structType.Field(i).Tag.Get("csv"); csvTag != "" {
 			headers = append(headers, csvTag)
 		}
 	}
@@ -55,21 +55,20 @@ func getPlayerHeaders() []string {
 	return headers
 }
 
-// playerToMap converts a Player struct to a map[string]string for CSV writing
-func playerToMap(player models.Player) (map[string]string, error) {
+// structToMap converts a struct to a map[string]string keyed by csv tag
+func structToMap[T any](item T) (map[string]string, error) {
 	record := make(map[string]string)
-	playerValue := reflect.ValueOf(player)
-	playerType := playerValue.Type()
+	structValue := reflect.ValueOf(item)
+	structType := structValue.Type()
 
-	for i := 0; i < playerType.NumField(); i++ {
-		field := playerType.Field(i)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
 		csvTag := field.Tag.Get("csv")
 		if csvTag == "" {
 			continue
 		}
 
-		fieldValue := playerValue.Field(i)
-		strValue, err := fieldValueToString(fieldValue)
+		strValue, err := fieldValueToString(structValue.Field(i))
 		if err != nil {
 			return nil, fmt.Errorf("field %s: %w", field.Name, err)
 		}
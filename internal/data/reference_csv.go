@@ -0,0 +1,143 @@
+// ABOUTME: Reference and schedule CSV loading functionality for FOF9 Editor
+// ABOUTME: Maps cities.csv, colleges.csv, metro_areas.csv, schedule template and season schedule rows to model structs using csv struct tags
+
+package data
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// LoadCities reads a cities CSV file and returns a slice of City structs
+func LoadCities(filepath string) ([]models.City, error) {
+	reader := NewCSVReader(filepath)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cities CSV: %w", err)
+	}
+
+	cities := make([]models.City, 0, len(records))
+	for i, record := range records {
+		var city models.City
+		if err := mapRowToStruct(record, &city); err != nil {
+			return nil, fmt.Errorf("error parsing city at row %d: %w", i+2, err)
+		}
+		cities = append(cities, city)
+	}
+
+	return cities, nil
+}
+
+// LoadColleges reads a colleges CSV file and returns a slice of College structs
+func LoadColleges(filepath string) ([]models.College, error) {
+	reader := NewCSVReader(filepath)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read colleges CSV: %w", err)
+	}
+
+	colleges := make([]models.College, 0, len(records))
+	for i, record := range records {
+		var college models.College
+		if err := mapRowToStruct(record, &college); err != nil {
+			return nil, fmt.Errorf("error parsing college at row %d: %w", i+2, err)
+		}
+		colleges = append(colleges, college)
+	}
+
+	return colleges, nil
+}
+
+// LoadMetroAreas reads a metro areas CSV file and returns a slice of MetroArea structs
+func LoadMetroAreas(filepath string) ([]models.MetroArea, error) {
+	reader := NewCSVReader(filepath)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metro areas CSV: %w", err)
+	}
+
+	metros := make([]models.MetroArea, 0, len(records))
+	for i, record := range records {
+		var metro models.MetroArea
+		if err := mapRowToStruct(record, &metro); err != nil {
+			return nil, fmt.Errorf("error parsing metro area at row %d: %w", i+2, err)
+		}
+		metros = append(metros, metro)
+	}
+
+	return metros, nil
+}
+
+// LoadScheduleTemplate reads a schedule template CSV file (e.g. 32_8_18_schedule.csv)
+func LoadScheduleTemplate(filepath string) ([]models.ScheduleTemplateGame, error) {
+	reader := NewCSVReader(filepath)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedule template CSV: %w", err)
+	}
+
+	games := make([]models.ScheduleTemplateGame, 0, len(records))
+	for i, record := range records {
+		var game models.ScheduleTemplateGame
+		if err := mapRowToStruct(record, &game); err != nil {
+			return nil, fmt.Errorf("error parsing schedule game at row %d: %w", i+2, err)
+		}
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+// LoadSeasonSchedule reads a dated season schedule CSV file (e.g. 2024_schedule.csv)
+func LoadSeasonSchedule(filepath string) ([]models.ScheduledGame, error) {
+	reader := NewCSVReader(filepath)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read season schedule CSV: %w", err)
+	}
+
+	games := make([]models.ScheduledGame, 0, len(records))
+	for i, record := range records {
+		var game models.ScheduledGame
+		if err := mapRowToStruct(record, &game); err != nil {
+			return nil, fmt.Errorf("error parsing scheduled game at row %d: %w", i+2, err)
+		}
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+// mapRowToStruct converts a CSV row (map of column->value) into the struct
+// pointed to by target, using the csv struct tags of its fields
+func mapRowToStruct(row map[string]string, target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("target must be a pointer to a struct")
+	}
+
+	structValue := targetValue.Elem()
+	structType := structValue.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		csvTag := field.Tag.Get("csv")
+		if csvTag == "" {
+			continue
+		}
+
+		value, ok := row[csvTag]
+		if !ok {
+			// Column missing from CSV - use zero value
+			continue
+		}
+
+		if err := setFieldValue(structValue.Field(i), value, field.Name); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+// ABOUTME: Tests for reference and schedule CSV loading functionality
+// ABOUTME: Validates parsing of cities.csv, colleges.csv, metro_areas.csv, schedule template and season schedule files
+
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestLoadCities_SimpleFile(t *testing.T) {
+	cities, err := LoadCities("../../testdata/fixtures/csv/cities_simple.csv")
+	if err != nil {
+		t.Fatalf("LoadCities failed: %v", err)
+	}
+
+	if len(cities) != 5 {
+		t.Fatalf("Expected 5 cities, got %d", len(cities))
+	}
+
+	boston := cities[3]
+	if boston.CityID != 7215 {
+		t.Errorf("Expected CityID 7215, got %d", boston.CityID)
+	}
+	if boston.Name != "Boston" || boston.Region != "MA" {
+		t.Errorf("Expected Boston, MA, got %s, %s", boston.Name, boston.Region)
+	}
+	if boston.Latitude != 42354 || boston.Longitude != -71066 {
+		t.Errorf("Expected coordinates 42354/-71066, got %d/%d", boston.Latitude, boston.Longitude)
+	}
+	if boston.Population != 237446 {
+		t.Errorf("Expected Population 237446, got %d", boston.Population)
+	}
+}
+
+func TestLoadCities_NonExistentFile(t *testing.T) {
+	_, err := LoadCities("../../testdata/fixtures/csv/nonexistent.csv")
+	if err == nil {
+		t.Error("Expected error for non-existent file, got nil")
+	}
+}
+
+func TestLoadColleges_SimpleFile(t *testing.T) {
+	colleges, err := LoadColleges("../../testdata/fixtures/csv/colleges_simple.csv")
+	if err != nil {
+		t.Fatalf("LoadColleges failed: %v", err)
+	}
+
+	if len(colleges) != 3 {
+		t.Fatalf("Expected 3 colleges, got %d", len(colleges))
+	}
+
+	michigan := colleges[1]
+	if michigan.CollegeID != 5678 || michigan.Name != "Michigan" || michigan.NickName != "Wolverines" {
+		t.Errorf("Expected 5678 Michigan Wolverines, got %d %s %s", michigan.CollegeID, michigan.Name, michigan.NickName)
+	}
+	if michigan.CityID != 1234 || michigan.Region != "MI" {
+		t.Errorf("Expected city 1234 in MI, got %d in %s", michigan.CityID, michigan.Region)
+	}
+}
+
+func TestLoadColleges_DefaultData(t *testing.T) {
+	colleges, err := LoadColleges("../../default_data/colleges.csv")
+	if err != nil {
+		t.Fatalf("LoadColleges failed: %v", err)
+	}
+	if len(colleges) == 0 || colleges[0].CollegeID != 0 || colleges[0].Name != "No College" {
+		t.Errorf("Expected colleges.csv to start with 0 No College, got %+v", colleges[0])
+	}
+}
+
+func TestLoadMetroAreas_DefaultData(t *testing.T) {
+	metros, err := LoadMetroAreas("../../default_data/metro_areas.csv")
+	if err != nil {
+		t.Fatalf("LoadMetroAreas failed: %v", err)
+	}
+
+	var greenBay *models.MetroArea
+	for i := range metros {
+		if metros[i].MetroID == 347 {
+			greenBay = &metros[i]
+		}
+	}
+	if greenBay == nil {
+		t.Fatal("Expected metro area 347 (Green Bay)")
+	}
+	if greenBay.GetDisplayName() != "Green Bay, WI" || greenBay.Population != 322906 {
+		t.Errorf("Expected Green Bay, WI with 322906 people, got %s with %d", greenBay.GetDisplayName(), greenBay.Population)
+	}
+}
+
+func TestLoadScheduleTemplate_SimpleFile(t *testing.T) {
+	games, err := LoadScheduleTemplate("../../testdata/fixtures/csv/4_2_3_schedule.csv")
+	if err != nil {
+		t.Fatalf("LoadScheduleTemplate failed: %v", err)
+	}
+
+	if len(games) != 16 {
+		t.Fatalf("Expected 16 games, got %d", len(games))
+	}
+
+	exhibition := games[0]
+	if exhibition.IsRegularSeason() {
+		t.Error("Expected first game to be an exhibition game")
+	}
+
+	game := games[2]
+	expected := models.ScheduleTemplateGame{
+		Rotation: 1, Season: 1, Standings: 0, Week: 2,
+		HomeDiv: 1, HomeTeam: 1, VisDiv: 1, VisTeam: 2,
+	}
+	if game != expected {
+		t.Errorf("Expected %+v, got %+v", expected, game)
+	}
+	if !game.IsDivisional() {
+		t.Error("Expected game to be divisional")
+	}
+}
+
+func TestLoadSeasonSchedule_SimpleFile(t *testing.T) {
+	games, err := LoadSeasonSchedule("../../testdata/fixtures/csv/2024_league4_schedule.csv")
+	if err != nil {
+		t.Fatalf("LoadSeasonSchedule failed: %v", err)
+	}
+
+	if len(games) != 7 {
+		t.Fatalf("Expected 7 games, got %d", len(games))
+	}
+	if games[0].IsRegularSeason() {
+		t.Error("Expected first game to be an exhibition game")
+	}
+
+	game := games[5]
+	expected := models.ScheduledGame{
+		Season: 1, Week: 3, Month: 9, Day: 15, Year: 2024,
+		Home: 2, Visitor: 3, Location: 1594,
+	}
+	if game != expected {
+		t.Errorf("Expected %+v, got %+v", expected, game)
+	}
+	if date := game.Date().Format("2006-01-02"); date != "2024-09-15" {
+		t.Errorf("Expected date 2024-09-15, got %s", date)
+	}
+}
+
+func TestLoadSeasonSchedule_DefaultData(t *testing.T) {
+	games, err := LoadSeasonSchedule("../../default_data/2024_schedule.csv")
+	if err != nil {
+		t.Fatalf("LoadSeasonSchedule failed: %v", err)
+	}
+
+	for i, game := range games {
+		if game.Date().Weekday() != time.Sunday {
+			t.Errorf("Game %d: expected a Sunday, got %s", i, game.Date().Weekday())
+		}
+	}
+}
+
+func TestMapRowToStruct_InvalidTarget(t *testing.T) {
+	var city models.City
+	if err := mapRowToStruct(map[string]string{}, city); err == nil {
+		t.Error("Expected error for non-pointer target")
+	}
+
+	if err := mapRowToStruct(map[string]string{"CITYID": "abc"}, &city); err == nil {
+		t.Error("Expected error for invalid integer value")
+	}
+}
@@ -128,6 +128,32 @@ func TestSavePlayers_ModifyAndSave(t *testing.T) {
 	}
 }
 
+func TestSaveQuarterbacks_RoundTrip(t *testing.T) {
+	quarterbacks, err := LoadQuarterbacks("../../default_data/2024_quarterbacks.csv")
+	if err != nil {
+		t.Fatalf("LoadQuarterbacks failed: %v", err)
+	}
+
+	tmpFile := filepath.Join(t.TempDir(), "quarterbacks_output.csv")
+	quarterbacks[0].Uniform = 99
+	if err := SaveQuarterbacks(tmpFile, quarterbacks); err != nil {
+		t.Fatalf("SaveQuarterbacks failed: %v", err)
+	}
+
+	loaded, err := LoadQuarterbacks(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadQuarterbacks (second) failed: %v", err)
+	}
+	if len(loaded) != len(quarterbacks) {
+		t.Fatalf("Expected %d quarterbacks after round-trip, got %d", len(quarterbacks), len(loaded))
+	}
+	for i := range quarterbacks {
+		if loaded[i] != quarterbacks[i] {
+			t.Fatalf("Quarterback %d changed after round-trip: %+v != %+v", i, loaded[i], quarterbacks[i])
+		}
+	}
+}
+
 // Coach Save/Load Tests
 
 func TestSaveCoaches_RoundTrip(t *testing.T) {
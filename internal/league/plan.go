@@ -0,0 +1,215 @@
+// ABOUTME: League structure plans for creating new FOF9 league formats
+// ABOUTME: Assigns teams to divisions and writes league_info, default_teams, schedule and info files together
+
+package league
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/schedule"
+	"github.com/igorilic/fof9editor/internal/validation"
+)
+
+// File names of the game's league structure tables
+const (
+	LeagueInfoFile   = "league_info.csv"
+	DefaultTeamsFile = "default_teams.csv"
+)
+
+// Plan holds every artifact of a new league format
+type Plan struct {
+	Structure *models.LeagueStructure
+	Teams     []models.DefaultTeam
+	Schedule  []models.ScheduleTemplateGame
+	BaseYear  int // Used when creating a new xxxx_info.csv
+}
+
+// NewPlan creates a plan for a league structure with placeholder teams
+func NewPlan(structure *models.LeagueStructure, baseYear int) *Plan {
+	p := &Plan{
+		Structure: structure,
+		BaseYear:  baseYear,
+	}
+	p.Teams = PlaceholderTeams(structure.Teams)
+	AssignDivisions(p.Teams, structure)
+	return p
+}
+
+// PlaceholderTeams creates numbered teams for a new league format
+func PlaceholderTeams(count int) []models.DefaultTeam {
+	teams := make([]models.DefaultTeam, count)
+	for i := range teams {
+		teams[i] = models.DefaultTeam{
+			TeamID:       i + 1,
+			TeamName:     fmt.Sprintf("Team %d", i+1),
+			NickName:     "Team",
+			Abbreviation: fmt.Sprintf("T%02d", i+1),
+		}
+	}
+	return teams
+}
+
+// AssignDivisions fills divisions in order with the given teams, following the
+// structure's team count per division, and numbers the teams from 1
+func AssignDivisions(teams []models.DefaultTeam, structure *models.LeagueStructure) {
+	dpc := structure.DivisionsPerConference()
+	division, filled := 1, 0
+
+	for i := range teams {
+		for division <= structure.Divisions && filled >= structure.GetDivisionTeams(division) {
+			division++
+			filled = 0
+		}
+
+		teams[i].League = structure.ScheduleID
+		teams[i].TeamID = i + 1
+		if division <= structure.Divisions && dpc > 0 {
+			teams[i].Conference = (division-1)/dpc + 1
+			teams[i].Division = (division-1)%dpc + 1
+		}
+		filled++
+	}
+}
+
+// SetScheduleID renames the league format and updates its teams to match
+func (p *Plan) SetScheduleID(scheduleID string) {
+	p.Structure.ScheduleID = scheduleID
+	for i := range p.Teams {
+		p.Teams[i].League = scheduleID
+	}
+}
+
+// GenerateSchedule replaces the plan's schedule with a generated round robin
+func (p *Plan) GenerateSchedule() error {
+	games, err := schedule.GenerateTemplate(p.Structure)
+	if err != nil {
+		return err
+	}
+	p.Schedule = games
+	p.Structure.Rotations = 1
+	return nil
+}
+
+// LoadSchedule replaces the plan's schedule with an existing template file
+func (p *Plan) LoadSchedule(path string) error {
+	games, err := data.LoadScheduleTemplate(path)
+	if err != nil {
+		return err
+	}
+
+	rotations := 0
+	for _, game := range games {
+		rotations = max(rotations, game.Rotation)
+	}
+
+	p.Schedule = games
+	p.Structure.Rotations = max(rotations, 1)
+	return nil
+}
+
+// Validate checks the structure, teams and schedule against each other
+func (p *Plan) Validate() *validation.ValidationResult {
+	return validation.ValidateLeagueArtifacts(p.Structure, p.Teams, p.Schedule)
+}
+
+// ScheduleFileName returns the template file name for the plan, e.g. "32_8_18_schedule.csv"
+func (p *Plan) ScheduleFileName() string {
+	return p.Structure.ScheduleID + "_schedule.csv"
+}
+
+// WriteArtifacts validates the plan and writes all of its files: the
+// structure's row in league_info.csv and its rows in default_teams.csv
+// (replacing any rows with the same schedule ID), the schedule template, and,
+// when infoPath is set, the SCHEDULEID of the league's xxxx_info.csv.
+func (p *Plan) WriteArtifacts(dir, infoPath string) error {
+	if result := p.Validate(); !result.Valid {
+		return fmt.Errorf("league structure is invalid: %s", result.Errors[0].Error())
+	}
+
+	// league_info.csv
+	structuresPath := filepath.Join(dir, LeagueInfoFile)
+	var structures []models.LeagueStructure
+	if fileExists(structuresPath) {
+		var err error
+		if structures, err = data.LoadLeagueStructures(structuresPath); err != nil {
+			return err
+		}
+	}
+	replaced := false
+	for i := range structures {
+		if structures[i].ScheduleID == p.Structure.ScheduleID {
+			structures[i] = *p.Structure
+			replaced = true
+		}
+	}
+	if !replaced {
+		structures = append(structures, *p.Structure)
+	}
+
+	// default_teams.csv
+	teamsPath := filepath.Join(dir, DefaultTeamsFile)
+	var existingTeams []models.DefaultTeam
+	if fileExists(teamsPath) {
+		var err error
+		if existingTeams, err = data.LoadDefaultTeams(teamsPath); err != nil {
+			return err
+		}
+	}
+	teams := make([]models.DefaultTeam, 0, len(existingTeams)+len(p.Teams))
+	for _, team := range existingTeams {
+		if team.League != p.Structure.ScheduleID {
+			teams = append(teams, team)
+		}
+	}
+	teams = append(teams, p.Teams...)
+
+	// xxxx_info.csv
+	var info *models.LeagueInfo
+	if infoPath != "" {
+		if fileExists(infoPath) {
+			var err error
+			if info, err = data.LoadLeagueInfo(infoPath); err != nil {
+				return err
+			}
+		} else {
+			info = p.newLeagueInfo()
+		}
+		info.ScheduleID = p.Structure.ScheduleID
+	}
+
+	// Everything has been read and checked; write the artifacts
+	if err := data.SaveLeagueStructures(structuresPath, structures); err != nil {
+		return fmt.Errorf("failed to save %s: %w", LeagueInfoFile, err)
+	}
+	if err := data.SaveDefaultTeams(teamsPath, teams); err != nil {
+		return fmt.Errorf("failed to save %s: %w", DefaultTeamsFile, err)
+	}
+	if err := data.SaveScheduleTemplate(filepath.Join(dir, p.ScheduleFileName()), p.Schedule); err != nil {
+		return fmt.Errorf("failed to save %s: %w", p.ScheduleFileName(), err)
+	}
+	if info != nil {
+		if err := data.SaveLeagueInfo(infoPath, info); err != nil {
+			return fmt.Errorf("failed to save %s: %w", filepath.Base(infoPath), err)
+		}
+	}
+
+	return nil
+}
+
+// newLeagueInfo creates a custom league info file from the structure's salary settings
+func (p *Plan) newLeagueInfo() *models.LeagueInfo {
+	info := models.NewDefaultLeagueInfo(p.BaseYear)
+	info.SalaryCap = p.Structure.SalaryCap
+	info.SetSalaryMinimums(p.Structure.SalaryMinimums())
+	return info
+}
+
+// fileExists returns true if a file exists at path
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
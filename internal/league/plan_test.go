@@ -0,0 +1,154 @@
+// ABOUTME: Tests for league structure plans
+// ABOUTME: Validates division assignment, schedule generation and writing all artifacts together
+
+package league
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestAssignDivisions(t *testing.T) {
+	structure := models.NewLeagueStructure(10, 4, 12)
+	teams := PlaceholderTeams(10)
+	AssignDivisions(teams, structure)
+
+	// 10 teams over 4 divisions: 3, 3, 2, 2
+	expected := [][2]int{{1, 1}, {1, 1}, {1, 1}, {1, 2}, {1, 2}, {1, 2}, {2, 1}, {2, 1}, {2, 2}, {2, 2}}
+	for i, team := range teams {
+		if team.Conference != expected[i][0] || team.Division != expected[i][1] {
+			t.Errorf("Team %d: expected conference %d division %d, got %d/%d",
+				i+1, expected[i][0], expected[i][1], team.Conference, team.Division)
+		}
+		if team.League != "10_4_12" || team.TeamID != i+1 {
+			t.Errorf("Team %d: expected league 10_4_12 and ID %d, got %s/%d", i+1, i+1, team.League, team.TeamID)
+		}
+	}
+}
+
+func TestPlan_GenerateAndValidate(t *testing.T) {
+	plan := NewPlan(models.NewLeagueStructure(12, 4, 14), 2025)
+
+	if result := plan.Validate(); result.Valid {
+		t.Error("Expected plan without a schedule to be invalid")
+	}
+
+	if err := plan.GenerateSchedule(); err != nil {
+		t.Fatalf("GenerateSchedule failed: %v", err)
+	}
+	if result := plan.Validate(); !result.Valid {
+		t.Errorf("Expected generated plan to be valid, got %v", result.Errors)
+	}
+
+	// Moving a team breaks the division team counts
+	plan.Teams[0].Division = 2
+	if result := plan.Validate(); result.Valid {
+		t.Error("Expected plan with unbalanced divisions to be invalid")
+	}
+}
+
+func TestPlan_SetScheduleID(t *testing.T) {
+	plan := NewPlan(models.NewLeagueStructure(4, 2, 6), 2025)
+	plan.SetScheduleID("custom")
+
+	if plan.Structure.ScheduleID != "custom" {
+		t.Errorf("Expected schedule ID 'custom', got '%s'", plan.Structure.ScheduleID)
+	}
+	for _, team := range plan.Teams {
+		if team.League != "custom" {
+			t.Errorf("Expected team league 'custom', got '%s'", team.League)
+		}
+	}
+}
+
+func TestPlan_LoadSchedule(t *testing.T) {
+	structure := models.NewLeagueStructure(4, 2, 3)
+	structure.ExGames, structure.ExWeeks, structure.Weeks = 1, 1, 3
+	plan := NewPlan(structure, 2025)
+
+	if err := plan.LoadSchedule("../../testdata/fixtures/csv/4_2_3_schedule.csv"); err != nil {
+		t.Fatalf("LoadSchedule failed: %v", err)
+	}
+	if plan.Structure.Rotations != 2 {
+		t.Errorf("Expected 2 rotations, got %d", plan.Structure.Rotations)
+	}
+	if result := plan.Validate(); !result.Valid {
+		t.Errorf("Expected plan with fixture schedule to be valid, got %v", result.Errors)
+	}
+}
+
+func TestPlan_WriteArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	infoPath := filepath.Join(dir, "mylg_info.csv")
+
+	// An existing format that must be kept, and an outdated copy of the new one
+	existing := models.NewLeagueStructure(4, 2, 6)
+	outdated := models.NewLeagueStructure(6, 2, 10)
+	if err := data.SaveLeagueStructures(filepath.Join(dir, LeagueInfoFile), []models.LeagueStructure{*existing, *outdated}); err != nil {
+		t.Fatalf("SaveLeagueStructures failed: %v", err)
+	}
+	if err := data.SaveDefaultTeams(filepath.Join(dir, DefaultTeamsFile), []models.DefaultTeam{
+		{League: "4_2_6", TeamID: 1, TeamName: "Old"},
+		{League: "6_2_10", TeamID: 1, TeamName: "Outdated"},
+	}); err != nil {
+		t.Fatalf("SaveDefaultTeams failed: %v", err)
+	}
+
+	plan := NewPlan(models.NewLeagueStructure(6, 2, 10), 2025)
+	plan.Structure.Conf1 = "Eastern"
+	if err := plan.GenerateSchedule(); err != nil {
+		t.Fatalf("GenerateSchedule failed: %v", err)
+	}
+
+	if err := plan.WriteArtifacts(dir, infoPath); err != nil {
+		t.Fatalf("WriteArtifacts failed: %v", err)
+	}
+
+	structures, err := data.LoadLeagueStructures(filepath.Join(dir, LeagueInfoFile))
+	if err != nil {
+		t.Fatalf("LoadLeagueStructures failed: %v", err)
+	}
+	if len(structures) != 2 || structures[1].Conf1 != "Eastern" {
+		t.Errorf("Expected the 6_2_10 row to be replaced, got %+v", structures)
+	}
+
+	teams, err := data.LoadDefaultTeams(filepath.Join(dir, DefaultTeamsFile))
+	if err != nil {
+		t.Fatalf("LoadDefaultTeams failed: %v", err)
+	}
+	if len(teams) != 7 || teams[0].TeamName != "Old" || teams[1].TeamName != "Team 1" {
+		t.Errorf("Expected old 4_2_6 team plus 6 new teams, got %d teams", len(teams))
+	}
+
+	games, err := data.LoadScheduleTemplate(filepath.Join(dir, "6_2_10_schedule.csv"))
+	if err != nil {
+		t.Fatalf("LoadScheduleTemplate failed: %v", err)
+	}
+	if len(games) != len(plan.Schedule) {
+		t.Errorf("Expected %d schedule games, got %d", len(plan.Schedule), len(games))
+	}
+
+	info, err := data.LoadLeagueInfo(infoPath)
+	if err != nil {
+		t.Fatalf("LoadLeagueInfo failed: %v", err)
+	}
+	if info.ScheduleID != "6_2_10" || info.BaseYear != 2025 {
+		t.Errorf("Expected info with schedule 6_2_10 and base year 2025, got %s/%d", info.ScheduleID, info.BaseYear)
+	}
+}
+
+func TestPlan_WriteArtifacts_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	plan := NewPlan(models.NewLeagueStructure(6, 2, 10), 2025)
+
+	if err := plan.WriteArtifacts(dir, ""); err == nil {
+		t.Error("Expected error for plan without a schedule")
+	}
+	if _, err := os.Stat(filepath.Join(dir, LeagueInfoFile)); !os.IsNotExist(err) {
+		t.Error("Expected no files to be written for an invalid plan")
+	}
+}
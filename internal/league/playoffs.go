@@ -0,0 +1,236 @@
+// ABOUTME: Playoff bracket previews for league structures
+// ABOUTME: Seeds division winners and wild cards per conference from a hypothetical standings order
+
+package league
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/validation"
+)
+
+// PlayoffSeed is a playoff team and its seed within its conference
+type PlayoffSeed struct {
+	Seed           int
+	Team           models.DefaultTeam
+	DivisionWinner bool
+}
+
+// PlayoffSlot is one side of a playoff game: a seeded team in the first game
+// it plays, or the winner of an earlier game
+type PlayoffSlot struct {
+	Seed int          // Seed of the team, or 0 for the winner of From
+	From *PlayoffGame // Earlier game whose winner fills this slot
+}
+
+// PlayoffGame is a game between two slots in a playoff round
+type PlayoffGame struct {
+	Round   int // 1-based round within the conference bracket
+	High    PlayoffSlot
+	Low     PlayoffSlot
+	ordinal int // Game number within the round, used for labels
+}
+
+// ConferenceBracket is one conference's seeds and games
+type ConferenceBracket struct {
+	Conference int
+	Name       string
+	Seeds      []PlayoffSeed
+	Byes       []int           // Seeds that skip the first round
+	Rounds     [][]PlayoffGame // Games per round, first round first
+}
+
+// PlayoffBracket is a preview of a league structure's playoffs
+type PlayoffBracket struct {
+	Structure   *models.LeagueStructure
+	Conferences []ConferenceBracket
+}
+
+// BuildPlayoffBracket seeds a league structure's playoffs from a standings
+// order (best team first). Each conference gets half of the playoff spots:
+// division winners take the top seeds in standings order, followed by the
+// best remaining teams as wild cards. When the spots are not a power of two
+// the top seeds get first-round byes. Later rounds follow a fixed bracket.
+func BuildPlayoffBracket(structure *models.LeagueStructure, standings []models.DefaultTeam) (*PlayoffBracket, error) {
+	if result := validation.ValidatePlayoffSpots(structure); !result.Valid {
+		return nil, fmt.Errorf("%s", result.Errors[0].Message)
+	}
+
+	dpc := structure.DivisionsPerConference()
+	spots := structure.PlayoffTeams / 2
+	bracket := &PlayoffBracket{Structure: structure}
+
+	for conference := 1; conference <= 2; conference++ {
+		var members []models.DefaultTeam
+		for _, team := range standings {
+			if team.Conference == conference {
+				members = append(members, team)
+			}
+		}
+		if len(members) < spots {
+			return nil, fmt.Errorf("%s has %d teams in the standings for %d playoff spots",
+				structure.GetConferenceName(conference), len(members), spots)
+		}
+
+		// Division winners are the best team of each division
+		winners := make(map[int]bool)
+		var seeds []PlayoffSeed
+		for i, team := range members {
+			if !winners[team.Division] && team.Division >= 1 && team.Division <= dpc {
+				winners[team.Division] = true
+				seeds = append(seeds, PlayoffSeed{Team: members[i], DivisionWinner: true})
+			}
+		}
+		if len(seeds) != dpc {
+			return nil, fmt.Errorf("%s standings cover %d of %d divisions",
+				structure.GetConferenceName(conference), len(seeds), dpc)
+		}
+
+		// Wild cards are the best remaining teams
+		for _, team := range members {
+			if len(seeds) == spots {
+				break
+			}
+			if !isSeeded(seeds, team.TeamID) {
+				seeds = append(seeds, PlayoffSeed{Team: team})
+			}
+		}
+		for i := range seeds {
+			seeds[i].Seed = i + 1
+		}
+
+		conf := ConferenceBracket{
+			Conference: conference,
+			Name:       structure.GetConferenceName(conference),
+			Seeds:      seeds,
+		}
+		conf.Byes, conf.Rounds = bracketRounds(len(seeds))
+		bracket.Conferences = append(bracket.Conferences, conf)
+	}
+
+	return bracket, nil
+}
+
+// isSeeded returns true if a team already has a seed
+func isSeeded(seeds []PlayoffSeed, teamID int) bool {
+	for _, seed := range seeds {
+		if seed.Team.TeamID == teamID {
+			return true
+		}
+	}
+	return false
+}
+
+// bracketRounds builds a fixed single-elimination bracket for n seeds and
+// returns the seeds with first-round byes and the games of every round
+func bracketRounds(n int) ([]int, [][]PlayoffGame) {
+	size := 1
+	for size < n {
+		size *= 2
+	}
+
+	// Standard bracket order keeps the top seeds apart: 1, 8, 4, 5, 2, 7, 3, 6
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		for _, seed := range order {
+			next = append(next, seed, 2*len(order)+1-seed)
+		}
+		order = next
+	}
+
+	slots := make([]PlayoffSlot, len(order))
+	for i, seed := range order {
+		slots[i] = PlayoffSlot{Seed: seed}
+	}
+
+	var byes []int
+	var rounds [][]PlayoffGame
+	for round := 1; len(slots) > 1; round++ {
+		var games []PlayoffGame
+		next := make([]PlayoffSlot, 0, len(slots)/2)
+		for i := 0; i < len(slots); i += 2 {
+			a, b := slots[i], slots[i+1]
+			switch {
+			case b.From == nil && b.Seed > n:
+				byes = append(byes, a.Seed)
+				next = append(next, a)
+			case a.From == nil && a.Seed > n:
+				byes = append(byes, b.Seed)
+				next = append(next, b)
+			default:
+				games = append(games, PlayoffGame{Round: round, High: a, Low: b, ordinal: len(games) + 1})
+				next = append(next, PlayoffSlot{})
+			}
+		}
+		rounds = append(rounds, games)
+
+		// Point winner slots at this round's games now that the slice is final
+		g := 0
+		for i := range next {
+			if next[i].From == nil && next[i].Seed == 0 {
+				next[i].From = &rounds[len(rounds)-1][g]
+				g++
+			}
+		}
+		slots = next
+	}
+
+	return byes, rounds
+}
+
+// RoundName returns the display name of a conference round
+func (c *ConferenceBracket) RoundName(round int) string {
+	if round == len(c.Rounds) {
+		return "Conference Final"
+	}
+	return fmt.Sprintf("Round %d", round)
+}
+
+// slotLabel describes a game slot, e.g. "(3) BOS" or "Winner R1 G2"
+func (c *ConferenceBracket) slotLabel(slot PlayoffSlot) string {
+	if slot.From != nil {
+		return fmt.Sprintf("Winner R%d G%d", slot.From.Round, slot.From.ordinal)
+	}
+	seed := c.Seeds[slot.Seed-1]
+	return fmt.Sprintf("(%d) %s", seed.Seed, seed.Team.Abbreviation)
+}
+
+// Render returns a plain-text preview of the bracket
+func (b *PlayoffBracket) Render() string {
+	var sb strings.Builder
+
+	for _, conf := range b.Conferences {
+		fmt.Fprintf(&sb, "%s (%d teams)\n", conf.Name, len(conf.Seeds))
+		for _, seed := range conf.Seeds {
+			kind := "Wild card"
+			if seed.DivisionWinner {
+				kind = b.Structure.GetDivisionName(b.Structure.DivisionIndex(conf.Conference, seed.Team.Division)) + " winner"
+			}
+			fmt.Fprintf(&sb, "  %2d. %-5s %s\n", seed.Seed, seed.Team.Abbreviation, kind)
+		}
+		if len(conf.Byes) > 0 {
+			labels := make([]string, len(conf.Byes))
+			for i, seed := range conf.Byes {
+				labels[i] = fmt.Sprintf("(%d)", seed)
+			}
+			fmt.Fprintf(&sb, "  First-round bye: %s\n", strings.Join(labels, ", "))
+		}
+		for r, games := range conf.Rounds {
+			fmt.Fprintf(&sb, "  %s\n", conf.RoundName(r+1))
+			for _, game := range games {
+				fmt.Fprintf(&sb, "    G%d: %s vs %s\n", game.ordinal, conf.slotLabel(game.Low), conf.slotLabel(game.High))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(b.Conferences) == 2 {
+		fmt.Fprintf(&sb, "%s: %s champion vs %s champion\n",
+			b.Structure.Championship, b.Conferences[0].Name, b.Conferences[1].Name)
+	}
+
+	return sb.String()
+}
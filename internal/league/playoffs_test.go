@@ -0,0 +1,115 @@
+// ABOUTME: Tests for playoff bracket previews
+// ABOUTME: Validates seeding, first-round byes, bracket rounds and structure checks
+
+package league
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestBuildPlayoffBracket_FourteenTeams(t *testing.T) {
+	structure := models.NewLeagueStructure(32, 8, 17)
+	structure.PlayoffTeams = 14
+	plan := NewPlan(structure, 2025)
+
+	bracket, err := BuildPlayoffBracket(structure, plan.Teams)
+	if err != nil {
+		t.Fatalf("BuildPlayoffBracket failed: %v", err)
+	}
+	if len(bracket.Conferences) != 2 {
+		t.Fatalf("Expected 2 conferences, got %d", len(bracket.Conferences))
+	}
+
+	conf := bracket.Conferences[0]
+	if len(conf.Seeds) != 7 {
+		t.Fatalf("Expected 7 seeds, got %d", len(conf.Seeds))
+	}
+	// Teams 1, 5, 9 and 13 lead their divisions; 2, 3 and 4 are the wild cards
+	expected := []int{1, 5, 9, 13, 2, 3, 4}
+	for i, seed := range conf.Seeds {
+		if seed.Team.TeamID != expected[i] {
+			t.Errorf("Seed %d: expected team %d, got %d", i+1, expected[i], seed.Team.TeamID)
+		}
+		if seed.DivisionWinner != (i < 4) {
+			t.Errorf("Seed %d: unexpected division winner flag %v", i+1, seed.DivisionWinner)
+		}
+	}
+
+	if len(conf.Byes) != 1 || conf.Byes[0] != 1 {
+		t.Errorf("Expected a bye for seed 1, got %v", conf.Byes)
+	}
+	if len(conf.Rounds) != 3 {
+		t.Fatalf("Expected 3 rounds, got %d", len(conf.Rounds))
+	}
+	gamesPerRound := []int{3, 2, 1}
+	for r, games := range conf.Rounds {
+		if len(games) != gamesPerRound[r] {
+			t.Errorf("Round %d: expected %d games, got %d", r+1, gamesPerRound[r], len(games))
+		}
+	}
+
+	// Seed 1 waits for the winner of the 4 vs 5 game
+	game := conf.Rounds[1][0]
+	if game.High.Seed != 1 || game.Low.From == nil || game.Low.From.High.Seed != 4 || game.Low.From.Low.Seed != 5 {
+		t.Errorf("Expected seed 1 to meet the winner of 4 vs 5, got %+v", game)
+	}
+
+	text := bracket.Render()
+	for _, want := range []string{"First-round bye: (1)", "G1: (5) T02 vs (4) T13", "Conference Final", "Championship: Conference 1 champion vs Conference 2 champion"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected rendered bracket to contain '%s'\n%s", want, text)
+		}
+	}
+}
+
+func TestBuildPlayoffBracket_Byes(t *testing.T) {
+	tests := []struct {
+		playoffTeams int
+		byes         int
+		rounds       int
+	}{
+		{2, 0, 0},
+		{4, 0, 1},
+		{8, 0, 2},
+		{12, 2, 3},
+		{16, 0, 3},
+	}
+
+	for _, tt := range tests {
+		structure := models.NewLeagueStructure(32, 2, 16)
+		structure.PlayoffTeams = tt.playoffTeams
+		plan := NewPlan(structure, 2025)
+
+		bracket, err := BuildPlayoffBracket(structure, plan.Teams)
+		if err != nil {
+			t.Fatalf("%d playoff teams: BuildPlayoffBracket failed: %v", tt.playoffTeams, err)
+		}
+		conf := bracket.Conferences[1]
+		if len(conf.Byes) != tt.byes {
+			t.Errorf("%d playoff teams: expected %d byes, got %v", tt.playoffTeams, tt.byes, conf.Byes)
+		}
+		if len(conf.Rounds) != tt.rounds {
+			t.Errorf("%d playoff teams: expected %d rounds, got %d", tt.playoffTeams, tt.rounds, len(conf.Rounds))
+		}
+	}
+}
+
+func TestBuildPlayoffBracket_Impossible(t *testing.T) {
+	// Two spots per conference cannot hold four division winners
+	structure := models.NewLeagueStructure(32, 8, 17)
+	structure.PlayoffTeams = 4
+	if _, err := BuildPlayoffBracket(structure, NewPlan(structure, 2025).Teams); err == nil {
+		t.Error("Expected error for fewer spots than division winners")
+	}
+
+	// Standings missing a conference's teams
+	structure = models.NewLeagueStructure(8, 2, 7)
+	structure.PlayoffTeams = 4
+	teams := NewPlan(structure, 2025).Teams[:4]
+	if _, err := BuildPlayoffBracket(structure, teams); err == nil {
+		t.Error("Expected error for standings without the second conference")
+	}
+}
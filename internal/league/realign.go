@@ -0,0 +1,402 @@
+// ABOUTME: Geographic realignment of teams into conferences and divisions
+// ABOUTME: Proposes a layout with low intra-division travel for a league structure's division sizes
+
+package league
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// Assignment is a team's current and proposed place in the league
+type Assignment struct {
+	Year              int
+	TeamID            int
+	Abbreviation      string
+	CurrentConference int
+	CurrentDivision   int
+	Conference        int
+	Division          int
+}
+
+// Changed returns true if the proposal moves the team to another division
+func (a *Assignment) Changed() bool {
+	return a.Conference != a.CurrentConference || a.Division != a.CurrentDivision
+}
+
+// Alignment is a proposed conference and division for every team
+type Alignment struct {
+	Structure          *models.LeagueStructure
+	Assignments        []Assignment // In the same order as the teams passed in
+	CurrentTravelMiles float64      // Intra-division travel of the current layout
+	TravelMiles        float64      // Intra-division travel of the proposed layout
+}
+
+// ChangedCount returns the number of teams the proposal moves
+func (a *Alignment) ChangedCount() int {
+	count := 0
+	for i := range a.Assignments {
+		if a.Assignments[i].Changed() {
+			count++
+		}
+	}
+	return count
+}
+
+// Apply copies the proposed conferences and divisions onto matching teams
+// (same year and team ID) and returns the number of teams updated
+func (a *Alignment) Apply(teams []models.Team) int {
+	type teamKey struct{ year, teamID int }
+	proposed := make(map[teamKey]*Assignment, len(a.Assignments))
+	for i := range a.Assignments {
+		proposed[teamKey{a.Assignments[i].Year, a.Assignments[i].TeamID}] = &a.Assignments[i]
+	}
+
+	updated := 0
+	for i := range teams {
+		if assignment, ok := proposed[teamKey{teams[i].Year, teams[i].TeamID}]; ok {
+			teams[i].Conference = assignment.Conference
+			teams[i].Division = assignment.Division
+			updated++
+		}
+	}
+	return updated
+}
+
+// DivisionTravelMiles returns the total distance between every pair of teams
+// sharing a conference and division, using each team's current layout
+func DivisionTravelMiles(teams []models.Team, cities map[int]models.City) (float64, error) {
+	distances, err := distanceMatrix(teams, cities)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+	for i := range teams {
+		for j := i + 1; j < len(teams); j++ {
+			if teams[i].Conference == teams[j].Conference && teams[i].Division == teams[j].Division {
+				total += distances[i][j]
+			}
+		}
+	}
+	return total, nil
+}
+
+// ProposeAlignment assigns teams to the structure's divisions so the total
+// distance between division rivals is low. Divisions are first clustered by
+// travel, then grouped into compact conferences, and finally numbered to keep
+// as many teams as possible in their current conference and division.
+func ProposeAlignment(teams []models.Team, cities map[int]models.City, structure *models.LeagueStructure) (*Alignment, error) {
+	if len(teams) != structure.Teams {
+		return nil, fmt.Errorf("league structure %s has %d teams, got %d", structure.ScheduleID, structure.Teams, len(teams))
+	}
+	if structure.Divisions < 2 || structure.Divisions%2 != 0 {
+		return nil, fmt.Errorf("league structure %s needs an even number of divisions", structure.ScheduleID)
+	}
+
+	sizes := make([]int, structure.Divisions+1) // 1-based division index
+	total := 0
+	for d := 1; d <= structure.Divisions; d++ {
+		sizes[d] = structure.GetDivisionTeams(d)
+		total += sizes[d]
+	}
+	if total != len(teams) {
+		return nil, fmt.Errorf("divisions hold %d teams, league has %d", total, len(teams))
+	}
+
+	distances, err := distanceMatrix(teams, cities)
+	if err != nil {
+		return nil, err
+	}
+	currentTravel, _ := DivisionTravelMiles(teams, cities)
+
+	r := &realigner{
+		teams:     teams,
+		cities:    cities,
+		structure: structure,
+		sizes:     sizes,
+		distances: distances,
+	}
+
+	// Refine layouts seeded west-to-east and north-to-south and keep the better one
+	var best []int
+	bestTravel := 0.0
+	for _, seed := range [][]int{r.seed(true), r.seed(false)} {
+		r.improveDivisions(seed)
+		if travel := r.travel(seed); best == nil || travel < bestTravel {
+			best, bestTravel = seed, travel
+		}
+	}
+	r.improveConferences(best)
+	r.matchCurrentLabels(best)
+
+	alignment := &Alignment{
+		Structure:          structure,
+		Assignments:        make([]Assignment, len(teams)),
+		CurrentTravelMiles: currentTravel,
+		TravelMiles:        r.travel(best),
+	}
+	dpc := structure.DivisionsPerConference()
+	for i, team := range teams {
+		alignment.Assignments[i] = Assignment{
+			Year:              team.Year,
+			TeamID:            team.TeamID,
+			Abbreviation:      team.Abbreviation,
+			CurrentConference: team.Conference,
+			CurrentDivision:   team.Division,
+			Conference:        (best[i]-1)/dpc + 1,
+			Division:          (best[i]-1)%dpc + 1,
+		}
+	}
+
+	return alignment, nil
+}
+
+// distanceMatrix returns the distance in miles between every pair of teams' cities
+func distanceMatrix(teams []models.Team, cities map[int]models.City) ([][]float64, error) {
+	located := make([]models.City, len(teams))
+	var missing []string
+	for i, team := range teams {
+		city, ok := cities[team.City]
+		if !ok || !city.HasCoordinates() {
+			missing = append(missing, team.Abbreviation)
+			continue
+		}
+		located[i] = city
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no city coordinates for teams: %s", strings.Join(missing, ", "))
+	}
+
+	distances := make([][]float64, len(teams))
+	for i := range distances {
+		distances[i] = make([]float64, len(teams))
+		for j := 0; j < i; j++ {
+			distances[i][j] = located[i].DistanceMiles(&located[j])
+			distances[j][i] = distances[i][j]
+		}
+	}
+	return distances, nil
+}
+
+// realigner holds the inputs shared by the realignment steps. Layouts are
+// slices of 1-based league-wide division indexes, one per team.
+type realigner struct {
+	teams     []models.Team
+	cities    map[int]models.City
+	structure *models.LeagueStructure
+	sizes     []int
+	distances [][]float64
+}
+
+// seed creates a starting layout by splitting teams into conferences by
+// longitude (or latitude) and filling divisions in order of the other axis
+func (r *realigner) seed(byLongitude bool) []int {
+	order := make([]int, len(r.teams))
+	for i := range order {
+		order[i] = i
+	}
+	coordinate := func(i int, primary bool) float64 {
+		city := r.cities[r.teams[i].City]
+		lat, lon := city.Coordinates()
+		if primary == byLongitude {
+			return -lon // East first
+		}
+		return -lat // North first
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return coordinate(order[a], true) < coordinate(order[b], true)
+	})
+
+	dpc := r.structure.DivisionsPerConference()
+	layout := make([]int, len(r.teams))
+	start := 0
+	for conference := 0; conference < 2; conference++ {
+		count := 0
+		for d := conference*dpc + 1; d <= (conference+1)*dpc; d++ {
+			count += r.sizes[d]
+		}
+		members := order[start : start+count]
+		sort.SliceStable(members, func(a, b int) bool {
+			return coordinate(members[a], false) < coordinate(members[b], false)
+		})
+
+		next := 0
+		for d := conference*dpc + 1; d <= (conference+1)*dpc; d++ {
+			for k := 0; k < r.sizes[d]; k++ {
+				layout[members[next]] = d
+				next++
+			}
+		}
+		start += count
+	}
+
+	return layout
+}
+
+// travel returns the total distance between division rivals in a layout
+func (r *realigner) travel(layout []int) float64 {
+	total := 0.0
+	for i := range layout {
+		for j := i + 1; j < len(layout); j++ {
+			if layout[i] == layout[j] {
+				total += r.distances[i][j]
+			}
+		}
+	}
+	return total
+}
+
+// groupTravel returns the distance from team i to every other team in group
+func (r *realigner) groupTravel(layout []int, i, group int) float64 {
+	total := 0.0
+	for k, g := range layout {
+		if g == group && k != i {
+			total += r.distances[i][k]
+		}
+	}
+	return total
+}
+
+// improveDivisions swaps teams between divisions while any swap lowers travel
+func (r *realigner) improveDivisions(layout []int) {
+	const epsilon = 1e-9
+
+	for improved := true; improved; {
+		improved = false
+		for i := range layout {
+			for j := i + 1; j < len(layout); j++ {
+				a, b := layout[i], layout[j]
+				if a == b {
+					continue
+				}
+
+				before := r.groupTravel(layout, i, a) + r.groupTravel(layout, j, b)
+				after := r.groupTravel(layout, j, a) - r.distances[i][j] +
+					r.groupTravel(layout, i, b) - r.distances[i][j]
+				if after < before-epsilon {
+					layout[i], layout[j] = b, a
+					improved = true
+				}
+			}
+		}
+	}
+}
+
+// conferenceTravel returns the total distance between conference rivals in a layout
+func (r *realigner) conferenceTravel(layout []int) float64 {
+	dpc := r.structure.DivisionsPerConference()
+	total := 0.0
+	for i := range layout {
+		for j := i + 1; j < len(layout); j++ {
+			if (layout[i]-1)/dpc == (layout[j]-1)/dpc {
+				total += r.distances[i][j]
+			}
+		}
+	}
+	return total
+}
+
+// improveConferences exchanges whole divisions of equal size between the
+// conferences while that makes the conferences more compact
+func (r *realigner) improveConferences(layout []int) {
+	dpc := r.structure.DivisionsPerConference()
+
+	for improved := true; improved; {
+		improved = false
+		current := r.conferenceTravel(layout)
+		for a := 1; a <= dpc; a++ {
+			for b := dpc + 1; b <= r.structure.Divisions; b++ {
+				if r.sizes[a] != r.sizes[b] {
+					continue
+				}
+				swapGroups(layout, a, b)
+				if travel := r.conferenceTravel(layout); travel < current {
+					current = travel
+					improved = true
+				} else {
+					swapGroups(layout, a, b)
+				}
+			}
+		}
+	}
+}
+
+// matchCurrentLabels renumbers conferences and equal-size divisions within a
+// conference so as many teams as possible keep their current placement
+func (r *realigner) matchCurrentLabels(layout []int) {
+	dpc := r.structure.DivisionsPerConference()
+	kept := func() int {
+		count := 0
+		for i, d := range layout {
+			if r.teams[i].Conference == (d-1)/dpc+1 && r.teams[i].Division == (d-1)%dpc+1 {
+				count++
+			}
+		}
+		return count
+	}
+	keptConference := func() int {
+		count := 0
+		for i, d := range layout {
+			if r.teams[i].Conference == (d-1)/dpc+1 {
+				count++
+			}
+		}
+		return count
+	}
+
+	// Swap the conferences when their division sizes allow it
+	mirrored := true
+	for d := 1; d <= dpc; d++ {
+		mirrored = mirrored && r.sizes[d] == r.sizes[d+dpc]
+	}
+	if mirrored {
+		before := keptConference()
+		for d := 1; d <= dpc; d++ {
+			swapGroups(layout, d, d+dpc)
+		}
+		if keptConference() <= before {
+			for d := 1; d <= dpc; d++ {
+				swapGroups(layout, d, d+dpc)
+			}
+		}
+	}
+
+	// Then renumber divisions within each conference
+	for improved := true; improved; {
+		improved = false
+		current := kept()
+		for conference := 0; conference < 2; conference++ {
+			for a := conference*dpc + 1; a <= (conference+1)*dpc; a++ {
+				for b := a + 1; b <= (conference+1)*dpc; b++ {
+					if r.sizes[a] != r.sizes[b] {
+						continue
+					}
+					swapGroups(layout, a, b)
+					if count := kept(); count > current {
+						current = count
+						improved = true
+					} else {
+						swapGroups(layout, a, b)
+					}
+				}
+			}
+		}
+	}
+}
+
+// swapGroups exchanges the members of two divisions in a layout
+func swapGroups(layout []int, a, b int) {
+	for i, d := range layout {
+		switch d {
+		case a:
+			layout[i] = b
+		case b:
+			layout[i] = a
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// ABOUTME: Tests for geographic realignment of teams
+// ABOUTME: Validates proposals against fixture and shipped league data
+
+package league
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func loadRealignFixture(t *testing.T) ([]models.Team, map[int]models.City) {
+	t.Helper()
+
+	teams, err := data.LoadTeams("../../testdata/fixtures/csv/teams_league4.csv")
+	if err != nil {
+		t.Fatalf("LoadTeams failed: %v", err)
+	}
+	cities, err := data.LoadCities("../../testdata/fixtures/csv/cities_simple.csv")
+	if err != nil {
+		t.Fatalf("LoadCities failed: %v", err)
+	}
+	return teams, cityMap(cities)
+}
+
+func cityMap(cities []models.City) map[int]models.City {
+	reference := &models.ReferenceData{}
+	reference.SetCities(cities)
+	return reference.Cities
+}
+
+func TestProposeAlignment_RegroupsNeighbours(t *testing.T) {
+	teams, cities := loadRealignFixture(t)
+
+	// Pair Boston with Los Angeles and New York with Miami
+	teams[1].Conference, teams[1].Division = 2, 1
+	teams[3].Conference, teams[3].Division = 1, 1
+
+	alignment, err := ProposeAlignment(teams, cities, models.NewLeagueStructure(4, 2, 3))
+	if err != nil {
+		t.Fatalf("ProposeAlignment failed: %v", err)
+	}
+
+	byAbbr := make(map[string]*Assignment)
+	for i := range alignment.Assignments {
+		byAbbr[alignment.Assignments[i].Abbreviation] = &alignment.Assignments[i]
+	}
+	if byAbbr["BOS"].Conference != byAbbr["NYE"].Conference {
+		t.Error("Expected Boston and New York in the same division")
+	}
+	if byAbbr["MIA"].Conference != byAbbr["LAS"].Conference {
+		t.Error("Expected Miami and Los Angeles in the same division")
+	}
+
+	// Boston and Miami keep their conferences; the others swap
+	if byAbbr["BOS"].Changed() || byAbbr["MIA"].Changed() {
+		t.Error("Expected Boston and Miami to keep their divisions")
+	}
+	if alignment.ChangedCount() != 2 {
+		t.Errorf("Expected 2 changed teams, got %d", alignment.ChangedCount())
+	}
+	if alignment.TravelMiles >= alignment.CurrentTravelMiles {
+		t.Errorf("Expected proposed travel %.0f below current %.0f", alignment.TravelMiles, alignment.CurrentTravelMiles)
+	}
+
+	updated := alignment.Apply(teams)
+	if updated != 4 {
+		t.Errorf("Expected 4 teams updated, got %d", updated)
+	}
+	if teams[1].Conference != 1 || teams[3].Conference != 2 {
+		t.Errorf("Expected applied conferences 1 and 2, got %d and %d", teams[1].Conference, teams[3].Conference)
+	}
+}
+
+func TestProposeAlignment_Errors(t *testing.T) {
+	teams, cities := loadRealignFixture(t)
+
+	if _, err := ProposeAlignment(teams, cities, models.NewLeagueStructure(6, 2, 5)); err == nil {
+		t.Error("Expected error for team count mismatch")
+	}
+
+	teams[0].City = 99999
+	if _, err := ProposeAlignment(teams, cities, models.NewLeagueStructure(4, 2, 3)); err == nil {
+		t.Error("Expected error for team without city coordinates")
+	}
+}
+
+func TestProposeAlignment_DefaultLeague(t *testing.T) {
+	structures, err := data.LoadLeagueStructures("../../default_data/league_info.csv")
+	if err != nil {
+		t.Fatalf("LoadLeagueStructures failed: %v", err)
+	}
+	defaultTeams, err := data.LoadDefaultTeams("../../default_data/default_teams.csv")
+	if err != nil {
+		t.Fatalf("LoadDefaultTeams failed: %v", err)
+	}
+	cities, err := data.LoadCities("../../default_data/cities.csv")
+	if err != nil {
+		t.Fatalf("LoadCities failed: %v", err)
+	}
+
+	var structure *models.LeagueStructure
+	for i := range structures {
+		if structures[i].ScheduleID == "32_8_18" {
+			structure = &structures[i]
+		}
+	}
+	if structure == nil {
+		t.Fatal("Expected 32_8_18 league structure")
+	}
+
+	var teams []models.Team
+	for _, team := range defaultTeams {
+		if team.League == structure.ScheduleID {
+			teams = append(teams, team.ToTeam())
+		}
+	}
+
+	alignment, err := ProposeAlignment(teams, cityMap(cities), structure)
+	if err != nil {
+		t.Fatalf("ProposeAlignment failed: %v", err)
+	}
+	if alignment.TravelMiles > alignment.CurrentTravelMiles {
+		t.Errorf("Expected proposed travel %.0f not above current %.0f", alignment.TravelMiles, alignment.CurrentTravelMiles)
+	}
+
+	counts := make(map[[2]int]int)
+	for _, a := range alignment.Assignments {
+		counts[[2]int{a.Conference, a.Division}]++
+	}
+	if len(counts) != 8 {
+		t.Errorf("Expected 8 divisions, got %d", len(counts))
+	}
+	for key, count := range counts {
+		if count != 4 {
+			t.Errorf("Expected 4 teams in conference %d division %d, got %d", key[0], key[1], count)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// ABOUTME: This file defines the City reference data structure for FOF9 custom leagues
+// ABOUTME: It mirrors cities.csv and provides geographic helpers such as distance between cities
+package models
+
+import "math"
+
+// earthRadiusMiles is the mean radius of the Earth used for great-circle distances
+const earthRadiusMiles = 3958.8
+
+// City represents a row of the game's cities.csv reference table
+type City struct {
+	CityID      int    `csv:"CITYID"`
+	Name        string `csv:"NAME"`
+	RegionCode  int    `csv:"RCODE"`
+	Region      string `csv:"REGION"`
+	CountryCode int    `csv:"CCODE"`
+	Country     string `csv:"COUNTRY"`
+	Area        int    `csv:"AREA"`
+	Population  int    `csv:"POPULATION"`
+	Income      int    `csv:"INCOME"`
+	Latitude    int    `csv:"LATITUDE"`  // Degrees multiplied by 1000
+	Longitude   int    `csv:"LONGITUDE"` // Degrees multiplied by 1000
+	MetroArea   int    `csv:"METROAREA"`
+	IsHost      int    `csv:"ISHOST"`
+	IsHome      int    `csv:"ISHOME"`
+	Closest     int    `csv:"CLOSEST"`
+	TVArea      int    `csv:"TVAREA"`
+	DistTV      int    `csv:"DISTTV"`
+}
+
+// GetDisplayName returns the city name with its region, e.g. "Boston, MA"
+func (c *City) GetDisplayName() string {
+	if c.Region == "" || c.Region == "ZZ" {
+		return c.Name
+	}
+	return c.Name + ", " + c.Region
+}
+
+// BirthCity returns the city as person files write BIRTHCITY, e.g. "Boston_MA"
+func (c *City) BirthCity() string {
+	return c.Name + "_" + c.Region
+}
+
+// HasBirthCityRegion returns true for US and Canadian cities, whose region is
+// the state or province BIRTHCITY ends with. Elsewhere the shipped files end
+// BIRTHCITY with the country name instead.
+func (c *City) HasBirthCityRegion() bool {
+	return c.Country == "US" || c.Country == "CA"
+}
+
+// HasCoordinates returns true if the city has a latitude/longitude set
+func (c *City) HasCoordinates() bool {
+	return c.Latitude != 0 || c.Longitude != 0
+}
+
+// Coordinates returns the latitude and longitude in degrees
+func (c *City) Coordinates() (float64, float64) {
+	return float64(c.Latitude) / 1000, float64(c.Longitude) / 1000
+}
+
+// DistanceMiles returns the great-circle distance in miles between two cities
+func (c *City) DistanceMiles(other *City) float64 {
+	lat1, lon1 := c.Coordinates()
+	lat2, lon2 := other.Coordinates()
+
+	toRadians := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMiles * math.Asin(math.Sqrt(a))
+}
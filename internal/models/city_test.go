@@ -0,0 +1,118 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCityGetDisplayName(t *testing.T) {
+	tests := []struct {
+		city     City
+		expected string
+	}{
+		{City{Name: "Boston", Region: "MA"}, "Boston, MA"},
+		{City{Name: "Another Country", Region: "ZZ"}, "Another Country"},
+		{City{Name: "Frankfurt"}, "Frankfurt"},
+	}
+
+	for _, tt := range tests {
+		actual := tt.city.GetDisplayName()
+		if actual != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, actual)
+		}
+	}
+}
+
+func TestCityBirthCity(t *testing.T) {
+	boston := City{Name: "Boston", Region: "MA", Country: "US"}
+	if boston.BirthCity() != "Boston_MA" || !boston.HasBirthCityRegion() {
+		t.Errorf("Expected Boston_MA with a state, got %s", boston.BirthCity())
+	}
+
+	lagos := City{Name: "Lagos", Region: "NG", Country: "NG"}
+	if lagos.HasBirthCityRegion() {
+		t.Error("Expected cities outside the US and Canada to have no birth city region")
+	}
+}
+
+func TestCityCoordinates(t *testing.T) {
+	city := &City{Latitude: 60903, Longitude: -161422}
+
+	lat, lon := city.Coordinates()
+	if lat != 60.903 || lon != -161.422 {
+		t.Errorf("Expected (60.903, -161.422), got (%v, %v)", lat, lon)
+	}
+
+	if !city.HasCoordinates() {
+		t.Error("Expected HasCoordinates to return true")
+	}
+
+	empty := &City{}
+	if empty.HasCoordinates() {
+		t.Error("Expected HasCoordinates to return false for zero coordinates")
+	}
+}
+
+func TestCityDistanceMiles(t *testing.T) {
+	newYork := &City{Name: "New York", Latitude: 40713, Longitude: -74006}
+	losAngeles := &City{Name: "Los Angeles", Latitude: 34052, Longitude: -118244}
+
+	distance := newYork.DistanceMiles(losAngeles)
+	// Great-circle distance is roughly 2,445 miles
+	if math.Abs(distance-2445) > 10 {
+		t.Errorf("Expected distance near 2445 miles, got %.1f", distance)
+	}
+
+	if reverse := losAngeles.DistanceMiles(newYork); math.Abs(reverse-distance) > 0.001 {
+		t.Errorf("Expected symmetric distance, got %.3f and %.3f", distance, reverse)
+	}
+
+	if same := newYork.DistanceMiles(newYork); same != 0 {
+		t.Errorf("Expected zero distance to itself, got %.3f", same)
+	}
+}
+
+func TestReferenceDataCities(t *testing.T) {
+	refData := NewReferenceData()
+	if refData.HasCities() {
+		t.Error("Expected no cities by default")
+	}
+
+	refData.SetCities([]City{
+		{CityID: 7215, Name: "Boston", Region: "MA"},
+		{CityID: 13493, Name: "New York", Region: "NY"},
+	})
+
+	if !refData.HasCities() {
+		t.Error("Expected cities after SetCities")
+	}
+	if name := refData.GetCityNameByID(7215); name != "Boston, MA" {
+		t.Errorf("Expected 'Boston, MA', got '%s'", name)
+	}
+	if name := refData.GetCityNameByID(1); name != "Unknown City" {
+		t.Errorf("Expected 'Unknown City', got '%s'", name)
+	}
+}
+
+func TestMarketPopulation(t *testing.T) {
+	refs := NewReferenceData()
+	refs.SetCities([]City{
+		{CityID: 1, Population: 107000, MetroArea: 347},
+		{CityID: 2, Population: 5000},
+	})
+	refs.SetMetros([]MetroArea{{MetroID: 347, Name: "Green Bay", Population: 322906}})
+
+	tests := []struct {
+		cityID   int
+		expected int
+	}{
+		{1, 322906}, // Metro area population
+		{2, 5000},   // Outside any metro area
+		{3, 0},      // Unknown city
+	}
+	for _, tt := range tests {
+		if got := refs.MarketPopulation(tt.cityID); got != tt.expected {
+			t.Errorf("City %d: expected %d, got %d", tt.cityID, tt.expected, got)
+		}
+	}
+}
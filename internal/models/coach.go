@@ -2,15 +2,49 @@
 // ABOUTME: It includes coach attributes, position types, and coaching styles
 package models
 
-// Coach position constants
+// Coach position constants (POSITION column)
 const (
-	PositionHeadCoach                 = 0
-	PositionOffensiveCoordinator      = 1
-	PositionDefensiveCoordinator      = 2
-	PositionSpecialTeamsCoordinator   = 3
-	PositionStrengthConditioning      = 4
+	PositionHeadCoach            = 0
+	PositionOffensiveCoordinator = 1
+	PositionDefensiveCoordinator = 2
+	PositionAssistantCoach       = 3
+	PositionStrengthCoach        = 4
+	PositionUnemployed           = 12
 )
 
+// CoachPositions lists the POSITION values in display order
+var CoachPositions = []int{
+	PositionHeadCoach,
+	PositionOffensiveCoordinator,
+	PositionDefensiveCoordinator,
+	PositionAssistantCoach,
+	PositionStrengthCoach,
+	PositionUnemployed,
+}
+
+// Coach position group constants (POSITIONGROUP column)
+const (
+	CoachGroupQuarterbacks  = 3
+	CoachGroupRunningBacks  = 4
+	CoachGroupTightEnds     = 5
+	CoachGroupWideReceivers = 6
+	CoachGroupOffensiveLine = 7
+	CoachGroupDefensiveLine = 8
+	CoachGroupLinebackers   = 9
+	CoachGroupSecondary     = 10
+	CoachGroupStrength      = 11
+)
+
+// IsOffensiveCoachGroup returns true for the quarterback to offensive line groups
+func IsOffensiveCoachGroup(group int) bool {
+	return group >= CoachGroupQuarterbacks && group <= CoachGroupOffensiveLine
+}
+
+// IsDefensiveCoachGroup returns true for the defensive line, linebacker and secondary groups
+func IsDefensiveCoachGroup(group int) bool {
+	return group >= CoachGroupDefensiveLine && group <= CoachGroupSecondary
+}
+
 // Coach represents a football coach with all attributes
 type Coach struct {
 	// Basic Info
@@ -32,8 +66,8 @@ type Coach struct {
 
 	// Position and Team
 	Team          int `csv:"TEAM"`
-	Position      int `csv:"POSITION"`      // 0=Head Coach, 1=OC, 2=DC, 3=ST, 4=S&C
-	PositionGroup int `csv:"POSITIONGROUP"` // Position-specific role
+	Position      int `csv:"POSITION"`      // 0=Head Coach, 1=OC, 2=DC, 3=Assistant, 4=Strength, 12=Unemployed
+	PositionGroup int `csv:"POSITIONGROUP"` // 3-7 offense, 8-10 defense, 11 strength
 
 	// Coaching Styles
 	OffensiveStyle int `csv:"OFFENSIVESTYLE"` // 0-6
@@ -50,17 +84,56 @@ func (c *Coach) GetDisplayName() string {
 
 // GetPositionName returns the human-readable position name
 func (c *Coach) GetPositionName() string {
-	switch c.Position {
+	return GetCoachPositionName(c.Position)
+}
+
+// IsUnemployed returns true if the coach has no team (TEAM 0) or the
+// unemployed position (POSITION 12)
+func (c *Coach) IsUnemployed() bool {
+	return c.Team == 0 || c.Position == PositionUnemployed
+}
+
+// GetCoachPositionName returns the name of a POSITION value, or "Unknown"
+func GetCoachPositionName(position int) string {
+	switch position {
 	case PositionHeadCoach:
 		return "Head Coach"
 	case PositionOffensiveCoordinator:
 		return "Offensive Coordinator"
 	case PositionDefensiveCoordinator:
 		return "Defensive Coordinator"
-	case PositionSpecialTeamsCoordinator:
-		return "Special Teams Coordinator"
-	case PositionStrengthConditioning:
-		return "Strength & Conditioning"
+	case PositionAssistantCoach:
+		return "Assistant Coach"
+	case PositionStrengthCoach:
+		return "Strength Coach"
+	case PositionUnemployed:
+		return "Unemployed"
+	default:
+		return "Unknown"
+	}
+}
+
+// GetCoachGroupName returns the name of a POSITIONGROUP value, or "Unknown"
+func GetCoachGroupName(group int) string {
+	switch group {
+	case CoachGroupQuarterbacks:
+		return "Quarterbacks"
+	case CoachGroupRunningBacks:
+		return "Running Backs"
+	case CoachGroupTightEnds:
+		return "Tight Ends"
+	case CoachGroupWideReceivers:
+		return "Wide Receivers"
+	case CoachGroupOffensiveLine:
+		return "Offensive Line"
+	case CoachGroupDefensiveLine:
+		return "Defensive Line"
+	case CoachGroupLinebackers:
+		return "Linebackers"
+	case CoachGroupSecondary:
+		return "Secondary"
+	case CoachGroupStrength:
+		return "Strength"
 	default:
 		return "Unknown"
 	}
@@ -26,8 +26,8 @@ func TestCoachGetPositionName(t *testing.T) {
 		{PositionHeadCoach, "Head Coach"},
 		{PositionOffensiveCoordinator, "Offensive Coordinator"},
 		{PositionDefensiveCoordinator, "Defensive Coordinator"},
-		{PositionSpecialTeamsCoordinator, "Special Teams Coordinator"},
-		{PositionStrengthConditioning, "Strength & Conditioning"},
+		{PositionAssistantCoach, "Assistant Coach"},
+		{PositionStrengthCoach, "Strength Coach"},
 		{99, "Unknown"},
 	}
 
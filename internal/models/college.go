@@ -0,0 +1,20 @@
+// ABOUTME: This file defines the College reference data structure for FOF9 custom leagues
+// ABOUTME: It mirrors colleges.csv, which players and coaches reference by COLLEGEID
+package models
+
+// College represents a row of the game's colleges.csv reference table
+type College struct {
+	CollegeID int    `csv:"COLLEGEID"`
+	Name      string `csv:"NAME"`
+	NickName  string `csv:"NICKNAME"`
+	CityID    int    `csv:"CITYID"`
+	Region    string `csv:"STPRV"`
+	Level     int    `csv:"LEVEL"`
+	Football  int    `csv:"FOOTBALL"`
+	Division  int    `csv:"DIVISION"`
+}
+
+// GetDisplayName returns the college name, e.g. "Alabama"
+func (c *College) GetDisplayName() string {
+	return c.Name
+}
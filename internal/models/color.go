@@ -0,0 +1,105 @@
+// ABOUTME: This file defines RGB team colors and the color measures the game and WCAG use
+// ABOUTME: It computes team_colors.csv DARKNESS, WCAG contrast ratios and nearby shades of a color
+package models
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+)
+
+// ReservedMagenta is the color the game draws as transparent in logos and portraits
+var ReservedMagenta = RGB{255, 0, 255}
+
+// MinPrimaryDarkness is the team_colors.csv DARKNESS below which a color
+// should not be a primary color, as light text is drawn on primaries
+const MinPrimaryDarkness = 50
+
+// RGB is a team color with components from 0 to 255
+type RGB struct {
+	Red, Green, Blue int
+}
+
+// String returns the color as "R,G,B", e.g. "255,0,255"
+func (c RGB) String() string {
+	return fmt.Sprintf("%d,%d,%d", c.Red, c.Green, c.Blue)
+}
+
+// Color returns the color as a color.Color, clamping components to 0-255
+func (c RGB) Color() color.Color {
+	return color.RGBA{R: clampComponent(c.Red), G: clampComponent(c.Green), B: clampComponent(c.Blue), A: 255}
+}
+
+// Darkness returns the color's DARKNESS as team_colors.csv computes it: 100
+// less the perceived brightness as a percentage
+func (c RGB) Darkness() int {
+	brightness := 0.299*float64(c.Red) + 0.587*float64(c.Green) + 0.114*float64(c.Blue)
+	return int(math.Round(100 - brightness/2.55))
+}
+
+// RelativeLuminance returns the WCAG relative luminance of the color, 0 for
+// black to 1 for white
+func (c RGB) RelativeLuminance() float64 {
+	linear := func(component int) float64 {
+		v := float64(component) / 255
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(c.Red) + 0.7152*linear(c.Green) + 0.0722*linear(c.Blue)
+}
+
+// ContrastRatio returns the WCAG contrast ratio of two colors, from 1 for
+// equal luminance to 21 for black on white
+func ContrastRatio(a, b RGB) float64 {
+	lighter, darker := a.RelativeLuminance(), b.RelativeLuminance()
+	if lighter < darker {
+		lighter, darker = darker, lighter
+	}
+	return (lighter + 0.05) / (darker + 0.05)
+}
+
+// Distance returns the Euclidean distance between two colors in RGB space
+func (c RGB) Distance(other RGB) float64 {
+	dr, dg, db := float64(c.Red-other.Red), float64(c.Green-other.Green), float64(c.Blue-other.Blue)
+	return math.Sqrt(dr*dr + dg*dg + db*db)
+}
+
+// Mix returns the color moved a fraction t (0-1) of the way to other
+func (c RGB) Mix(other RGB, t float64) RGB {
+	mix := func(from, to int) int {
+		return int(math.Round(float64(from) + (float64(to)-float64(from))*t))
+	}
+	return RGB{mix(c.Red, other.Red), mix(c.Green, other.Green), mix(c.Blue, other.Blue)}
+}
+
+// NearestShade returns the shade closest to the color that is acceptable:
+// the color darkened or lightened in steps of 1% until acceptable returns
+// true, preferring the darker shade at equal steps. It returns false if no
+// shade is acceptable.
+func (c RGB) NearestShade(acceptable func(RGB) bool) (RGB, bool) {
+	black, white := RGB{0, 0, 0}, RGB{255, 255, 255}
+	for step := 1; step <= 100; step++ {
+		t := float64(step) / 100
+		if shade := c.Mix(black, t); acceptable(shade) {
+			return shade, true
+		}
+		if shade := c.Mix(white, t); acceptable(shade) {
+			return shade, true
+		}
+	}
+	return c, false
+}
+
+// clampComponent limits a color component to a byte
+func clampComponent(value int) uint8 {
+	switch {
+	case value < 0:
+		return 0
+	case value > 255:
+		return 255
+	default:
+		return uint8(value)
+	}
+}
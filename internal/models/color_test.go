@@ -0,0 +1,52 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRGBDarkness(t *testing.T) {
+	// Rows of the game's team_colors.csv with their DARKNESS
+	tests := []struct {
+		color    RGB
+		expected int
+	}{
+		{RGB{63, 16, 16}, 88},
+		{RGB{170, 0, 0}, 80},
+		{RGB{117, 40, 40}, 75},
+		{RGB{0, 0, 0}, 100},
+		{RGB{255, 255, 255}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.color.Darkness(); got != tt.expected {
+			t.Errorf("%s: expected DARKNESS %d, got %d", tt.color, tt.expected, got)
+		}
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	black, white := RGB{0, 0, 0}, RGB{255, 255, 255}
+
+	if got := ContrastRatio(black, white); math.Abs(got-21) > 0.01 {
+		t.Errorf("Expected 21:1 for black on white, got %.2f", got)
+	}
+	if got := ContrastRatio(white, black); math.Abs(got-21) > 0.01 {
+		t.Errorf("Expected the ratio regardless of order, got %.2f", got)
+	}
+	if got := ContrastRatio(ReservedMagenta, ReservedMagenta); got != 1 {
+		t.Errorf("Expected 1:1 for equal colors, got %.2f", got)
+	}
+}
+
+func TestRGBNearestShade(t *testing.T) {
+	darkEnough := func(c RGB) bool { return c.Darkness() >= MinPrimaryDarkness }
+
+	shade, ok := RGB{162, 141, 91}.NearestShade(darkEnough)
+	if !ok || shade != (RGB{147, 128, 83}) {
+		t.Errorf("Expected the Saints gold darkened to 147,128,83, got %s (%v)", shade, ok)
+	}
+
+	if _, ok := ReservedMagenta.NearestShade(func(RGB) bool { return false }); ok {
+		t.Error("Expected no shade when none is acceptable")
+	}
+}
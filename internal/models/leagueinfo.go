@@ -2,7 +2,19 @@
 // ABOUTME: It manages league configuration including schedule, salary cap, and salary minimums
 package models
 
-import "strings"
+import (
+	"math"
+	"strings"
+)
+
+// BASE_YEAR limits enforced by the game
+const (
+	MinBaseYear = 1900
+	MaxBaseYear = 2199
+)
+
+// SalaryMinimumColumns are the salary minimum columns in order of experience
+var SalaryMinimumColumns = []string{"MINIMUM", "SALARY1", "SALARY2", "SALARY3", "SALARY45", "SALARY789", "SALARY10"}
 
 // LeagueInfo represents the league configuration settings
 type LeagueInfo struct {
@@ -15,7 +27,7 @@ type LeagueInfo struct {
 	Salary1   int `csv:"SALARY1"`   // 1 year experience
 	Salary2   int `csv:"SALARY2"`   // 2 years experience
 	Salary3   int `csv:"SALARY3"`   // 3 years experience
-	Salary45  int `csv:"SALARY45"`  // 4-5 years experience
+	Salary45  int `csv:"SALARY45"`  // 4-6 years experience
 	Salary789 int `csv:"SALARY789"` // 7-9 years experience
 	Salary10  int `csv:"SALARY10"`  // 10+ years experience
 }
@@ -36,10 +48,13 @@ func NewDefaultLeagueInfo(baseYear int) *LeagueInfo {
 	}
 }
 
-// GetSalaryMinimum returns the appropriate salary minimum based on experience
+// GetSalaryMinimum returns the appropriate salary minimum based on experience.
+// The game's docs describe SALARY45 as "4-5 years" and SALARY789 as "7-9
+// years", leaving 6 uncovered; as in the NFL's minimum salary scale, 6 years
+// shares the 4-5 year bracket.
 func (l *LeagueInfo) GetSalaryMinimum(experience int) int {
 	switch {
-	case experience == 0:
+	case experience <= 0:
 		return l.Minimum
 	case experience == 1:
 		return l.Salary1
@@ -47,15 +62,54 @@ func (l *LeagueInfo) GetSalaryMinimum(experience int) int {
 		return l.Salary2
 	case experience == 3:
 		return l.Salary3
-	case experience >= 4 && experience <= 5:
+	case experience <= 6:
 		return l.Salary45
-	case experience >= 7 && experience <= 9:
+	case experience <= 9:
 		return l.Salary789
-	case experience >= 10:
-		return l.Salary10
 	default:
-		return l.Minimum
+		return l.Salary10
+	}
+}
+
+// SalaryMinimums returns the salary minimums in the order of SalaryMinimumColumns
+func (l *LeagueInfo) SalaryMinimums() []int {
+	return []int{l.Minimum, l.Salary1, l.Salary2, l.Salary3, l.Salary45, l.Salary789, l.Salary10}
+}
+
+// SetSalaryMinimums sets the salary minimums from values in the order of SalaryMinimumColumns
+func (l *LeagueInfo) SetSalaryMinimums(values []int) {
+	fields := []*int{&l.Minimum, &l.Salary1, &l.Salary2, &l.Salary3, &l.Salary45, &l.Salary789, &l.Salary10}
+	for i := range fields {
+		if i < len(values) {
+			*fields[i] = values[i]
+		}
+	}
+}
+
+// ScaledMinimums returns the reference structure's salary minimums scaled
+// to this league's salary cap, keeping each minimum-to-cap ratio, or nil if
+// the reference has no salary cap
+func (l *LeagueInfo) ScaledMinimums(reference *LeagueStructure) []int {
+	if reference == nil || reference.SalaryCap <= 0 {
+		return nil
+	}
+	scaled := reference.SalaryMinimums()
+	for i, value := range scaled {
+		scaled[i] = int(math.Round(float64(value) * float64(l.SalaryCap) / float64(reference.SalaryCap)))
+	}
+	return scaled
+}
+
+// RescaleMinimums sets the salary minimums to the reference structure's
+// scaled to this league's salary cap. It returns false and changes nothing
+// if the reference has no salary cap.
+func (l *LeagueInfo) RescaleMinimums(reference *LeagueStructure) bool {
+	scaled := l.ScaledMinimums(reference)
+	if scaled == nil {
+		return false
 	}
+	l.SetSalaryMinimums(scaled)
+	return true
 }
 
 // ValidateScheduleID checks if the ScheduleID has the correct format "x_y_z"
@@ -42,7 +42,7 @@ func TestGetSalaryMinimum(t *testing.T) {
 		{3, 115},  // 3 years
 		{4, 130},  // 4 years
 		{5, 130},  // 5 years
-		{6, 70},   // 6 years (falls through to minimum)
+		{6, 130},  // 6 years (shares the 4-5 bracket)
 		{7, 150},  // 7 years
 		{8, 150},  // 8 years
 		{9, 150},  // 9 years
@@ -117,3 +117,35 @@ func TestLeagueInfoStructFields(t *testing.T) {
 		t.Error("Expected Salary789 < Salary10")
 	}
 }
+
+func TestRescaleMinimums(t *testing.T) {
+	reference := &LeagueStructure{ScheduleID: "32_8_17", SalaryCap: 2248,
+		Minimum: 75, Salary1: 87, Salary2: 94, Salary3: 101, Salary45: 108, Salary789: 117, Salary10: 117}
+	info := &LeagueInfo{ScheduleID: "12_2_16", SalaryCap: 524,
+		Minimum: 17, Salary1: 22, Salary2: 28, Salary3: 33, Salary45: 39, Salary789: 48, Salary10: 55}
+
+	expected := []int{17, 20, 22, 24, 25, 27, 27}
+	if !info.RescaleMinimums(reference) {
+		t.Fatal("Expected the minimums to be rescaled")
+	}
+	for i, got := range info.SalaryMinimums() {
+		if got != expected[i] {
+			t.Errorf("%s: expected %d, got %d", SalaryMinimumColumns[i], expected[i], got)
+		}
+	}
+
+	if info.RescaleMinimums(&LeagueStructure{}) || info.RescaleMinimums(nil) {
+		t.Error("Expected no rescaling without a reference salary cap")
+	}
+}
+
+func TestFindLeagueStructure(t *testing.T) {
+	structures := []LeagueStructure{{ScheduleID: "32_8_17"}, {ScheduleID: "12_2_16"}}
+
+	if got := FindLeagueStructure(structures, "12_2_16"); got != &structures[1] {
+		t.Errorf("Expected the 12_2_16 structure, got %+v", got)
+	}
+	if got := FindLeagueStructure(structures, "8_2_14"); got != nil {
+		t.Errorf("Expected no structure, got %+v", got)
+	}
+}
@@ -0,0 +1,272 @@
+// ABOUTME: This file defines league structure data for FOF9 custom leagues
+// ABOUTME: It mirrors league_info.csv (league formats) and default_teams.csv (teams per format)
+package models
+
+import "fmt"
+
+// MaxDivisions is the maximum number of divisions supported by the game
+const MaxDivisions = 8
+
+// MaxTeamsPerDivision is the maximum number of teams in a single division
+const MaxTeamsPerDivision = 8
+
+// MaxTeams is the maximum number of teams in a league
+const MaxTeams = MaxDivisions * MaxTeamsPerDivision
+
+// LeagueStructure represents a row of league_info.csv describing a league format
+type LeagueStructure struct {
+	ScheduleID   string `csv:"SCHEDULEID"`   // Format: "x_y_z" (teams_divisions_games)
+	Teams        int    `csv:"TEAMS"`        // Total number of teams
+	Divisions    int    `csv:"DIVISIONS"`    // Even, at most 8
+	PlayoffTeams int    `csv:"PLAYOFFTEAMS"` // Even, less than Teams
+	Games        int    `csv:"GAMES"`        // Regular season games per team
+	Weeks        int    `csv:"WEEKS"`        // Regular season weeks
+	ExGames      int    `csv:"EXGAMES"`      // Exhibition games per team
+	ExWeeks      int    `csv:"EXWEEKS"`      // Exhibition weeks
+
+	// Conferences
+	Conf1     string `csv:"CONF1"`
+	Conf1Abbr string `csv:"CONF1ABBR"`
+	Conf2     string `csv:"CONF2"`
+	Conf2Abbr string `csv:"CONF2ABBR"`
+
+	// Divisions (first conference first, blank/0 when unused)
+	Div1      string `csv:"DIV1"`
+	Div1Teams int    `csv:"DIV1TEAMS"`
+	Div2      string `csv:"DIV2"`
+	Div2Teams int    `csv:"DIV2TEAMS"`
+	Div3      string `csv:"DIV3"`
+	Div3Teams int    `csv:"DIV3TEAMS"`
+	Div4      string `csv:"DIV4"`
+	Div4Teams int    `csv:"DIV4TEAMS"`
+	Div5      string `csv:"DIV5"`
+	Div5Teams int    `csv:"DIV5TEAMS"`
+	Div6      string `csv:"DIV6"`
+	Div6Teams int    `csv:"DIV6TEAMS"`
+	Div7      string `csv:"DIV7"`
+	Div7Teams int    `csv:"DIV7TEAMS"`
+	Div8      string `csv:"DIV8"`
+	Div8Teams int    `csv:"DIV8TEAMS"`
+
+	Championship string `csv:"CHAMPIONSHIP"`
+
+	// Salary cap (units of $100,000) and salary minimums (units of $10,000)
+	SalaryCap int `csv:"SALARYCAP"`
+	Minimum   int `csv:"MINIMUM"`
+	Salary1   int `csv:"SALARY1"`
+	Salary2   int `csv:"SALARY2"`
+	Salary3   int `csv:"SALARY3"`
+	Salary45  int `csv:"SALARY45"`
+	Salary789 int `csv:"SALARY789"`
+	Salary10  int `csv:"SALARY10"`
+
+	// Schedule rotations
+	Rotations    int `csv:"ROTATIONS"`
+	RotationBase int `csv:"ROTATIONBASE"`
+}
+
+// NewLeagueStructure creates a league structure with the default salary
+// settings of the game's 32-team leagues
+func NewLeagueStructure(teams, divisions, games int) *LeagueStructure {
+	ls := &LeagueStructure{
+		Teams:        teams,
+		Divisions:    divisions,
+		PlayoffTeams: 12,
+		Games:        games,
+		Weeks:        games + 1,
+		ExGames:      3,
+		ExWeeks:      3,
+		Conf1:        "Conference 1",
+		Conf1Abbr:    "C1",
+		Conf2:        "Conference 2",
+		Conf2Abbr:    "C2",
+		Championship: "Championship",
+		SalaryCap:    2248,
+		Minimum:      75,
+		Salary1:      87,
+		Salary2:      94,
+		Salary3:      101,
+		Salary45:     108,
+		Salary789:    117,
+		Salary10:     117,
+		Rotations:    1,
+		RotationBase: 2002,
+	}
+	if teams > 0 && ls.PlayoffTeams >= teams {
+		ls.PlayoffTeams = (teams / 2) &^ 1
+	}
+	ls.UpdateScheduleID()
+
+	// Spread teams as evenly as possible across divisions
+	for i := 1; i <= divisions && i <= MaxDivisions; i++ {
+		count := teams / divisions
+		if i <= teams%divisions {
+			count++
+		}
+		ls.SetDivision(i, fmt.Sprintf("Division %d", i), count)
+	}
+
+	return ls
+}
+
+// SalaryMinimums returns the salary minimums in the order of SalaryMinimumColumns
+func (l *LeagueStructure) SalaryMinimums() []int {
+	return []int{l.Minimum, l.Salary1, l.Salary2, l.Salary3, l.Salary45, l.Salary789, l.Salary10}
+}
+
+// FindLeagueStructure returns the structure with a schedule ID, or nil
+func FindLeagueStructure(structures []LeagueStructure, scheduleID string) *LeagueStructure {
+	for i := range structures {
+		if structures[i].ScheduleID == scheduleID {
+			return &structures[i]
+		}
+	}
+	return nil
+}
+
+// UpdateScheduleID sets the schedule ID from the team, division and game counts
+func (l *LeagueStructure) UpdateScheduleID() {
+	l.ScheduleID = fmt.Sprintf("%d_%d_%d", l.Teams, l.Divisions, l.Games)
+}
+
+// DivisionsPerConference returns the number of divisions in each conference
+func (l *LeagueStructure) DivisionsPerConference() int {
+	return l.Divisions / 2
+}
+
+// divisionFields returns pointers to the name and team count of a 1-based division
+func (l *LeagueStructure) divisionFields(index int) (*string, *int) {
+	switch index {
+	case 1:
+		return &l.Div1, &l.Div1Teams
+	case 2:
+		return &l.Div2, &l.Div2Teams
+	case 3:
+		return &l.Div3, &l.Div3Teams
+	case 4:
+		return &l.Div4, &l.Div4Teams
+	case 5:
+		return &l.Div5, &l.Div5Teams
+	case 6:
+		return &l.Div6, &l.Div6Teams
+	case 7:
+		return &l.Div7, &l.Div7Teams
+	case 8:
+		return &l.Div8, &l.Div8Teams
+	default:
+		return nil, nil
+	}
+}
+
+// GetDivisionName returns the name of a 1-based division index
+func (l *LeagueStructure) GetDivisionName(index int) string {
+	if name, _ := l.divisionFields(index); name != nil {
+		return *name
+	}
+	return ""
+}
+
+// GetDivisionTeams returns the team count of a 1-based division index
+func (l *LeagueStructure) GetDivisionTeams(index int) int {
+	if _, teams := l.divisionFields(index); teams != nil {
+		return *teams
+	}
+	return 0
+}
+
+// SetDivision sets the name and team count of a 1-based division index
+func (l *LeagueStructure) SetDivision(index int, name string, teams int) {
+	if namePtr, teamsPtr := l.divisionFields(index); namePtr != nil {
+		*namePtr = name
+		*teamsPtr = teams
+	}
+}
+
+// ClearUnusedDivisions blanks divisions beyond the division count
+func (l *LeagueStructure) ClearUnusedDivisions() {
+	for i := l.Divisions + 1; i <= MaxDivisions; i++ {
+		l.SetDivision(i, "", 0)
+	}
+}
+
+// DivisionIndex returns the 1-based league-wide division index for a team's
+// conference (1-2) and division within the conference (1-based)
+func (l *LeagueStructure) DivisionIndex(conference, division int) int {
+	return (conference-1)*l.DivisionsPerConference() + division
+}
+
+// GetConferenceName returns the name of a conference (1 or 2)
+func (l *LeagueStructure) GetConferenceName(conference int) string {
+	switch conference {
+	case 1:
+		return l.Conf1
+	case 2:
+		return l.Conf2
+	default:
+		return ""
+	}
+}
+
+// DefaultTeam represents a row of default_teams.csv, the teams created for a
+// league structure when starting a new league
+type DefaultTeam struct {
+	League       string `csv:"LEAGUE"` // SCHEDULEID of the league structure
+	TeamID       int    `csv:"TEAMID"`
+	TeamName     string `csv:"TEAMNAME"`
+	NickName     string `csv:"NICKNAME"`
+	Abbreviation string `csv:"ABBREVIATION"`
+	Conference   int    `csv:"CONFERENCE"` // 1 or 2
+	Division     int    `csv:"DIVISION"`   // 1-based within the conference
+	City         int    `csv:"CITY"`       // References cities.csv
+
+	PrimaryRed     int `csv:"PRIMARYRED"`
+	PrimaryGreen   int `csv:"PRIMARYGREEN"`
+	PrimaryBlue    int `csv:"PRIMARYBLUE"`
+	SecondaryRed   int `csv:"SECONDARYRED"`
+	SecondaryGreen int `csv:"SECONDARYGREEN"`
+	SecondaryBlue  int `csv:"SECONDARYBLUE"`
+}
+
+// GetDisplayName returns the team's full name
+func (t *DefaultTeam) GetDisplayName() string {
+	return t.TeamName + " " + t.NickName
+}
+
+// ToTeam converts the default team to a Team with identity, structure, city and colors set
+func (t *DefaultTeam) ToTeam() Team {
+	return Team{
+		TeamID:         t.TeamID,
+		TeamName:       t.TeamName,
+		NickName:       t.NickName,
+		Abbreviation:   t.Abbreviation,
+		Conference:     t.Conference,
+		Division:       t.Division,
+		City:           t.City,
+		PrimaryRed:     t.PrimaryRed,
+		PrimaryGreen:   t.PrimaryGreen,
+		PrimaryBlue:    t.PrimaryBlue,
+		SecondaryRed:   t.SecondaryRed,
+		SecondaryGreen: t.SecondaryGreen,
+		SecondaryBlue:  t.SecondaryBlue,
+	}
+}
+
+// DefaultTeamFromTeam creates a default team row for a league structure from a Team
+func DefaultTeamFromTeam(league string, team Team) DefaultTeam {
+	return DefaultTeam{
+		League:         league,
+		TeamID:         team.TeamID,
+		TeamName:       team.TeamName,
+		NickName:       team.NickName,
+		Abbreviation:   team.Abbreviation,
+		Conference:     team.Conference,
+		Division:       team.Division,
+		City:           team.City,
+		PrimaryRed:     team.PrimaryRed,
+		PrimaryGreen:   team.PrimaryGreen,
+		PrimaryBlue:    team.PrimaryBlue,
+		SecondaryRed:   team.SecondaryRed,
+		SecondaryGreen: team.SecondaryGreen,
+		SecondaryBlue:  team.SecondaryBlue,
+	}
+}
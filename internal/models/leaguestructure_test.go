@@ -0,0 +1,78 @@
+package models
+
+import "testing"
+
+func TestNewLeagueStructure(t *testing.T) {
+	ls := NewLeagueStructure(30, 6, 16)
+
+	if ls.ScheduleID != "30_6_16" {
+		t.Errorf("Expected ScheduleID 30_6_16, got %s", ls.ScheduleID)
+	}
+	if ls.DivisionsPerConference() != 3 {
+		t.Errorf("Expected 3 divisions per conference, got %d", ls.DivisionsPerConference())
+	}
+
+	total := 0
+	for i := 1; i <= ls.Divisions; i++ {
+		total += ls.GetDivisionTeams(i)
+	}
+	if total != 30 {
+		t.Errorf("Expected divisions to hold 30 teams, got %d", total)
+	}
+	if ls.GetDivisionTeams(7) != 0 || ls.GetDivisionName(7) != "" {
+		t.Error("Expected division 7 to be unused")
+	}
+
+	small := NewLeagueStructure(10, 2, 12)
+	if small.PlayoffTeams >= small.Teams || small.PlayoffTeams%2 != 0 {
+		t.Errorf("Expected an even playoff team count below 10, got %d", small.PlayoffTeams)
+	}
+}
+
+func TestLeagueStructureDivisions(t *testing.T) {
+	ls := &LeagueStructure{Divisions: 8}
+
+	ls.SetDivision(6, "North", 4)
+	if ls.Div6 != "North" || ls.Div6Teams != 4 {
+		t.Errorf("Expected Div6 North/4, got %s/%d", ls.Div6, ls.Div6Teams)
+	}
+	if ls.GetDivisionName(6) != "North" || ls.GetDivisionTeams(6) != 4 {
+		t.Error("GetDivisionName/GetDivisionTeams did not return division 6")
+	}
+
+	// Out of range indexes are ignored
+	ls.SetDivision(9, "Nowhere", 4)
+	if ls.GetDivisionName(9) != "" || ls.GetDivisionTeams(0) != 0 {
+		t.Error("Expected empty values for out of range divisions")
+	}
+
+	if index := ls.DivisionIndex(2, 2); index != 6 {
+		t.Errorf("Expected conference 2 division 2 to be division 6, got %d", index)
+	}
+
+	ls.Divisions = 4
+	ls.ClearUnusedDivisions()
+	if ls.Div6 != "" || ls.Div6Teams != 0 {
+		t.Error("Expected division 6 to be cleared")
+	}
+}
+
+func TestDefaultTeamConversion(t *testing.T) {
+	team := Team{
+		Year: 2024, TeamID: 3, TeamName: "Baltimore", NickName: "Nightwings", Abbreviation: "BAL",
+		Conference: 1, Division: 2, City: 7609, PrimaryRed: 26, SecondaryBlue: 12,
+	}
+
+	dt := DefaultTeamFromTeam("32_8_18", team)
+	if dt.League != "32_8_18" || dt.TeamID != 3 || dt.City != 7609 || dt.SecondaryBlue != 12 {
+		t.Errorf("Unexpected default team: %+v", dt)
+	}
+	if dt.GetDisplayName() != "Baltimore Nightwings" {
+		t.Errorf("Expected 'Baltimore Nightwings', got '%s'", dt.GetDisplayName())
+	}
+
+	back := dt.ToTeam()
+	if back.TeamName != team.TeamName || back.Division != 2 || back.PrimaryRed != 26 {
+		t.Errorf("Unexpected team from default team: %+v", back)
+	}
+}
@@ -0,0 +1,28 @@
+// ABOUTME: This file defines the MetroArea reference data structure for FOF9 custom leagues
+// ABOUTME: It mirrors the population columns of metro_areas.csv, which cities reference by METROAREA
+package models
+
+// MetroArea represents a row of the game's metro_areas.csv reference table.
+// The monthly weather columns are not modeled.
+type MetroArea struct {
+	MetroID    int    `csv:"METROID"`
+	Name       string `csv:"NAME"`
+	Region     string `csv:"REGION"`
+	Country    int    `csv:"COUNTRY"`
+	HostFlag   int    `csv:"HFLAG"`
+	RegionName int    `csv:"RNAME"`
+	Population int    `csv:"POP2019"`
+	Growth     int    `csv:"GROWTH"`    // Yearly growth multiplied by 1000, 1000 is no growth
+	Latitude   int    `csv:"LATITUDE"`  // Degrees multiplied by 1000
+	Longitude  int    `csv:"LONGITUDE"` // Degrees multiplied by 1000
+	TVName     string `csv:"TVNAME"`
+	TVCity     int    `csv:"TVCITY"`
+}
+
+// GetDisplayName returns the metro area name with its region, e.g. "Green Bay, WI"
+func (m *MetroArea) GetDisplayName() string {
+	if m.Region == "" || m.Region == "ZZ" {
+		return m.Name
+	}
+	return m.Name + ", " + m.Region
+}
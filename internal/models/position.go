@@ -98,3 +98,79 @@ func GetPositionAbbr(id int) string {
 	}
 	return "??"
 }
+
+// UniformRange is an inclusive range of uniform numbers
+type UniformRange struct {
+	Min int
+	Max int
+}
+
+// Contains returns true if the number is within the range
+func (r UniformRange) Contains(number int) bool {
+	return number >= r.Min && number <= r.Max
+}
+
+// uniformConventions lists the customary numbers for each position, most
+// traditional first, following the NFL's 2023 numbering rules
+var uniformConventions = map[int][]UniformRange{
+	PositionQB:   {{1, 19}, {0, 0}, {20, 49}},
+	PositionRB:   {{20, 49}, {0, 19}, {80, 89}},
+	PositionFB:   {{30, 49}, {20, 29}, {0, 19}, {80, 89}},
+	PositionTE:   {{80, 89}, {40, 49}, {0, 39}},
+	PositionFL:   {{10, 19}, {80, 89}, {0, 9}, {20, 49}},
+	PositionSE:   {{10, 19}, {80, 89}, {0, 9}, {20, 49}},
+	PositionLT:   {{60, 79}, {50, 59}},
+	PositionLG:   {{60, 79}, {50, 59}},
+	PositionC:    {{50, 79}},
+	PositionRG:   {{60, 79}, {50, 59}},
+	PositionRT:   {{60, 79}, {50, 59}},
+	PositionP:    {{1, 19}, {0, 0}, {20, 49}},
+	PositionK:    {{1, 19}, {0, 0}, {20, 49}},
+	PositionDLE:  {{90, 99}, {50, 79}, {0, 49}},
+	PositionDLT:  {{90, 99}, {50, 79}, {0, 49}},
+	PositionDNT:  {{90, 99}, {50, 79}, {0, 49}},
+	PositionDRT:  {{90, 99}, {50, 79}, {0, 49}},
+	PositionDRE:  {{90, 99}, {50, 79}, {0, 49}},
+	PositionSLB:  {{50, 59}, {40, 49}, {90, 99}, {0, 39}},
+	PositionSILB: {{50, 59}, {40, 49}, {90, 99}, {0, 39}},
+	PositionMLB:  {{50, 59}, {40, 49}, {90, 99}, {0, 39}},
+	PositionWILB: {{50, 59}, {40, 49}, {90, 99}, {0, 39}},
+	PositionWLB:  {{50, 59}, {40, 49}, {90, 99}, {0, 39}},
+	PositionLCB:  {{20, 49}, {0, 19}},
+	PositionRCB:  {{20, 49}, {0, 19}},
+	PositionSS:   {{20, 49}, {0, 19}},
+	PositionFS:   {{20, 49}, {0, 19}},
+	PositionLS:   {{40, 69}},
+}
+
+// UniformRanges returns the customary uniform numbers for a position, most
+// traditional first, or nil for an unknown position
+func UniformRanges(positionKey int) []UniformRange {
+	return uniformConventions[positionKey]
+}
+
+// IsConventionalUniform returns true if the number is customary for the
+// position. Unknown positions accept any number.
+func IsConventionalUniform(positionKey, number int) bool {
+	ranges := uniformConventions[positionKey]
+	if ranges == nil {
+		return true
+	}
+	for _, r := range ranges {
+		if r.Contains(number) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPositionType returns the position type (Offense, Defense or Special
+// Teams) for a given ID, or "Unknown"
+func GetPositionType(id int) string {
+	for _, pos := range DefaultPositions() {
+		if pos.ID == id {
+			return pos.PositionType
+		}
+	}
+	return "Unknown"
+}
@@ -4,6 +4,7 @@ package models
 
 import (
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -19,6 +20,17 @@ type Project struct {
 	ReferencePath   string                 `json:"referencePath"`
 	CSVFiles        map[string]string      `json:"csvFiles"`
 	UserPreferences map[string]interface{} `json:"userPreferences"`
+	PortraitsPath   string                 `json:"portraitsPath,omitempty"`   // Folder of custom portraits named by player ID, e.g. 1465.bmp
+	ValidationRules []ValidationRule       `json:"validationRules,omitempty"` // House rules checked with the built-in validation
+}
+
+// ValidationRule is a league's house rule in the validation rule language,
+// e.g. "each player: AGE <= 35" or "per team: count(POSITION = 'QB') >= 3"
+type ValidationRule struct {
+	Name     string `json:"name"`               // Short name; its rule code is RULE_ and the name in capitals
+	Rule     string `json:"rule"`               // The condition that must hold
+	Severity string `json:"severity,omitempty"` // "error", "warning" (the default) or "info"
+	Message  string `json:"message,omitempty"`  // Shown with findings instead of the rule text
 }
 
 // NewProject creates a new project with default settings
@@ -51,3 +63,84 @@ func (p *Project) GetFullPath(csvKey string) string {
 	}
 	return ""
 }
+
+// PreferenceValidationSuppressions is the UserPreferences key listing the
+// validation findings a project suppresses, as "CODE" or "CODE@recordKey"
+const PreferenceValidationSuppressions = "validationSuppressions"
+
+// ValidationSuppressions returns the project's suppressed validation findings
+func (p *Project) ValidationSuppressions() []string {
+	// Loaded projects hold JSON arrays as []interface{}
+	switch entries := p.UserPreferences[PreferenceValidationSuppressions].(type) {
+	case []string:
+		return append([]string(nil), entries...)
+	case []interface{}:
+		result := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			if s, ok := entry.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// AddValidationSuppression suppresses a validation finding, returning false
+// if it was already suppressed
+func (p *Project) AddValidationSuppression(entry string) bool {
+	entries := p.ValidationSuppressions()
+	for _, existing := range entries {
+		if existing == entry {
+			return false
+		}
+	}
+	p.SetValidationSuppressions(append(entries, entry))
+	return true
+}
+
+// RemoveValidationSuppression stops suppressing a validation finding,
+// returning false if it was not suppressed
+func (p *Project) RemoveValidationSuppression(entry string) bool {
+	entries := p.ValidationSuppressions()
+	for i, existing := range entries {
+		if existing == entry {
+			p.SetValidationSuppressions(append(entries[:i], entries[i+1:]...))
+			return true
+		}
+	}
+	return false
+}
+
+// RenameSuppressionRecords points record suppressions ("CODE@recordKey") at
+// new record keys, e.g. after renumbering players, and returns the number changed
+func (p *Project) RenameSuppressionRecords(keys map[string]string) int {
+	entries := p.ValidationSuppressions()
+	changed := 0
+	for i, entry := range entries {
+		at := strings.LastIndex(entry, "@")
+		if at < 0 {
+			continue
+		}
+		if key, ok := keys[entry[at+1:]]; ok {
+			entries[i] = entry[:at+1] + key
+			changed++
+		}
+	}
+	if changed > 0 {
+		p.SetValidationSuppressions(entries)
+	}
+	return changed
+}
+
+// SetValidationSuppressions stores the suppression list, removing the key when it is empty
+func (p *Project) SetValidationSuppressions(entries []string) {
+	if len(entries) == 0 {
+		delete(p.UserPreferences, PreferenceValidationSuppressions)
+		return
+	}
+	if p.UserPreferences == nil {
+		p.UserPreferences = make(map[string]interface{})
+	}
+	p.UserPreferences[PreferenceValidationSuppressions] = entries
+}
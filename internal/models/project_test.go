@@ -97,3 +97,55 @@ func TestProjectJSONMarshaling(t *testing.T) {
 		t.Errorf("CSVFiles length mismatch after unmarshal")
 	}
 }
+
+func TestValidationSuppressions(t *testing.T) {
+	project := NewProject("Test League", "testleague", "/test", 2024)
+	if len(project.ValidationSuppressions()) != 0 {
+		t.Error("Expected a new project to suppress nothing")
+	}
+
+	if !project.AddValidationSuppression("PLAYER_ID_LOW") || !project.AddValidationSuppression("PLAYER_UNIFORM_DUP@player:1000") {
+		t.Fatal("Expected new suppressions to be added")
+	}
+	if project.AddValidationSuppression("PLAYER_ID_LOW") {
+		t.Error("Expected a duplicate suppression to be ignored")
+	}
+
+	// JSON arrays load back as []interface{}
+	jsonData, err := json.Marshal(project)
+	if err != nil {
+		t.Fatalf("Failed to marshal project: %v", err)
+	}
+	var loaded Project
+	if err := json.Unmarshal(jsonData, &loaded); err != nil {
+		t.Fatalf("Failed to unmarshal project: %v", err)
+	}
+	got := loaded.ValidationSuppressions()
+	if len(got) != 2 || got[0] != "PLAYER_ID_LOW" || got[1] != "PLAYER_UNIFORM_DUP@player:1000" {
+		t.Errorf("Unexpected suppressions after round trip: %v", got)
+	}
+
+	if !loaded.RemoveValidationSuppression("PLAYER_ID_LOW") || loaded.RemoveValidationSuppression("PLAYER_ID_LOW") {
+		t.Error("Expected the suppression to be removed once")
+	}
+	loaded.RemoveValidationSuppression("PLAYER_UNIFORM_DUP@player:1000")
+	if _, ok := loaded.UserPreferences[PreferenceValidationSuppressions]; ok {
+		t.Error("Expected an empty suppression list to be removed from the preferences")
+	}
+}
+
+func TestRenameSuppressionRecords(t *testing.T) {
+	project := NewProject("Test League", "testleague", "/test", 2024)
+	project.AddValidationSuppression("PLAYER_ID_LOW")
+	project.AddValidationSuppression("PLAYER_ID_LOW@player:999")
+	project.AddValidationSuppression("PLAYER_UNIFORM_DUP@player:1000")
+
+	changed := project.RenameSuppressionRecords(map[string]string{"player:999": "player:1001"})
+	if changed != 1 {
+		t.Errorf("Expected 1 suppression changed, got %d", changed)
+	}
+	got := project.ValidationSuppressions()
+	if got[0] != "PLAYER_ID_LOW" || got[1] != "PLAYER_ID_LOW@player:1001" || got[2] != "PLAYER_UNIFORM_DUP@player:1000" {
+		t.Errorf("Unexpected suppressions: %v", got)
+	}
+}
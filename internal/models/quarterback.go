@@ -0,0 +1,87 @@
+// ABOUTME: This file defines the Quarterback data structure for FOF9 custom leagues
+// ABOUTME: It mirrors xxxx_quarterbacks.csv, which keeps quarterbacks apart from the player file
+package models
+
+// Quarterback represents a quarterback from the game's quarterback file. It
+// shares the player file's biographical, draft and contract columns but has
+// quarterback-specific skill ratings and no POSITION_KEY.
+type Quarterback struct {
+	// Basic Info
+	PlayerID  int    `csv:"PLAYERID"`
+	LastName  string `csv:"LASTNAME"`
+	FirstName string `csv:"FIRSTNAME"`
+	Team      int    `csv:"TEAM"`
+	Uniform   int    `csv:"UNIFORM"`
+
+	// Physical Attributes
+	Height    int `csv:"HEIGHT"`
+	HandSize  int `csv:"HANDSIZE"`
+	ArmLength int `csv:"ARMLENGTH"`
+	Weight    int `csv:"WEIGHT"`
+
+	// Birth Info
+	BirthMonth  int    `csv:"BIRTHMONTH"`
+	BirthDay    int    `csv:"BIRTHDAY"`
+	BirthYear   int    `csv:"BIRTHYEAR"`
+	BirthCity   string `csv:"BIRTHCITY"`
+	BirthCityID int    `csv:"CITYID"`
+
+	// College
+	College   string `csv:"COLLEGE"`
+	CollegeID int    `csv:"COLLEGEID"`
+
+	// Draft History
+	YearEntry        int `csv:"YEARENTRY"`
+	RoundDrafted     int `csv:"ROUNDDRAFTED"`
+	SelectionDrafted int `csv:"SELECTIONDRAFTED"`
+	Supplemental     int `csv:"SUPPLEMENTAL"`
+	OriginalTeam     int `csv:"ORIGINALTEAM"`
+
+	// Career Stats
+	Experience       int `csv:"EXPERIENCE"`
+	YearSigned       int `csv:"YEARSIGNED"`
+	PlayPercentage   int `csv:"PLAYPERCENTAGE"`
+	HallOfFamePoints int `csv:"HALLOFFAMEPOINTS"`
+
+	// Contract
+	SalaryYears int `csv:"SALARYYEARS"`
+	SalaryYear1 int `csv:"SALARYYEAR1"`
+	BonusYear1  int `csv:"BONUSYEAR1"`
+	SalaryYear2 int `csv:"SALARYYEAR2"`
+	BonusYear2  int `csv:"BONUSYEAR2"`
+	SalaryYear3 int `csv:"SALARYYEAR3"`
+	BonusYear3  int `csv:"BONUSYEAR3"`
+	SalaryYear4 int `csv:"SALARYYEAR4"`
+	BonusYear4  int `csv:"BONUSYEAR4"`
+	SalaryYear5 int `csv:"SALARYYEAR5"`
+	BonusYear5  int `csv:"BONUSYEAR5"`
+
+	// Overall Rating
+	OverallRating int `csv:"OVERALLRATING"`
+
+	// Skill Attributes (-1 lets the game generate the rating)
+	Touch           int `csv:"TOUCH"`
+	Quality         int `csv:"QUALITY"`
+	ArmStrength     int `csv:"ARM_STRENGTH"`
+	Scramble        int `csv:"SCRAMBLE"`
+	Decisions       int `csv:"DECISIONS"`
+	Accuracy        int `csv:"ACCURACY"`
+	Timing          int `csv:"TIMING"`
+	SenseRush       int `csv:"SENSE_RUSH"`
+	ReadDefense     int `csv:"READ_DEFENSE"`
+	TwoMinute       int `csv:"TWO_MINUTE"`
+	Footwork        int `csv:"FOOTWORK"`
+	Improvisation   int `csv:"IMPROVISATION"`
+	Confidence      int `csv:"CONFIDENCE"`
+	SkillSpeed      int `csv:"SKILL_SPEED"`
+	HoleRecognition int `csv:"HOLE_RECOGNITION"`
+	SecureHandling  int `csv:"SECURE_HANDLING"`
+
+	// Base Year (determines draft class)
+	BaseYear int `csv:"BASE_YEAR"`
+}
+
+// GetDisplayName returns the quarterback's full name
+func (q *Quarterback) GetDisplayName() string {
+	return q.FirstName + " " + q.LastName
+}
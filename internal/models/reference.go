@@ -6,7 +6,13 @@ package models
 // ReferenceData contains all reference/lookup data for the application
 type ReferenceData struct {
 	Positions []Position
-	Teams     []Team // Teams can serve as reference data for dropdowns
+	Teams     []Team            // Teams can serve as reference data for dropdowns
+	Cities    map[int]City      // Cities from the game's cities.csv, keyed by CITYID
+	Colleges  map[int]College   // Colleges from the game's colleges.csv, keyed by COLLEGEID
+	Metros    map[int]MetroArea // Metro areas from the game's metro_areas.csv, keyed by METROID
+
+	LeagueStructures []LeagueStructure // League formats from the game's league_info.csv
+	RatingBaseline   []Player          // Players of the game's 2024_players.csv, for rating comparisons
 }
 
 // NewReferenceData creates a new ReferenceData instance with default values
@@ -14,9 +20,73 @@ func NewReferenceData() *ReferenceData {
 	return &ReferenceData{
 		Positions: DefaultPositions(),
 		Teams:     make([]Team, 0),
+		Cities:    make(map[int]City),
+		Colleges:  make(map[int]College),
+		Metros:    make(map[int]MetroArea),
+	}
+}
+
+// SetCities replaces the city lookup table
+func (r *ReferenceData) SetCities(cities []City) {
+	r.Cities = make(map[int]City, len(cities))
+	for _, city := range cities {
+		r.Cities[city.CityID] = city
+	}
+}
+
+// HasCities returns true if city reference data has been loaded
+func (r *ReferenceData) HasCities() bool {
+	return len(r.Cities) > 0
+}
+
+// SetColleges replaces the college lookup table
+func (r *ReferenceData) SetColleges(colleges []College) {
+	r.Colleges = make(map[int]College, len(colleges))
+	for _, college := range colleges {
+		r.Colleges[college.CollegeID] = college
+	}
+}
+
+// HasColleges returns true if college reference data has been loaded
+func (r *ReferenceData) HasColleges() bool {
+	return len(r.Colleges) > 0
+}
+
+// SetMetros replaces the metro area lookup table
+func (r *ReferenceData) SetMetros(metros []MetroArea) {
+	r.Metros = make(map[int]MetroArea, len(metros))
+	for _, metro := range metros {
+		r.Metros[metro.MetroID] = metro
 	}
 }
 
+// HasMetros returns true if metro area reference data has been loaded
+func (r *ReferenceData) HasMetros() bool {
+	return len(r.Metros) > 0
+}
+
+// MarketPopulation returns the population a team in a city draws from: its
+// metro area's, or the city's own if it is outside a loaded metro area.
+// It returns 0 for an unknown city.
+func (r *ReferenceData) MarketPopulation(cityID int) int {
+	city, ok := r.Cities[cityID]
+	if !ok {
+		return 0
+	}
+	if metro, ok := r.Metros[city.MetroArea]; ok && city.MetroArea != 0 {
+		return metro.Population
+	}
+	return city.Population
+}
+
+// GetCityNameByID returns the city display name for a given ID
+func (r *ReferenceData) GetCityNameByID(id int) string {
+	if city, exists := r.Cities[id]; exists {
+		return city.GetDisplayName()
+	}
+	return "Unknown City"
+}
+
 // GetPositionOptions returns position names for dropdown selections
 func (r *ReferenceData) GetPositionOptions() []string {
 	options := make([]string, len(r.Positions))
@@ -67,41 +137,27 @@ func (r *ReferenceData) GetTeamNameByID(id int) string {
 
 // GetCoachPositionOptions returns coach position names for dropdown selections
 func (r *ReferenceData) GetCoachPositionOptions() []string {
-	return []string{
-		"Head Coach",
-		"Offensive Coordinator",
-		"Defensive Coordinator",
-		"Special Teams Coordinator",
-		"Strength & Conditioning",
+	options := make([]string, len(CoachPositions))
+	for i, position := range CoachPositions {
+		options[i] = GetCoachPositionName(position)
 	}
+	return options
 }
 
 // GetCoachPositionIDByName returns the coach position ID for a given name
 func (r *ReferenceData) GetCoachPositionIDByName(name string) int {
-	positions := map[string]int{
-		"Head Coach":                    0,
-		"Offensive Coordinator":         1,
-		"Defensive Coordinator":         2,
-		"Special Teams Coordinator":     3,
-		"Strength & Conditioning":       4,
-	}
-	if id, exists := positions[name]; exists {
-		return id
+	for _, position := range CoachPositions {
+		if GetCoachPositionName(position) == name {
+			return position
+		}
 	}
 	return -1
 }
 
 // GetCoachPositionNameByID returns the coach position name for a given ID
 func (r *ReferenceData) GetCoachPositionNameByID(id int) string {
-	names := []string{
-		"Head Coach",
-		"Offensive Coordinator",
-		"Defensive Coordinator",
-		"Special Teams Coordinator",
-		"Strength & Conditioning",
-	}
-	if id >= 0 && id < len(names) {
-		return names[id]
+	if name := GetCoachPositionName(id); name != "Unknown" {
+		return name
 	}
 	return "Unknown Position"
 }
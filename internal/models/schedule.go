@@ -0,0 +1,58 @@
+// ABOUTME: This file defines schedule template and season schedule structures for FOF9 leagues
+// ABOUTME: It mirrors the x_y_z_schedule.csv templates and the dated xxxx_schedule.csv season files
+package models
+
+import "time"
+
+// Schedule template season type constants
+const (
+	SeasonExhibition = 0
+	SeasonRegular    = 1
+)
+
+// ScheduleTemplateGame represents a single matchup in a schedule template file.
+// Teams are identified by division index (1-based, first conference first) and
+// team index within the division (1-based, lowest team ID first).
+type ScheduleTemplateGame struct {
+	Rotation  int `csv:"ROTATION"`
+	Season    int `csv:"SEASON"`    // 0=exhibition, 1=regular season
+	Standings int `csv:"STANDINGS"` // 0=team index, 1=previous year's standings
+	Week      int `csv:"WEEK"`
+	HomeDiv   int `csv:"HOMEDIV"`
+	HomeTeam  int `csv:"HOMETEAM"`
+	VisDiv    int `csv:"VISDIV"`
+	VisTeam   int `csv:"VISTEAM"`
+}
+
+// IsRegularSeason returns true if the game counts toward the regular season
+func (g *ScheduleTemplateGame) IsRegularSeason() bool {
+	return g.Season == SeasonRegular
+}
+
+// IsDivisional returns true if both teams are in the same division
+func (g *ScheduleTemplateGame) IsDivisional() bool {
+	return g.HomeDiv == g.VisDiv
+}
+
+// ScheduledGame represents a single dated game in a season schedule file
+// (e.g. 2024_schedule.csv). Teams are identified by team ID.
+type ScheduledGame struct {
+	Season   int `csv:"SEASON"` // 0=exhibition, 1=regular season
+	Week     int `csv:"WEEK"`
+	Month    int `csv:"MONTH"`
+	Day      int `csv:"DAY"`
+	Year     int `csv:"YEAR"`
+	Home     int `csv:"HOME"`
+	Visitor  int `csv:"VISITOR"`
+	Location int `csv:"LOCATION"` // City ID, or 0 for the home team's city
+}
+
+// IsRegularSeason returns true if the game counts toward the regular season
+func (g *ScheduledGame) IsRegularSeason() bool {
+	return g.Season == SeasonRegular
+}
+
+// Date returns the calendar date of the game (midnight UTC)
+func (g *ScheduledGame) Date() time.Time {
+	return time.Date(g.Year, time.Month(g.Month), g.Day, 0, 0, 0, 0, time.UTC)
+}
@@ -57,7 +57,7 @@ type Team struct {
 	// Future Stadium Plans
 	Plan              int    `csv:"PLAN"`              // 0=no plans, 1=plans active
 	Completed         int    `csv:"COMPLETED"`         // Year future stadium completed
-	Future            int    `csv:"FUTURE"`            // Future stadium active
+	Future            int    `csv:"FUTURE"`            // City of the future stadium (references cities.csv)
 	FutureName        string `csv:"FUTURENAME"`        // Future stadium name
 	FutureAbbr        string `csv:"FUTUREABBR"`        // Future abbreviation
 	FutureRoof        int    `csv:"FUTUREROOF"`        // Future roof type
@@ -91,3 +91,89 @@ func (t *Team) GetSecondaryColor() color.Color {
 		A: 255,
 	}
 }
+
+// PrimaryRGB returns the primary color components
+func (t *Team) PrimaryRGB() RGB {
+	return RGB{t.PrimaryRed, t.PrimaryGreen, t.PrimaryBlue}
+}
+
+// SecondaryRGB returns the secondary color components
+func (t *Team) SecondaryRGB() RGB {
+	return RGB{t.SecondaryRed, t.SecondaryGreen, t.SecondaryBlue}
+}
+
+// HasFuturePlan returns true if the team has a plan to build a new stadium
+func (t *Team) HasFuturePlan() bool {
+	return t.Plan != 0
+}
+
+// FuturePlanColumns returns the CSV columns of the future stadium plan that
+// are set, other than PLAN itself
+func (t *Team) FuturePlanColumns() []string {
+	var columns []string
+	for _, field := range []struct {
+		column string
+		set    bool
+	}{
+		{"COMPLETED", t.Completed != 0},
+		{"FUTURE", t.Future != 0},
+		{"FUTURENAME", t.FutureName != ""},
+		{"FUTUREABBR", t.FutureAbbr != ""},
+		{"FUTUREROOF", t.FutureRoof != 0},
+		{"FUTURETURF", t.FutureTurf != 0},
+		{"FUTURECAP", t.FutureCap != 0},
+		{"FUTURELUXURY", t.FutureLuxury != 0},
+		{"TEAMCONTRIBUTION", t.TeamContribution != 0},
+	} {
+		if field.set {
+			columns = append(columns, field.column)
+		}
+	}
+	return columns
+}
+
+// HasStaleFuturePlan returns true if the future stadium plan cannot take
+// effect: a plan completed by the team's YEAR, or plan fields without a plan
+func (t *Team) HasStaleFuturePlan() bool {
+	if t.HasFuturePlan() {
+		return t.Year != 0 && t.Completed <= t.Year
+	}
+	return len(t.FuturePlanColumns()) > 0
+}
+
+// ClearFuturePlan removes the future stadium plan, zeroing PLAN and every future field
+func (t *Team) ClearFuturePlan() {
+	t.Plan = 0
+	t.Completed = 0
+	t.Future = 0
+	t.FutureName = ""
+	t.FutureAbbr = ""
+	t.FutureRoof = 0
+	t.FutureTurf = 0
+	t.FutureCap = 0
+	t.FutureLuxury = 0
+	t.TeamContribution = 0
+}
+
+// LatestTeamYear returns the most recent YEAR found in a team list, or 0 if none is set
+func LatestTeamYear(teams []Team) int {
+	latest := 0
+	for _, team := range teams {
+		if team.Year > latest {
+			latest = team.Year
+		}
+	}
+	return latest
+}
+
+// TeamsForYear returns the teams of a single season from a multi-year team list.
+// Teams without a YEAR value are always included.
+func TeamsForYear(teams []Team, year int) []Team {
+	result := make([]Team, 0, len(teams))
+	for _, team := range teams {
+		if team.Year == 0 || team.Year == year {
+			result = append(result, team)
+		}
+	}
+	return result
+}
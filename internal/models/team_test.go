@@ -119,3 +119,55 @@ func TestTeamConstants(t *testing.T) {
 		t.Errorf("Expected TurfHybrid = 2, got %d", TurfHybrid)
 	}
 }
+
+func TestTeamsForYear(t *testing.T) {
+	teams := []Team{
+		{Year: 2023, TeamID: 1},
+		{Year: 2024, TeamID: 1},
+		{Year: 2024, TeamID: 2},
+		{TeamID: 3},
+	}
+
+	if latest := LatestTeamYear(teams); latest != 2024 {
+		t.Errorf("Expected latest year 2024, got %d", latest)
+	}
+
+	season := TeamsForYear(teams, 2024)
+	if len(season) != 3 {
+		t.Fatalf("Expected 3 teams for 2024, got %d", len(season))
+	}
+	if season[0].Year != 2024 || season[2].TeamID != 3 {
+		t.Errorf("Unexpected teams for 2024: %+v", season)
+	}
+
+	if latest := LatestTeamYear(nil); latest != 0 {
+		t.Errorf("Expected latest year 0 for empty list, got %d", latest)
+	}
+}
+
+func TestTeamFuturePlan(t *testing.T) {
+	team := &Team{Year: 2015, Plan: 1, Completed: 2020, Future: 21050, FutureName: "Las Vegas", FutureAbbr: "LV",
+		FutureRoof: RoofDome, FutureCap: 65000, FutureLuxury: 100, TeamContribution: 100}
+
+	if !team.HasFuturePlan() || team.HasStaleFuturePlan() {
+		t.Error("Expected an active plan completed after the team's year")
+	}
+	if got := len(team.FuturePlanColumns()); got != 8 {
+		t.Errorf("Expected 8 future columns, got %v", team.FuturePlanColumns())
+	}
+
+	team.Year = 2020
+	if !team.HasStaleFuturePlan() {
+		t.Error("Expected a plan completed in the team's year to be stale")
+	}
+
+	team.ClearFuturePlan()
+	if team.HasFuturePlan() || team.HasStaleFuturePlan() || len(team.FuturePlanColumns()) != 0 {
+		t.Errorf("Expected no plan after clearing, got %+v", team)
+	}
+
+	team.FutureCap = 70000
+	if !team.HasStaleFuturePlan() {
+		t.Error("Expected future fields without a plan to be stale")
+	}
+}
@@ -0,0 +1,65 @@
+// ABOUTME: Age distribution of the player and quarterback files by position
+// ABOUTME: Counts players by age in the league's base year season
+
+package roster
+
+import (
+	"sort"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// PositionAges is the age histogram of one position
+type PositionAges struct {
+	PositionKey int
+	Counts      map[int]int // Players by age
+	Total       int
+	Youngest    int
+	Oldest      int
+	AgeSum      int
+}
+
+// Average returns the mean age, or 0 without players
+func (p PositionAges) Average() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.AgeSum) / float64(p.Total)
+}
+
+// AgeHistogram counts the players of both files by position and by age in
+// the base year, ordered by position. Ages are whole years, base year minus
+// birth year. Players without a birth year and entries of a custom file
+// whose BASE_YEAR is a later draft class are left out.
+func AgeHistogram(players []models.Player, quarterbacks []models.Quarterback, baseYear int) []PositionAges {
+	byPosition := make(map[int]*PositionAges)
+	for _, member := range Members(players, quarterbacks) {
+		if member.BirthYear <= 0 || (member.BaseYear > 0 && member.BaseYear != baseYear) {
+			continue
+		}
+
+		ages, ok := byPosition[member.PositionKey]
+		if !ok {
+			ages = &PositionAges{PositionKey: member.PositionKey, Counts: make(map[int]int)}
+			byPosition[member.PositionKey] = ages
+		}
+
+		age := baseYear - member.BirthYear
+		if ages.Total == 0 || age < ages.Youngest {
+			ages.Youngest = age
+		}
+		if ages.Total == 0 || age > ages.Oldest {
+			ages.Oldest = age
+		}
+		ages.Counts[age]++
+		ages.Total++
+		ages.AgeSum += age
+	}
+
+	histogram := make([]PositionAges, 0, len(byPosition))
+	for _, ages := range byPosition {
+		histogram = append(histogram, *ages)
+	}
+	sort.Slice(histogram, func(i, j int) bool { return histogram[i].PositionKey < histogram[j].PositionKey })
+	return histogram
+}
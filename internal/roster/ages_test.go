@@ -0,0 +1,36 @@
+// ABOUTME: Tests for the age distribution by position
+// ABOUTME: Verifies histogram counts, averages and which players are left out
+
+package roster
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestAgeHistogram(t *testing.T) {
+	quarterbacks := []models.Quarterback{{PlayerID: 501, BirthYear: 1983}}
+	players := []models.Player{
+		{PlayerID: 1000, PositionKey: models.PositionK, BirthYear: 2000},
+		{PlayerID: 1001, PositionKey: models.PositionK, BirthYear: 1995},
+		{PlayerID: 1002, PositionKey: models.PositionK, BirthYear: 2000},
+		{PlayerID: 1003, PositionKey: models.PositionK},                                  // No birth year
+		{PlayerID: 1004, PositionKey: models.PositionK, BirthYear: 2004, BaseYear: 2026}, // Future draft class
+	}
+
+	histogram := AgeHistogram(players, quarterbacks, 2024)
+	if len(histogram) != 2 {
+		t.Fatalf("Expected 2 positions, got %+v", histogram)
+	}
+	if qb := histogram[0]; qb.PositionKey != models.PositionQB || qb.Total != 1 || qb.Counts[41] != 1 {
+		t.Errorf("Unexpected QB ages: %+v", qb)
+	}
+	k := histogram[1]
+	if k.Total != 3 || k.Counts[24] != 2 || k.Counts[29] != 1 || k.Youngest != 24 || k.Oldest != 29 {
+		t.Errorf("Unexpected K ages: %+v", k)
+	}
+	if avg := k.Average(); avg < 25.66 || avg > 25.67 {
+		t.Errorf("Expected an average of 25.67, got %.2f", avg)
+	}
+}
@@ -0,0 +1,239 @@
+// ABOUTME: Roster composition of each team by position and position type
+// ABOUTME: Compares team rosters with configurable per-position and total minimums and maximums
+
+package roster
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// PreferenceRosterLimits is the project UserPreferences key holding roster
+// limits as position abbreviation (or "Total") to "min-max", e.g. {"QB": "2-5"}
+const PreferenceRosterLimits = "rosterLimits"
+
+// totalKey names the total roster limit in preferences
+const totalKey = "Total"
+
+// Limit is an inclusive range of players. A Max of 0 means no maximum.
+type Limit struct {
+	Min int
+	Max int
+}
+
+// Allows returns true if the count is within the limit
+func (l Limit) Allows(count int) bool {
+	return count >= l.Min && (l.Max == 0 || count <= l.Max)
+}
+
+// String formats the limit as "min-max", or "min+" without a maximum
+func (l Limit) String() string {
+	if l.Max == 0 {
+		return fmt.Sprintf("%d+", l.Min)
+	}
+	return fmt.Sprintf("%d-%d", l.Min, l.Max)
+}
+
+// ParseLimit reads a limit formatted by String
+func ParseLimit(text string) (Limit, error) {
+	text = strings.TrimSpace(text)
+	if min, ok := strings.CutSuffix(text, "+"); ok {
+		n, err := strconv.Atoi(min)
+		if err != nil || n < 0 {
+			return Limit{}, fmt.Errorf("invalid roster limit %q", text)
+		}
+		return Limit{Min: n}, nil
+	}
+
+	min, max, ok := strings.Cut(text, "-")
+	lo, err1 := strconv.Atoi(strings.TrimSpace(min))
+	hi, err2 := strconv.Atoi(strings.TrimSpace(max))
+	if !ok || err1 != nil || err2 != nil || lo < 0 || hi < lo || hi == 0 {
+		return Limit{}, fmt.Errorf("invalid roster limit %q; use min-max or min+", text)
+	}
+	return Limit{Min: lo, Max: hi}, nil
+}
+
+// Limits are the roster sizes a team should have
+type Limits struct {
+	Total     Limit
+	Positions map[int]Limit // By POSITION_KEY; positions without an entry are not checked
+}
+
+// DefaultLimits returns limits that keep the game from generating players
+// to fill a position: enough players to field every spot in either defensive
+// front, two quarterbacks and a long snapper, within the 53 to 90 player
+// range of an NFL roster
+func DefaultLimits() Limits {
+	return Limits{
+		Total: Limit{Min: 53, Max: 90},
+		Positions: map[int]Limit{
+			models.PositionQB:  {Min: 2, Max: 5},
+			models.PositionRB:  {Min: 2},
+			models.PositionTE:  {Min: 2},
+			models.PositionFL:  {Min: 2},
+			models.PositionSE:  {Min: 2},
+			models.PositionLT:  {Min: 1},
+			models.PositionLG:  {Min: 1},
+			models.PositionC:   {Min: 1},
+			models.PositionRG:  {Min: 1},
+			models.PositionRT:  {Min: 1},
+			models.PositionP:   {Min: 1, Max: 3},
+			models.PositionK:   {Min: 1, Max: 3},
+			models.PositionDLE: {Min: 1},
+			models.PositionDRE: {Min: 1},
+			models.PositionLCB: {Min: 2},
+			models.PositionRCB: {Min: 2},
+			models.PositionSS:  {Min: 1},
+			models.PositionFS:  {Min: 1},
+			models.PositionLS:  {Min: 1, Max: 2},
+		},
+	}
+}
+
+// LimitsFromProject returns the roster limits stored in the project
+// preferences, or DefaultLimits if the project has none. Positions missing
+// from stored limits are not checked.
+func LimitsFromProject(project *models.Project) (Limits, error) {
+	limits := DefaultLimits()
+	if project == nil {
+		return limits, nil
+	}
+
+	// Loaded projects hold JSON objects as map[string]interface{}
+	entries := make(map[string]string)
+	switch stored := project.UserPreferences[PreferenceRosterLimits].(type) {
+	case map[string]string:
+		entries = stored
+	case map[string]interface{}:
+		for key, value := range stored {
+			if text, ok := value.(string); ok {
+				entries[key] = text
+			}
+		}
+	default:
+		return limits, nil
+	}
+	limits.Positions = make(map[int]Limit)
+
+	for key, text := range entries {
+		limit, err := ParseLimit(text)
+		if err != nil {
+			return limits, fmt.Errorf("%s: %w", key, err)
+		}
+		if key == totalKey {
+			limits.Total = limit
+			continue
+		}
+		position := positionByAbbr(key)
+		if position == 0 {
+			return limits, fmt.Errorf("unknown position %q in roster limits", key)
+		}
+		limits.Positions[position] = limit
+	}
+	return limits, nil
+}
+
+// SaveToProject stores the limits in the project preferences
+func (l Limits) SaveToProject(project *models.Project) {
+	entries := map[string]string{totalKey: l.Total.String()}
+	for position, limit := range l.Positions {
+		entries[models.GetPositionAbbr(position)] = limit.String()
+	}
+	if project.UserPreferences == nil {
+		project.UserPreferences = make(map[string]interface{})
+	}
+	project.UserPreferences[PreferenceRosterLimits] = entries
+}
+
+// positionByAbbr returns the POSITION_KEY for an abbreviation, or 0
+func positionByAbbr(abbr string) int {
+	for _, pos := range models.DefaultPositions() {
+		if pos.Abbreviation == abbr {
+			return pos.ID
+		}
+	}
+	return 0
+}
+
+// TeamRoster counts a team's players from both files
+type TeamRoster struct {
+	TeamID     int
+	Total      int
+	ByPosition map[int]int    // By POSITION_KEY
+	ByType     map[string]int // By position type: Offense, Defense, Special Teams
+}
+
+// Compose counts the players of the given teams, and of any other team with
+// players in either file, ordered by team ID. Free agents are left out.
+func Compose(teamIDs []int, players []models.Player, quarterbacks []models.Quarterback) []TeamRoster {
+	byTeam := ByTeam(Members(players, quarterbacks))
+	for _, teamID := range teamIDs {
+		if _, ok := byTeam[teamID]; !ok && teamID != 0 {
+			byTeam[teamID] = nil
+		}
+	}
+
+	rosters := make([]TeamRoster, 0, len(byTeam))
+	for teamID, members := range byTeam {
+		roster := TeamRoster{
+			TeamID:     teamID,
+			Total:      len(members),
+			ByPosition: make(map[int]int),
+			ByType:     make(map[string]int),
+		}
+		for _, member := range members {
+			roster.ByPosition[member.PositionKey]++
+			roster.ByType[models.GetPositionType(member.PositionKey)]++
+		}
+		rosters = append(rosters, roster)
+	}
+
+	sort.Slice(rosters, func(i, j int) bool { return rosters[i].TeamID < rosters[j].TeamID })
+	return rosters
+}
+
+// RosterIssue is a team with too few or too many players at a position, or in total
+type RosterIssue struct {
+	TeamID      int
+	PositionKey int // 0 for the total roster
+	Count       int
+	Limit       Limit
+}
+
+// Message describes the issue, e.g. "1 QB, expected 2-5"
+func (i RosterIssue) Message() string {
+	what := "players"
+	if i.PositionKey != 0 {
+		what = models.GetPositionAbbr(i.PositionKey)
+	}
+	return fmt.Sprintf("%d %s, expected %s", i.Count, what, i.Limit)
+}
+
+// Check returns the issues of every roster, ordered by team and position
+// with the total first
+func (l Limits) Check(rosters []TeamRoster) []RosterIssue {
+	positions := make([]int, 0, len(l.Positions))
+	for position := range l.Positions {
+		positions = append(positions, position)
+	}
+	sort.Ints(positions)
+
+	var issues []RosterIssue
+	for _, roster := range rosters {
+		if !l.Total.Allows(roster.Total) {
+			issues = append(issues, RosterIssue{TeamID: roster.TeamID, Count: roster.Total, Limit: l.Total})
+		}
+		for _, position := range positions {
+			limit := l.Positions[position]
+			if count := roster.ByPosition[position]; !limit.Allows(count) {
+				issues = append(issues, RosterIssue{TeamID: roster.TeamID, PositionKey: position, Count: count, Limit: limit})
+			}
+		}
+	}
+	return issues
+}
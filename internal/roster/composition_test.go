@@ -0,0 +1,140 @@
+// ABOUTME: Tests for roster composition and roster limits
+// ABOUTME: Verifies counts by position and type, limit checks and limits stored in a project
+
+package roster
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestCompose(t *testing.T) {
+	quarterbacks := []models.Quarterback{{PlayerID: 501, Team: 1}}
+	players := []models.Player{
+		{PlayerID: 1000, Team: 1, PositionKey: models.PositionLS},
+		{PlayerID: 1001, Team: 1, PositionKey: models.PositionMLB},
+		{PlayerID: 1002, Team: 2, PositionKey: models.PositionMLB},
+		{PlayerID: 1003, Team: 0, PositionKey: models.PositionK},
+	}
+
+	rosters := Compose([]int{1, 2, 3}, players, quarterbacks)
+	if len(rosters) != 3 {
+		t.Fatalf("Expected 3 rosters, got %+v", rosters)
+	}
+
+	first := rosters[0]
+	if first.TeamID != 1 || first.Total != 3 || first.ByPosition[models.PositionQB] != 1 || first.ByPosition[models.PositionLS] != 1 {
+		t.Errorf("Unexpected roster for team 1: %+v", first)
+	}
+	if first.ByType["Offense"] != 1 || first.ByType["Defense"] != 1 || first.ByType["Special Teams"] != 1 {
+		t.Errorf("Unexpected position types for team 1: %v", first.ByType)
+	}
+	if rosters[2].TeamID != 3 || rosters[2].Total != 0 {
+		t.Errorf("Expected an empty roster for team 3, got %+v", rosters[2])
+	}
+}
+
+func TestLimitsCheck(t *testing.T) {
+	limits := Limits{
+		Total:     Limit{Min: 2, Max: 3},
+		Positions: map[int]Limit{models.PositionQB: {Min: 2}, models.PositionLS: {Min: 1, Max: 1}},
+	}
+	rosters := []TeamRoster{
+		{TeamID: 1, Total: 4, ByPosition: map[int]int{models.PositionQB: 2, models.PositionLS: 2}},
+		{TeamID: 2, Total: 2, ByPosition: map[int]int{models.PositionQB: 1, models.PositionLS: 1}},
+	}
+
+	issues := limits.Check(rosters)
+	expected := []string{"4 players, expected 2-3", "2 LS, expected 1-1", "1 QB, expected 2+"}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %+v", len(expected), issues)
+	}
+	for i, want := range expected {
+		if got := issues[i].Message(); got != want {
+			t.Errorf("Issue %d: expected %q, got %q", i, want, got)
+		}
+	}
+	if issues[2].TeamID != 2 {
+		t.Errorf("Expected the quarterback issue on team 2, got team %d", issues[2].TeamID)
+	}
+}
+
+func TestLimitsCheck_DefaultData(t *testing.T) {
+	players, err := data.LoadPlayers("../../default_data/2024_players.csv")
+	if err != nil {
+		t.Fatalf("LoadPlayers failed: %v", err)
+	}
+	quarterbacks, err := data.LoadQuarterbacks("../../default_data/2024_quarterbacks.csv")
+	if err != nil {
+		t.Fatalf("LoadQuarterbacks failed: %v", err)
+	}
+
+	rosters := Compose(nil, players, quarterbacks)
+	if len(rosters) != 32 {
+		t.Errorf("Expected 32 teams, got %d", len(rosters))
+	}
+	if issues := DefaultLimits().Check(rosters); len(issues) != 0 {
+		t.Errorf("Expected the shipped rosters to meet the default limits, got %+v", issues)
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	valid := map[string]Limit{"2-5": {2, 5}, "1+": {1, 0}, " 0 - 3 ": {0, 3}}
+	for text, want := range valid {
+		got, err := ParseLimit(text)
+		if err != nil || got != want {
+			t.Errorf("ParseLimit(%q) = %+v, %v; expected %+v", text, got, err, want)
+		}
+	}
+	for _, text := range []string{"", "5-2", "x-3", "-1+", "0-0", "3"} {
+		if _, err := ParseLimit(text); err == nil {
+			t.Errorf("Expected ParseLimit(%q) to fail", text)
+		}
+	}
+}
+
+func TestLimitsFromProject(t *testing.T) {
+	project := models.NewProject("Test League", "test", "/tmp", 2024)
+	limits := DefaultLimits()
+	limits.Total = Limit{Min: 46, Max: 60}
+	limits.Positions[models.PositionFB] = Limit{Min: 1, Max: 2}
+	limits.SaveToProject(project)
+
+	// Round trip through JSON as a saved project would
+	jsonData, err := json.Marshal(project)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var loaded models.Project
+	if err := json.Unmarshal(jsonData, &loaded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	got, err := LimitsFromProject(&loaded)
+	if err != nil {
+		t.Fatalf("LimitsFromProject failed: %v", err)
+	}
+	if got.Total != limits.Total || got.Positions[models.PositionFB] != limits.Positions[models.PositionFB] {
+		t.Errorf("Expected the saved limits, got %+v", got)
+	}
+	if got.Positions[models.PositionQB] != (Limit{Min: 2, Max: 5}) {
+		t.Errorf("Expected the saved QB limit, got %+v", got.Positions[models.PositionQB])
+	}
+
+	delete(limits.Positions, models.PositionQB)
+	limits.SaveToProject(&loaded)
+	if got, _ := LimitsFromProject(&loaded); len(got.Positions) != len(limits.Positions) {
+		t.Errorf("Expected a removed limit to stay removed, got %+v", got.Positions)
+	}
+	if got, _ := LimitsFromProject(models.NewProject("New", "new", "/tmp", 2024)); got.Total != DefaultLimits().Total {
+		t.Errorf("Expected the default limits without stored ones, got %+v", got)
+	}
+
+	loaded.UserPreferences[PreferenceRosterLimits] = map[string]interface{}{"XX": "1-2"}
+	if _, err := LimitsFromProject(&loaded); err == nil {
+		t.Error("Expected an error for an unknown position")
+	}
+}
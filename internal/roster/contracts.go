@@ -0,0 +1,208 @@
+// ABOUTME: Salary cap totals and contract checks across the player and quarterback files
+// ABOUTME: Compares contracts with the league's salary cap and experience-based salary minimums
+
+package roster
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// ContractYears is the number of seasons a contract in the player files covers
+const ContractYears = 5
+
+// MaxContractValue is the largest salary or bonus allowed; the docs say
+// "salaries and bonuses must be below 10,000 ($1 billion) in a player file".
+// The field rules of the player files report larger values.
+const MaxContractValue = 9999
+
+// Contract is a player's remaining contract. Salaries and bonuses are in
+// units of $10,000, the first element being the first season.
+type Contract struct {
+	Years  int // SALARYYEARS
+	Salary [ContractYears]int
+	Bonus  [ContractYears]int
+}
+
+// PlayerContract returns the contract of a player file entry
+func PlayerContract(p *models.Player) Contract {
+	return Contract{
+		Years:  p.SalaryYears,
+		Salary: [ContractYears]int{p.SalaryYear1, p.SalaryYear2, p.SalaryYear3, p.SalaryYear4, p.SalaryYear5},
+		Bonus:  [ContractYears]int{p.BonusYear1, p.BonusYear2, p.BonusYear3, p.BonusYear4, p.BonusYear5},
+	}
+}
+
+// QuarterbackContract returns the contract of a quarterback file entry
+func QuarterbackContract(q *models.Quarterback) Contract {
+	return Contract{
+		Years:  q.SalaryYears,
+		Salary: [ContractYears]int{q.SalaryYear1, q.SalaryYear2, q.SalaryYear3, q.SalaryYear4, q.SalaryYear5},
+		Bonus:  [ContractYears]int{q.BonusYear1, q.BonusYear2, q.BonusYear3, q.BonusYear4, q.BonusYear5},
+	}
+}
+
+// CapHit returns the salary plus bonus counted against the cap in a
+// contract year, 0 being the first season
+func (c Contract) CapHit(year int) int {
+	return c.Salary[year] + c.Bonus[year]
+}
+
+// SalariedYears returns the number of years with a non-zero salary
+func (c Contract) SalariedYears() int {
+	count := 0
+	for _, salary := range c.Salary {
+		if salary != 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// IsEmpty returns true if the contract has no years, salaries or bonuses
+func (c Contract) IsEmpty() bool {
+	return c == Contract{}
+}
+
+// CapUnits converts LeagueInfo.SalaryCap from units of $100,000 to the
+// $10,000 units of contracts
+func CapUnits(salaryCap int) int {
+	return salaryCap * 10
+}
+
+// TeamCap is a team's committed salary plus bonus in each contract year
+type TeamCap struct {
+	TeamID  int
+	Players int
+	Totals  [ContractYears]int // In units of $10,000
+}
+
+// CapTotals sums the contracts of the given teams, and of any other team
+// with players in either file, ordered by team ID. Free agents are left out.
+func CapTotals(teamIDs []int, players []models.Player, quarterbacks []models.Quarterback) []TeamCap {
+	byTeam := ByTeam(Members(players, quarterbacks))
+	for _, teamID := range teamIDs {
+		if _, ok := byTeam[teamID]; !ok && teamID != 0 {
+			byTeam[teamID] = nil
+		}
+	}
+
+	caps := make([]TeamCap, 0, len(byTeam))
+	for teamID, members := range byTeam {
+		teamCap := TeamCap{TeamID: teamID, Players: len(members)}
+		for _, member := range members {
+			for year := 0; year < ContractYears; year++ {
+				teamCap.Totals[year] += member.Contract.CapHit(year)
+			}
+		}
+		caps = append(caps, teamCap)
+	}
+
+	sort.Slice(caps, func(i, j int) bool { return caps[i].TeamID < caps[j].TeamID })
+	return caps
+}
+
+// ContractProblem identifies what a contract issue is about
+type ContractProblem int
+
+const (
+	// ContractOverCap is a team whose total in a contract year exceeds the salary cap
+	ContractOverCap ContractProblem = iota
+	// ContractBelowMinimum is a first-year salary below the experience minimum
+	ContractBelowMinimum
+	// ContractYearsMismatch is a SALARYYEARS that differs from the salaried years
+	ContractYearsMismatch
+	// ContractFreeAgent is a free agent (team 0) holding a contract
+	ContractFreeAgent
+)
+
+// ContractIssue is a problem with a team's cap total or a player's contract
+type ContractIssue struct {
+	Problem ContractProblem
+	TeamID  int
+	Member  Member // Zero for ContractOverCap
+	Year    int    // 1-based contract year for ContractOverCap and ContractBelowMinimum
+	Value   int    // Team total, salary or SALARYYEARS
+	Limit   int    // Cap, salary minimum or salaried years
+}
+
+// Message describes the issue, e.g. "salary $750,000 is below the $800,000
+// minimum for 0 years of experience"
+func (i ContractIssue) Message() string {
+	switch i.Problem {
+	case ContractOverCap:
+		return fmt.Sprintf("team %d commits %s in contract year %d, over the %s salary cap",
+			i.TeamID, FormatMoney(i.Value), i.Year, FormatMoney(i.Limit))
+	case ContractBelowMinimum:
+		return fmt.Sprintf("salary %s is below the %s minimum for %d years of experience",
+			FormatMoney(i.Value), FormatMoney(i.Limit), i.Member.Experience)
+	case ContractYearsMismatch:
+		return fmt.Sprintf("SALARYYEARS is %d but %d contract years have a salary", i.Value, i.Limit)
+	case ContractFreeAgent:
+		return "free agents (team 0) should not have a contract"
+	default:
+		return ""
+	}
+}
+
+// FormatMoney formats an amount in units of $10,000 as dollars, e.g. "$1,080,000"
+func FormatMoney(units int) string {
+	digits := fmt.Sprintf("%d", units*10000)
+	sign := ""
+	if units < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return sign + "$" + digits
+}
+
+// CheckContracts returns the contract issues of every player, quarterbacks
+// first and each file in order, followed by the teams over the cap in each
+// contract year. Later years are held to the current cap; the salary
+// minimums apply to the first season only, as the game raises them in later
+// seasons. Without league info neither is checked.
+// Future draftees are left to CheckProspects when the info has a BASE_YEAR.
+func CheckContracts(players []models.Player, quarterbacks []models.Quarterback, info *models.LeagueInfo) []ContractIssue {
+	var issues []ContractIssue
+	for _, member := range Members(players, quarterbacks) {
+		if info != nil && member.IsDraftee(info.BaseYear) {
+			continue
+		}
+		contract := member.Contract
+		add := func(problem ContractProblem, year, value, limit int) {
+			issues = append(issues, ContractIssue{
+				Problem: problem, TeamID: member.Team, Member: member, Year: year, Value: value, Limit: limit,
+			})
+		}
+
+		if salaried := contract.SalariedYears(); contract.Years != salaried {
+			add(ContractYearsMismatch, 0, contract.Years, salaried)
+		}
+		if member.Team == 0 && !contract.IsEmpty() {
+			add(ContractFreeAgent, 0, 0, 0)
+		}
+		if info != nil && contract.Salary[0] != 0 {
+			if minimum := info.GetSalaryMinimum(member.Experience); contract.Salary[0] < minimum {
+				add(ContractBelowMinimum, 1, contract.Salary[0], minimum)
+			}
+		}
+	}
+
+	if info != nil && info.SalaryCap > 0 {
+		limit := CapUnits(info.SalaryCap)
+		for _, teamCap := range CapTotals(nil, players, quarterbacks) {
+			for year, total := range teamCap.Totals {
+				if total > limit {
+					issues = append(issues, ContractIssue{
+						Problem: ContractOverCap, TeamID: teamCap.TeamID, Year: year + 1, Value: total, Limit: limit,
+					})
+				}
+			}
+		}
+	}
+	return issues
+}
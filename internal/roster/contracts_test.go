@@ -0,0 +1,117 @@
+// ABOUTME: Tests for salary cap totals and contract checks
+// ABOUTME: Covers each contract problem, money formatting and the shipped default data
+
+package roster
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestCapTotals(t *testing.T) {
+	quarterbacks := []models.Quarterback{{PlayerID: 501, Team: 1, SalaryYears: 2, SalaryYear1: 3000, BonusYear1: 500, SalaryYear2: 3200}}
+	players := []models.Player{
+		{PlayerID: 1000, Team: 1, SalaryYears: 1, SalaryYear1: 100, BonusYear1: 20},
+		{PlayerID: 1001, Team: 0, SalaryYear1: 80},
+	}
+
+	caps := CapTotals([]int{1, 2}, players, quarterbacks)
+	if len(caps) != 2 {
+		t.Fatalf("Expected 2 teams, got %+v", caps)
+	}
+	if caps[0].TeamID != 1 || caps[0].Players != 2 || caps[0].Totals != [ContractYears]int{3620, 3200, 0, 0, 0} {
+		t.Errorf("Unexpected cap for team 1: %+v", caps[0])
+	}
+	if caps[1].TeamID != 2 || caps[1].Totals[0] != 0 {
+		t.Errorf("Expected an empty cap for team 2, got %+v", caps[1])
+	}
+}
+
+func TestCheckContracts(t *testing.T) {
+	info := models.NewDefaultLeagueInfo(2024)
+	info.SalaryCap = 40 // $4M
+
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 501, Team: 1, Experience: 6, SalaryYears: 1, SalaryYear1: 120},
+	}
+	players := []models.Player{
+		{PlayerID: 1000, Team: 1, Experience: 6, SalaryYears: 1, SalaryYear1: 130},
+		{PlayerID: 1001, Team: 1, Experience: 2, SalaryYears: 3, SalaryYear1: 100, SalaryYear2: 450},
+		{PlayerID: 1002, Team: 1, SalaryYears: 1, SalaryYear1: 70, BonusYear1: 10000},
+		{PlayerID: 1003, Team: 0, SalaryYears: 1, SalaryYear1: 70},
+	}
+
+	issues := CheckContracts(players, quarterbacks, info)
+	expected := []struct {
+		problem  ContractProblem
+		playerID int
+		message  string
+	}{
+		{ContractBelowMinimum, 501, "salary $1,200,000 is below the $1,300,000 minimum for 6 years of experience"},
+		{ContractYearsMismatch, 1001, "SALARYYEARS is 3 but 2 contract years have a salary"},
+		{ContractFreeAgent, 1003, "free agents (team 0) should not have a contract"},
+		{ContractOverCap, 0, "team 1 commits $104,200,000 in contract year 1, over the $4,000,000 salary cap"},
+		{ContractOverCap, 0, "team 1 commits $4,500,000 in contract year 2, over the $4,000,000 salary cap"},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %+v", len(expected), issues)
+	}
+	for i, want := range expected {
+		got := issues[i]
+		if got.Problem != want.problem || got.Member.PlayerID != want.playerID || got.Message() != want.message {
+			t.Errorf("Issue %d: expected %d for %d (%s), got %d for %d (%s)",
+				i, want.problem, want.playerID, want.message, got.Problem, got.Member.PlayerID, got.Message())
+		}
+	}
+
+	// Without league info only the contracts themselves are checked; the
+	// bonus of 10000 is left to the field rules
+	if issues := CheckContracts(players, quarterbacks, nil); len(issues) != 2 {
+		t.Errorf("Expected 2 issues without league info, got %+v", issues)
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := map[int]string{
+		0:      "$0",
+		75:     "$750,000",
+		25540:  "$255,400,000",
+		-10:    "-$100,000",
+		999999: "$9,999,990,000",
+	}
+	for units, want := range tests {
+		if got := FormatMoney(units); got != want {
+			t.Errorf("FormatMoney(%d): expected %s, got %s", units, want, got)
+		}
+	}
+}
+
+func TestCheckContracts_DefaultData(t *testing.T) {
+	players, err := data.LoadPlayers("../../default_data/2024_players.csv")
+	if err != nil {
+		t.Fatalf("LoadPlayers failed: %v", err)
+	}
+	quarterbacks, err := data.LoadQuarterbacks("../../default_data/2024_quarterbacks.csv")
+	if err != nil {
+		t.Fatalf("LoadQuarterbacks failed: %v", err)
+	}
+
+	// 2024 salary settings from league_years.csv
+	info := &models.LeagueInfo{SalaryCap: 2554, Minimum: 80, Salary1: 92, Salary2: 99, Salary3: 106,
+		Salary45: 113, Salary789: 121, Salary10: 121}
+
+	counts := make(map[ContractProblem]int)
+	for _, issue := range CheckContracts(players, quarterbacks, info) {
+		counts[issue.Problem]++
+	}
+	if counts[ContractYearsMismatch] != 0 || counts[ContractFreeAgent] != 0 {
+		t.Errorf("Expected the shipped contracts to be consistent, got %v", counts)
+	}
+	// A few shipped salaries sit below the minimum, two teams start over the
+	// cap and nine years of later commitments exceed today's cap
+	if counts[ContractBelowMinimum] != 15 || counts[ContractOverCap] != 11 {
+		t.Errorf("Unexpected minimum and cap issues: %v", counts)
+	}
+}
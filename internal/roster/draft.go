@@ -0,0 +1,198 @@
+// ABOUTME: Past draft boards rebuilt from the player and quarterback files
+// ABOUTME: Checks that draft rounds, selections and original teams describe consistent drafts
+
+package roster
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// Picks a round may lose to forfeited selections or gain from compensatory
+// selections, beyond one pick per team. Real drafts since 1994 lose at most
+// 2 picks in a round and add up to 38 compensatory picks by round 7.
+const (
+	forfeitedPicksPerRound    = 2
+	compensatoryPicksPerRound = 8
+)
+
+// DraftPick is a player's draft history from the player files
+type DraftPick struct {
+	Member       Member
+	Year         int // YEARENTRY, the player's draft class
+	Round        int // 0 if undrafted
+	Selection    int // Overall selection, 0 if undrafted
+	Supplemental bool
+	OriginalTeam int
+}
+
+// Drafted returns true if the player was selected in a draft
+func (p DraftPick) Drafted() bool {
+	return p.Round > 0
+}
+
+// DraftPicks returns the draft history of every player from both files,
+// quarterbacks first, each in file order
+func DraftPicks(players []models.Player, quarterbacks []models.Quarterback) []DraftPick {
+	members := Members(players, quarterbacks)
+	picks := make([]DraftPick, len(members))
+	for i, member := range members {
+		pick := DraftPick{Member: member}
+		if member.Quarterback {
+			qb := &quarterbacks[member.Index]
+			pick.Year, pick.Round, pick.Selection = qb.YearEntry, qb.RoundDrafted, qb.SelectionDrafted
+			pick.Supplemental, pick.OriginalTeam = qb.Supplemental != 0, qb.OriginalTeam
+		} else {
+			player := &players[member.Index]
+			pick.Year, pick.Round, pick.Selection = player.YearEntry, player.RoundDrafted, player.SelectionDrafted
+			pick.Supplemental, pick.OriginalTeam = player.Supplemental != 0, player.OriginalTeam
+		}
+		picks[i] = pick
+	}
+	return picks
+}
+
+// DraftClass is the rebuilt draft board of one YEARENTRY
+type DraftClass struct {
+	Year      int
+	Picks     []DraftPick // Drafted players by overall selection, supplemental picks last
+	Undrafted int
+}
+
+// DraftBoards rebuilds the draft of every YEARENTRY in the files, ordered by year
+func DraftBoards(players []models.Player, quarterbacks []models.Quarterback) []DraftClass {
+	byYear := make(map[int]*DraftClass)
+	for _, pick := range DraftPicks(players, quarterbacks) {
+		if pick.Year <= 0 {
+			continue
+		}
+		class, ok := byYear[pick.Year]
+		if !ok {
+			class = &DraftClass{Year: pick.Year}
+			byYear[pick.Year] = class
+		}
+		if pick.Drafted() {
+			class.Picks = append(class.Picks, pick)
+		} else {
+			class.Undrafted++
+		}
+	}
+
+	classes := make([]DraftClass, 0, len(byYear))
+	for _, class := range byYear {
+		sort.SliceStable(class.Picks, func(i, j int) bool {
+			a, b := class.Picks[i], class.Picks[j]
+			if a.Supplemental != b.Supplemental {
+				return !a.Supplemental
+			}
+			if a.Selection != b.Selection {
+				return a.Selection < b.Selection
+			}
+			return a.Round < b.Round
+		})
+		classes = append(classes, *class)
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i].Year < classes[j].Year })
+	return classes
+}
+
+// RoundSelections returns the range of overall selections a round can hold
+// in a league of the given number of teams, allowing for forfeited and
+// compensatory picks in every round up to it
+func RoundSelections(round, teams int) (first, last int) {
+	first = (round-1)*(teams-forfeitedPicksPerRound) + 1
+	last = round * (teams + compensatoryPicksPerRound)
+	return first, last
+}
+
+// DraftProblem identifies what a draft issue is about
+type DraftProblem int
+
+const (
+	// DraftDuplicateSelection is an overall selection already held by another player of the class
+	DraftDuplicateSelection DraftProblem = iota
+	// DraftRoundMismatch is a round that cannot hold the overall selection
+	DraftRoundMismatch
+	// DraftNoOriginalTeam is a drafted player with ORIGINALTEAM 0
+	DraftNoOriginalTeam
+	// DraftSelectionMismatch is a selection without a round or a round without a selection
+	DraftSelectionMismatch
+)
+
+// DraftIssue is a problem with a player's draft history
+type DraftIssue struct {
+	Problem DraftProblem
+	Pick    DraftPick
+	Other   DraftPick // The earlier holder of the selection for DraftDuplicateSelection
+	Teams   int       // League size for DraftRoundMismatch
+}
+
+// Message describes the issue, e.g. "selection 12 of the 2020 draft is also
+// held by John Doe"
+func (i DraftIssue) Message() string {
+	pick := i.Pick
+	switch i.Problem {
+	case DraftDuplicateSelection:
+		return fmt.Sprintf("selection %d of the %d draft is also held by %s", pick.Selection, pick.Year, i.Other.Member.Name)
+	case DraftRoundMismatch:
+		first, last := RoundSelections(pick.Round, i.Teams)
+		return fmt.Sprintf("selection %d cannot be in round %d; with %d teams round %d holds selections %d-%d",
+			pick.Selection, pick.Round, i.Teams, pick.Round, first, last)
+	case DraftNoOriginalTeam:
+		return fmt.Sprintf("drafted in round %d of the %d draft but ORIGINALTEAM is 0", pick.Round, pick.Year)
+	case DraftSelectionMismatch:
+		if pick.Drafted() {
+			return fmt.Sprintf("drafted in round %d but SELECTIONDRAFTED is 0", pick.Round)
+		}
+		return fmt.Sprintf("undrafted (ROUNDDRAFTED 0) but SELECTIONDRAFTED is %d", pick.Selection)
+	default:
+		return ""
+	}
+}
+
+// CheckDrafts returns the draft issues of every player, quarterbacks first
+// and each file in order. Supplemental picks have no overall selection and
+// are only checked for an original team. A team count of 0 skips the round
+// check.
+func CheckDrafts(players []models.Player, quarterbacks []models.Quarterback, teams int) []DraftIssue {
+	var issues []DraftIssue
+	held := make(map[[2]int]DraftPick) // Year and selection
+	for _, pick := range DraftPicks(players, quarterbacks) {
+		add := func(problem DraftProblem) {
+			issues = append(issues, DraftIssue{Problem: problem, Pick: pick, Teams: teams})
+		}
+
+		if !pick.Drafted() {
+			if pick.Selection != 0 {
+				add(DraftSelectionMismatch)
+			}
+			continue
+		}
+
+		if pick.OriginalTeam == 0 {
+			add(DraftNoOriginalTeam)
+		}
+		if pick.Supplemental {
+			continue
+		}
+		if pick.Selection == 0 {
+			add(DraftSelectionMismatch)
+			continue
+		}
+
+		slot := [2]int{pick.Year, pick.Selection}
+		if other, ok := held[slot]; ok {
+			issues = append(issues, DraftIssue{Problem: DraftDuplicateSelection, Pick: pick, Other: other})
+		} else {
+			held[slot] = pick
+		}
+		if teams > 0 {
+			if first, last := RoundSelections(pick.Round, teams); pick.Selection < first || pick.Selection > last {
+				add(DraftRoundMismatch)
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,104 @@
+// ABOUTME: Tests for rebuilt draft boards and draft history checks
+// ABOUTME: Covers board ordering, each draft problem and the shipped default data
+
+package roster
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestDraftBoards(t *testing.T) {
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 501, YearEntry: 2020, RoundDrafted: 1, SelectionDrafted: 6, OriginalTeam: 20},
+	}
+	players := []models.Player{
+		{PlayerID: 1000, YearEntry: 2020, RoundDrafted: 5, Supplemental: 1, OriginalTeam: 3},
+		{PlayerID: 1001, YearEntry: 2020, RoundDrafted: 1, SelectionDrafted: 2, OriginalTeam: 4},
+		{PlayerID: 1002, YearEntry: 2020},
+		{PlayerID: 1003, YearEntry: 2019, RoundDrafted: 7, SelectionDrafted: 250, OriginalTeam: 5},
+	}
+
+	boards := DraftBoards(players, quarterbacks)
+	if len(boards) != 2 || boards[0].Year != 2019 || boards[1].Year != 2020 {
+		t.Fatalf("Expected the 2019 and 2020 drafts, got %+v", boards)
+	}
+	class := boards[1]
+	if class.Undrafted != 1 || len(class.Picks) != 3 {
+		t.Fatalf("Expected 3 picks and 1 undrafted in 2020, got %+v", class)
+	}
+	ids := []int{class.Picks[0].Member.PlayerID, class.Picks[1].Member.PlayerID, class.Picks[2].Member.PlayerID}
+	if ids[0] != 1001 || ids[1] != 501 || ids[2] != 1000 {
+		t.Errorf("Expected picks 2, 6 then the supplemental pick, got %v", ids)
+	}
+}
+
+func TestRoundSelections(t *testing.T) {
+	if first, last := RoundSelections(1, 32); first != 1 || last != 40 {
+		t.Errorf("Expected round 1 to hold 1-40, got %d-%d", first, last)
+	}
+	if first, last := RoundSelections(7, 32); first != 181 || last != 280 {
+		t.Errorf("Expected round 7 to hold 181-280, got %d-%d", first, last)
+	}
+}
+
+func TestCheckDrafts(t *testing.T) {
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 501, FirstName: "Joe", LastName: "Burrow", YearEntry: 2020, RoundDrafted: 1, SelectionDrafted: 1, OriginalTeam: 7},
+	}
+	players := []models.Player{
+		{PlayerID: 1000, FirstName: "Chase", LastName: "Young", YearEntry: 2020, RoundDrafted: 1, SelectionDrafted: 1, OriginalTeam: 31},
+		{PlayerID: 1001, YearEntry: 2020, RoundDrafted: 3, SelectionDrafted: 20, OriginalTeam: 4},
+		{PlayerID: 1002, YearEntry: 2020, RoundDrafted: 2, SelectionDrafted: 40},
+		{PlayerID: 1003, YearEntry: 2020, SelectionDrafted: 90},
+		{PlayerID: 1004, YearEntry: 2019, RoundDrafted: 1, SelectionDrafted: 1, OriginalTeam: 2},
+		{PlayerID: 1005, YearEntry: 2020, RoundDrafted: 5, Supplemental: 1, OriginalTeam: 3},
+	}
+
+	issues := CheckDrafts(players, quarterbacks, 32)
+	expected := []struct {
+		problem  DraftProblem
+		playerID int
+		message  string
+	}{
+		{DraftDuplicateSelection, 1000, "selection 1 of the 2020 draft is also held by Joe Burrow"},
+		{DraftRoundMismatch, 1001, "selection 20 cannot be in round 3; with 32 teams round 3 holds selections 61-120"},
+		{DraftNoOriginalTeam, 1002, "drafted in round 2 of the 2020 draft but ORIGINALTEAM is 0"},
+		{DraftSelectionMismatch, 1003, "undrafted (ROUNDDRAFTED 0) but SELECTIONDRAFTED is 90"},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %+v", len(expected), issues)
+	}
+	for i, want := range expected {
+		got := issues[i]
+		if got.Problem != want.problem || got.Pick.Member.PlayerID != want.playerID || got.Message() != want.message {
+			t.Errorf("Issue %d: expected %d for %d (%s), got %d for %d (%s)",
+				i, want.problem, want.playerID, want.message, got.Problem, got.Pick.Member.PlayerID, got.Message())
+		}
+	}
+
+	if issues := CheckDrafts(players, quarterbacks, 0); len(issues) != 3 {
+		t.Errorf("Expected 3 issues without a team count, got %+v", issues)
+	}
+}
+
+func TestCheckDrafts_DefaultData(t *testing.T) {
+	// The 2023 file gives selection 183 of the 2020 draft to two players
+	expected := map[string]int{"2023": 1, "2024": 0}
+	for year, want := range expected {
+		players, err := data.LoadPlayers("../../default_data/" + year + "_players.csv")
+		if err != nil {
+			t.Fatalf("LoadPlayers failed: %v", err)
+		}
+		quarterbacks, err := data.LoadQuarterbacks("../../default_data/" + year + "_quarterbacks.csv")
+		if err != nil {
+			t.Fatalf("LoadQuarterbacks failed: %v", err)
+		}
+
+		if issues := CheckDrafts(players, quarterbacks, 32); len(issues) != want {
+			t.Errorf("%s: expected %d draft issues, got %+v", year, want, issues)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// ABOUTME: Player ID renumbering across the player and quarterback files
+// ABOUTME: Plans new IDs that are unique, in each file's range and sorted, and moves portraits with them
+
+package roster
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// Player ID ranges from players.txt and quarterbacks.txt
+const (
+	MinPlayerID      = 1000 // "Players should have player IDs of at least 1000"
+	MinQuarterbackID = 500  // "Quarterbacks should have player IDs between 500-999"
+	MaxQuarterbackID = 999
+)
+
+// RenumberMode selects how RenumberPlayerIDs assigns IDs
+type RenumberMode int
+
+const (
+	// RenumberSort keeps every valid ID and gives duplicate and out-of-range
+	// IDs new ones continuing from the highest ID in the file's range
+	RenumberSort RenumberMode = iota
+	// RenumberCompact numbers each file sequentially from the start of its
+	// range, keeping the current ID order
+	RenumberCompact
+)
+
+// IDChange is a proposed new player ID
+type IDChange struct {
+	Member   Member
+	To       int
+	SharedID bool // Another player had the same ID, so its portrait cannot be moved
+}
+
+// PlayerKey is a player's record key in validation suppressions, e.g. "player:1000"
+func PlayerKey(playerID int) string {
+	return fmt.Sprintf("player:%d", playerID)
+}
+
+// RenumberPlayerIDs plans new IDs for both files so that every ID is unique
+// in the universe, quarterbacks are within 500-999, other players are 1000 or
+// more and, once applied, both files are sorted by ID
+func RenumberPlayerIDs(players []models.Player, quarterbacks []models.Quarterback, mode RenumberMode) ([]IDChange, error) {
+	members := Members(players, quarterbacks)
+	holders := make(map[int]int)
+	for _, member := range members {
+		holders[member.PlayerID]++
+	}
+
+	var qbs, others []Member
+	for _, member := range members {
+		if member.Quarterback {
+			qbs = append(qbs, member)
+		} else {
+			others = append(others, member)
+		}
+	}
+	if len(qbs) > MaxQuarterbackID-MinQuarterbackID+1 {
+		return nil, fmt.Errorf("%d quarterbacks do not fit IDs %d-%d", len(qbs), MinQuarterbackID, MaxQuarterbackID)
+	}
+
+	var assigned map[Member]int
+	if mode == RenumberCompact {
+		assigned = compactIDs(qbs, MinQuarterbackID)
+		for member, id := range compactIDs(others, MinPlayerID) {
+			assigned[member] = id
+		}
+	} else {
+		var err error
+		if assigned, err = sortIDs(members); err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []IDChange
+	for _, member := range members {
+		if to := assigned[member]; to != member.PlayerID {
+			changes = append(changes, IDChange{Member: member, To: to, SharedID: holders[member.PlayerID] > 1})
+		}
+	}
+	return changes, nil
+}
+
+// compactIDs numbers members sequentially from first in ID order
+func compactIDs(members []Member, first int) map[Member]int {
+	ordered := append([]Member(nil), members...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].PlayerID < ordered[j].PlayerID
+	})
+
+	assigned := make(map[Member]int, len(ordered))
+	for i, member := range ordered {
+		assigned[member] = first + i
+	}
+	return assigned
+}
+
+// sortIDs keeps the first holder of each valid ID and gives everyone else
+// the next free ID in their file's range
+func sortIDs(members []Member) (map[Member]int, error) {
+	inRange := func(member Member, id int) bool {
+		if member.Quarterback {
+			return id >= MinQuarterbackID && id <= MaxQuarterbackID
+		}
+		return id >= MinPlayerID
+	}
+
+	assigned := make(map[Member]int, len(members))
+	used := make(map[int]bool)
+	nextQB, nextPlayer := MinQuarterbackID, MinPlayerID
+	for _, member := range members {
+		if inRange(member, member.PlayerID) && !used[member.PlayerID] {
+			assigned[member] = member.PlayerID
+			used[member.PlayerID] = true
+			if member.Quarterback && member.PlayerID >= nextQB {
+				nextQB = member.PlayerID + 1
+			} else if !member.Quarterback && member.PlayerID >= nextPlayer {
+				nextPlayer = member.PlayerID + 1
+			}
+		}
+	}
+
+	for _, member := range members {
+		if _, ok := assigned[member]; ok {
+			continue
+		}
+		if member.Quarterback {
+			// Continue from the highest quarterback ID, then fill gaps
+			for nextQB <= MaxQuarterbackID && used[nextQB] {
+				nextQB++
+			}
+			id := nextQB
+			if id > MaxQuarterbackID {
+				for id = MinQuarterbackID; id <= MaxQuarterbackID && used[id]; id++ {
+				}
+				if id > MaxQuarterbackID {
+					return nil, fmt.Errorf("no free quarterback IDs in %d-%d", MinQuarterbackID, MaxQuarterbackID)
+				}
+			}
+			assigned[member] = id
+			used[id] = true
+		} else {
+			for used[nextPlayer] {
+				nextPlayer++
+			}
+			assigned[member] = nextPlayer
+			used[nextPlayer] = true
+		}
+	}
+	return assigned, nil
+}
+
+// ApplyPlayerIDs sets the planned IDs and sorts both files by ID. It returns
+// the number of players renumbered.
+func ApplyPlayerIDs(changes []IDChange, players []models.Player, quarterbacks []models.Quarterback) int {
+	updated := 0
+	for _, change := range changes {
+		m := change.Member
+		if m.Quarterback && m.Index < len(quarterbacks) && quarterbacks[m.Index].PlayerID == m.PlayerID {
+			quarterbacks[m.Index].PlayerID = change.To
+			updated++
+		} else if !m.Quarterback && m.Index < len(players) && players[m.Index].PlayerID == m.PlayerID {
+			players[m.Index].PlayerID = change.To
+			updated++
+		}
+	}
+
+	sort.SliceStable(players, func(i, j int) bool { return players[i].PlayerID < players[j].PlayerID })
+	sort.SliceStable(quarterbacks, func(i, j int) bool { return quarterbacks[i].PlayerID < quarterbacks[j].PlayerID })
+	return updated
+}
+
+// SuppressionKeys maps the record keys of renumbered players to their new
+// keys, for updating a project's validation suppressions
+func SuppressionKeys(changes []IDChange) map[string]string {
+	keys := make(map[string]string)
+	for _, change := range changes {
+		if !change.SharedID {
+			keys[PlayerKey(change.Member.PlayerID)] = PlayerKey(change.To)
+		}
+	}
+	return keys
+}
+
+// ReverseIDChanges returns the changes that give renumbered players back
+// their previous IDs, e.g. to move their portraits back
+func ReverseIDChanges(changes []IDChange) []IDChange {
+	reversed := make([]IDChange, len(changes))
+	for i, change := range changes {
+		reversed[i] = change
+		reversed[i].Member.PlayerID = change.To
+		reversed[i].To = change.Member.PlayerID
+	}
+	return reversed
+}
+
+// PortraitFile returns the file name of a player's custom portrait, which the
+// game names by player ID, e.g. "1465.bmp"
+func PortraitFile(playerID int) string {
+	return fmt.Sprintf("%d.bmp", playerID)
+}
+
+// PortraitMoves returns, for each change, the portrait in dir that
+// RenamePortraits moves, or "" if there is none
+func PortraitMoves(dir string, changes []IDChange) []string {
+	moves := make([]string, len(changes))
+	if dir == "" {
+		return moves
+	}
+	for i, change := range changes {
+		if change.SharedID {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, PortraitFile(change.Member.PlayerID))); err == nil {
+			moves[i] = PortraitFile(change.Member.PlayerID)
+		}
+	}
+	return moves
+}
+
+// RenamePortraits moves custom player portraits, which the game names by
+// player ID (e.g. 1465.bmp), to the planned IDs. Portraits of IDs shared by
+// several players are left alone. It returns the number of portraits moved.
+func RenamePortraits(dir string, changes []IDChange) (int, error) {
+	type move struct{ temp, target string }
+	var moves []move
+
+	// Move every portrait aside first so that swapped IDs cannot collide
+	for _, change := range changes {
+		if change.SharedID {
+			continue
+		}
+		source := filepath.Join(dir, PortraitFile(change.Member.PlayerID))
+		if _, err := os.Stat(source); err != nil {
+			continue
+		}
+		temp := source + ".renumber"
+		if err := os.Rename(source, temp); err != nil {
+			return len(moves), fmt.Errorf("failed to move portrait %s: %w", filepath.Base(source), err)
+		}
+		moves = append(moves, move{temp, filepath.Join(dir, PortraitFile(change.To))})
+	}
+
+	moved := 0
+	for _, m := range moves {
+		if _, err := os.Stat(m.target); err == nil {
+			return moved, fmt.Errorf("portrait %s already exists; the moved portrait was kept as %s",
+				filepath.Base(m.target), filepath.Base(m.temp))
+		}
+		if err := os.Rename(m.temp, m.target); err != nil {
+			return moved, fmt.Errorf("failed to move portrait to %s: %w", filepath.Base(m.target), err)
+		}
+		moved++
+	}
+	return moved, nil
+}
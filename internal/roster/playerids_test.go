@@ -0,0 +1,152 @@
+// ABOUTME: Tests for player ID renumbering
+// ABOUTME: Verifies sort and compact plans, applying them and moving portraits
+
+package roster
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// idFixtures returns quarterbacks and players with a duplicate, out-of-range
+// and unsorted IDs
+func idFixtures() ([]models.Player, []models.Quarterback) {
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 510, FirstName: "P", LastName: "A"},
+		{PlayerID: 505, FirstName: "P", LastName: "B"},
+		{PlayerID: 1200, FirstName: "P", LastName: "C"}, // Out of the quarterback range
+	}
+	players := []models.Player{
+		{PlayerID: 1002, FirstName: "P", LastName: "D"},
+		{PlayerID: 1002, FirstName: "P", LastName: "E"}, // Duplicate
+		{PlayerID: 50, FirstName: "P", LastName: "F"},   // Below 1000
+		{PlayerID: 1200, FirstName: "P", LastName: "G"}, // Shared with a quarterback
+	}
+	return players, quarterbacks
+}
+
+func TestRenumberPlayerIDs_Sort(t *testing.T) {
+	players, quarterbacks := idFixtures()
+
+	changes, err := RenumberPlayerIDs(players, quarterbacks, RenumberSort)
+	if err != nil {
+		t.Fatalf("RenumberPlayerIDs failed: %v", err)
+	}
+
+	expected := []struct {
+		name     string
+		from, to int
+		shared   bool
+	}{
+		{"C", 1200, 511, true},
+		{"E", 1002, 1201, true},
+		{"F", 50, 1202, false},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %+v", len(expected), changes)
+	}
+	for i, want := range expected {
+		got := changes[i]
+		if got.Member.Name != "P "+want.name || got.Member.PlayerID != want.from || got.To != want.to || got.SharedID != want.shared {
+			t.Errorf("Change %d: expected %s %d -> %d (shared %v), got %s %d -> %d (shared %v)",
+				i, want.name, want.from, want.to, want.shared, got.Member.Name, got.Member.PlayerID, got.To, got.SharedID)
+		}
+	}
+
+	if updated := ApplyPlayerIDs(changes, players, quarterbacks); updated != 3 {
+		t.Errorf("Expected 3 players renumbered, got %d", updated)
+	}
+	wantQBs := []int{505, 510, 511}
+	for i, id := range wantQBs {
+		if quarterbacks[i].PlayerID != id {
+			t.Errorf("Quarterback %d: expected ID %d, got %d", i, id, quarterbacks[i].PlayerID)
+		}
+	}
+	wantPlayers := []string{"D", "G", "E", "F"}
+	for i, name := range wantPlayers {
+		if players[i].LastName != name {
+			t.Errorf("Player %d: expected %s, got %s (%d)", i, name, players[i].LastName, players[i].PlayerID)
+		}
+	}
+
+	if changes, _ := RenumberPlayerIDs(players, quarterbacks, RenumberSort); len(changes) != 0 {
+		t.Errorf("Expected no changes once sorted, got %+v", changes)
+	}
+}
+
+func TestRenumberPlayerIDs_Compact(t *testing.T) {
+	players, quarterbacks := idFixtures()
+
+	changes, err := RenumberPlayerIDs(players, quarterbacks, RenumberCompact)
+	if err != nil {
+		t.Fatalf("RenumberPlayerIDs failed: %v", err)
+	}
+	ApplyPlayerIDs(changes, players, quarterbacks)
+
+	// Each file keeps its ID order, duplicates in file order
+	wantQBs := map[string]int{"B": 500, "A": 501, "C": 502}
+	for _, qb := range quarterbacks {
+		if qb.PlayerID != wantQBs[qb.LastName] {
+			t.Errorf("Quarterback %s: expected ID %d, got %d", qb.LastName, wantQBs[qb.LastName], qb.PlayerID)
+		}
+	}
+	wantPlayers := map[string]int{"F": 1000, "D": 1001, "E": 1002, "G": 1003}
+	for _, player := range players {
+		if player.PlayerID != wantPlayers[player.LastName] {
+			t.Errorf("Player %s: expected ID %d, got %d", player.LastName, wantPlayers[player.LastName], player.PlayerID)
+		}
+	}
+}
+
+func TestRenumberPlayerIDs_TooManyQuarterbacks(t *testing.T) {
+	quarterbacks := make([]models.Quarterback, 501)
+	if _, err := RenumberPlayerIDs(nil, quarterbacks, RenumberCompact); err == nil {
+		t.Error("Expected an error for more quarterbacks than IDs")
+	}
+}
+
+func TestRenamePortraits(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"1000.bmp", "1001.bmp", "1002.bmp"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Swap 1000 and 1001 and leave the shared 1002 alone
+	changes := []IDChange{
+		{Member: Member{PlayerID: 1000}, To: 1001},
+		{Member: Member{PlayerID: 1001}, To: 1000},
+		{Member: Member{PlayerID: 1002}, To: 1005, SharedID: true},
+	}
+	if got := PortraitMoves(dir, changes); !slices.Equal(got, []string{"1000.bmp", "1001.bmp", ""}) {
+		t.Errorf("Expected the two portraits to be moved, got %v", got)
+	}
+	if got := PortraitMoves("", changes); !slices.Equal(got, []string{"", "", ""}) {
+		t.Errorf("Expected no portraits without a folder, got %v", got)
+	}
+
+	moved, err := RenamePortraits(dir, changes)
+	if err != nil {
+		t.Fatalf("RenamePortraits failed: %v", err)
+	}
+	if moved != 2 {
+		t.Errorf("Expected 2 portraits moved, got %d", moved)
+	}
+
+	for name, content := range map[string]string{"1000.bmp": "1001.bmp", "1001.bmp": "1000.bmp", "1002.bmp": "1002.bmp"} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(got) != content {
+			t.Errorf("Expected %s to hold %s, got %q (%v)", name, content, got, err)
+		}
+	}
+
+	keys := SuppressionKeys(changes)
+	if len(keys) != 2 || keys["player:1000"] != "player:1001" {
+		t.Errorf("Unexpected suppression keys: %v", keys)
+	}
+}
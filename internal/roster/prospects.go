@@ -0,0 +1,130 @@
+// ABOUTME: Future draft classes of the player and quarterback files by BASE_YEAR
+// ABOUTME: Groups draftees by the season they join the draft pool and checks their team, contract and class size
+
+package roster
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// Draft pool sizes from the custom player file docs
+const (
+	// DraftPoolPerTeam is about how many draftable players the game generates per team
+	DraftPoolPerTeam = 28
+
+	// MaxDrafteeShare is the share of the draft pool that draftees from the
+	// files can fill before the game's random draftees lose starter potential
+	MaxDrafteeShare = 0.10
+)
+
+// DraftPoolSize returns about how many draftable players the game creates
+// each season for a league of teams teams
+func DraftPoolSize(teams int) int {
+	return teams * DraftPoolPerTeam
+}
+
+// ProspectClass is the draftees of both files joining the draft pool in one season
+type ProspectClass struct {
+	Year    int // BASE_YEAR of the class
+	Members []Member
+}
+
+// Share returns the part of the draft pool of a league of teams teams the
+// class fills, or 0 without teams
+func (c *ProspectClass) Share(teams int) float64 {
+	if teams <= 0 {
+		return 0
+	}
+	return float64(len(c.Members)) / float64(DraftPoolSize(teams))
+}
+
+// TooLarge returns true if the class fills more than MaxDrafteeShare of the draft pool
+func (c *ProspectClass) TooLarge(teams int) bool {
+	return c.Share(teams) > MaxDrafteeShare
+}
+
+// ProspectClasses groups the future draftees of both files by BASE_YEAR,
+// ordered by year, each with quarterbacks first and each file in order
+func ProspectClasses(players []models.Player, quarterbacks []models.Quarterback, leagueBaseYear int) []ProspectClass {
+	byYear := make(map[int]*ProspectClass)
+	for _, member := range Members(players, quarterbacks) {
+		if !member.IsDraftee(leagueBaseYear) {
+			continue
+		}
+		class, ok := byYear[member.BaseYear]
+		if !ok {
+			class = &ProspectClass{Year: member.BaseYear}
+			byYear[member.BaseYear] = class
+		}
+		class.Members = append(class.Members, member)
+	}
+
+	classes := make([]ProspectClass, 0, len(byYear))
+	for _, class := range byYear {
+		classes = append(classes, *class)
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i].Year < classes[j].Year })
+	return classes
+}
+
+// ProspectProblem identifies a kind of draftee issue
+type ProspectProblem int
+
+const (
+	// ProspectOnTeam is a draftee assigned to a team instead of TEAM 0
+	ProspectOnTeam ProspectProblem = iota
+	// ProspectContract is a draftee with a contract
+	ProspectContract
+	// ProspectClassTooLarge is a class filling too much of its draft pool
+	ProspectClassTooLarge
+)
+
+// ProspectIssue is a problem with a draftee or a whole draft class
+type ProspectIssue struct {
+	Problem ProspectProblem
+	Member  Member // The draftee, or the first member of a class
+	Class   *ProspectClass
+	Teams   int // League size of a class size issue
+}
+
+// Message describes the issue
+func (i ProspectIssue) Message() string {
+	switch i.Problem {
+	case ProspectOnTeam:
+		return fmt.Sprintf("draftee of the %d class is on team %d; draftees should have TEAM 0", i.Class.Year, i.Member.Team)
+	case ProspectContract:
+		return fmt.Sprintf("draftee of the %d class has a contract; draftees should have none", i.Class.Year)
+	case ProspectClassTooLarge:
+		return fmt.Sprintf("the %d draft class has %d players, %.0f%% of a %d-player draft pool; above %.0f%% the game's own draftees lose starter potential",
+			i.Class.Year, len(i.Class.Members), i.Class.Share(i.Teams)*100, DraftPoolSize(i.Teams), MaxDrafteeShare*100)
+	default:
+		return "unknown draft class problem"
+	}
+}
+
+// CheckProspects checks the draft classes of both files: every draftee
+// should have TEAM 0 and no contract, and no class should fill more than
+// MaxDrafteeShare of the draft pool. A team count of 0 skips the class size
+// check. Issues are ordered by class, draftees first and the class size last.
+func CheckProspects(players []models.Player, quarterbacks []models.Quarterback, leagueBaseYear, teams int) []ProspectIssue {
+	var issues []ProspectIssue
+	classes := ProspectClasses(players, quarterbacks, leagueBaseYear)
+	for c := range classes {
+		class := &classes[c]
+		for _, member := range class.Members {
+			if member.Team != 0 {
+				issues = append(issues, ProspectIssue{Problem: ProspectOnTeam, Member: member, Class: class})
+			}
+			if !member.Contract.IsEmpty() {
+				issues = append(issues, ProspectIssue{Problem: ProspectContract, Member: member, Class: class})
+			}
+		}
+		if teams > 0 && class.TooLarge(teams) {
+			issues = append(issues, ProspectIssue{Problem: ProspectClassTooLarge, Member: class.Members[0], Class: class, Teams: teams})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,109 @@
+// ABOUTME: Tests for future draft classes grouped by BASE_YEAR
+// ABOUTME: Covers class grouping, each draftee problem and the custom example files
+
+package roster
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestProspectClasses(t *testing.T) {
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 501, BaseYear: 2026},
+		{PlayerID: 502, BaseYear: 2024},
+	}
+	players := []models.Player{
+		{PlayerID: 1000, BaseYear: 2025},
+		{PlayerID: 1001},
+		{PlayerID: 1002, BaseYear: 2026},
+	}
+
+	classes := ProspectClasses(players, quarterbacks, 2024)
+	if len(classes) != 2 || classes[0].Year != 2025 || classes[1].Year != 2026 {
+		t.Fatalf("Expected the 2025 and 2026 classes, got %+v", classes)
+	}
+	if len(classes[1].Members) != 2 || !classes[1].Members[0].Quarterback || classes[1].Members[1].PlayerID != 1002 {
+		t.Errorf("Expected the quarterback first in 2026, got %+v", classes[1].Members)
+	}
+
+	if got := ProspectClasses(players, quarterbacks, 0); len(got) != 0 {
+		t.Errorf("Expected no classes without a league base year, got %+v", got)
+	}
+
+	// A 2-team league drafts about 56 players; 6 draftees are over 10%
+	class := ProspectClass{Year: 2025, Members: make([]Member, 6)}
+	if !class.TooLarge(2) || class.TooLarge(3) || class.TooLarge(0) {
+		t.Errorf("Unexpected class size checks for %d draftees", len(class.Members))
+	}
+}
+
+func TestCheckProspects(t *testing.T) {
+	quarterbacks := []models.Quarterback{{PlayerID: 501, BaseYear: 2025, SalaryYears: 1, SalaryYear1: 100}}
+	players := []models.Player{
+		{PlayerID: 1000, BaseYear: 2025, Team: 3},
+		{PlayerID: 1001, BaseYear: 2025},
+		{PlayerID: 1002, BaseYear: 2024, Team: 3, SalaryYears: 1, SalaryYear1: 100},
+	}
+
+	issues := CheckProspects(players, quarterbacks, 2024, 1)
+	expected := []struct {
+		problem  ProspectProblem
+		playerID int
+		message  string
+	}{
+		{ProspectContract, 501, "draftee of the 2025 class has a contract; draftees should have none"},
+		{ProspectOnTeam, 1000, "draftee of the 2025 class is on team 3; draftees should have TEAM 0"},
+		{ProspectClassTooLarge, 501, "the 2025 draft class has 3 players, 11% of a 28-player draft pool; above 10% the game's own draftees lose starter potential"},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %+v", len(expected), issues)
+	}
+	for i, want := range expected {
+		got := issues[i]
+		if got.Problem != want.problem || got.Member.PlayerID != want.playerID || got.Message() != want.message {
+			t.Errorf("Issue %d: expected %d for %d (%s), got %d for %d (%s)",
+				i, want.problem, want.playerID, want.message, got.Problem, got.Member.PlayerID, got.Message())
+		}
+	}
+}
+
+func TestCheckContracts_SkipsDraftees(t *testing.T) {
+	info := models.NewDefaultLeagueInfo(2024)
+	players := []models.Player{{PlayerID: 1000, BaseYear: 2025, SalaryYears: 1, SalaryYear1: 100}}
+
+	if issues := CheckContracts(players, nil, info); len(issues) != 0 {
+		t.Errorf("Expected draftee contracts to be left to CheckProspects, got %+v", issues)
+	}
+}
+
+func TestCheckProspects_CustomExample(t *testing.T) {
+	players, err := data.LoadPlayers("../../custom_example/example_players.csv")
+	if err != nil {
+		t.Fatalf("LoadPlayers failed: %v", err)
+	}
+	quarterbacks, err := data.LoadQuarterbacks("../../custom_example/example_quarterbacks.csv")
+	if err != nil {
+		t.Fatalf("LoadQuarterbacks failed: %v", err)
+	}
+
+	classes := ProspectClasses(players, quarterbacks, 1998)
+	if len(classes) != 5 || classes[0].Year != 1999 || classes[4].Year != 2003 {
+		t.Fatalf("Expected the 1999-2003 classes, got %d", len(classes))
+	}
+	if got := len(classes[0].Members); got != 283 {
+		t.Errorf("Expected 283 draftees in 1999, got %d", got)
+	}
+
+	// The example splits a whole player file into a 12-team league and
+	// five draft classes, each far over 10% of its draft pool
+	counts := make(map[ProspectProblem]int)
+	for _, issue := range CheckProspects(players, quarterbacks, 1998, 12) {
+		counts[issue.Problem]++
+	}
+	if counts[ProspectOnTeam] != 0 || counts[ProspectContract] != 0 || counts[ProspectClassTooLarge] != 5 {
+		t.Errorf("Expected only the 5 class sizes, got %v", counts)
+	}
+}
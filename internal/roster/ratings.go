@@ -0,0 +1,202 @@
+// ABOUTME: OVERALLRATING distribution of a player file compared with a baseline file
+// ABOUTME: Reports divergence by position and team and low-value positions above their rating cap
+
+package roster
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// OVERALLRATING values. 10 is not the top of the scale: it marks a player
+// below replacement quality, who only makes a team to fill a roster.
+const (
+	MaxOverallRating       = 10
+	BelowReplacementRating = 10
+
+	// LowValueRatingCap is the highest rating of a FB, P, K or LS
+	LowValueRatingCap = 2
+
+	// NotableDivergence is the divergence from the baseline worth a look.
+	// Every team of the shipped 2024 file stays below it.
+	NotableDivergence = 0.25
+)
+
+// RatingOrder lists the OVERALLRATING values from worst to best
+var RatingOrder = []int{BelowReplacementRating, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+// IsLowValuePosition returns true for the positions whose rating is capped
+// at LowValueRatingCap: fullback, punter, kicker and long snapper
+func IsLowValuePosition(positionKey int) bool {
+	switch positionKey {
+	case models.PositionFB, models.PositionP, models.PositionK, models.PositionLS:
+		return true
+	default:
+		return false
+	}
+}
+
+// RatingDistribution counts players by OVERALLRATING
+type RatingDistribution struct {
+	Counts [MaxOverallRating + 1]int `json:"counts"` // Players by rating
+	Total  int                       `json:"total"`
+}
+
+// Add counts a rating; ratings outside 0-10 are ignored
+func (d *RatingDistribution) Add(rating int) {
+	if rating < 0 || rating > MaxOverallRating {
+		return
+	}
+	d.Counts[rating]++
+	d.Total++
+}
+
+// Share returns the fraction of players with a rating, or 0 without players
+func (d *RatingDistribution) Share(rating int) float64 {
+	if d.Total == 0 || rating < 0 || rating > MaxOverallRating {
+		return 0
+	}
+	return float64(d.Counts[rating]) / float64(d.Total)
+}
+
+// Average returns the mean rating of the players rated 0-9, leaving out
+// players below replacement quality, or 0 without such players
+func (d *RatingDistribution) Average() float64 {
+	sum, players := 0, 0
+	for rating := 0; rating < BelowReplacementRating; rating++ {
+		sum += rating * d.Counts[rating]
+		players += d.Counts[rating]
+	}
+	if players == 0 {
+		return 0
+	}
+	return float64(sum) / float64(players)
+}
+
+// Divergence returns the total variation distance between the rating shares
+// of two distributions: 0 for identical shares, 1 for shares with no rating
+// in common. It is 0 if either distribution is empty.
+func (d *RatingDistribution) Divergence(baseline *RatingDistribution) float64 {
+	if d.Total == 0 || baseline.Total == 0 {
+		return 0
+	}
+	distance := 0.0
+	for rating := 0; rating <= MaxOverallRating; rating++ {
+		distance += math.Abs(d.Share(rating) - baseline.Share(rating))
+	}
+	return distance / 2
+}
+
+// RatingComparison is the distribution of a group of players next to the
+// same group of the baseline file
+type RatingComparison struct {
+	Key        int                `json:"key"` // Position key or team ID, 0 for the whole file
+	Label      string             `json:"label"`
+	Project    RatingDistribution `json:"project"`
+	Baseline   RatingDistribution `json:"baseline"`
+	Divergence float64            `json:"divergence"`
+}
+
+// Notable returns true if the group diverges from the baseline by at least NotableDivergence
+func (c *RatingComparison) Notable() bool {
+	return c.Divergence >= NotableDivergence
+}
+
+// RatingCapIssue is a low-value position player rated above LowValueRatingCap
+type RatingCapIssue struct {
+	PlayerID    int    `json:"playerId"`
+	Name        string `json:"name"`
+	Team        int    `json:"team"`
+	PositionKey int    `json:"positionKey"`
+	Rating      int    `json:"rating"`
+}
+
+// Message describes the issue
+func (i RatingCapIssue) Message() string {
+	return fmt.Sprintf("Player %d (%s) OVERALLRATING %d is above the %s cap of %d",
+		i.PlayerID, i.Name, i.Rating, models.GetPositionAbbr(i.PositionKey), LowValueRatingCap)
+}
+
+// RatingReport compares the OVERALLRATING distribution of a player file with a baseline file
+type RatingReport struct {
+	Overall   RatingComparison   `json:"overall"`
+	Positions []RatingComparison `json:"positions"`
+	Teams     []RatingComparison `json:"teams"`
+	OverCap   []RatingCapIssue   `json:"overCap"`
+}
+
+// AnalyzeRatings compares the OVERALLRATING distribution of players with the
+// baseline players, such as the shipped 2024_players.csv, for the whole file,
+// by position and by team. Positions compare with the same position of the
+// baseline; teams compare with all baseline players on a team, as baseline
+// team IDs need not match. Free agents count by position only. Quarterbacks
+// have their own file and are not compared.
+func AnalyzeRatings(players []models.Player, baseline []models.Player) *RatingReport {
+	report := &RatingReport{
+		Overall:   RatingComparison{Label: "All Players"},
+		Positions: make([]RatingComparison, 0),
+		Teams:     make([]RatingComparison, 0),
+		OverCap:   make([]RatingCapIssue, 0),
+	}
+
+	positions := make(map[int]*RatingComparison)
+	position := func(key int) *RatingComparison {
+		comparison, ok := positions[key]
+		if !ok {
+			comparison = &RatingComparison{Key: key, Label: models.GetPositionAbbr(key)}
+			positions[key] = comparison
+		}
+		return comparison
+	}
+
+	var baselineTeams RatingDistribution
+	for _, player := range baseline {
+		report.Overall.Baseline.Add(player.OverallRating)
+		position(player.PositionKey).Baseline.Add(player.OverallRating)
+		if player.Team > 0 {
+			baselineTeams.Add(player.OverallRating)
+		}
+	}
+
+	teams := make(map[int]*RatingComparison)
+	for _, player := range players {
+		report.Overall.Project.Add(player.OverallRating)
+		position(player.PositionKey).Project.Add(player.OverallRating)
+		if player.Team > 0 {
+			team, ok := teams[player.Team]
+			if !ok {
+				team = &RatingComparison{Key: player.Team, Label: fmt.Sprintf("Team %d", player.Team), Baseline: baselineTeams}
+				teams[player.Team] = team
+			}
+			team.Project.Add(player.OverallRating)
+		}
+
+		if IsLowValuePosition(player.PositionKey) && player.OverallRating > LowValueRatingCap &&
+			player.OverallRating != BelowReplacementRating {
+			report.OverCap = append(report.OverCap, RatingCapIssue{
+				PlayerID:    player.PlayerID,
+				Name:        player.GetDisplayName(),
+				Team:        player.Team,
+				PositionKey: player.PositionKey,
+				Rating:      player.OverallRating,
+			})
+		}
+	}
+
+	report.Overall.Divergence = report.Overall.Project.Divergence(&report.Overall.Baseline)
+	for _, comparison := range positions {
+		comparison.Divergence = comparison.Project.Divergence(&comparison.Baseline)
+		report.Positions = append(report.Positions, *comparison)
+	}
+	for _, comparison := range teams {
+		comparison.Divergence = comparison.Project.Divergence(&comparison.Baseline)
+		report.Teams = append(report.Teams, *comparison)
+	}
+	sort.Slice(report.Positions, func(i, j int) bool { return report.Positions[i].Key < report.Positions[j].Key })
+	sort.Slice(report.Teams, func(i, j int) bool { return report.Teams[i].Key < report.Teams[j].Key })
+
+	return report
+}
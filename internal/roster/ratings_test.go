@@ -0,0 +1,109 @@
+// ABOUTME: Tests for the OVERALLRATING distribution analysis
+// ABOUTME: Covers shares, divergence, the low-value position cap and the shipped default data
+
+package roster
+
+import (
+	"math"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestRatingDistribution(t *testing.T) {
+	var d RatingDistribution
+	for _, rating := range []int{0, 2, 2, 4, 10, 11, -1} {
+		d.Add(rating)
+	}
+
+	if d.Total != 5 || d.Counts[2] != 2 || d.Counts[10] != 1 {
+		t.Errorf("Expected 5 ratings in range, got %+v", d)
+	}
+	if d.Share(2) != 0.4 || d.Share(11) != 0 {
+		t.Errorf("Unexpected shares: %v %v", d.Share(2), d.Share(11))
+	}
+	if d.Average() != 2 {
+		t.Errorf("Expected below replacement players to be left out of the average, got %v", d.Average())
+	}
+
+	var baseline RatingDistribution
+	for _, rating := range []int{0, 0, 2, 4, 10} {
+		baseline.Add(rating)
+	}
+	if got := d.Divergence(&baseline); math.Abs(got-0.2) > 1e-9 {
+		t.Errorf("Expected divergence 0.2, got %v", got)
+	}
+	if got := d.Divergence(&d); got != 0 {
+		t.Errorf("Expected no divergence from itself, got %v", got)
+	}
+	if got := d.Divergence(&RatingDistribution{}); got != 0 {
+		t.Errorf("Expected no divergence from an empty baseline, got %v", got)
+	}
+}
+
+func TestAnalyzeRatings(t *testing.T) {
+	baseline := []models.Player{
+		{PositionKey: models.PositionRB, Team: 1, OverallRating: 1},
+		{PositionKey: models.PositionRB, Team: 2, OverallRating: 3},
+		{PositionKey: models.PositionK, Team: 0, OverallRating: 2},
+	}
+	players := []models.Player{
+		{PlayerID: 1000, FirstName: "Run", LastName: "Back", PositionKey: models.PositionRB, Team: 1, OverallRating: 1},
+		{PlayerID: 1001, FirstName: "Big", LastName: "Leg", PositionKey: models.PositionK, Team: 1, OverallRating: 5},
+		{PlayerID: 1002, FirstName: "Long", LastName: "Snap", PositionKey: models.PositionLS, Team: 0, OverallRating: 10},
+	}
+
+	report := AnalyzeRatings(players, baseline)
+
+	if report.Overall.Project.Total != 3 || report.Overall.Baseline.Total != 3 {
+		t.Errorf("Unexpected overall totals: %+v", report.Overall)
+	}
+	if len(report.Positions) != 3 || report.Positions[0].Label != "RB" || report.Positions[0].Divergence != 0.5 {
+		t.Errorf("Unexpected positions: %+v", report.Positions)
+	}
+	if last := report.Positions[2]; last.Label != "LS" || last.Baseline.Total != 0 || last.Divergence != 0 {
+		t.Errorf("Expected LS without baseline players, got %+v", last)
+	}
+
+	if len(report.Teams) != 1 || report.Teams[0].Project.Total != 2 || report.Teams[0].Baseline.Total != 2 {
+		t.Fatalf("Expected team 1 against all baseline team players, got %+v", report.Teams)
+	}
+	if !report.Teams[0].Notable() {
+		t.Errorf("Expected team 1 to diverge notably, got %v", report.Teams[0].Divergence)
+	}
+
+	if len(report.OverCap) != 1 || report.OverCap[0].PlayerID != 1001 {
+		t.Fatalf("Expected only the kicker over the cap, got %+v", report.OverCap)
+	}
+	if got := report.OverCap[0].Message(); got != "Player 1001 (Big Leg) OVERALLRATING 5 is above the K cap of 2" {
+		t.Errorf("Unexpected message: %s", got)
+	}
+}
+
+func TestAnalyzeRatings_DefaultData(t *testing.T) {
+	baseline, err := data.LoadPlayers("../../default_data/2024_players.csv")
+	if err != nil {
+		t.Fatalf("LoadPlayers failed: %v", err)
+	}
+	players, err := data.LoadPlayers("../../default_data/2023_players.csv")
+	if err != nil {
+		t.Fatalf("LoadPlayers failed: %v", err)
+	}
+
+	// Below replacement 10s are not over the cap
+	report := AnalyzeRatings(baseline, baseline)
+	if report.Overall.Divergence != 0 || len(report.OverCap) != 0 {
+		t.Errorf("Expected the baseline to match itself, got %v and %+v", report.Overall.Divergence, report.OverCap)
+	}
+	for _, team := range report.Teams {
+		if team.Notable() {
+			t.Errorf("Expected shipped team %d below the notable divergence, got %v", team.Key, team.Divergence)
+		}
+	}
+
+	report = AnalyzeRatings(players, baseline)
+	if report.Overall.Divergence > 0.05 {
+		t.Errorf("Expected the 2023 file to stay close to 2024, got %v", report.Overall.Divergence)
+	}
+}
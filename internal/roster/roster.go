@@ -0,0 +1,82 @@
+// ABOUTME: Team rosters drawn from the player and quarterback files
+// ABOUTME: Gives a single view of every player regardless of which file holds them
+
+package roster
+
+import "github.com/igorilic/fof9editor/internal/models"
+
+// Member is a player from the player file or the quarterback file
+type Member struct {
+	Quarterback bool // True if the player is in the quarterback file
+	Index       int  // Index in the player or quarterback slice
+	PlayerID    int
+	Name        string
+	Team        int
+	PositionKey int // PositionQB for the quarterback file
+	Uniform     int
+	Experience  int
+	BirthYear   int
+	BaseYear    int // BASE_YEAR of a custom player or quarterback file entry, 0 if not set
+	Contract    Contract
+}
+
+// Members returns every player from both files, quarterbacks first, each in file order
+func Members(players []models.Player, quarterbacks []models.Quarterback) []Member {
+	members := make([]Member, 0, len(players)+len(quarterbacks))
+	for i := range quarterbacks {
+		qb := &quarterbacks[i]
+		members = append(members, Member{
+			Quarterback: true,
+			Index:       i,
+			PlayerID:    qb.PlayerID,
+			Name:        qb.GetDisplayName(),
+			Team:        qb.Team,
+			PositionKey: models.PositionQB,
+			Uniform:     qb.Uniform,
+			Experience:  qb.Experience,
+			BirthYear:   qb.BirthYear,
+			BaseYear:    qb.BaseYear,
+			Contract:    QuarterbackContract(qb),
+		})
+	}
+	for i := range players {
+		player := &players[i]
+		members = append(members, Member{
+			Index:       i,
+			PlayerID:    player.PlayerID,
+			Name:        player.GetDisplayName(),
+			Team:        player.Team,
+			PositionKey: player.PositionKey,
+			Uniform:     player.Uniform,
+			Experience:  player.Experience,
+			BirthYear:   player.BirthYear,
+			BaseYear:    player.BaseYear,
+			Contract:    PlayerContract(player),
+		})
+	}
+	return members
+}
+
+// IsDraftee returns true if the member is a future draft prospect: an entry
+// whose BASE_YEAR is later than the league's BASE_YEAR, which the game adds
+// to the draft pool of that season
+func (m Member) IsDraftee(leagueBaseYear int) bool {
+	return IsDraftee(m.BaseYear, leagueBaseYear)
+}
+
+// IsDraftee returns true if an entry's BASE_YEAR makes it a future draft
+// prospect of a league starting in leagueBaseYear
+func IsDraftee(baseYear, leagueBaseYear int) bool {
+	return leagueBaseYear > 0 && baseYear > leagueBaseYear
+}
+
+// ByTeam groups members by team, leaving out free agents (team 0)
+func ByTeam(members []Member) map[int][]Member {
+	teams := make(map[int][]Member)
+	for _, member := range members {
+		if member.Team != 0 {
+			teams[member.Team] = append(teams[member.Team], member)
+		}
+	}
+	return teams
+}
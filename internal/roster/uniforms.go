@@ -0,0 +1,114 @@
+// ABOUTME: Uniform number conflict resolution for team rosters
+// ABOUTME: Proposes free, position-appropriate numbers for players sharing a number on one team
+
+package roster
+
+import (
+	"sort"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// maxUniform is the highest uniform number
+const maxUniform = 99
+
+// UniformChange is a proposed new uniform number for a player
+type UniformChange struct {
+	Member Member
+	To     int
+}
+
+// ProposeUniformFixes finds players sharing a uniform number with a teammate
+// in either file and proposes a free number for all but one of them. The
+// player the number is customary for keeps it, or the first in file order if
+// that does not decide. New numbers come from the free numbers customary for
+// the position, most traditional first, then from any free number. Players
+// without a uniform (-1) are left alone.
+func ProposeUniformFixes(players []models.Player, quarterbacks []models.Quarterback) []UniformChange {
+	teams := ByTeam(Members(players, quarterbacks))
+	teamIDs := make([]int, 0, len(teams))
+	for teamID := range teams {
+		teamIDs = append(teamIDs, teamID)
+	}
+	sort.Ints(teamIDs)
+
+	var changes []UniformChange
+	for _, teamID := range teamIDs {
+		members := teams[teamID]
+
+		used := make(map[int]bool)
+		holders := make(map[int][]Member)
+		var numbers []int
+		for _, member := range members {
+			if member.Uniform < 0 {
+				continue
+			}
+			if !used[member.Uniform] {
+				numbers = append(numbers, member.Uniform)
+			}
+			used[member.Uniform] = true
+			holders[member.Uniform] = append(holders[member.Uniform], member)
+		}
+
+		for _, number := range numbers {
+			sharing := holders[number]
+			if len(sharing) < 2 {
+				continue
+			}
+
+			keeper := 0
+			for i, member := range sharing {
+				if models.IsConventionalUniform(member.PositionKey, number) {
+					keeper = i
+					break
+				}
+			}
+
+			for i, member := range sharing {
+				if i == keeper {
+					continue
+				}
+				if to, ok := freeUniform(member.PositionKey, used); ok {
+					used[to] = true
+					changes = append(changes, UniformChange{Member: member, To: to})
+				}
+			}
+		}
+	}
+
+	return changes
+}
+
+// freeUniform returns the first unused number customary for the position,
+// or the first unused number at all
+func freeUniform(positionKey int, used map[int]bool) (int, bool) {
+	for _, r := range models.UniformRanges(positionKey) {
+		for number := r.Min; number <= r.Max; number++ {
+			if !used[number] {
+				return number, true
+			}
+		}
+	}
+	for number := 0; number <= maxUniform; number++ {
+		if !used[number] {
+			return number, true
+		}
+	}
+	return 0, false
+}
+
+// ApplyUniformChanges sets the proposed uniform numbers and returns the number of players updated
+func ApplyUniformChanges(changes []UniformChange, players []models.Player, quarterbacks []models.Quarterback) int {
+	updated := 0
+	for _, change := range changes {
+		m := change.Member
+		if m.Quarterback && m.Index < len(quarterbacks) && quarterbacks[m.Index].PlayerID == m.PlayerID {
+			quarterbacks[m.Index].Uniform = change.To
+			updated++
+		} else if !m.Quarterback && m.Index < len(players) && players[m.Index].PlayerID == m.PlayerID {
+			players[m.Index].Uniform = change.To
+			updated++
+		}
+	}
+	return updated
+}
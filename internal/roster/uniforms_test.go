@@ -0,0 +1,63 @@
+// ABOUTME: Tests for uniform number conflict resolution
+// ABOUTME: Verifies who keeps a shared number and which free numbers are proposed
+
+package roster
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestProposeUniformFixes(t *testing.T) {
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 501, FirstName: "Aaron", LastName: "Rodgers", Team: 20, Uniform: 12},
+	}
+	players := []models.Player{
+		{PlayerID: 1000, FirstName: "Wide", LastName: "Out", Team: 20, PositionKey: models.PositionFL, Uniform: 12},
+		{PlayerID: 1001, FirstName: "Left", LastName: "Tackle", Team: 20, PositionKey: models.PositionLT, Uniform: 60},
+		{PlayerID: 1002, FirstName: "Right", LastName: "Tackle", Team: 20, PositionKey: models.PositionRT, Uniform: 60},
+		{PlayerID: 1003, FirstName: "Nose", LastName: "Tackle", Team: 20, PositionKey: models.PositionDNT, Uniform: 61},
+		{PlayerID: 1004, FirstName: "Free", LastName: "Agent", PositionKey: models.PositionRT, Uniform: 60},
+		{PlayerID: 1005, FirstName: "Other", LastName: "Team", Team: 21, PositionKey: models.PositionFL, Uniform: 12},
+	}
+
+	changes := ProposeUniformFixes(players, quarterbacks)
+
+	expected := []struct {
+		playerID int
+		to       int
+	}{
+		{1000, 10}, // Both wear a customary 12, so the quarterback file's player keeps it
+		{1002, 62}, // 60 and 61 are taken; the nose tackle's unusual 61 is not a conflict
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected 2 changes, got %+v", changes)
+	}
+	for i, want := range expected {
+		if changes[i].Member.PlayerID != want.playerID || changes[i].To != want.to {
+			t.Errorf("Change %d: expected player %d to %d, got %d to %d",
+				i, want.playerID, want.to, changes[i].Member.PlayerID, changes[i].To)
+		}
+	}
+
+	updated := ApplyUniformChanges(changes, players, quarterbacks)
+	if updated != 2 || players[0].Uniform != 10 || players[2].Uniform != 62 || quarterbacks[0].Uniform != 12 {
+		t.Errorf("Unexpected uniforms after applying %d changes: %+v", updated, players)
+	}
+	if len(ProposeUniformFixes(players, quarterbacks)) != 0 {
+		t.Error("Expected no conflicts after applying the fixes")
+	}
+}
+
+func TestProposeUniformFixes_KeepsConventionalHolder(t *testing.T) {
+	players := []models.Player{
+		{PlayerID: 1000, Team: 3, PositionKey: models.PositionLG, Uniform: 88},
+		{PlayerID: 1001, Team: 3, PositionKey: models.PositionTE, Uniform: 88},
+	}
+
+	changes := ProposeUniformFixes(players, nil)
+	if len(changes) != 1 || changes[0].Member.PlayerID != 1000 || changes[0].To != 60 {
+		t.Errorf("Expected the guard to move to 60, got %+v", changes)
+	}
+}
@@ -0,0 +1,430 @@
+// ABOUTME: Schedule balance and fairness analytics for FOF9 schedule templates
+// ABOUTME: Computes per-team home/away, division/conference, road stretch and travel statistics
+
+package schedule
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// CycleRotation is the rotation number used for statistics aggregated over the full cycle
+const CycleRotation = 0
+
+// TeamStats holds the schedule statistics of a single team
+type TeamStats struct {
+	TeamID             int
+	Abbreviation       string
+	Games              int
+	Home               int
+	Away               int
+	Division           int     // Games against teams in the same division
+	Conference         int     // Games against same-conference teams outside the division
+	Interconference    int     // Games against teams in the other conference
+	RoadStretches      int     // Runs of two or more consecutive road games
+	LongestRoadStretch int     // Longest run of consecutive road games
+	TravelMiles        float64 // Round-trip miles from the home city to every road game
+}
+
+// RotationStats holds the statistics of every team for one rotation (or the full cycle)
+type RotationStats struct {
+	Rotation  int
+	Teams     []TeamStats // Same order as Analysis.Teams
+	Opponents [][]int     // Opponents[i][j] is the number of games between team i and team j
+}
+
+// Summary describes the spread of key statistics across teams
+type Summary struct {
+	MinHome, MaxHome     int
+	MinAway, MaxAway     int
+	MinTravel, MaxTravel float64
+	MaxRoadStretch       int
+}
+
+// Analysis is the result of analyzing a schedule template against a team list
+type Analysis struct {
+	ScheduleID string
+	Teams      []models.Team // Teams in schedule slot order (division index, then team index)
+	Rotations  []RotationStats
+	Cycle      RotationStats
+	Warnings   []string
+	// Regular season games placed by the previous season's standings
+	// (STANDINGS 1), counted as in a new career
+	StandingsGames int
+}
+
+// slot identifies a team within a schedule template
+type slot struct {
+	division int
+	team     int
+}
+
+// ParseScheduleID extracts the team, division and game counts from a schedule
+// ID such as "32_8_18"
+func ParseScheduleID(scheduleID string) (teams, divisions, games int, err error) {
+	parts := strings.Split(scheduleID, "_")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("invalid schedule ID '%s': expected TEAMS_DIVISIONS_GAMES", scheduleID)
+	}
+
+	values := make([]int, 3)
+	for i, part := range parts {
+		value, convErr := strconv.Atoi(part)
+		if convErr != nil || value <= 0 {
+			return 0, 0, 0, fmt.Errorf("invalid schedule ID '%s': '%s' is not a positive number", scheduleID, part)
+		}
+		values[i] = value
+	}
+
+	return values[0], values[1], values[2], nil
+}
+
+// ScheduleIDFromPath returns the schedule ID of a template file such as
+// ".../32_8_18_schedule.csv"
+func ScheduleIDFromPath(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return strings.TrimSuffix(name, "_schedule")
+}
+
+// Analyze computes per-team statistics for each rotation of a schedule template
+// and for the full cycle. Only regular season games are counted. Cities are used
+// for travel distances and may be nil. Games placed by the previous season's
+// standings are counted as in a new career, where the standings follow the team
+// index; in later seasons their opponents, division counts and travel differ.
+func Analyze(scheduleID string, games []models.ScheduleTemplateGame, teams []models.Team, cities map[int]models.City) (*Analysis, error) {
+	teamCount, divisions, _, err := ParseScheduleID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if divisions%2 != 0 {
+		return nil, fmt.Errorf("schedule %s has an odd number of divisions", scheduleID)
+	}
+	if len(teams) != teamCount {
+		return nil, fmt.Errorf("schedule %s requires %d teams, league has %d", scheduleID, teamCount, len(teams))
+	}
+
+	ordered, slots, err := assignSlots(teams, divisions/2)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &Analysis{
+		ScheduleID: scheduleID,
+		Teams:      ordered,
+	}
+
+	// Distances between every pair of teams, zero when a city is unknown
+	distances := make([][]float64, len(ordered))
+	for i := range ordered {
+		distances[i] = make([]float64, len(ordered))
+	}
+	missing := make(map[int]bool)
+	for i := range ordered {
+		home, ok := lookupCity(cities, ordered[i].City)
+		if !ok {
+			missing[i] = true
+			continue
+		}
+		for j := range ordered {
+			if away, ok := lookupCity(cities, ordered[j].City); ok {
+				distances[i][j] = home.DistanceMiles(&away)
+			}
+		}
+	}
+	for i := range ordered {
+		if missing[i] {
+			analysis.Warnings = append(analysis.Warnings,
+				fmt.Sprintf("No coordinates for %s (city %d); travel is not counted", ordered[i].Abbreviation, ordered[i].City))
+		}
+	}
+
+	// Group regular season games by rotation, keeping file order within a rotation
+	byRotation := make(map[int][]models.ScheduleTemplateGame)
+	for _, game := range games {
+		if !game.IsRegularSeason() {
+			continue
+		}
+		byRotation[game.Rotation] = append(byRotation[game.Rotation], game)
+		if game.Standings == 1 {
+			analysis.StandingsGames++
+		}
+	}
+	if analysis.StandingsGames > 0 {
+		// Listed first, as it applies to every statistic
+		analysis.Warnings = append([]string{fmt.Sprintf(
+			"%d games are placed by the previous season's standings and counted as in a new career", analysis.StandingsGames)},
+			analysis.Warnings...)
+	}
+
+	rotations := make([]int, 0, len(byRotation))
+	for rotation := range byRotation {
+		rotations = append(rotations, rotation)
+	}
+	sort.Ints(rotations)
+
+	dpc := divisions / 2
+	analysis.Cycle = newRotationStats(CycleRotation, ordered)
+	for _, rotation := range rotations {
+		stats := newRotationStats(rotation, ordered)
+		// Sequence of home (true) / road (false) games per team, in week order
+		sequences := make([][]bool, len(ordered))
+
+		rotationGames := byRotation[rotation]
+		sort.SliceStable(rotationGames, func(a, b int) bool {
+			return rotationGames[a].Week < rotationGames[b].Week
+		})
+
+		for _, game := range rotationGames {
+			home, ok := slots[slot{game.HomeDiv, game.HomeTeam}]
+			if !ok {
+				return nil, fmt.Errorf("rotation %d week %d references division %d team %d, which does not exist",
+					rotation, game.Week, game.HomeDiv, game.HomeTeam)
+			}
+			visitor, ok := slots[slot{game.VisDiv, game.VisTeam}]
+			if !ok {
+				return nil, fmt.Errorf("rotation %d week %d references division %d team %d, which does not exist",
+					rotation, game.Week, game.VisDiv, game.VisTeam)
+			}
+
+			stats.Teams[home].Home++
+			stats.Teams[visitor].Away++
+			stats.Teams[visitor].TravelMiles += 2 * distances[visitor][home]
+
+			switch {
+			case game.HomeDiv == game.VisDiv:
+				stats.Teams[home].Division++
+				stats.Teams[visitor].Division++
+			case (game.HomeDiv-1)/dpc == (game.VisDiv-1)/dpc:
+				stats.Teams[home].Conference++
+				stats.Teams[visitor].Conference++
+			default:
+				stats.Teams[home].Interconference++
+				stats.Teams[visitor].Interconference++
+			}
+
+			stats.Opponents[home][visitor]++
+			stats.Opponents[visitor][home]++
+
+			sequences[home] = append(sequences[home], true)
+			sequences[visitor] = append(sequences[visitor], false)
+		}
+
+		for i := range stats.Teams {
+			team := &stats.Teams[i]
+			team.Games = team.Home + team.Away
+			team.RoadStretches, team.LongestRoadStretch = roadStretches(sequences[i])
+		}
+
+		analysis.Cycle.add(&stats)
+		analysis.Rotations = append(analysis.Rotations, stats)
+	}
+
+	return analysis, nil
+}
+
+// GetRotation returns the statistics for a rotation number, or the full cycle
+// for CycleRotation
+func (a *Analysis) GetRotation(rotation int) (*RotationStats, bool) {
+	if rotation == CycleRotation {
+		return &a.Cycle, true
+	}
+	for i := range a.Rotations {
+		if a.Rotations[i].Rotation == rotation {
+			return &a.Rotations[i], true
+		}
+	}
+	return nil, false
+}
+
+// Summarize returns the spread of home/away counts, travel and road stretches
+func (r *RotationStats) Summarize() Summary {
+	var s Summary
+	for i, team := range r.Teams {
+		if i == 0 {
+			s.MinHome, s.MaxHome = team.Home, team.Home
+			s.MinAway, s.MaxAway = team.Away, team.Away
+			s.MinTravel, s.MaxTravel = team.TravelMiles, team.TravelMiles
+		}
+		s.MinHome = min(s.MinHome, team.Home)
+		s.MaxHome = max(s.MaxHome, team.Home)
+		s.MinAway = min(s.MinAway, team.Away)
+		s.MaxAway = max(s.MaxAway, team.Away)
+		s.MinTravel = min(s.MinTravel, team.TravelMiles)
+		s.MaxTravel = max(s.MaxTravel, team.TravelMiles)
+		s.MaxRoadStretch = max(s.MaxRoadStretch, team.LongestRoadStretch)
+	}
+	return s
+}
+
+// StatsHeaders returns the column headers used for exporting team statistics
+func StatsHeaders() []string {
+	return []string{
+		"ROTATION", "TEAMID", "ABBREVIATION", "GAMES", "HOME", "AWAY",
+		"DIVISION", "CONFERENCE", "INTERCONFERENCE",
+		"ROADSTRETCHES", "LONGESTROADSTRETCH", "TRAVELMILES",
+	}
+}
+
+// StatsRows returns one row per team and rotation, followed by the full cycle
+// rows (rotation "ALL"), matching StatsHeaders
+func (a *Analysis) StatsRows() [][]string {
+	rows := make([][]string, 0, (len(a.Rotations)+1)*len(a.Teams))
+	for i := range a.Rotations {
+		rows = append(rows, a.Rotations[i].rows(strconv.Itoa(a.Rotations[i].Rotation))...)
+	}
+	return append(rows, a.Cycle.rows("ALL")...)
+}
+
+// OpponentMatrix returns headers and rows describing how often each pair of
+// teams meets in the given rotation
+func (a *Analysis) OpponentMatrix(rotation int) ([]string, [][]string, error) {
+	stats, ok := a.GetRotation(rotation)
+	if !ok {
+		return nil, nil, fmt.Errorf("rotation %d not found", rotation)
+	}
+
+	headers := make([]string, 0, len(a.Teams)+1)
+	headers = append(headers, "TEAM")
+	for _, team := range a.Teams {
+		headers = append(headers, team.Abbreviation)
+	}
+
+	rows := make([][]string, len(a.Teams))
+	for i, team := range a.Teams {
+		row := make([]string, 0, len(a.Teams)+1)
+		row = append(row, team.Abbreviation)
+		for j := range a.Teams {
+			row = append(row, strconv.Itoa(stats.Opponents[i][j]))
+		}
+		rows[i] = row
+	}
+
+	return headers, rows, nil
+}
+
+// rows formats the team statistics as CSV rows
+func (r *RotationStats) rows(rotation string) [][]string {
+	rows := make([][]string, len(r.Teams))
+	for i, team := range r.Teams {
+		rows[i] = []string{
+			rotation,
+			strconv.Itoa(team.TeamID),
+			team.Abbreviation,
+			strconv.Itoa(team.Games),
+			strconv.Itoa(team.Home),
+			strconv.Itoa(team.Away),
+			strconv.Itoa(team.Division),
+			strconv.Itoa(team.Conference),
+			strconv.Itoa(team.Interconference),
+			strconv.Itoa(team.RoadStretches),
+			strconv.Itoa(team.LongestRoadStretch),
+			strconv.FormatFloat(team.TravelMiles, 'f', 0, 64),
+		}
+	}
+	return rows
+}
+
+// add accumulates another rotation's statistics into this one
+func (r *RotationStats) add(other *RotationStats) {
+	for i := range r.Teams {
+		team, o := &r.Teams[i], other.Teams[i]
+		team.Games += o.Games
+		team.Home += o.Home
+		team.Away += o.Away
+		team.Division += o.Division
+		team.Conference += o.Conference
+		team.Interconference += o.Interconference
+		team.RoadStretches += o.RoadStretches
+		team.LongestRoadStretch = max(team.LongestRoadStretch, o.LongestRoadStretch)
+		team.TravelMiles += o.TravelMiles
+		for j := range r.Opponents[i] {
+			r.Opponents[i][j] += other.Opponents[i][j]
+		}
+	}
+}
+
+// newRotationStats creates empty statistics for the given teams
+func newRotationStats(rotation int, teams []models.Team) RotationStats {
+	stats := RotationStats{
+		Rotation:  rotation,
+		Teams:     make([]TeamStats, len(teams)),
+		Opponents: make([][]int, len(teams)),
+	}
+	for i, team := range teams {
+		stats.Teams[i] = TeamStats{TeamID: team.TeamID, Abbreviation: team.Abbreviation}
+		stats.Opponents[i] = make([]int, len(teams))
+	}
+	return stats
+}
+
+// assignSlots orders teams by schedule slot and maps each slot to its index.
+// Division indexes are 1-based with the first conference's divisions first;
+// within a division, team 1 has the lowest team ID.
+func assignSlots(teams []models.Team, divisionsPerConference int) ([]models.Team, map[slot]int, error) {
+	ordered := make([]models.Team, len(teams))
+	copy(ordered, teams)
+
+	divisionOf := func(team models.Team) int {
+		return (team.Conference-1)*divisionsPerConference + team.Division
+	}
+
+	for _, team := range ordered {
+		if team.Conference < 1 || team.Conference > 2 || team.Division < 1 || team.Division > divisionsPerConference {
+			return nil, nil, fmt.Errorf("team %d (%s) has conference %d division %d, expected conference 1-2 and division 1-%d",
+				team.TeamID, team.Abbreviation, team.Conference, team.Division, divisionsPerConference)
+		}
+	}
+
+	sort.SliceStable(ordered, func(a, b int) bool {
+		divA, divB := divisionOf(ordered[a]), divisionOf(ordered[b])
+		if divA != divB {
+			return divA < divB
+		}
+		return ordered[a].TeamID < ordered[b].TeamID
+	})
+
+	slots := make(map[slot]int, len(ordered))
+	index := 0
+	for i, team := range ordered {
+		if i > 0 && divisionOf(ordered[i-1]) == divisionOf(team) {
+			index++
+		} else {
+			index = 1
+		}
+		slots[slot{divisionOf(team), index}] = i
+	}
+
+	return ordered, slots, nil
+}
+
+// roadStretches counts runs of two or more consecutive road games and the longest run
+func roadStretches(sequence []bool) (stretches, longest int) {
+	run := 0
+	for i, home := range sequence {
+		if !home {
+			run++
+		}
+		if home || i == len(sequence)-1 {
+			if run >= 2 {
+				stretches++
+			}
+			longest = max(longest, run)
+			run = 0
+		}
+	}
+	return stretches, longest
+}
+
+// lookupCity returns the city with the given ID if it has coordinates
+func lookupCity(cities map[int]models.City, id int) (models.City, bool) {
+	city, ok := cities[id]
+	if !ok || !city.HasCoordinates() {
+		return models.City{}, false
+	}
+	return city, true
+}
@@ -0,0 +1,285 @@
+// ABOUTME: Tests for schedule balance and fairness analytics
+// ABOUTME: Validates slot assignment, per-rotation statistics, cycle totals and CSV export
+
+package schedule
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+const fixtures = "../../testdata/fixtures/csv/"
+
+func loadFixtureAnalysis(t *testing.T) *Analysis {
+	t.Helper()
+
+	games, err := data.LoadScheduleTemplate(fixtures + "4_2_3_schedule.csv")
+	if err != nil {
+		t.Fatalf("LoadScheduleTemplate failed: %v", err)
+	}
+	teams, err := data.LoadTeams(fixtures + "teams_league4.csv")
+	if err != nil {
+		t.Fatalf("LoadTeams failed: %v", err)
+	}
+	cityList, err := data.LoadCities(fixtures + "cities_simple.csv")
+	if err != nil {
+		t.Fatalf("LoadCities failed: %v", err)
+	}
+	cities := make(map[int]models.City)
+	for _, city := range cityList {
+		cities[city.CityID] = city
+	}
+
+	analysis, err := Analyze(ScheduleIDFromPath(fixtures+"4_2_3_schedule.csv"), games, teams, cities)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	return analysis
+}
+
+func TestParseScheduleID(t *testing.T) {
+	teams, divisions, games, err := ParseScheduleID("32_8_18")
+	if err != nil {
+		t.Fatalf("ParseScheduleID failed: %v", err)
+	}
+	if teams != 32 || divisions != 8 || games != 18 {
+		t.Errorf("Expected 32/8/18, got %d/%d/%d", teams, divisions, games)
+	}
+
+	for _, invalid := range []string{"", "32_8", "32_x_18", "0_2_10"} {
+		if _, _, _, err := ParseScheduleID(invalid); err == nil {
+			t.Errorf("Expected error for schedule ID '%s'", invalid)
+		}
+	}
+}
+
+func TestScheduleIDFromPath(t *testing.T) {
+	if id := ScheduleIDFromPath("/games/fof9/default_data/32_8_18_schedule.csv"); id != "32_8_18" {
+		t.Errorf("Expected '32_8_18', got '%s'", id)
+	}
+}
+
+func TestAnalyze_RotationStats(t *testing.T) {
+	analysis := loadFixtureAnalysis(t)
+
+	if len(analysis.Rotations) != 2 {
+		t.Fatalf("Expected 2 rotations, got %d", len(analysis.Rotations))
+	}
+
+	// Slot order: division 1 (BOS, NYE), then division 2 (MIA, LAS)
+	expectedOrder := []string{"BOS", "NYE", "MIA", "LAS"}
+	for i, abbr := range expectedOrder {
+		if analysis.Teams[i].Abbreviation != abbr {
+			t.Errorf("Slot %d: expected %s, got %s", i, abbr, analysis.Teams[i].Abbreviation)
+		}
+	}
+
+	rotation1, ok := analysis.GetRotation(1)
+	if !ok {
+		t.Fatal("Rotation 1 not found")
+	}
+
+	boston := rotation1.Teams[0]
+	if boston.Games != 3 || boston.Home != 2 || boston.Away != 1 {
+		t.Errorf("BOS rotation 1: expected 3 games (2 home, 1 away), got %d (%d home, %d away)",
+			boston.Games, boston.Home, boston.Away)
+	}
+	if boston.Division != 1 || boston.Conference != 0 || boston.Interconference != 2 {
+		t.Errorf("BOS rotation 1: expected 1 division / 0 conference / 2 interconference, got %d/%d/%d",
+			boston.Division, boston.Conference, boston.Interconference)
+	}
+
+	newYork := rotation1.Teams[1]
+	if newYork.RoadStretches != 1 || newYork.LongestRoadStretch != 2 {
+		t.Errorf("NYE rotation 1: expected 1 road stretch of 2, got %d (longest %d)",
+			newYork.RoadStretches, newYork.LongestRoadStretch)
+	}
+
+	// NYE travels to Boston and Los Angeles and back
+	if newYork.TravelMiles < 5000 || newYork.TravelMiles > 5600 {
+		t.Errorf("NYE rotation 1: expected roughly 5300 travel miles, got %.0f", newYork.TravelMiles)
+	}
+}
+
+func TestAnalyze_CycleStats(t *testing.T) {
+	analysis := loadFixtureAnalysis(t)
+
+	for _, team := range analysis.Cycle.Teams {
+		if team.Home != 3 || team.Away != 3 {
+			t.Errorf("%s cycle: expected 3 home and 3 away, got %d and %d", team.Abbreviation, team.Home, team.Away)
+		}
+		if team.RoadStretches != 1 {
+			t.Errorf("%s cycle: expected 1 road stretch, got %d", team.Abbreviation, team.RoadStretches)
+		}
+	}
+
+	for i := range analysis.Teams {
+		for j := range analysis.Teams {
+			expected := 2
+			if i == j {
+				expected = 0
+			}
+			if analysis.Cycle.Opponents[i][j] != expected {
+				t.Errorf("Opponents[%d][%d]: expected %d, got %d", i, j, expected, analysis.Cycle.Opponents[i][j])
+			}
+		}
+	}
+
+	summary := analysis.Cycle.Summarize()
+	if summary.MinHome != 3 || summary.MaxHome != 3 {
+		t.Errorf("Expected home range 3-3, got %d-%d", summary.MinHome, summary.MaxHome)
+	}
+	if summary.MaxRoadStretch != 2 {
+		t.Errorf("Expected longest road stretch 2, got %d", summary.MaxRoadStretch)
+	}
+}
+
+func TestAnalyze_Errors(t *testing.T) {
+	teams := []models.Team{
+		{TeamID: 1, Conference: 1, Division: 1},
+		{TeamID: 2, Conference: 2, Division: 1},
+	}
+
+	if _, err := Analyze("4_2_3", nil, teams, nil); err == nil {
+		t.Error("Expected error for team count mismatch")
+	}
+
+	teams[1].Conference = 0
+	if _, err := Analyze("2_2_1", nil, teams, nil); err == nil {
+		t.Error("Expected error for invalid conference")
+	}
+
+	teams[1].Conference = 2
+	games := []models.ScheduleTemplateGame{
+		{Rotation: 1, Season: models.SeasonRegular, Week: 1, HomeDiv: 1, HomeTeam: 2, VisDiv: 2, VisTeam: 1},
+	}
+	if _, err := Analyze("2_2_1", games, teams, nil); err == nil {
+		t.Error("Expected error for game referencing a missing team")
+	}
+}
+
+func TestAnalyze_MissingCities(t *testing.T) {
+	teams := []models.Team{
+		{TeamID: 1, Abbreviation: "AAA", Conference: 1, Division: 1, City: 99},
+		{TeamID: 2, Abbreviation: "BBB", Conference: 2, Division: 1, City: 98},
+	}
+	games := []models.ScheduleTemplateGame{
+		{Rotation: 1, Season: models.SeasonRegular, Week: 1, HomeDiv: 1, HomeTeam: 1, VisDiv: 2, VisTeam: 1},
+	}
+
+	analysis, err := Analyze("2_2_1", games, teams, nil)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(analysis.Warnings) != 2 {
+		t.Errorf("Expected 2 warnings, got %d", len(analysis.Warnings))
+	}
+	if analysis.Cycle.Teams[1].TravelMiles != 0 {
+		t.Errorf("Expected no travel without cities, got %.0f", analysis.Cycle.Teams[1].TravelMiles)
+	}
+}
+
+func TestAnalyze_StandingsGames(t *testing.T) {
+	teams := []models.Team{
+		{TeamID: 1, Abbreviation: "AAA", Conference: 1, Division: 1},
+		{TeamID: 2, Abbreviation: "BBB", Conference: 2, Division: 1},
+	}
+	games := []models.ScheduleTemplateGame{
+		{Rotation: 1, Season: models.SeasonRegular, Week: 1, HomeDiv: 1, HomeTeam: 1, VisDiv: 2, VisTeam: 1},
+		{Rotation: 1, Season: models.SeasonRegular, Week: 2, HomeDiv: 2, HomeTeam: 1, VisDiv: 1, VisTeam: 1, Standings: 1},
+	}
+
+	analysis, err := Analyze("2_2_2", games, teams, nil)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	// Standings games are counted by team index, with a warning listed first
+	if analysis.StandingsGames != 1 || analysis.Cycle.Teams[0].Games != 2 {
+		t.Errorf("Expected 1 standings game among 2 counted, got %d", analysis.StandingsGames)
+	}
+	if len(analysis.Warnings) != 3 || !strings.Contains(analysis.Warnings[0], "standings") {
+		t.Errorf("Expected the standings warning first, got %v", analysis.Warnings)
+	}
+}
+
+func TestAnalysis_Export(t *testing.T) {
+	analysis := loadFixtureAnalysis(t)
+
+	rows := analysis.StatsRows()
+	// 4 teams x 2 rotations + 4 cycle rows
+	if len(rows) != 12 {
+		t.Fatalf("Expected 12 rows, got %d", len(rows))
+	}
+	if len(rows[0]) != len(StatsHeaders()) {
+		t.Errorf("Expected %d columns, got %d", len(StatsHeaders()), len(rows[0]))
+	}
+	if rows[11][0] != "ALL" || rows[11][2] != "LAS" {
+		t.Errorf("Expected last row to be the LAS cycle row, got %v", rows[11])
+	}
+
+	headers, matrix, err := analysis.OpponentMatrix(CycleRotation)
+	if err != nil {
+		t.Fatalf("OpponentMatrix failed: %v", err)
+	}
+	if len(headers) != 5 || headers[1] != "BOS" {
+		t.Errorf("Unexpected matrix headers: %v", headers)
+	}
+	if matrix[0][0] != "BOS" || matrix[0][1] != "0" || matrix[0][2] != "2" {
+		t.Errorf("Unexpected BOS matrix row: %v", matrix[0])
+	}
+
+	if _, _, err := analysis.OpponentMatrix(7); err == nil {
+		t.Error("Expected error for unknown rotation")
+	}
+}
+
+func TestRoadStretches(t *testing.T) {
+	tests := []struct {
+		sequence           []bool
+		stretches, longest int
+	}{
+		{[]bool{true, false, true, false}, 0, 1},
+		{[]bool{false, false, true, false, false, false}, 2, 3},
+		{[]bool{}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		stretches, longest := roadStretches(tt.sequence)
+		if stretches != tt.stretches || longest != tt.longest {
+			t.Errorf("roadStretches(%v): expected %d/%d, got %d/%d",
+				tt.sequence, tt.stretches, tt.longest, stretches, longest)
+		}
+	}
+}
+
+func TestAnalyze_DefaultTemplate(t *testing.T) {
+	games, err := data.LoadScheduleTemplate("../../default_data/16_4_16_schedule.csv")
+	if err != nil {
+		t.Fatalf("LoadScheduleTemplate failed: %v", err)
+	}
+
+	teams := make([]models.Team, 16)
+	for i := range teams {
+		teams[i] = models.Team{TeamID: i + 1, Conference: i/8 + 1, Division: (i%8)/4 + 1}
+	}
+
+	analysis, err := Analyze("16_4_16", games, teams, nil)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	for _, rotation := range analysis.Rotations {
+		for _, team := range rotation.Teams {
+			if team.Games != 16 {
+				t.Errorf("Rotation %d team %d: expected 16 games, got %d", rotation.Rotation, team.TeamID, team.Games)
+			}
+		}
+	}
+	if math.Abs(analysis.Cycle.Summarize().MaxTravel) != 0 {
+		t.Error("Expected no travel without cities")
+	}
+}
@@ -0,0 +1,100 @@
+// ABOUTME: iCalendar (.ics) export of dated season schedules
+// ABOUTME: Writes one all-day event per game with deterministic UIDs and timestamps
+
+package schedule
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// calendarLineLimit is the maximum line length in octets before folding (RFC 5545)
+const calendarLineLimit = 75
+
+// WriteICalendar writes the season schedule as an iCalendar file. The output
+// depends only on the schedule, so the same input always produces the same bytes.
+func WriteICalendar(w io.Writer, season *SeasonSchedule) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeCalendarLine(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//FOF9 Editor//Season Schedule//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escapeCalendarText(fmt.Sprintf("%d Season Schedule", season.Year)))
+
+	for i := range season.Games {
+		game := &season.Games[i]
+		date := game.Date()
+		category := "Regular Season"
+		if !game.IsRegularSeason() {
+			category = "Exhibition"
+		}
+
+		line("BEGIN", "VEVENT")
+		line("UID", fmt.Sprintf("%d-w%02d-%d-at-%d@fof9editor", game.Year, game.Week, game.Visitor, game.Home))
+		line("DTSTAMP", date.Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE", date.Format("20060102"))
+		line("DTEND;VALUE=DATE", date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escapeCalendarText(season.Title(game)))
+		if location := season.Location(game); location != "" {
+			line("LOCATION", escapeCalendarText(location))
+		}
+		line("CATEGORIES", escapeCalendarText(category))
+		line("DESCRIPTION", escapeCalendarText(season.WeekLabel(game.Week)))
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// SaveICalendar writes the season schedule to an .ics file
+func SaveICalendar(path string, season *SeasonSchedule) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create calendar file: %w", err)
+	}
+	defer file.Close()
+
+	if err := WriteICalendar(file, season); err != nil {
+		return fmt.Errorf("failed to write calendar file: %w", err)
+	}
+	return nil
+}
+
+// escapeCalendarText escapes a TEXT property value
+func escapeCalendarText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(text)
+}
+
+// writeCalendarLine writes a content line with CRLF, folding it at 75 octets
+// without splitting UTF-8 characters
+func writeCalendarLine(w *bufio.Writer, content string) {
+	limit := calendarLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(content[cut]) {
+			cut--
+		}
+		w.WriteString(content[:cut])
+		w.WriteString("\r\n ")
+		content = content[cut:]
+		limit = calendarLineLimit - 1 // Continuation lines start with a space
+	}
+	w.WriteString(content)
+	w.WriteString("\r\n")
+}
+
+// isUTF8Start returns true if b is not a UTF-8 continuation byte
+func isUTF8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
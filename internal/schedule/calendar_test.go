@@ -0,0 +1,87 @@
+// ABOUTME: Tests for iCalendar export of season schedules
+// ABOUTME: Compares output with a golden file and checks escaping and line folding
+
+package schedule
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteICalendar_Golden(t *testing.T) {
+	season := loadFixtureSeason(t)
+
+	var buf bytes.Buffer
+	if err := WriteICalendar(&buf, season); err != nil {
+		t.Fatalf("WriteICalendar failed: %v", err)
+	}
+	compareGolden(t, "2024_league4_schedule.ics", buf.Bytes())
+
+	// Exporting again produces identical bytes
+	var again bytes.Buffer
+	if err := WriteICalendar(&again, season); err != nil {
+		t.Fatalf("WriteICalendar failed: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+		t.Error("Expected identical output for identical input")
+	}
+
+	if count := strings.Count(buf.String(), "BEGIN:VEVENT"); count != len(season.Games) {
+		t.Errorf("Expected %d events, got %d", len(season.Games), count)
+	}
+}
+
+func TestEscapeCalendarText(t *testing.T) {
+	got := escapeCalendarText("Boston, MA; \"home\"\\away\nnext")
+	want := `Boston\, MA\; "home"\\away\nnext`
+	if got != want {
+		t.Errorf("Expected '%s', got '%s'", want, got)
+	}
+}
+
+func TestWriteCalendarLine_Folding(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeCalendarLine(w, "SUMMARY:"+strings.Repeat("é", 80))
+	w.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("Expected folded lines, got %d", len(lines))
+	}
+	unfolded := lines[0]
+	for _, line := range lines {
+		if len(line) > calendarLineLimit {
+			t.Errorf("Line longer than %d octets: %d", calendarLineLimit, len(line))
+		}
+	}
+	for _, line := range lines[1:] {
+		if !strings.HasPrefix(line, " ") {
+			t.Errorf("Expected continuation line to start with a space: %q", line)
+		}
+		unfolded += line[1:]
+	}
+	if unfolded != "SUMMARY:"+strings.Repeat("é", 80) {
+		t.Error("Expected folded line to unfold to the original content")
+	}
+}
+
+func TestSaveICalendar(t *testing.T) {
+	season := loadFixtureSeason(t)
+	path := filepath.Join(t.TempDir(), "schedule.ics")
+
+	if err := SaveICalendar(path, season); err != nil {
+		t.Fatalf("SaveICalendar failed: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read calendar: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "BEGIN:VCALENDAR\r\n") {
+		t.Errorf("Expected calendar header, got %q", string(content[:20]))
+	}
+}
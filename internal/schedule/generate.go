@@ -0,0 +1,134 @@
+// ABOUTME: Schedule template generation for new FOF9 league structures
+// ABOUTME: Builds a single-rotation round-robin template from a league_info.csv row
+
+package schedule
+
+import (
+	"fmt"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// pairing is a single matchup between two slot indexes in a round
+type pairing struct {
+	home, visitor int
+}
+
+// GenerateTemplate builds a single-rotation schedule template for a league
+// structure using the circle (round-robin) method. Exhibition games use the
+// last rounds of the rotation so week-one opponents differ. The league must
+// have an even number of teams.
+func GenerateTemplate(structure *models.LeagueStructure) ([]models.ScheduleTemplateGame, error) {
+	slots := make([]slot, 0, structure.Teams)
+	for div := 1; div <= structure.Divisions; div++ {
+		for team := 1; team <= structure.GetDivisionTeams(div); team++ {
+			slots = append(slots, slot{division: div, team: team})
+		}
+	}
+
+	n := len(slots)
+	switch {
+	case n != structure.Teams:
+		return nil, fmt.Errorf("divisions hold %d teams, league has %d", n, structure.Teams)
+	case n < 2 || n%2 != 0:
+		return nil, fmt.Errorf("generated schedules need an even number of teams, got %d", n)
+	case structure.Games < 1 || structure.Weeks < structure.Games:
+		return nil, fmt.Errorf("%d games do not fit in %d weeks", structure.Games, structure.Weeks)
+	case structure.ExWeeks < structure.ExGames:
+		return nil, fmt.Errorf("%d exhibition games do not fit in %d weeks", structure.ExGames, structure.ExWeeks)
+	}
+
+	rounds := roundRobin(n)
+	games := make([]models.ScheduleTemplateGame, 0, (structure.ExGames+structure.Games)*n/2)
+
+	// Home teams are picked greedily per season type to balance home games
+	// and break up road stretches
+	addRound := func(season, week int, round []pairing, balance *homeBalance) {
+		for _, p := range round {
+			home, visitor := balance.pick(p, week)
+			games = append(games, models.ScheduleTemplateGame{
+				Rotation: 1,
+				Season:   season,
+				Week:     week,
+				HomeDiv:  slots[home].division,
+				HomeTeam: slots[home].team,
+				VisDiv:   slots[visitor].division,
+				VisTeam:  slots[visitor].team,
+			})
+		}
+	}
+
+	exhibition := newHomeBalance(n)
+	for g := 0; g < structure.ExGames; g++ {
+		round := len(rounds) - 1 - g%len(rounds)
+		week := 1 + g*structure.ExWeeks/structure.ExGames
+		addRound(models.SeasonExhibition, week, rounds[round], exhibition)
+	}
+
+	regular := newHomeBalance(n)
+	for g := 0; g < structure.Games; g++ {
+		week := structure.ExWeeks + 1 + g*structure.Weeks/structure.Games
+		addRound(models.SeasonRegular, week, rounds[g%len(rounds)], regular)
+	}
+
+	return games, nil
+}
+
+// homeBalance tracks home games and current road streaks while assigning home teams
+type homeBalance struct {
+	homes       []int
+	awayStreaks []int
+}
+
+// newHomeBalance creates an empty home balance tracker for n teams
+func newHomeBalance(n int) *homeBalance {
+	return &homeBalance{homes: make([]int, n), awayStreaks: make([]int, n)}
+}
+
+// pick chooses the home team of a pairing: the team on the longer road
+// streak, then the team with fewer home games, then alternating by week
+func (b *homeBalance) pick(p pairing, week int) (home, visitor int) {
+	home, visitor = p.home, p.visitor
+	switch {
+	case b.awayStreaks[home] != b.awayStreaks[visitor]:
+		if b.awayStreaks[visitor] > b.awayStreaks[home] {
+			home, visitor = visitor, home
+		}
+	case b.homes[home] != b.homes[visitor]:
+		if b.homes[visitor] < b.homes[home] {
+			home, visitor = visitor, home
+		}
+	case week%2 == 1:
+		home, visitor = visitor, home
+	}
+
+	b.homes[home]++
+	b.awayStreaks[home] = 0
+	b.awayStreaks[visitor]++
+	return home, visitor
+}
+
+// roundRobin returns the n-1 rounds of a single round robin between n teams
+// (n even) using the circle method
+func roundRobin(n int) [][]pairing {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+
+	rounds := make([][]pairing, 0, n-1)
+	for r := 0; r < n-1; r++ {
+		round := make([]pairing, 0, n/2)
+		for i := 0; i < n/2; i++ {
+			round = append(round, pairing{home: order[i], visitor: order[n-1-i]})
+		}
+		rounds = append(rounds, round)
+
+		// Rotate every team except the first one position
+		last := order[n-1]
+		copy(order[2:], order[1:n-1])
+		order[1] = last
+	}
+
+	return rounds
+}
@@ -0,0 +1,118 @@
+// ABOUTME: Tests for schedule template generation
+// ABOUTME: Validates round-robin matchups, week numbering and home/away balance
+
+package schedule
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// structureTeams creates teams matching a league structure's divisions
+func structureTeams(structure *models.LeagueStructure) []models.Team {
+	teams := make([]models.Team, 0, structure.Teams)
+	dpc := structure.DivisionsPerConference()
+	for div := 1; div <= structure.Divisions; div++ {
+		for i := 0; i < structure.GetDivisionTeams(div); i++ {
+			teams = append(teams, models.Team{
+				TeamID:     len(teams) + 1,
+				Conference: (div-1)/dpc + 1,
+				Division:   (div-1)%dpc + 1,
+			})
+		}
+	}
+	return teams
+}
+
+func TestGenerateTemplate(t *testing.T) {
+	structure := models.NewLeagueStructure(32, 8, 17)
+	structure.Weeks, structure.ExGames, structure.ExWeeks = 18, 3, 4
+
+	games, err := GenerateTemplate(structure)
+	if err != nil {
+		t.Fatalf("GenerateTemplate failed: %v", err)
+	}
+
+	if len(games) != (17+3)*16 {
+		t.Fatalf("Expected %d games, got %d", (17+3)*16, len(games))
+	}
+
+	lastWeek := 0
+	for _, game := range games {
+		if game.Rotation != 1 {
+			t.Fatalf("Expected rotation 1, got %d", game.Rotation)
+		}
+		if game.Week < lastWeek {
+			t.Fatalf("Expected games sorted by week, week %d follows %d", game.Week, lastWeek)
+		}
+		lastWeek = game.Week
+		if !game.IsRegularSeason() && game.Week > structure.ExWeeks {
+			t.Errorf("Exhibition game in week %d", game.Week)
+		}
+		if game.IsRegularSeason() && (game.Week <= structure.ExWeeks || game.Week > structure.ExWeeks+structure.Weeks) {
+			t.Errorf("Regular season game in week %d", game.Week)
+		}
+	}
+
+	analysis, err := Analyze(structure.ScheduleID, games, structureTeams(structure), nil)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	summary := analysis.Cycle.Summarize()
+	if summary.MinHome < 8 || summary.MaxHome > 9 {
+		t.Errorf("Expected 8-9 home games per team, got %d-%d", summary.MinHome, summary.MaxHome)
+	}
+	if summary.MaxRoadStretch > 2 {
+		t.Errorf("Expected road stretches of at most 2 games, got %d", summary.MaxRoadStretch)
+	}
+	for _, team := range analysis.Cycle.Teams {
+		if team.Games != 17 {
+			t.Errorf("Team %d: expected 17 games, got %d", team.TeamID, team.Games)
+		}
+	}
+}
+
+func TestGenerateTemplate_RepeatsOpponents(t *testing.T) {
+	// 4 teams and 6 games: every opponent twice
+	structure := models.NewLeagueStructure(4, 2, 6)
+
+	games, err := GenerateTemplate(structure)
+	if err != nil {
+		t.Fatalf("GenerateTemplate failed: %v", err)
+	}
+
+	analysis, err := Analyze(structure.ScheduleID, games, structureTeams(structure), nil)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	for i := range analysis.Teams {
+		for j := range analysis.Teams {
+			if i != j && analysis.Cycle.Opponents[i][j] != 2 {
+				t.Errorf("Expected teams %d and %d to meet twice, got %d", i, j, analysis.Cycle.Opponents[i][j])
+			}
+		}
+		if team := analysis.Cycle.Teams[i]; team.Home != 3 {
+			t.Errorf("Team %d: expected 3 home games, got %d", team.TeamID, team.Home)
+		}
+	}
+}
+
+func TestGenerateTemplate_Errors(t *testing.T) {
+	odd := models.NewLeagueStructure(31, 6, 16)
+	if _, err := GenerateTemplate(odd); err == nil {
+		t.Error("Expected error for odd number of teams")
+	}
+
+	weeks := models.NewLeagueStructure(16, 4, 16)
+	weeks.Weeks = 15
+	if _, err := GenerateTemplate(weeks); err == nil {
+		t.Error("Expected error when games do not fit in weeks")
+	}
+
+	mismatch := models.NewLeagueStructure(16, 4, 16)
+	mismatch.Div1Teams = 3
+	if _, err := GenerateTemplate(mismatch); err == nil {
+		t.Error("Expected error when divisions do not hold every team")
+	}
+}
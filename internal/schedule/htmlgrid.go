@@ -0,0 +1,173 @@
+// ABOUTME: Static HTML export of dated season schedules
+// ABOUTME: Renders a team-by-week grid and week-by-week game lists with html/template
+
+package schedule
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+)
+
+// htmlGridCell is one team's opponent in one week of the grid
+type htmlGridCell struct {
+	Text  string // e.g. "BOS", "@BOS" or "" for a bye
+	Class string
+}
+
+// htmlGridRow is one team's line in the grid
+type htmlGridRow struct {
+	Team  string
+	Name  string
+	Cells []htmlGridCell
+}
+
+// htmlWeekGame is one game in a week's game list
+type htmlWeekGame struct {
+	Visitor, Home, Location string
+	Neutral                 bool
+}
+
+// htmlWeek is one week's heading and games
+type htmlWeek struct {
+	Label string
+	Short string
+	Dates string
+	Games []htmlWeekGame
+}
+
+// htmlSchedulePage holds everything the HTML template renders
+type htmlSchedulePage struct {
+	Title string
+	Weeks []htmlWeek
+	Rows  []htmlGridRow
+}
+
+var scheduleHTMLTemplate = template.Must(template.New("schedule").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.25em 0.5em; text-align: center; }
+td.team, td.game { text-align: left; }
+td.away { color: #555; }
+td.bye { background: #f3f3f3; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<h2>Grid</h2>
+<table class="grid">
+<thead>
+<tr><th>Team</th>{{range .Weeks}}<th title="{{.Label}}">{{.Short}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr><td class="team" title="{{.Name}}">{{.Team}}</td>{{range .Cells}}<td class="{{.Class}}">{{.Text}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+{{- range .Weeks}}
+<h2>{{.Label}} <small>{{.Dates}}</small></h2>
+<table class="week">
+<thead>
+<tr><th>Visitor</th><th>Home</th><th>Location</th></tr>
+</thead>
+<tbody>
+{{- range .Games}}
+<tr><td class="game">{{.Visitor}}</td><td class="game">{{.Home}}</td><td class="game">{{.Location}}{{if .Neutral}} (neutral site){{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteScheduleHTML writes the season schedule as a static HTML page. The
+// output depends only on the schedule, so the same input always produces the
+// same bytes.
+func WriteScheduleHTML(w io.Writer, season *SeasonSchedule) error {
+	page := htmlSchedulePage{
+		Title: fmt.Sprintf("%d Season Schedule", season.Year),
+	}
+
+	weeks := season.Weeks()
+	rowIndex := make(map[int]int)
+	addRow := func(teamID int) {
+		if _, ok := rowIndex[teamID]; ok {
+			return
+		}
+		rowIndex[teamID] = len(page.Rows)
+		page.Rows = append(page.Rows, htmlGridRow{
+			Team:  season.TeamAbbreviation(teamID),
+			Name:  season.TeamName(teamID),
+			Cells: make([]htmlGridCell, len(weeks)),
+		})
+	}
+	for _, team := range season.Teams {
+		addRow(team.TeamID)
+	}
+	for _, game := range season.Games {
+		addRow(game.Home)
+		addRow(game.Visitor)
+	}
+	for i := range page.Rows {
+		for w := range page.Rows[i].Cells {
+			page.Rows[i].Cells[w] = htmlGridCell{Class: "bye"}
+		}
+	}
+
+	for w, week := range weeks {
+		entry := htmlWeek{Label: week.Label, Short: week.Short}
+		lastDate := ""
+		for i := range week.Games {
+			game := &week.Games[i]
+
+			if date := game.Date().Format("Monday, January 2, 2006"); date != lastDate {
+				if entry.Dates != "" {
+					entry.Dates += " / "
+				}
+				entry.Dates += date
+				lastDate = date
+			}
+
+			entry.Games = append(entry.Games, htmlWeekGame{
+				Visitor:  season.TeamName(game.Visitor),
+				Home:     season.TeamName(game.Home),
+				Location: season.Location(game),
+				Neutral:  season.IsNeutralSite(game),
+			})
+
+			page.Rows[rowIndex[game.Home]].Cells[w] = htmlGridCell{
+				Text:  season.TeamAbbreviation(game.Visitor),
+				Class: "home",
+			}
+			page.Rows[rowIndex[game.Visitor]].Cells[w] = htmlGridCell{
+				Text:  "@" + season.TeamAbbreviation(game.Home),
+				Class: "away",
+			}
+		}
+		page.Weeks = append(page.Weeks, entry)
+	}
+
+	return scheduleHTMLTemplate.Execute(w, page)
+}
+
+// SaveScheduleHTML writes the season schedule to an HTML file
+func SaveScheduleHTML(path string, season *SeasonSchedule) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create HTML file: %w", err)
+	}
+	defer file.Close()
+
+	if err := WriteScheduleHTML(file, season); err != nil {
+		return fmt.Errorf("failed to write HTML file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// ABOUTME: Tests for HTML export of season schedules
+// ABOUTME: Compares output with a golden file and checks the team-by-week grid
+
+package schedule
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestWriteScheduleHTML_Golden(t *testing.T) {
+	season := loadFixtureSeason(t)
+
+	var buf bytes.Buffer
+	if err := WriteScheduleHTML(&buf, season); err != nil {
+		t.Fatalf("WriteScheduleHTML failed: %v", err)
+	}
+	compareGolden(t, "2024_league4_schedule.html", buf.Bytes())
+
+	html := buf.String()
+	if !strings.Contains(html, `<td class="away">@BOS</td>`) {
+		t.Error("Expected an away cell for a game at Boston")
+	}
+	if !strings.Contains(html, "Los Angeles, CA (neutral site)") {
+		t.Error("Expected the neutral site game to be marked")
+	}
+}
+
+func TestWriteScheduleHTML_EscapesNames(t *testing.T) {
+	games := []models.ScheduledGame{{Season: 1, Week: 1, Month: 9, Day: 7, Year: 2025, Home: 1, Visitor: 2}}
+	teams := []models.Team{
+		{Year: 2025, TeamID: 1, TeamName: "<script>", NickName: "Hackers", Abbreviation: "H&K"},
+		{Year: 2025, TeamID: 2, TeamName: "Visitors", NickName: "Team", Abbreviation: "VIS"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteScheduleHTML(&buf, NewSeasonSchedule(games, teams, nil)); err != nil {
+		t.Fatalf("WriteScheduleHTML failed: %v", err)
+	}
+	html := buf.String()
+	if strings.Contains(html, "<script>") {
+		t.Error("Expected team names to be escaped")
+	}
+	if !strings.Contains(html, "@H&amp;K") {
+		t.Error("Expected escaped abbreviation in the grid")
+	}
+}
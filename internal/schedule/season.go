@@ -0,0 +1,152 @@
+// ABOUTME: Dated season schedules for export to calendars and web pages
+// ABOUTME: Resolves team and city names and week labels for xxxx_schedule.csv games
+
+package schedule
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// SeasonSchedule is a dated season schedule with the teams and cities needed
+// to describe its games. Games are ordered by week, then date, keeping file
+// order otherwise, so exports are reproducible.
+type SeasonSchedule struct {
+	Year  int
+	Games []models.ScheduledGame
+	Teams []models.Team // The teams of the schedule's year, ordered by team ID
+
+	teams            map[int]models.Team
+	cities           map[int]models.City
+	firstRegularWeek int
+}
+
+// SeasonWeek is one week of a season schedule
+type SeasonWeek struct {
+	Week  int
+	Label string // e.g. "Exhibition Week 1" or "Week 3"
+	Short string // e.g. "E1" or "3"
+	Games []models.ScheduledGame
+}
+
+// NewSeasonSchedule prepares a season schedule for export. Teams of the
+// schedule's year are used when present, otherwise the latest year's teams.
+func NewSeasonSchedule(games []models.ScheduledGame, teams []models.Team, cities map[int]models.City) *SeasonSchedule {
+	s := &SeasonSchedule{
+		Games:  append([]models.ScheduledGame(nil), games...),
+		teams:  make(map[int]models.Team),
+		cities: cities,
+	}
+
+	sort.SliceStable(s.Games, func(i, j int) bool {
+		a, b := &s.Games[i], &s.Games[j]
+		if a.Week != b.Week {
+			return a.Week < b.Week
+		}
+		return a.Date().Before(b.Date())
+	})
+
+	for _, game := range s.Games {
+		if game.IsRegularSeason() && (s.firstRegularWeek == 0 || game.Week < s.firstRegularWeek) {
+			s.firstRegularWeek = game.Week
+		}
+		if s.Year == 0 || game.Year < s.Year {
+			s.Year = game.Year
+		}
+	}
+
+	seasonTeams := models.TeamsForYear(teams, s.Year)
+	if len(seasonTeams) == 0 {
+		seasonTeams = models.TeamsForYear(teams, models.LatestTeamYear(teams))
+	}
+	for _, team := range seasonTeams {
+		s.teams[team.TeamID] = team
+	}
+	s.Teams = append([]models.Team(nil), seasonTeams...)
+	sort.SliceStable(s.Teams, func(i, j int) bool {
+		return s.Teams[i].TeamID < s.Teams[j].TeamID
+	})
+
+	return s
+}
+
+// TeamName returns a team's full name, or "Team N" if it is not loaded
+func (s *SeasonSchedule) TeamName(teamID int) string {
+	if team, ok := s.teams[teamID]; ok {
+		return team.GetDisplayName()
+	}
+	return fmt.Sprintf("Team %d", teamID)
+}
+
+// TeamAbbreviation returns a team's abbreviation, or its ID if it is not loaded
+func (s *SeasonSchedule) TeamAbbreviation(teamID int) string {
+	if team, ok := s.teams[teamID]; ok {
+		return team.Abbreviation
+	}
+	return fmt.Sprintf("%d", teamID)
+}
+
+// Location returns the display name of the city a game is played in: the
+// game's location, or the home team's city when the location is 0
+func (s *SeasonSchedule) Location(game *models.ScheduledGame) string {
+	cityID := game.Location
+	if cityID == 0 {
+		team, ok := s.teams[game.Home]
+		if !ok {
+			return ""
+		}
+		cityID = team.City
+	}
+
+	if city, ok := s.cities[cityID]; ok {
+		return city.GetDisplayName()
+	}
+	return ""
+}
+
+// IsNeutralSite returns true if a game is not played in the home team's city
+func (s *SeasonSchedule) IsNeutralSite(game *models.ScheduledGame) bool {
+	team, ok := s.teams[game.Home]
+	return game.Location != 0 && (!ok || game.Location != team.City)
+}
+
+// WeekLabel returns the display label of a schedule week. Regular season
+// weeks are numbered from 1 after the exhibition weeks.
+func (s *SeasonSchedule) WeekLabel(week int) string {
+	if s.firstRegularWeek == 0 || week < s.firstRegularWeek {
+		return fmt.Sprintf("Exhibition Week %d", week)
+	}
+	return fmt.Sprintf("Week %d", week-s.firstRegularWeek+1)
+}
+
+// weekShort returns a compact week label for grid headers
+func (s *SeasonSchedule) weekShort(week int) string {
+	if s.firstRegularWeek == 0 || week < s.firstRegularWeek {
+		return fmt.Sprintf("E%d", week)
+	}
+	return fmt.Sprintf("%d", week-s.firstRegularWeek+1)
+}
+
+// Title returns the event title of a game, e.g. "Boston Minutemen at New York Empire"
+func (s *SeasonSchedule) Title(game *models.ScheduledGame) string {
+	return fmt.Sprintf("%s at %s", s.TeamName(game.Visitor), s.TeamName(game.Home))
+}
+
+// Weeks groups the schedule's games by week in week order
+func (s *SeasonSchedule) Weeks() []SeasonWeek {
+	var weeks []SeasonWeek
+	for _, game := range s.Games {
+		if len(weeks) == 0 || weeks[len(weeks)-1].Week != game.Week {
+			weeks = append(weeks, SeasonWeek{
+				Week:  game.Week,
+				Label: s.WeekLabel(game.Week),
+				Short: s.weekShort(game.Week),
+			})
+		}
+		last := &weeks[len(weeks)-1]
+		last.Games = append(last.Games, game)
+	}
+	return weeks
+}
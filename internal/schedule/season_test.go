@@ -0,0 +1,132 @@
+// ABOUTME: Tests for dated season schedules prepared for export
+// ABOUTME: Validates game ordering, week labels, team names and game locations
+
+package schedule
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+const golden = "../../testdata/golden/"
+
+// update rewrites golden files with the current output: go test ./internal/schedule -update
+var update = flag.Bool("update", false, "update golden files")
+
+func loadFixtureSeason(t *testing.T) *SeasonSchedule {
+	t.Helper()
+
+	games, err := data.LoadSeasonSchedule(fixtures + "2024_league4_schedule.csv")
+	if err != nil {
+		t.Fatalf("LoadSeasonSchedule failed: %v", err)
+	}
+	teams, err := data.LoadTeams(fixtures + "teams_league4.csv")
+	if err != nil {
+		t.Fatalf("LoadTeams failed: %v", err)
+	}
+	cityList, err := data.LoadCities(fixtures + "cities_simple.csv")
+	if err != nil {
+		t.Fatalf("LoadCities failed: %v", err)
+	}
+	cities := make(map[int]models.City)
+	for _, city := range cityList {
+		cities[city.CityID] = city
+	}
+
+	return NewSeasonSchedule(games, teams, cities)
+}
+
+// compareGolden checks output against a golden file, or rewrites it with -update
+func compareGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := golden + name
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Output does not match %s (run with -update to regenerate)\ngot:\n%s", name, got)
+	}
+}
+
+func TestNewSeasonSchedule(t *testing.T) {
+	season := loadFixtureSeason(t)
+
+	if season.Year != 2024 {
+		t.Errorf("Expected year 2024, got %d", season.Year)
+	}
+	if len(season.Teams) != 4 {
+		t.Errorf("Expected 4 teams, got %d", len(season.Teams))
+	}
+
+	if label := season.WeekLabel(1); label != "Exhibition Week 1" {
+		t.Errorf("Expected 'Exhibition Week 1', got '%s'", label)
+	}
+	if label := season.WeekLabel(3); label != "Week 2" {
+		t.Errorf("Expected 'Week 2', got '%s'", label)
+	}
+
+	weeks := season.Weeks()
+	if len(weeks) != 4 {
+		t.Fatalf("Expected 4 weeks, got %d", len(weeks))
+	}
+	if len(weeks[3].Games) != 1 {
+		t.Errorf("Expected 1 game in the last week, got %d", len(weeks[3].Games))
+	}
+}
+
+func TestSeasonSchedule_NamesAndLocations(t *testing.T) {
+	season := loadFixtureSeason(t)
+
+	game := &season.Games[0]
+	if title := season.Title(game); title != "Miami Surf at Boston Minutemen" {
+		t.Errorf("Expected 'Miami Surf at Boston Minutemen', got '%s'", title)
+	}
+	if location := season.Location(game); location != "Boston, MA" {
+		t.Errorf("Expected home city 'Boston, MA', got '%s'", location)
+	}
+	if season.IsNeutralSite(game) {
+		t.Error("Expected home game not to be at a neutral site")
+	}
+
+	neutral := &models.ScheduledGame{Home: 2, Visitor: 3, Location: 1594}
+	if location := season.Location(neutral); location != "Los Angeles, CA" {
+		t.Errorf("Expected 'Los Angeles, CA', got '%s'", location)
+	}
+	if !season.IsNeutralSite(neutral) {
+		t.Error("Expected neutral site game")
+	}
+
+	if name := season.TeamName(99); name != "Team 99" {
+		t.Errorf("Expected 'Team 99' for unknown team, got '%s'", name)
+	}
+}
+
+func TestNewSeasonSchedule_SortsByWeek(t *testing.T) {
+	games := []models.ScheduledGame{
+		{Season: 1, Week: 3, Month: 9, Day: 15, Year: 2024, Home: 1, Visitor: 2},
+		{Season: 0, Week: 1, Month: 8, Day: 11, Year: 2024, Home: 3, Visitor: 4},
+		{Season: 1, Week: 2, Month: 9, Day: 8, Year: 2024, Home: 2, Visitor: 1},
+	}
+
+	season := NewSeasonSchedule(games, nil, nil)
+	for i, week := range []int{1, 2, 3} {
+		if season.Games[i].Week != week {
+			t.Errorf("Game %d: expected week %d, got %d", i, week, season.Games[i].Week)
+		}
+	}
+	if games[0].Week != 3 {
+		t.Error("Expected input games to be left unchanged")
+	}
+}
@@ -1,10 +1,13 @@
 // ABOUTME: Application state management for FOF9 Editor
-// ABOUTME: Maintains current project, loaded data, and UI state using singleton pattern
+// ABOUTME: Maintains current project, loaded data, undo history and UI state using singleton pattern
 
 package state
 
 import (
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -12,6 +15,9 @@ import (
 	"github.com/igorilic/fof9editor/internal/models"
 )
 
+// RatingBaselineFile is the shipped player file that overall ratings are compared with
+const RatingBaselineFile = "2024_players.csv"
+
 // AppState represents the global application state
 type AppState struct {
 	// Current project
@@ -19,9 +25,12 @@ type AppState struct {
 	ProjectPath string // Path to the .fof9proj file
 
 	// Loaded data
-	Players []models.Player
-	Coaches []models.Coach
-	Teams   []models.Team
+	Players      []models.Player
+	Quarterbacks []models.Quarterback // Quarterback file, when the project lists one
+	Coaches      []models.Coach
+	Teams        []models.Team
+	Schedule     []models.ScheduledGame // Season schedule, when the project lists one
+	LeagueInfo   *models.LeagueInfo     // Salary cap and minimums from xxxx_info.csv
 
 	// Reference data
 	ReferenceData *models.ReferenceData
@@ -33,6 +42,13 @@ type AppState struct {
 	// Dirty flag
 	IsDirty bool // True if there are unsaved changes
 
+	// Undo history of the loaded data
+	history history
+
+	// Change notification
+	revision        uint64   // Incremented on every data change
+	changeListeners []func() // Called after every data change, nil once removed
+
 	// Mutex for thread safety
 	mu sync.RWMutex
 }
@@ -57,6 +73,7 @@ func GetInstance() *AppState {
 
 // LoadProject loads a project from the given file path
 func (s *AppState) LoadProject(filepath string) error {
+	defer s.notifyChange()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -78,6 +95,13 @@ func (s *AppState) LoadProject(filepath string) error {
 			s.Players = players
 		}
 
+		// Load quarterbacks if the project has a quarterback file
+		if quarterbacksPath := project.GetFullPath("quarterbacks"); quarterbacksPath != "" {
+			if quarterbacks, err := data.LoadQuarterbacks(quarterbacksPath); err == nil {
+				s.Quarterbacks = quarterbacks
+			}
+		}
+
 		// Load coaches
 		coachesPath := project.GetFullPath("coaches")
 		if coaches, err := data.LoadCoaches(coachesPath); err == nil {
@@ -93,10 +117,25 @@ func (s *AppState) LoadProject(filepath string) error {
 				s.ReferenceData.Teams = teams
 			}
 		}
+
+		// Load the league's salary settings
+		if infoPath := project.GetFullPath("info"); infoPath != "" {
+			if info, err := data.LoadLeagueInfo(infoPath); err == nil {
+				s.LeagueInfo = info
+			}
+		}
+
+		// Load the season schedule if the project has one
+		if schedulePath := project.GetFullPath("schedule"); schedulePath != "" {
+			if games, err := data.LoadSeasonSchedule(schedulePath); err == nil {
+				s.Schedule = games
+			}
+		}
 	}
 
-	// Mark as clean (no unsaved changes)
+	// Mark as clean (no unsaved changes) and start a new history
 	s.IsDirty = false
+	s.clearHistory()
 
 	return nil
 }
@@ -130,6 +169,13 @@ func (s *AppState) SaveProject() error {
 			return fmt.Errorf("failed to save players: %w", err)
 		}
 
+		// Save quarterbacks
+		if quarterbacksPath := s.Project.GetFullPath("quarterbacks"); quarterbacksPath != "" {
+			if err := data.SaveQuarterbacks(quarterbacksPath, s.Quarterbacks); err != nil {
+				return fmt.Errorf("failed to save quarterbacks: %w", err)
+			}
+		}
+
 		// Save coaches
 		coachesPath := s.Project.GetFullPath("coaches")
 		if err := data.SaveCoaches(coachesPath, s.Coaches); err != nil {
@@ -141,19 +187,36 @@ func (s *AppState) SaveProject() error {
 		if err := data.SaveTeams(teamsPath, s.Teams); err != nil {
 			return fmt.Errorf("failed to save teams: %w", err)
 		}
+
+		// Save the season schedule, unless it could not be loaded
+		if schedulePath := s.Project.GetFullPath("schedule"); schedulePath != "" && s.Schedule != nil {
+			if err := data.SaveSeasonSchedule(schedulePath, s.Schedule); err != nil {
+				return fmt.Errorf("failed to save schedule: %w", err)
+			}
+		}
+
+		// Save league info
+		if infoPath := s.Project.GetFullPath("info"); infoPath != "" && s.LeagueInfo != nil {
+			if err := data.SaveLeagueInfo(infoPath, s.LeagueInfo); err != nil {
+				return fmt.Errorf("failed to save league info: %w", err)
+			}
+		}
 	}
 
 	// Mark as clean (no unsaved changes)
 	s.IsDirty = false
+	s.history.saved = s.history.applied
 
 	return nil
 }
 
-// SetProject sets the current project
+// SetProject sets the current project, starting a new undo history
 func (s *AppState) SetProject(project *models.Project) {
+	defer s.notifyChange()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.Project = project
+	s.clearHistory()
 }
 
 // GetProject returns the current project (thread-safe)
@@ -163,12 +226,22 @@ func (s *AppState) GetProject() *models.Project {
 	return s.Project
 }
 
+// GetProjectPath returns the path of the .fof9proj file, or "" if the
+// project has not been saved (thread-safe)
+func (s *AppState) GetProjectPath() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ProjectPath
+}
+
 // SetPlayers sets the players data
 func (s *AppState) SetPlayers(players []models.Player) {
+	defer s.notifyChange()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.Players = players
 	s.IsDirty = true
+	s.record("Set players")
 }
 
 // GetPlayers returns the players data (thread-safe)
@@ -178,12 +251,31 @@ func (s *AppState) GetPlayers() []models.Player {
 	return s.Players
 }
 
+// SetQuarterbacks sets the quarterbacks data
+func (s *AppState) SetQuarterbacks(quarterbacks []models.Quarterback) {
+	defer s.notifyChange()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Quarterbacks = quarterbacks
+	s.IsDirty = true
+	s.record("Set quarterbacks")
+}
+
+// GetQuarterbacks returns the quarterbacks data (thread-safe)
+func (s *AppState) GetQuarterbacks() []models.Quarterback {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.Quarterbacks
+}
+
 // SetCoaches sets the coaches data
 func (s *AppState) SetCoaches(coaches []models.Coach) {
+	defer s.notifyChange()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.Coaches = coaches
 	s.IsDirty = true
+	s.record("Set coaches")
 }
 
 // GetCoaches returns the coaches data (thread-safe)
@@ -195,6 +287,7 @@ func (s *AppState) GetCoaches() []models.Coach {
 
 // SetTeams sets the teams data
 func (s *AppState) SetTeams(teams []models.Team) {
+	defer s.notifyChange()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.Teams = teams
@@ -203,6 +296,7 @@ func (s *AppState) SetTeams(teams []models.Team) {
 		s.ReferenceData.Teams = teams
 	}
 	s.IsDirty = true
+	s.record("Set teams")
 }
 
 // GetTeams returns the teams data (thread-safe)
@@ -212,6 +306,140 @@ func (s *AppState) GetTeams() []models.Team {
 	return s.Teams
 }
 
+// SetSchedule sets the season schedule
+func (s *AppState) SetSchedule(games []models.ScheduledGame) {
+	defer s.notifyChange()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Schedule = games
+	s.IsDirty = true
+	s.record("Set schedule")
+}
+
+// GetSchedule returns the season schedule (thread-safe)
+func (s *AppState) GetSchedule() []models.ScheduledGame {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.Schedule
+}
+
+// SetLeagueInfo sets the league's salary cap and minimums
+func (s *AppState) SetLeagueInfo(info *models.LeagueInfo) {
+	defer s.notifyChange()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.LeagueInfo = info
+	s.IsDirty = true
+	s.record("Set league info")
+}
+
+// GetLeagueInfo returns the league's salary cap and minimums, or nil if the
+// project has no info file (thread-safe)
+func (s *AppState) GetLeagueInfo() *models.LeagueInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.LeagueInfo
+}
+
+// LoadReferenceData loads game reference tables from a folder such as the
+// game's default_data directory. cities.csv is required; colleges.csv,
+// metro_areas.csv, league_info.csv and the rating baseline 2024_players.csv
+// are loaded when present.
+func (s *AppState) LoadReferenceData(dir string) error {
+	cities, err := data.LoadCities(filepath.Join(dir, "cities.csv"))
+	if err != nil {
+		return fmt.Errorf("failed to load reference data: %w", err)
+	}
+
+	var colleges []models.College
+	collegesPath := filepath.Join(dir, "colleges.csv")
+	if _, err := os.Stat(collegesPath); err == nil {
+		if colleges, err = data.LoadColleges(collegesPath); err != nil {
+			return fmt.Errorf("failed to load reference data: %w", err)
+		}
+	}
+
+	var metros []models.MetroArea
+	metrosPath := filepath.Join(dir, "metro_areas.csv")
+	if _, err := os.Stat(metrosPath); err == nil {
+		if metros, err = data.LoadMetroAreas(metrosPath); err != nil {
+			return fmt.Errorf("failed to load reference data: %w", err)
+		}
+	}
+
+	var structures []models.LeagueStructure
+	structuresPath := filepath.Join(dir, "league_info.csv")
+	if _, err := os.Stat(structuresPath); err == nil {
+		if structures, err = data.LoadLeagueStructures(structuresPath); err != nil {
+			return fmt.Errorf("failed to load reference data: %w", err)
+		}
+	}
+
+	var baseline []models.Player
+	baselinePath := filepath.Join(dir, RatingBaselineFile)
+	if _, err := os.Stat(baselinePath); err == nil {
+		if baseline, err = data.LoadPlayers(baselinePath); err != nil {
+			return fmt.Errorf("failed to load reference data: %w", err)
+		}
+	}
+
+	defer s.notifyChange()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ReferenceData == nil {
+		s.ReferenceData = models.NewReferenceData()
+	}
+	s.ReferenceData.SetCities(cities)
+	s.ReferenceData.SetColleges(colleges)
+	s.ReferenceData.SetMetros(metros)
+	s.ReferenceData.LeagueStructures = structures
+	s.ReferenceData.RatingBaseline = baseline
+
+	return nil
+}
+
+// GetCities returns the loaded city reference data keyed by city ID (thread-safe)
+func (s *AppState) GetCities() map[int]models.City {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ReferenceData == nil {
+		return nil
+	}
+	return s.ReferenceData.Cities
+}
+
+// GetColleges returns the loaded college reference data keyed by college ID (thread-safe)
+func (s *AppState) GetColleges() map[int]models.College {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ReferenceData == nil {
+		return nil
+	}
+	return s.ReferenceData.Colleges
+}
+
+// GetLeagueStructures returns the league formats loaded from league_info.csv (thread-safe)
+func (s *AppState) GetLeagueStructures() []models.LeagueStructure {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ReferenceData == nil {
+		return nil
+	}
+	return s.ReferenceData.LeagueStructures
+}
+
+// GetRatingBaseline returns the players of the shipped player file loaded
+// with the reference data, or nil if it was not loaded (thread-safe)
+func (s *AppState) GetRatingBaseline() []models.Player {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ReferenceData == nil {
+		return nil
+	}
+	return s.ReferenceData.RatingBaseline
+}
+
 // SetCurrentSection sets the currently active section
 func (s *AppState) SetCurrentSection(section string) {
 	s.mu.Lock()
@@ -245,13 +473,24 @@ func (s *AppState) MarkClean() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.IsDirty = false
+	s.history.saved = s.history.applied
 }
 
-// MarkDirty marks the state as having unsaved changes
+// MarkDirty marks the state as having unsaved changes, recording the edits
+// made in place as an undoable "Edit"
 func (s *AppState) MarkDirty() {
+	s.Commit("Edit")
+}
+
+// Commit records the edits made in place since the last recorded change as
+// one undoable command with the description, e.g. "Delete Player 1000 (Tom
+// Brady)", and marks the state as having unsaved changes
+func (s *AppState) Commit(description string) {
+	defer s.notifyChange()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.IsDirty = true
+	s.record(description)
 }
 
 // IsDirtyState returns whether there are unsaved changes (thread-safe)
@@ -270,14 +509,90 @@ func (s *AppState) HasProject() bool {
 
 // Reset resets the application state to initial values
 func (s *AppState) Reset() {
+	defer s.notifyChange()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.Project = nil
 	s.Players = nil
+	s.Quarterbacks = nil
 	s.Coaches = nil
 	s.Teams = nil
+	s.Schedule = nil
+	s.LeagueInfo = nil
 	s.CurrentSection = "Players"
 	s.SelectedIndex = -1
 	s.IsDirty = false
+	s.clearHistory()
+}
+
+// AddChangeListener registers a function called after every change to the
+// loaded data, from the goroutine that made it, and returns a function that
+// removes the listener again. Listeners survive Reset.
+func (s *AppState) AddChangeListener(listener func()) (remove func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changeListeners = append(s.changeListeners, listener)
+	index := len(s.changeListeners) - 1
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.changeListeners[index] = nil
+	}
+}
+
+// Revision returns a number that changes whenever the loaded data changes,
+// so a result computed from the data can tell whether it is stale (thread-safe)
+func (s *AppState) Revision() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.revision
+}
+
+// Copy returns a copy of the project and loaded data that later edits leave
+// alone, for reading on another goroutine. The reference data is shared, as
+// it is only read; the copy has no history or change listeners.
+func (s *AppState) Copy() *AppState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := s.takeSnapshot()
+	copied := &AppState{
+		ProjectPath:    s.ProjectPath,
+		Players:        snap.players,
+		Quarterbacks:   snap.quarterbacks,
+		Coaches:        snap.coaches,
+		Teams:          snap.teams,
+		Schedule:       snap.schedule,
+		LeagueInfo:     snap.info,
+		ReferenceData:  s.ReferenceData,
+		CurrentSection: s.CurrentSection,
+		SelectedIndex:  s.SelectedIndex,
+		IsDirty:        s.IsDirty,
+		revision:       s.revision,
+	}
+	if s.Project != nil {
+		project := *s.Project
+		project.CSVFiles = maps.Clone(s.Project.CSVFiles)
+		project.UserPreferences = maps.Clone(s.Project.UserPreferences)
+		project.ValidationRules = snap.rules
+		copied.Project = &project
+	}
+	return copied
+}
+
+// notifyChange bumps the revision and calls the change listeners. Setters
+// defer it before taking the lock so listeners run after the lock is released.
+func (s *AppState) notifyChange() {
+	s.mu.Lock()
+	s.revision++
+	listeners := make([]func(), len(s.changeListeners))
+	copy(listeners, s.changeListeners)
+	s.mu.Unlock()
+
+	for _, listener := range listeners {
+		if listener != nil {
+			listener()
+		}
+	}
 }
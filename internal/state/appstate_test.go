@@ -4,8 +4,11 @@
 package state
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/igorilic/fof9editor/internal/data"
 	"github.com/igorilic/fof9editor/internal/models"
 )
 
@@ -177,6 +180,63 @@ func TestSetTeams_MarksDirty(t *testing.T) {
 	}
 }
 
+func TestSetGetQuarterbacks(t *testing.T) {
+	state := GetInstance()
+	state.Reset()
+
+	state.SetQuarterbacks([]models.Quarterback{{PlayerID: 501, Team: 20, Uniform: 8}})
+
+	if got := state.GetQuarterbacks(); len(got) != 1 || got[0].PlayerID != 501 {
+		t.Errorf("Expected the quarterbacks to be stored, got %+v", got)
+	}
+	if !state.IsDirtyState() {
+		t.Error("SetQuarterbacks should mark state as dirty")
+	}
+
+	state.Reset()
+	if state.GetQuarterbacks() != nil {
+		t.Error("Reset should clear the quarterbacks")
+	}
+}
+
+func TestSetGetSchedule(t *testing.T) {
+	state := GetInstance()
+	state.Reset()
+
+	games := []models.ScheduledGame{{Season: 1, Week: 1, Year: 2024, Home: 1, Visitor: 2}}
+	state.SetSchedule(games)
+
+	if got := state.GetSchedule(); len(got) != 1 || got[0].Home != 1 {
+		t.Errorf("Expected the schedule to be stored, got %+v", got)
+	}
+	if !state.IsDirtyState() {
+		t.Error("SetSchedule should mark state as dirty")
+	}
+
+	state.Reset()
+	if state.GetSchedule() != nil {
+		t.Error("Reset should clear the schedule")
+	}
+}
+
+func TestSetGetLeagueInfo(t *testing.T) {
+	state := GetInstance()
+	state.Reset()
+
+	state.SetLeagueInfo(models.NewDefaultLeagueInfo(2024))
+	if got := state.GetLeagueInfo(); got == nil || got.SalaryCap != 2000 {
+		t.Errorf("Expected the league info to be stored, got %+v", got)
+	}
+	if !state.IsDirtyState() {
+		t.Error("SetLeagueInfo should mark state as dirty")
+	}
+
+	state.Reset()
+	if state.GetLeagueInfo() != nil {
+		t.Error("Reset should clear the league info")
+	}
+}
+
 func TestSetGetCurrentSection(t *testing.T) {
 	state := GetInstance()
 	state.Reset()
@@ -224,6 +284,38 @@ func TestMarkCleanDirty(t *testing.T) {
 	}
 }
 
+func TestChangeListener(t *testing.T) {
+	state := GetInstance()
+	state.Reset()
+
+	calls := 0
+	remove := state.AddChangeListener(func() {
+		// Listeners run after the lock is released, so they can read the state
+		_ = state.GetPlayers()
+		calls++
+	})
+
+	revision := state.Revision()
+	state.SetPlayers([]models.Player{{PlayerID: 1}})
+	state.MarkDirty()
+	state.SetCurrentSection("Teams") // UI state is not a data change
+	state.MarkClean()
+
+	if calls != 2 {
+		t.Errorf("Expected 2 change notifications, got %d", calls)
+	}
+	if state.Revision() != revision+2 {
+		t.Errorf("Expected revision %d, got %d", revision+2, state.Revision())
+	}
+
+	remove()
+	state.MarkDirty()
+	if calls != 2 {
+		t.Errorf("Expected no notification after removing the listener, got %d", calls)
+	}
+	state.Reset()
+}
+
 func TestReset(t *testing.T) {
 	state := GetInstance()
 
@@ -297,6 +389,45 @@ func TestSaveProject_Stub_WithProject(t *testing.T) {
 	}
 }
 
+func TestSaveProject_SavesLeagueInfoAndSchedule(t *testing.T) {
+	state := GetInstance()
+	state.Reset()
+
+	dir := t.TempDir()
+	project := models.NewProject("Test", "test", dir, 2024)
+	for key, name := range project.CSVFiles {
+		project.CSVFiles[key] = filepath.Join(dir, filepath.Base(name))
+	}
+	project.CSVFiles["schedule"] = filepath.Join(dir, "2024_schedule.csv")
+	state.SetProject(project)
+	state.ProjectPath = filepath.Join(dir, "test.fof9proj")
+
+	info := models.NewDefaultLeagueInfo(2024)
+	info.SalaryCap = 2500
+	state.SetLeagueInfo(info)
+	state.SetSchedule([]models.ScheduledGame{{Season: 1, Week: 1, Month: 9, Day: 8, Year: 2024, Home: 1, Visitor: 2}})
+
+	if err := state.SaveProject(); err != nil {
+		t.Fatalf("SaveProject failed: %v", err)
+	}
+
+	saved, err := data.LoadLeagueInfo(project.GetFullPath("info"))
+	if err != nil {
+		t.Fatalf("LoadLeagueInfo failed: %v", err)
+	}
+	if saved.SalaryCap != 2500 || saved.BaseYear != 2024 {
+		t.Errorf("Expected the edited league info to be saved, got %+v", saved)
+	}
+
+	games, err := data.LoadSeasonSchedule(project.GetFullPath("schedule"))
+	if err != nil {
+		t.Fatalf("LoadSeasonSchedule failed: %v", err)
+	}
+	if len(games) != 1 || games[0].Visitor != 2 {
+		t.Errorf("Expected the edited schedule to be saved, got %+v", games)
+	}
+}
+
 // Thread safety test
 func TestConcurrentAccess(t *testing.T) {
 	state := GetInstance()
@@ -341,3 +472,112 @@ func TestConcurrentAccess(t *testing.T) {
 
 	// If we get here without deadlock, the test passes
 }
+
+func TestCopy(t *testing.T) {
+	state := GetInstance()
+	state.Reset()
+	defer state.Reset()
+	state.Players = []models.Player{{PlayerID: 1000, Weight: 220}}
+	state.LeagueInfo = &models.LeagueInfo{SalaryCap: 1000}
+	state.SetProject(models.NewProject("Test", "test", "", 2024))
+	state.GetProject().ValidationRules = []models.ValidationRule{{Rule: "each player: AGE <= 35"}}
+
+	copied := state.Copy()
+	if copied == state || copied.Revision() != state.Revision() || copied.ReferenceData != state.ReferenceData {
+		t.Fatal("Expected a copy at the same revision sharing the reference data")
+	}
+
+	// Edits in place leave the copy alone
+	state.GetPlayers()[0].Weight = 230
+	state.GetLeagueInfo().SalaryCap = 2000
+	state.GetProject().ValidationRules[0].Rule = "each player: AGE <= 40"
+	state.GetProject().CSVFiles["players"] = "other.csv"
+	if copied.GetPlayers()[0].Weight != 220 || copied.GetLeagueInfo().SalaryCap != 1000 ||
+		copied.GetProject().ValidationRules[0].Rule != "each player: AGE <= 35" || copied.GetProject().CSVFiles["players"] == "other.csv" {
+		t.Errorf("Expected the copy unchanged, got %+v", copied.GetPlayers())
+	}
+}
+
+func TestLoadReferenceData(t *testing.T) {
+	state := GetInstance()
+	state.Reset()
+
+	dir := t.TempDir()
+	content := "CITYID,NAME,REGION,LATITUDE,LONGITUDE\n7215,Boston,MA,42354,-71066\n13493,New York,NY,40766,-73974\n"
+	if err := os.WriteFile(filepath.Join(dir, "cities.csv"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write cities.csv: %v", err)
+	}
+
+	if err := state.LoadReferenceData(dir); err != nil {
+		t.Fatalf("LoadReferenceData failed: %v", err)
+	}
+
+	cities := state.GetCities()
+	if len(cities) != 2 {
+		t.Fatalf("Expected 2 cities, got %d", len(cities))
+	}
+	if cities[7215].Name != "Boston" {
+		t.Errorf("Expected city 7215 to be Boston, got '%s'", cities[7215].Name)
+	}
+
+	if len(state.GetColleges()) != 0 {
+		t.Errorf("Expected no colleges without colleges.csv, got %d", len(state.GetColleges()))
+	}
+	if len(state.GetLeagueStructures()) != 0 {
+		t.Errorf("Expected no league structures without league_info.csv, got %d", len(state.GetLeagueStructures()))
+	}
+
+	structures := "SCHEDULEID,TEAMS,DIVISIONS,GAMES\n4_2_3,4,2,3\n"
+	if err := os.WriteFile(filepath.Join(dir, "league_info.csv"), []byte(structures), 0644); err != nil {
+		t.Fatalf("Failed to write league_info.csv: %v", err)
+	}
+	if err := state.LoadReferenceData(dir); err != nil {
+		t.Fatalf("LoadReferenceData with league_info.csv failed: %v", err)
+	}
+	if got := state.GetLeagueStructures(); len(got) != 1 || got[0].ScheduleID != "4_2_3" {
+		t.Errorf("Expected league structure '4_2_3', got %+v", got)
+	}
+
+	colleges := "COLLEGEID,NAME,NICKNAME\n0,No College,No Nickname\n1,Alabama,Crimson Tide\n"
+	if err := os.WriteFile(filepath.Join(dir, "colleges.csv"), []byte(colleges), 0644); err != nil {
+		t.Fatalf("Failed to write colleges.csv: %v", err)
+	}
+	if err := state.LoadReferenceData(dir); err != nil {
+		t.Fatalf("LoadReferenceData with colleges.csv failed: %v", err)
+	}
+	if got := state.GetColleges(); len(got) != 2 || got[1].Name != "Alabama" {
+		t.Errorf("Expected colleges 0 and Alabama, got %+v", got)
+	}
+
+	if state.ReferenceData.HasMetros() {
+		t.Error("Expected no metro areas without metro_areas.csv")
+	}
+	metros := "METROID,NAME,REGION,POP2019\n104,Boston,MA,2031884\n"
+	if err := os.WriteFile(filepath.Join(dir, "metro_areas.csv"), []byte(metros), 0644); err != nil {
+		t.Fatalf("Failed to write metro_areas.csv: %v", err)
+	}
+	if err := state.LoadReferenceData(dir); err != nil {
+		t.Fatalf("LoadReferenceData with metro_areas.csv failed: %v", err)
+	}
+	if got := state.ReferenceData.Metros; len(got) != 1 || got[104].Population != 2031884 {
+		t.Errorf("Expected metro area Boston, got %+v", got)
+	}
+
+	if len(state.GetRatingBaseline()) != 0 {
+		t.Errorf("Expected no rating baseline without %s", RatingBaselineFile)
+	}
+	baseline := "PLAYERID,LASTNAME,FIRSTNAME,TEAM,POSITION_KEY,OVERALLRATING\n1000,Brady,Tom,1,2,3\n"
+	if err := os.WriteFile(filepath.Join(dir, RatingBaselineFile), []byte(baseline), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", RatingBaselineFile, err)
+	}
+	if err := state.LoadReferenceData(dir); err != nil {
+		t.Fatalf("LoadReferenceData with %s failed: %v", RatingBaselineFile, err)
+	}
+	if got := state.GetRatingBaseline(); len(got) != 1 || got[0].OverallRating != 3 {
+		t.Errorf("Expected the baseline player rated 3, got %+v", got)
+	}
+
+	if err := state.LoadReferenceData(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Expected error for folder without cities.csv")
+	}
+}
@@ -0,0 +1,488 @@
+// ABOUTME: Undo and redo history of the application state
+// ABOUTME: Records each change to the loaded data as a reversible command diffed from a copy of the data
+
+package state
+
+import (
+	"slices"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// DefaultHistoryDepth is the number of commands kept for undo unless
+// SetHistoryDepth changes it
+const DefaultHistoryDepth = 100
+
+// HistoryEntry describes a recorded command, oldest first in History
+type HistoryEntry struct {
+	Description string // e.g. "Save Player 1000 (Tom Brady)"
+	Undone      bool   // True if the command was undone and can be redone
+}
+
+// command is a recorded change to the loaded data that can be undone and
+// redone. It holds one change per kind of data it touched.
+type command struct {
+	description string
+	changes     []change
+}
+
+// change reverts or repeats a command's change to one kind of data
+type change interface {
+	apply(s *AppState, undo bool)
+}
+
+// history is the undo history of the application state. Edits are made in
+// place or through the setters; recording one diffs the data with baseline,
+// the copy of the data as of the last recorded command.
+type history struct {
+	commands         []command
+	applied          int // Commands applied; the rest were undone and can be redone
+	saved            int // Commands applied when the data was saved, or -1 if no longer reachable
+	depth            int // Commands kept; 0 means DefaultHistoryDepth
+	groups           int // Nesting of open groups
+	groupDescription string
+	effects          []change // Effects recorded with the next command
+	baseline         snapshot
+}
+
+// snapshot is a copy of the loaded data that edits are diffed with
+type snapshot struct {
+	players      []models.Player
+	quarterbacks []models.Quarterback
+	coaches      []models.Coach
+	teams        []models.Team
+	schedule     []models.ScheduledGame
+	info         *models.LeagueInfo
+	rules        []models.ValidationRule
+	suppressions []string
+}
+
+// takeSnapshot copies the loaded data. Callers hold the lock.
+func (s *AppState) takeSnapshot() snapshot {
+	snap := snapshot{
+		players:      slices.Clone(s.Players),
+		quarterbacks: slices.Clone(s.Quarterbacks),
+		coaches:      slices.Clone(s.Coaches),
+		teams:        slices.Clone(s.Teams),
+		schedule:     slices.Clone(s.Schedule),
+		info:         cloneLeagueInfo(s.LeagueInfo),
+	}
+	if s.Project != nil {
+		snap.rules = slices.Clone(s.Project.ValidationRules)
+		snap.suppressions = s.Project.ValidationSuppressions()
+	}
+	return snap
+}
+
+// clearHistory forgets every command and takes the loaded data as saved.
+// Callers hold the lock.
+func (s *AppState) clearHistory() {
+	s.history.commands = nil
+	s.history.applied = 0
+	s.history.saved = 0
+	s.history.groups = 0
+	s.history.effects = nil
+	s.history.baseline = s.takeSnapshot()
+}
+
+// record adds the changes made since the last recorded command to the
+// history as one command, unless a group is open. Callers hold the lock.
+func (s *AppState) record(description string) {
+	h := &s.history
+	if h.groups > 0 {
+		return
+	}
+	changes := append(s.diff(), h.effects...)
+	h.effects = nil
+	if len(changes) == 0 {
+		return
+	}
+
+	if h.saved > h.applied {
+		h.saved = -1 // The saved data was undone and is now overwritten
+	}
+	h.commands = append(h.commands[:h.applied], command{description: description, changes: changes})
+	h.applied++
+	h.trim()
+	h.baseline = s.takeSnapshot()
+}
+
+// diff returns the changes from the baseline to the loaded data
+func (s *AppState) diff() []change {
+	base := s.history.baseline
+	var changes []change
+	changes = appendRecordsChange(changes, base.players, s.Players, func(s *AppState) *[]models.Player { return &s.Players })
+	changes = appendRecordsChange(changes, base.quarterbacks, s.Quarterbacks, func(s *AppState) *[]models.Quarterback { return &s.Quarterbacks })
+	changes = appendRecordsChange(changes, base.coaches, s.Coaches, func(s *AppState) *[]models.Coach { return &s.Coaches })
+	changes = appendRecordsChange(changes, base.teams, s.Teams, func(s *AppState) *[]models.Team { return &s.Teams })
+	changes = appendRecordsChange(changes, base.schedule, s.Schedule, func(s *AppState) *[]models.ScheduledGame { return &s.Schedule })
+
+	if !sameLeagueInfo(base.info, s.LeagueInfo) {
+		changes = append(changes, leagueInfoChange{before: base.info, after: cloneLeagueInfo(s.LeagueInfo)})
+	}
+	if s.Project != nil && !slices.Equal(base.rules, s.Project.ValidationRules) {
+		changes = append(changes, rulesChange{before: base.rules, after: slices.Clone(s.Project.ValidationRules)})
+	}
+	if s.Project != nil {
+		if suppressions := s.Project.ValidationSuppressions(); !slices.Equal(base.suppressions, suppressions) {
+			changes = append(changes, suppressionsChange{before: base.suppressions, after: suppressions})
+		}
+	}
+	return changes
+}
+
+// trim drops the oldest commands beyond the history depth
+func (h *history) trim() {
+	depth := h.depth
+	if depth == 0 {
+		depth = DefaultHistoryDepth
+	}
+	excess := len(h.commands) - depth
+	if excess <= 0 {
+		return
+	}
+	h.commands = slices.Delete(h.commands, 0, excess)
+	h.applied = max(h.applied-excess, 0)
+	if h.saved >= 0 {
+		h.saved -= excess
+		if h.saved < 0 {
+			h.saved = -1
+		}
+	}
+}
+
+// move undoes or redoes the next command and takes the result as the new
+// baseline. Callers hold the lock and check there is a command to move over.
+func (s *AppState) move(undo bool) string {
+	h := &s.history
+	var cmd command
+	if undo {
+		h.applied--
+		cmd = h.commands[h.applied]
+		for i := len(cmd.changes) - 1; i >= 0; i-- {
+			cmd.changes[i].apply(s, true)
+		}
+	} else {
+		cmd = h.commands[h.applied]
+		h.applied++
+		for _, c := range cmd.changes {
+			c.apply(s, false)
+		}
+	}
+
+	if s.ReferenceData != nil {
+		s.ReferenceData.Teams = s.Teams
+	}
+	s.IsDirty = h.applied != h.saved
+	h.baseline = s.takeSnapshot()
+	return cmd.description
+}
+
+// BeginGroup starts a compound edit. The changes made until the matching
+// EndGroup, through setters or in place, are recorded as one command with the
+// description. Groups nest; the outermost description is kept.
+func (s *AppState) BeginGroup(description string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.history.groups == 0 {
+		s.history.groupDescription = description
+	}
+	s.history.groups++
+}
+
+// RecordEffect records a change made outside the loaded data, such as renamed
+// files, with the open group, or as its own command with the description.
+// Undo and redo call apply, holding the lock, to revert or repeat it.
+func (s *AppState) RecordEffect(description string, apply func(undo bool)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history.effects = append(s.history.effects, effectChange(apply))
+	s.record(description)
+}
+
+// EndGroup ends a compound edit, recording it once the outermost group ends
+func (s *AppState) EndGroup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.history.groups == 0 {
+		return
+	}
+	s.history.groups--
+	s.record(s.history.groupDescription)
+}
+
+// Undo reverts the last command and returns its description, or false if
+// there is nothing to undo. Edits not yet recorded are recorded first, so
+// they are what is undone.
+func (s *AppState) Undo() (string, bool) {
+	moved := false
+	defer func() {
+		if moved {
+			s.notifyChange()
+		}
+	}()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.history.groups > 0 {
+		return "", false
+	}
+	s.record("Edit")
+	if s.history.applied == 0 {
+		return "", false
+	}
+	moved = true
+	return s.move(true), true
+}
+
+// Redo repeats the last undone command and returns its description, or
+// false if there is nothing to redo
+func (s *AppState) Redo() (string, bool) {
+	moved := false
+	defer func() {
+		if moved {
+			s.notifyChange()
+		}
+	}()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.history.groups > 0 || s.history.applied == len(s.history.commands) {
+		return "", false
+	}
+	moved = true
+	return s.move(false), true
+}
+
+// GoToHistory undoes or redoes commands until the given number of the
+// commands in History are applied
+func (s *AppState) GoToHistory(applied int) {
+	moved := false
+	defer func() {
+		if moved {
+			s.notifyChange()
+		}
+	}()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.history.groups > 0 || applied < 0 || applied > len(s.history.commands) {
+		return
+	}
+	for s.history.applied > applied {
+		s.move(true)
+		moved = true
+	}
+	for s.history.applied < applied {
+		s.move(false)
+		moved = true
+	}
+}
+
+// UndoDescription returns the description of the command Undo reverts, or ""
+// if there is nothing to undo (thread-safe)
+func (s *AppState) UndoDescription() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.history.applied == 0 {
+		return ""
+	}
+	return s.history.commands[s.history.applied-1].description
+}
+
+// RedoDescription returns the description of the command Redo repeats, or ""
+// if there is nothing to redo (thread-safe)
+func (s *AppState) RedoDescription() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.history.applied == len(s.history.commands) {
+		return ""
+	}
+	return s.history.commands[s.history.applied].description
+}
+
+// History returns the recorded commands, oldest first (thread-safe)
+func (s *AppState) History() []HistoryEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := make([]HistoryEntry, len(s.history.commands))
+	for i, cmd := range s.history.commands {
+		entries[i] = HistoryEntry{Description: cmd.description, Undone: i >= s.history.applied}
+	}
+	return entries
+}
+
+// HistoryDepth returns the number of commands kept for undo (thread-safe)
+func (s *AppState) HistoryDepth() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.history.depth == 0 {
+		return DefaultHistoryDepth
+	}
+	return s.history.depth
+}
+
+// SetHistoryDepth sets the number of commands kept for undo, dropping the
+// oldest ones beyond it. Depths below 1 are taken as 1.
+func (s *AppState) SetHistoryDepth(depth int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history.depth = max(depth, 1)
+	s.history.trim()
+}
+
+// splice replaces the records before, from index start on, with after
+type splice[T comparable] struct {
+	start  int
+	before []T
+	after  []T
+}
+
+// diffRecords returns the splices that turn one file's records into
+// another's: a splice per run of edited records when no record was added or
+// removed, else one splice between the common start and end
+func diffRecords[T comparable](before, after []T) []splice[T] {
+	if len(before) == len(after) {
+		var splices []splice[T]
+		for i := 0; i < len(before); i++ {
+			if before[i] == after[i] {
+				continue
+			}
+			end := i + 1
+			for end < len(before) && before[end] != after[end] {
+				end++
+			}
+			splices = append(splices, splice[T]{start: i, before: slices.Clone(before[i:end]), after: slices.Clone(after[i:end])})
+			i = end
+		}
+		return splices
+	}
+
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+	return []splice[T]{{
+		start:  prefix,
+		before: slices.Clone(before[prefix : len(before)-suffix]),
+		after:  slices.Clone(after[prefix : len(after)-suffix]),
+	}}
+}
+
+// recordsChange is a change to the records of one file
+type recordsChange[T comparable] struct {
+	records func(s *AppState) *[]T
+	splices []splice[T]
+}
+
+// appendRecordsChange appends the change between two versions of a file's
+// records, if there is one
+func appendRecordsChange[T comparable](changes []change, before, after []T, records func(s *AppState) *[]T) []change {
+	if splices := diffRecords(before, after); len(splices) > 0 {
+		changes = append(changes, recordsChange[T]{records: records, splices: splices})
+	}
+	return changes
+}
+
+// apply replaces the records of each splice. Edits keep the slice, so the
+// records handed out by the getters stay current; additions and removals
+// replace it.
+func (c recordsChange[T]) apply(s *AppState, undo bool) {
+	records := c.records(s)
+	for _, sp := range c.splices {
+		from, to := sp.before, sp.after
+		if undo {
+			from, to = to, from
+		}
+		if sp.start+len(from) > len(*records) {
+			continue
+		}
+		if len(from) == len(to) {
+			copy((*records)[sp.start:], to)
+			continue
+		}
+		*records = slices.Concat((*records)[:sp.start], to, (*records)[sp.start+len(from):])
+	}
+}
+
+// leagueInfoChange is a change to the league's salary settings
+type leagueInfoChange struct {
+	before, after *models.LeagueInfo
+}
+
+// apply restores the settings in place, so the settings handed out stay current
+func (c leagueInfoChange) apply(s *AppState, undo bool) {
+	to := c.after
+	if undo {
+		to = c.before
+	}
+	switch {
+	case to == nil:
+		s.LeagueInfo = nil
+	case s.LeagueInfo == nil:
+		s.LeagueInfo = cloneLeagueInfo(to)
+	default:
+		*s.LeagueInfo = *to
+	}
+}
+
+// rulesChange is a change to the project's house rules
+type rulesChange struct {
+	before, after []models.ValidationRule
+}
+
+func (c rulesChange) apply(s *AppState, undo bool) {
+	if s.Project == nil {
+		return
+	}
+	to := c.after
+	if undo {
+		to = c.before
+	}
+	s.Project.ValidationRules = slices.Clone(to)
+}
+
+// suppressionsChange is a change to the project's suppressed findings
+type suppressionsChange struct {
+	before, after []string
+}
+
+func (c suppressionsChange) apply(s *AppState, undo bool) {
+	if s.Project == nil {
+		return
+	}
+	to := c.after
+	if undo {
+		to = c.before
+	}
+	s.Project.SetValidationSuppressions(slices.Clone(to))
+}
+
+// effectChange is a change made outside the loaded data, see RecordEffect
+type effectChange func(undo bool)
+
+func (c effectChange) apply(s *AppState, undo bool) {
+	c(undo)
+}
+
+// cloneLeagueInfo copies league settings, keeping nil
+func cloneLeagueInfo(info *models.LeagueInfo) *models.LeagueInfo {
+	if info == nil {
+		return nil
+	}
+	copied := *info
+	return &copied
+}
+
+// sameLeagueInfo returns true if two league settings are equal or both nil
+func sameLeagueInfo(a, b *models.LeagueInfo) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,307 @@
+// ABOUTME: Tests for the undo history of the application state
+// ABOUTME: Covers interleaved edits across files, groups, redo, depth and the saved state
+
+package state
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// historyState returns a clean state with two players, a coach, a team and
+// league settings, and an empty history
+func historyState(t *testing.T) *AppState {
+	t.Helper()
+	s := GetInstance()
+	s.Reset()
+	t.Cleanup(s.Reset)
+
+	s.Players = []models.Player{{PlayerID: 1000, FirstName: "Tom"}, {PlayerID: 1001, FirstName: "Joe"}}
+	s.Coaches = []models.Coach{{FirstName: "Bill"}}
+	s.Teams = []models.Team{{TeamID: 1, TeamName: "Boston"}}
+	s.LeagueInfo = &models.LeagueInfo{SalaryCap: 1000}
+	s.SetProject(models.NewProject("Test", "test", "", 2024))
+	return s
+}
+
+func TestHistory_InterleavedEdits(t *testing.T) {
+	s := historyState(t)
+	players := s.GetPlayers()
+
+	// Edits in place, through setters and of settings, across files
+	players[0].Weight = 220
+	s.Commit("Save Player 1000 (Tom)")
+	s.SetCoaches(append(s.GetCoaches(), models.Coach{FirstName: "Don"}))
+	s.GetTeams()[0].TeamName = "New England"
+	s.Commit("Save Team 1")
+	players[1].Weight = 190
+	s.Commit("Save Player 1001 (Joe)")
+	s.GetLeagueInfo().SalaryCap = 2000
+	s.Commit("Rescale salaries")
+	s.SetPlayers(s.GetPlayers()[1:])
+	s.Commit("Delete Player 1000 (Tom)") // Already recorded by SetPlayers
+
+	history := s.History()
+	expected := []string{"Save Player 1000 (Tom)", "Set coaches", "Save Team 1", "Save Player 1001 (Joe)", "Rescale salaries", "Set players"}
+	if len(history) != len(expected) {
+		t.Fatalf("Expected %d commands, got %v", len(expected), history)
+	}
+	for i, want := range expected {
+		if history[i].Description != want || history[i].Undone {
+			t.Errorf("Command %d: expected %q, got %+v", i, want, history[i])
+		}
+	}
+
+	// Undo back to the start, one file at a time
+	if description, ok := s.Undo(); !ok || description != "Set players" || len(s.GetPlayers()) != 2 {
+		t.Fatalf("Expected the deletion undone, got %q with %d players", description, len(s.GetPlayers()))
+	}
+	s.Undo()
+	if s.GetLeagueInfo().SalaryCap != 1000 {
+		t.Errorf("Expected the salary cap restored, got %d", s.GetLeagueInfo().SalaryCap)
+	}
+	s.Undo()
+	if s.GetPlayers()[1].Weight != 0 || s.GetTeams()[0].TeamName != "New England" {
+		t.Error("Expected only Joe's edit undone")
+	}
+	s.Undo()
+	s.Undo()
+	if s.GetTeams()[0].TeamName != "Boston" || len(s.GetCoaches()) != 1 {
+		t.Error("Expected the team and coach edits undone")
+	}
+	s.Undo()
+	if s.GetPlayers()[0].Weight != 0 || s.IsDirtyState() {
+		t.Errorf("Expected the original, clean data, got %+v", s.GetPlayers())
+	}
+	if _, ok := s.Undo(); ok {
+		t.Error("Expected nothing more to undo")
+	}
+
+	// Redo everything again
+	for range expected {
+		s.Redo()
+	}
+	if len(s.GetPlayers()) != 1 || s.GetPlayers()[0].Weight != 190 || len(s.GetCoaches()) != 2 ||
+		s.GetTeams()[0].TeamName != "New England" || s.GetLeagueInfo().SalaryCap != 2000 {
+		t.Errorf("Expected every edit redone, got %+v", s.GetPlayers())
+	}
+	if s.RedoDescription() != "" || s.UndoDescription() != "Set players" {
+		t.Errorf("Unexpected undo %q and redo %q", s.UndoDescription(), s.RedoDescription())
+	}
+}
+
+func TestHistory_EditsKeepSlices(t *testing.T) {
+	s := historyState(t)
+	players := s.GetPlayers()
+
+	players[1].Weight = 190
+	s.MarkDirty()
+	s.Undo()
+
+	// Undoing an edit restores the record in the slice handed out
+	if players[1].Weight != 0 || &s.GetPlayers()[0] != &players[0] {
+		t.Error("Expected the edit undone in place")
+	}
+}
+
+func TestHistory_Groups(t *testing.T) {
+	s := historyState(t)
+
+	s.BeginGroup("Renumber player IDs")
+	s.SetPlayers([]models.Player{{PlayerID: 2000, FirstName: "Tom"}, {PlayerID: 2001, FirstName: "Joe"}})
+	s.BeginGroup("Nested")
+	s.SetQuarterbacks([]models.Quarterback{{PlayerID: 2002}})
+	s.EndGroup()
+	if len(s.History()) != 0 {
+		t.Fatal("Expected nothing recorded while the group is open")
+	}
+	if _, ok := s.Undo(); ok {
+		t.Error("Expected no undo while a group is open")
+	}
+	s.EndGroup()
+
+	history := s.History()
+	if len(history) != 1 || history[0].Description != "Renumber player IDs" {
+		t.Fatalf("Expected one grouped command, got %v", history)
+	}
+	s.Undo()
+	if s.GetPlayers()[0].PlayerID != 1000 || len(s.GetQuarterbacks()) != 0 {
+		t.Error("Expected both files restored by one undo")
+	}
+	if entries := s.History(); !entries[0].Undone {
+		t.Error("Expected the command to be listed as undone")
+	}
+}
+
+func TestHistory_NewEditDropsRedo(t *testing.T) {
+	s := historyState(t)
+
+	s.GetPlayers()[0].Weight = 220
+	s.Commit("First")
+	s.Undo()
+	s.GetPlayers()[0].Weight = 230
+	s.Commit("Second")
+
+	if history := s.History(); len(history) != 1 || history[0].Description != "Second" {
+		t.Errorf("Expected the undone command dropped, got %v", history)
+	}
+	if _, ok := s.Redo(); ok {
+		t.Error("Expected nothing to redo")
+	}
+}
+
+func TestHistory_UnrecordedEditsAreUndone(t *testing.T) {
+	s := historyState(t)
+
+	s.GetPlayers()[0].Weight = 220
+	if description, ok := s.Undo(); !ok || description != "Edit" || s.GetPlayers()[0].Weight != 0 {
+		t.Errorf("Expected the edit made without MarkDirty undone, got %q", description)
+	}
+}
+
+func TestHistory_Depth(t *testing.T) {
+	s := historyState(t)
+	if s.HistoryDepth() != DefaultHistoryDepth {
+		t.Errorf("Expected the default depth, got %d", s.HistoryDepth())
+	}
+	defer s.SetHistoryDepth(DefaultHistoryDepth)
+
+	s.SetHistoryDepth(2)
+	for weight := 200; weight < 205; weight++ {
+		s.GetPlayers()[0].Weight = weight
+		s.MarkDirty()
+	}
+	if len(s.History()) != 2 {
+		t.Fatalf("Expected 2 commands kept, got %d", len(s.History()))
+	}
+	s.Undo()
+	s.Undo()
+	if _, ok := s.Undo(); ok || s.GetPlayers()[0].Weight != 202 {
+		t.Errorf("Expected undo to stop at the oldest command kept, got weight %d", s.GetPlayers()[0].Weight)
+	}
+
+	// The saved data was dropped from the history, so it cannot be reached
+	if !s.IsDirtyState() {
+		t.Error("Expected the state to stay dirty")
+	}
+}
+
+func TestHistory_SavedState(t *testing.T) {
+	s := historyState(t)
+
+	s.GetPlayers()[0].Weight = 220
+	s.MarkDirty()
+	s.MarkClean() // As if saved
+	s.GetPlayers()[0].Weight = 230
+	s.MarkDirty()
+
+	s.Undo()
+	if s.IsDirtyState() {
+		t.Error("Expected the saved data to be clean")
+	}
+	s.Undo()
+	if !s.IsDirtyState() {
+		t.Error("Expected data older than the save to be dirty")
+	}
+	s.GoToHistory(2)
+	if s.GetPlayers()[0].Weight != 230 || !s.IsDirtyState() {
+		t.Errorf("Expected both edits redone, got weight %d", s.GetPlayers()[0].Weight)
+	}
+}
+
+func TestHistory_HouseRules(t *testing.T) {
+	s := historyState(t)
+	project := s.GetProject()
+
+	project.ValidationRules = append(project.ValidationRules, models.ValidationRule{Rule: "each player: AGE <= 35"})
+	s.Commit("Add house rule")
+	s.Undo()
+	if len(project.ValidationRules) != 0 {
+		t.Errorf("Expected the rule removed, got %v", project.ValidationRules)
+	}
+	s.Redo()
+	if len(project.ValidationRules) != 1 {
+		t.Error("Expected the rule added again")
+	}
+}
+
+func TestHistory_SuppressionsAndEffects(t *testing.T) {
+	s := historyState(t)
+	project := s.GetProject()
+	project.AddValidationSuppression("AGE_HIGH@player:1000")
+	s.Commit("Suppress finding")
+
+	var applied []bool
+	s.BeginGroup("Renumber")
+	project.RenameSuppressionRecords(map[string]string{"player:1000": "player:1001"})
+	s.RecordEffect("Rename files", func(undo bool) { applied = append(applied, undo) })
+	if len(s.History()) != 1 {
+		t.Error("Expected the effect held until the group ends")
+	}
+	s.EndGroup()
+
+	if desc, ok := s.Undo(); !ok || desc != "Renumber" {
+		t.Fatalf("Expected the group undone, got %q (%v)", desc, ok)
+	}
+	if got := project.ValidationSuppressions(); len(got) != 1 || got[0] != "AGE_HIGH@player:1000" {
+		t.Errorf("Expected the suppression renamed back, got %v", got)
+	}
+	s.Redo()
+	if got := project.ValidationSuppressions(); got[0] != "AGE_HIGH@player:1001" {
+		t.Errorf("Expected the suppression renamed again, got %v", got)
+	}
+	if !slices.Equal(applied, []bool{true, false}) {
+		t.Errorf("Expected the effect undone and redone, got %v", applied)
+	}
+}
+
+func TestHistory_ProjectStartsNewHistory(t *testing.T) {
+	s := historyState(t)
+
+	s.GetPlayers()[0].Weight = 220
+	s.MarkDirty()
+	s.SetProject(models.NewProject("Other", "other", "", 2024))
+	if len(s.History()) != 0 || s.UndoDescription() != "" {
+		t.Error("Expected a new project to start a new history")
+	}
+}
+
+func TestDiffRecords(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after []int
+		want          []splice[int]
+	}{
+		{"unchanged", []int{1, 2, 3}, []int{1, 2, 3}, nil},
+		{"edits", []int{1, 2, 3, 4}, []int{9, 2, 8, 7}, []splice[int]{{0, []int{1}, []int{9}}, {2, []int{3, 4}, []int{8, 7}}}},
+		{"removal", []int{1, 2, 3}, []int{1, 3}, []splice[int]{{1, []int{2}, []int{}}}},
+		{"addition", []int{1, 2}, []int{1, 2, 3}, []splice[int]{{2, []int{}, []int{3}}}},
+		{"repeated records", []int{1, 1, 1}, []int{1, 1}, []splice[int]{{2, []int{1}, []int{}}}},
+	}
+	for _, tt := range tests {
+		got := diffRecords(tt.before, tt.after)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i].start != tt.want[i].start || len(got[i].before) != len(tt.want[i].before) || len(got[i].after) != len(tt.want[i].after) {
+				t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+			}
+		}
+
+		// Applying the splices turns before into after and back
+		records := append([]int(nil), tt.before...)
+		change := recordsChange[int]{splices: got, records: func(*AppState) *[]int { return &records }}
+		change.apply(nil, false)
+		if len(records) != len(tt.after) || (len(records) > 0 && records[len(records)-1] != tt.after[len(tt.after)-1]) {
+			t.Errorf("%s: applying gave %v", tt.name, records)
+		}
+		change.apply(nil, true)
+		if len(records) != len(tt.before) {
+			t.Errorf("%s: reverting gave %v", tt.name, records)
+		}
+	}
+}
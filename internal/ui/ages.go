@@ -0,0 +1,155 @@
+// ABOUTME: Age distribution window for FOF9 Editor
+// ABOUTME: Shows a histogram of player ages per position for the league's base year
+
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+	"github.com/igorilic/fof9editor/internal/state"
+	"github.com/igorilic/fof9editor/internal/validation"
+)
+
+// Age columns of the histogram; younger and older players are counted in the
+// first and last columns
+const (
+	youngestAgeColumn = 21
+	oldestAgeColumn   = 36
+)
+
+// ageSummaryColumns are the columns before the age counts
+var ageSummaryColumns = []string{"Position", "Players", "Average", "Youngest", "Oldest"}
+
+// AgesView displays the age histogram per position in its own window
+type AgesView struct {
+	window       fyne.Window
+	state        *state.AppState
+	baseYear     int
+	histogram    []roster.PositionAges
+	headers      []string
+	table        *widget.Table
+	summaryLabel *widget.Label
+}
+
+// NewAgesView creates the age distribution window
+func NewAgesView(app fyne.App, appState *state.AppState) *AgesView {
+	v := &AgesView{
+		window:  app.NewWindow("Age Distribution"),
+		state:   appState,
+		headers: append([]string(nil), ageSummaryColumns...),
+	}
+	for age := youngestAgeColumn; age <= oldestAgeColumn; age++ {
+		switch age {
+		case youngestAgeColumn:
+			v.headers = append(v.headers, fmt.Sprintf("<=%d", age))
+		case oldestAgeColumn:
+			v.headers = append(v.headers, fmt.Sprintf("%d+", age))
+		default:
+			v.headers = append(v.headers, strconv.Itoa(age))
+		}
+	}
+
+	v.setupContent()
+	return v
+}
+
+// setupContent builds the window layout
+func (v *AgesView) setupContent() {
+	v.summaryLabel = widget.NewLabel("")
+
+	v.table = widget.NewTable(
+		func() (int, int) {
+			return len(v.histogram) + 1, len(v.headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Youngest")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(v.headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			label.SetText(v.cellText(id.Row-1, id.Col))
+			label.TextStyle = fyne.TextStyle{}
+		},
+	)
+	v.table.SetColumnWidth(0, 80)
+	for col := 1; col < len(ageSummaryColumns); col++ {
+		v.table.SetColumnWidth(col, 80)
+	}
+	for col := len(ageSummaryColumns); col < len(v.headers); col++ {
+		v.table.SetColumnWidth(col, 45)
+	}
+
+	top := container.NewVBox(v.summaryLabel, widget.NewSeparator())
+	v.window.SetContent(container.NewBorder(top, nil, nil, nil, v.table))
+	v.window.Resize(fyne.NewSize(1200, 700))
+
+	v.Refresh()
+}
+
+// Show displays the age distribution window
+func (v *AgesView) Show() {
+	v.window.Show()
+}
+
+// Refresh recounts the loaded players
+func (v *AgesView) Refresh() {
+	v.baseYear = validation.LeagueBaseYear(v.state)
+	v.histogram = nil
+	if v.baseYear > 0 {
+		v.histogram = roster.AgeHistogram(v.state.GetPlayers(), v.state.GetQuarterbacks(), v.baseYear)
+	}
+
+	if len(v.histogram) == 0 {
+		v.summaryLabel.SetText("Load players and teams, or open a project, to see player ages.")
+	} else {
+		players := 0
+		for _, ages := range v.histogram {
+			players += ages.Total
+		}
+		v.summaryLabel.SetText(fmt.Sprintf("Ages of %d players in the %d season", players, v.baseYear))
+	}
+	v.table.Refresh()
+}
+
+// cellText returns the text for a table cell of the histogram
+func (v *AgesView) cellText(row, col int) string {
+	if row >= len(v.histogram) {
+		return ""
+	}
+	ages := &v.histogram[row]
+
+	switch col {
+	case 0:
+		return models.GetPositionAbbr(ages.PositionKey)
+	case 1:
+		return strconv.Itoa(ages.Total)
+	case 2:
+		return fmt.Sprintf("%.1f", ages.Average())
+	case 3:
+		return strconv.Itoa(ages.Youngest)
+	case 4:
+		return strconv.Itoa(ages.Oldest)
+	}
+
+	age := youngestAgeColumn + col - len(ageSummaryColumns)
+	count := 0
+	for a, n := range ages.Counts {
+		if a == age || (age == youngestAgeColumn && a < age) || (age == oldestAgeColumn && a > age) {
+			count += n
+		}
+	}
+	if count == 0 {
+		return ""
+	}
+	return strconv.Itoa(count)
+}
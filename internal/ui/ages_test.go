@@ -0,0 +1,43 @@
+// ABOUTME: Tests for the age distribution window
+// ABOUTME: Validates the histogram rows and the bucketing of the youngest and oldest ages
+
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+func TestAgesView(t *testing.T) {
+	appState := state.GetInstance()
+	appState.Reset()
+	appState.SetProject(models.NewProject("Test League", "test", t.TempDir(), 2024))
+	appState.SetPlayers([]models.Player{
+		{PlayerID: 1000, PositionKey: models.PositionK, BirthYear: 2004},
+		{PlayerID: 1001, PositionKey: models.PositionK, BirthYear: 2001},
+		{PlayerID: 1002, PositionKey: models.PositionK, BirthYear: 1980},
+	})
+
+	v := NewAgesView(test.NewApp(), appState)
+	if len(v.histogram) != 1 || v.baseYear != 2024 {
+		t.Fatalf("Expected 1 position in 2024, got %+v in %d", v.histogram, v.baseYear)
+	}
+	if v.cellText(0, 0) != "K" || v.cellText(0, 1) != "3" || v.cellText(0, 2) != "29.0" {
+		t.Errorf("Unexpected summary: %s %s %s", v.cellText(0, 0), v.cellText(0, 1), v.cellText(0, 2))
+	}
+
+	first := len(ageSummaryColumns)
+	last := len(v.headers) - 1
+	if v.headers[first] != "<=21" || v.cellText(0, first) != "1" {
+		t.Errorf("Expected the 20-year-old in %s, got '%s'", v.headers[first], v.cellText(0, first))
+	}
+	if v.cellText(0, first+2) != "1" || v.cellText(0, first+1) != "" {
+		t.Errorf("Expected one 23-year-old only, got '%s' and '%s'", v.cellText(0, first+2), v.cellText(0, first+1))
+	}
+	if v.headers[last] != "36+" || v.cellText(0, last) != "1" {
+		t.Errorf("Expected the 44-year-old in %s, got '%s'", v.headers[last], v.cellText(0, last))
+	}
+}
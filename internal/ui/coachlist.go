@@ -5,6 +5,7 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -17,6 +18,7 @@ type CoachList struct {
 	container      *fyne.Container
 	table          *widget.Table
 	coaches        []models.Coach
+	rows           []int // Index in coaches of each row, in display order
 	headers        []string
 	selectedRow    int
 	onSelectChange func(int)
@@ -42,7 +44,7 @@ func NewCoachList() *CoachList {
 func (cl *CoachList) setupTable() {
 	cl.table = widget.NewTable(
 		func() (int, int) {
-			return len(cl.coaches) + 1, len(cl.headers) // +1 for header row
+			return len(cl.rows) + 1, len(cl.headers) // +1 for header row
 		},
 		func() fyne.CanvasObject {
 			return widget.NewLabel("Template")
@@ -67,12 +69,12 @@ func (cl *CoachList) setupTable() {
 
 			// Data rows
 			coachIdx := id.Row - 1
-			if coachIdx >= len(cl.coaches) {
+			if coachIdx >= len(cl.rows) {
 				label.SetText("")
 				return
 			}
 
-			coach := cl.coaches[coachIdx]
+			coach := cl.coaches[cl.rows[coachIdx]]
 			switch id.Col {
 			case 0:
 				label.SetText(coach.FirstName)
@@ -106,8 +108,8 @@ func (cl *CoachList) setupTable() {
 		} else {
 			// Data row clicked - select coach
 			cl.selectedRow = id.Row - 1
-			if cl.onSelectChange != nil {
-				cl.onSelectChange(cl.selectedRow)
+			if cl.onSelectChange != nil && cl.selectedRow < len(cl.rows) {
+				cl.onSelectChange(cl.rows[cl.selectedRow])
 			}
 		}
 	}
@@ -117,25 +119,20 @@ func (cl *CoachList) setupTable() {
 
 // getPositionName converts position code to display name
 func (cl *CoachList) getPositionName(position int) string {
-	switch position {
-	case models.PositionHeadCoach:
-		return "Head Coach"
-	case models.PositionOffensiveCoordinator:
-		return "Offensive Coordinator"
-	case models.PositionDefensiveCoordinator:
-		return "Defensive Coordinator"
-	case models.PositionSpecialTeamsCoordinator:
-		return "Special Teams Coordinator"
-	case models.PositionStrengthConditioning:
-		return "Strength & Conditioning"
-	default:
-		return fmt.Sprintf("Position %d", position)
+	if name := models.GetCoachPositionName(position); name != "Unknown" {
+		return name
 	}
+	return fmt.Sprintf("Position %d", position)
 }
 
 // SetCoaches updates the displayed coaches
 func (cl *CoachList) SetCoaches(coaches []models.Coach) {
 	cl.coaches = coaches
+	cl.rows = make([]int, len(coaches))
+	for i := range cl.rows {
+		cl.rows[i] = i
+	}
+	cl.sortCoaches()
 	cl.table.Refresh()
 }
 
@@ -151,20 +148,30 @@ func (cl *CoachList) GetContainer() *fyne.Container {
 
 // GetSelectedCoach returns the currently selected coach, or nil if none selected
 func (cl *CoachList) GetSelectedCoach() *models.Coach {
-	if cl.selectedRow < 0 || cl.selectedRow >= len(cl.coaches) {
+	if cl.selectedRow < 0 || cl.selectedRow >= len(cl.rows) {
 		return nil
 	}
-	return &cl.coaches[cl.selectedRow]
+	return &cl.coaches[cl.rows[cl.selectedRow]]
 }
 
-// SetOnSelectChange sets the callback for when a coach is selected
+// SetOnSelectChange sets the callback for when a coach is selected. It is
+// passed the coach's index in the coaches set, not the list row.
 func (cl *CoachList) SetOnSelectChange(callback func(int)) {
 	cl.onSelectChange = callback
 }
 
+// SelectRecord selects the coach at an index of the coaches set as if
+// clicked, scrolling it into view; an already selected row is left as is
+func (cl *CoachList) SelectRecord(index int) {
+	if row := slices.Index(cl.rows, index); row >= 0 {
+		cl.table.Select(widget.TableCellID{Row: row + 1, Col: 0})
+	}
+}
+
 // Clear removes all coaches from the list
 func (cl *CoachList) Clear() {
 	cl.coaches = []models.Coach{}
+	cl.rows = nil
 	cl.selectedRow = -1
 	cl.table.Refresh()
 }
@@ -186,24 +193,25 @@ func (cl *CoachList) SortByColumn(column int) {
 	cl.table.Refresh()
 }
 
-// sortCoaches sorts the coaches based on current sort settings
+// sortCoaches sorts the rows based on current sort settings, leaving the
+// coaches set in its file order
 func (cl *CoachList) sortCoaches() {
-	if cl.sortColumn < 0 || len(cl.coaches) == 0 {
+	if cl.sortColumn < 0 || len(cl.rows) == 0 {
 		return
 	}
 
-	for i := 0; i < len(cl.coaches)-1; i++ {
-		for j := i + 1; j < len(cl.coaches); j++ {
+	for i := 0; i < len(cl.rows)-1; i++ {
+		for j := i + 1; j < len(cl.rows); j++ {
 			if cl.compareCoach(i, j) {
-				cl.coaches[i], cl.coaches[j] = cl.coaches[j], cl.coaches[i]
+				cl.rows[i], cl.rows[j] = cl.rows[j], cl.rows[i]
 			}
 		}
 	}
 }
 
-// compareCoach compares two coaches based on current sort column
+// compareCoach compares the coaches in two rows based on current sort column
 func (cl *CoachList) compareCoach(i, j int) bool {
-	c1, c2 := cl.coaches[i], cl.coaches[j]
+	c1, c2 := cl.coaches[cl.rows[i]], cl.coaches[cl.rows[j]]
 
 	var result bool
 	switch cl.sortColumn {
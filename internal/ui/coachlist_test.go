@@ -138,8 +138,8 @@ func TestCoachList_GetPositionName(t *testing.T) {
 		{models.PositionHeadCoach, "Head Coach"},
 		{models.PositionOffensiveCoordinator, "Offensive Coordinator"},
 		{models.PositionDefensiveCoordinator, "Defensive Coordinator"},
-		{models.PositionSpecialTeamsCoordinator, "Special Teams Coordinator"},
-		{models.PositionStrengthConditioning, "Strength & Conditioning"},
+		{models.PositionAssistantCoach, "Assistant Coach"},
+		{models.PositionStrengthCoach, "Strength Coach"},
 		{99, "Position 99"},
 	}
 
@@ -150,3 +150,27 @@ func TestCoachList_GetPositionName(t *testing.T) {
 		}
 	}
 }
+
+func TestCoachList_SelectRecord(t *testing.T) {
+	cl := NewCoachList()
+	coaches := []models.Coach{{FirstName: "Bill"}, {FirstName: "Andy"}}
+	cl.SetCoaches(coaches)
+
+	selected := -1
+	cl.SetOnSelectChange(func(index int) { selected = index })
+
+	cl.SortByColumn(0)
+	cl.SelectRecord(0)
+	if selected != 0 || cl.GetSelectedCoach().FirstName != "Bill" || cl.selectedRow != 1 {
+		t.Errorf("Expected Bill in the second row selected as coach 0, got index %d row %d", selected, cl.selectedRow)
+	}
+	if coaches[0].FirstName != "Bill" {
+		t.Errorf("Expected sorting to leave the coaches in file order, got %+v", coaches)
+	}
+
+	// Coaches set again keep the sort
+	cl.SetCoaches([]models.Coach{{FirstName: "Zed"}, {FirstName: "Amy"}})
+	if cl.coaches[cl.rows[0]].FirstName != "Amy" {
+		t.Errorf("Expected the new coaches sorted, got rows %v", cl.rows)
+	}
+}
@@ -0,0 +1,182 @@
+// ABOUTME: Draft classes window for FOF9 Editor
+// ABOUTME: Lists the future draftees of the player files by BASE_YEAR with their class size and issues
+
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+	"github.com/igorilic/fof9editor/internal/state"
+	"github.com/igorilic/fof9editor/internal/validation"
+)
+
+// DraftClassesView displays the future draft classes in its own window
+type DraftClassesView struct {
+	window       fyne.Window
+	state        *state.AppState
+	baseYear     int
+	teams        int
+	classes      []roster.ProspectClass
+	class        *roster.ProspectClass
+	issues       map[roster.Member]string // Draftee issue messages by player
+	headers      []string
+	yearSelect   *widget.Select
+	table        *widget.Table
+	summaryLabel *widget.Label
+}
+
+// NewDraftClassesView creates the draft classes window
+func NewDraftClassesView(app fyne.App, appState *state.AppState) *DraftClassesView {
+	v := &DraftClassesView{
+		window:  app.NewWindow("Draft Classes"),
+		state:   appState,
+		headers: []string{"ID", "Player", "Pos", "File", "Issues"},
+	}
+
+	v.setupContent()
+	return v
+}
+
+// setupContent builds the window layout
+func (v *DraftClassesView) setupContent() {
+	v.summaryLabel = widget.NewLabel("")
+	v.summaryLabel.Wrapping = fyne.TextWrapWord
+
+	v.table = widget.NewTable(
+		func() (int, int) {
+			rows := 1
+			if v.class != nil {
+				rows += len(v.class.Members)
+			}
+			return rows, len(v.headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Quarterbacks")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(v.headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			label.SetText(v.cellText(id.Row-1, id.Col))
+			label.TextStyle = fyne.TextStyle{Bold: v.cellText(id.Row-1, len(v.headers)-1) != ""}
+		},
+	)
+	v.table.SetColumnWidth(0, 70)
+	v.table.SetColumnWidth(1, 220)
+	v.table.SetColumnWidth(2, 50)
+	v.table.SetColumnWidth(3, 110)
+	v.table.SetColumnWidth(4, 520)
+
+	v.yearSelect = widget.NewSelect([]string{}, func(string) {
+		v.showYear(v.yearSelect.SelectedIndex())
+	})
+
+	toolbar := container.NewHBox(widget.NewLabel("Class:"), v.yearSelect)
+	top := container.NewVBox(toolbar, widget.NewSeparator())
+	bottom := container.NewVBox(widget.NewSeparator(), v.summaryLabel)
+
+	v.window.SetContent(container.NewBorder(top, bottom, nil, nil, v.table))
+	v.window.Resize(fyne.NewSize(1000, 700))
+
+	v.Refresh()
+}
+
+// Show displays the draft classes window
+func (v *DraftClassesView) Show() {
+	v.window.Show()
+}
+
+// Refresh regroups the loaded players by draft class and selects the first class
+func (v *DraftClassesView) Refresh() {
+	players := v.state.GetPlayers()
+	quarterbacks := v.state.GetQuarterbacks()
+	teams := v.state.GetTeams()
+
+	v.baseYear = validation.LeagueBaseYear(v.state)
+	v.teams = len(models.TeamsForYear(teams, models.LatestTeamYear(teams)))
+
+	v.issues = make(map[roster.Member]string)
+	for _, issue := range roster.CheckProspects(players, quarterbacks, v.baseYear, 0) {
+		if previous := v.issues[issue.Member]; previous != "" {
+			v.issues[issue.Member] = previous + "; " + issue.Message()
+		} else {
+			v.issues[issue.Member] = issue.Message()
+		}
+	}
+
+	v.classes = roster.ProspectClasses(players, quarterbacks, v.baseYear)
+	options := make([]string, len(v.classes))
+	for i, class := range v.classes {
+		options[i] = strconv.Itoa(class.Year)
+	}
+	v.yearSelect.Options = options
+	v.yearSelect.Refresh()
+
+	if len(v.classes) == 0 {
+		v.showYear(-1)
+		return
+	}
+	v.yearSelect.SetSelectedIndex(0)
+}
+
+// showYear shows the draft class at an index of the classes, or none
+func (v *DraftClassesView) showYear(index int) {
+	v.class = nil
+	if index >= 0 && index < len(v.classes) {
+		v.class = &v.classes[index]
+	}
+	v.table.Refresh()
+
+	if v.class == nil {
+		if v.baseYear == 0 {
+			v.summaryLabel.SetText("Open a project or load teams to know the league's base year.")
+		} else {
+			v.summaryLabel.SetText(fmt.Sprintf("No draftees: every player has a BASE_YEAR of %d or earlier.", v.baseYear))
+		}
+		return
+	}
+
+	summary := fmt.Sprintf("%d class: %d draftees joining a league that starts in %d", v.class.Year, len(v.class.Members), v.baseYear)
+	if v.teams > 0 {
+		summary += fmt.Sprintf(", %.0f%% of a %d-player draft pool", v.class.Share(v.teams)*100, roster.DraftPoolSize(v.teams))
+		if v.class.TooLarge(v.teams) {
+			summary += fmt.Sprintf(" (over %.0f%%: the game's own draftees will lack starter potential)", roster.MaxDrafteeShare*100)
+		}
+	}
+	v.summaryLabel.SetText(summary)
+}
+
+// cellText returns the text for a table cell of the selected class
+func (v *DraftClassesView) cellText(row, col int) string {
+	if v.class == nil || row >= len(v.class.Members) {
+		return ""
+	}
+	member := v.class.Members[row]
+
+	switch col {
+	case 0:
+		return strconv.Itoa(member.PlayerID)
+	case 1:
+		return member.Name
+	case 2:
+		return models.GetPositionAbbr(member.PositionKey)
+	case 3:
+		if member.Quarterback {
+			return "Quarterbacks"
+		}
+		return "Players"
+	case 4:
+		return v.issues[member]
+	default:
+		return ""
+	}
+}
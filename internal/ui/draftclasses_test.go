@@ -0,0 +1,45 @@
+// ABOUTME: Tests for the draft classes window
+// ABOUTME: Validates the class selector, draftee rows, issues and class size summary
+
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+func TestDraftClassesView(t *testing.T) {
+	appState := state.GetInstance()
+	appState.Reset()
+	appState.SetProject(models.NewProject("Test League", "test", t.TempDir(), 2024))
+	appState.SetTeams([]models.Team{{Year: 2024, TeamID: 1}})
+	appState.SetQuarterbacks([]models.Quarterback{
+		{PlayerID: 500, FirstName: "Future", LastName: "Passer", BaseYear: 2026},
+	})
+	appState.SetPlayers([]models.Player{
+		{PlayerID: 1000, FirstName: "Roster", LastName: "Player", Team: 1, BaseYear: 2024},
+		{PlayerID: 1001, FirstName: "Early", LastName: "Signer", PositionKey: models.PositionK, Team: 1, BaseYear: 2025},
+	})
+
+	v := NewDraftClassesView(test.NewApp(), appState)
+
+	if len(v.yearSelect.Options) != 2 || v.yearSelect.Selected != "2025" {
+		t.Fatalf("Expected the 2025 and 2026 classes with 2025 selected, got %v (%s)", v.yearSelect.Options, v.yearSelect.Selected)
+	}
+	want := "draftee of the 2025 class is on team 1; draftees should have TEAM 0"
+	if v.cellText(0, 1) != "Early Signer" || v.cellText(0, 2) != "K" || v.cellText(0, 4) != want {
+		t.Errorf("Unexpected draftee: %s %s '%s'", v.cellText(0, 1), v.cellText(0, 2), v.cellText(0, 4))
+	}
+	if !strings.Contains(v.summaryLabel.Text, "4% of a 28-player draft pool") {
+		t.Errorf("Unexpected summary: %s", v.summaryLabel.Text)
+	}
+
+	v.yearSelect.SetSelectedIndex(1)
+	if v.cellText(0, 3) != "Quarterbacks" || v.cellText(0, 4) != "" {
+		t.Errorf("Unexpected 2026 draftee: %s '%s'", v.cellText(0, 3), v.cellText(0, 4))
+	}
+}
@@ -0,0 +1,188 @@
+// ABOUTME: Draft history window for FOF9 Editor
+// ABOUTME: Shows the draft board rebuilt from the player files for each YEARENTRY with its issues
+
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+// DraftHistoryView displays rebuilt draft boards in its own window
+type DraftHistoryView struct {
+	window       fyne.Window
+	state        *state.AppState
+	boards       []roster.DraftClass
+	class        *roster.DraftClass
+	issues       map[roster.Member]string // Issue messages by player
+	teamNames    map[int]string
+	headers      []string
+	yearSelect   *widget.Select
+	table        *widget.Table
+	summaryLabel *widget.Label
+}
+
+// NewDraftHistoryView creates the draft history window
+func NewDraftHistoryView(app fyne.App, appState *state.AppState) *DraftHistoryView {
+	v := &DraftHistoryView{
+		window:  app.NewWindow("Draft History"),
+		state:   appState,
+		headers: []string{"Pick", "Round", "Player", "Pos", "Team", "Issues"},
+	}
+
+	v.setupContent()
+	return v
+}
+
+// setupContent builds the window layout
+func (v *DraftHistoryView) setupContent() {
+	v.summaryLabel = widget.NewLabel("")
+	v.summaryLabel.Wrapping = fyne.TextWrapWord
+
+	v.table = widget.NewTable(
+		func() (int, int) {
+			rows := 1
+			if v.class != nil {
+				rows += len(v.class.Picks)
+			}
+			return rows, len(v.headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Supplemental")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(v.headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			label.SetText(v.cellText(id.Row-1, id.Col))
+			label.TextStyle = fyne.TextStyle{Bold: v.cellText(id.Row-1, len(v.headers)-1) != ""}
+		},
+	)
+	v.table.SetColumnWidth(0, 110)
+	v.table.SetColumnWidth(1, 60)
+	v.table.SetColumnWidth(2, 220)
+	v.table.SetColumnWidth(3, 50)
+	v.table.SetColumnWidth(4, 70)
+	v.table.SetColumnWidth(5, 520)
+
+	v.yearSelect = widget.NewSelect([]string{}, func(string) {
+		v.showYear(v.yearSelect.SelectedIndex())
+	})
+
+	toolbar := container.NewHBox(widget.NewLabel("Draft:"), v.yearSelect)
+	top := container.NewVBox(toolbar, widget.NewSeparator())
+	bottom := container.NewVBox(widget.NewSeparator(), v.summaryLabel)
+
+	v.window.SetContent(container.NewBorder(top, bottom, nil, nil, v.table))
+	v.window.Resize(fyne.NewSize(1100, 700))
+
+	v.Refresh()
+}
+
+// Show displays the draft history window
+func (v *DraftHistoryView) Show() {
+	v.window.Show()
+}
+
+// Refresh rebuilds the draft boards from the loaded players and selects the latest draft
+func (v *DraftHistoryView) Refresh() {
+	players := v.state.GetPlayers()
+	quarterbacks := v.state.GetQuarterbacks()
+	teams := v.state.GetTeams()
+	teams = models.TeamsForYear(teams, models.LatestTeamYear(teams))
+
+	v.teamNames = make(map[int]string, len(teams))
+	for _, team := range teams {
+		v.teamNames[team.TeamID] = team.Abbreviation
+	}
+
+	v.issues = make(map[roster.Member]string)
+	for _, issue := range roster.CheckDrafts(players, quarterbacks, len(teams)) {
+		if previous := v.issues[issue.Pick.Member]; previous != "" {
+			v.issues[issue.Pick.Member] = previous + "; " + issue.Message()
+		} else {
+			v.issues[issue.Pick.Member] = issue.Message()
+		}
+	}
+
+	v.boards = roster.DraftBoards(players, quarterbacks)
+	options := make([]string, len(v.boards))
+	for i, board := range v.boards {
+		options[i] = strconv.Itoa(board.Year)
+	}
+	v.yearSelect.Options = options
+	v.yearSelect.Refresh()
+
+	if len(v.boards) == 0 {
+		v.showYear(-1)
+		return
+	}
+	v.yearSelect.SetSelectedIndex(len(v.boards) - 1)
+}
+
+// showYear shows the draft board at an index of the boards, or none
+func (v *DraftHistoryView) showYear(index int) {
+	v.class = nil
+	if index >= 0 && index < len(v.boards) {
+		v.class = &v.boards[index]
+	}
+	v.table.Refresh()
+
+	if v.class == nil {
+		v.summaryLabel.SetText("Load players to see their drafts.")
+		return
+	}
+
+	flagged := 0
+	for _, pick := range v.class.Picks {
+		if v.issues[pick.Member] != "" {
+			flagged++
+		}
+	}
+	v.summaryLabel.SetText(fmt.Sprintf("%d draft: %d players drafted, %d undrafted, %d picks with issues",
+		v.class.Year, len(v.class.Picks), v.class.Undrafted, flagged))
+}
+
+// cellText returns the text for a table cell of the selected draft
+func (v *DraftHistoryView) cellText(row, col int) string {
+	if v.class == nil || row >= len(v.class.Picks) {
+		return ""
+	}
+	pick := &v.class.Picks[row]
+
+	switch col {
+	case 0:
+		if pick.Supplemental {
+			return "Supplemental"
+		}
+		return strconv.Itoa(pick.Selection)
+	case 1:
+		return strconv.Itoa(pick.Round)
+	case 2:
+		return pick.Member.Name
+	case 3:
+		return models.GetPositionAbbr(pick.Member.PositionKey)
+	case 4:
+		if pick.OriginalTeam == 0 {
+			return ""
+		}
+		if name := v.teamNames[pick.OriginalTeam]; name != "" {
+			return name
+		}
+		return strconv.Itoa(pick.OriginalTeam)
+	case 5:
+		return v.issues[pick.Member]
+	default:
+		return ""
+	}
+}
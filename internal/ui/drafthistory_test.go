@@ -0,0 +1,47 @@
+// ABOUTME: Tests for the draft history window
+// ABOUTME: Validates the draft year selector, board rows and issue column
+
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+func TestDraftHistoryView(t *testing.T) {
+	appState := state.GetInstance()
+	appState.Reset()
+	appState.SetTeams([]models.Team{
+		{Year: 2024, TeamID: 1, Abbreviation: "BUF"},
+		{Year: 2024, TeamID: 2, Abbreviation: "MIA"},
+	})
+	appState.SetQuarterbacks([]models.Quarterback{
+		{PlayerID: 500, FirstName: "Josh", LastName: "Allen", YearEntry: 2018, RoundDrafted: 1, SelectionDrafted: 1, OriginalTeam: 1},
+	})
+	appState.SetPlayers([]models.Player{
+		{PlayerID: 1000, FirstName: "Old", LastName: "Timer", YearEntry: 2010, RoundDrafted: 2, SelectionDrafted: 3, OriginalTeam: 2},
+		{PlayerID: 1001, FirstName: "Same", LastName: "Pick", PositionKey: models.PositionK, YearEntry: 2018,
+			RoundDrafted: 1, SelectionDrafted: 1},
+	})
+
+	v := NewDraftHistoryView(test.NewApp(), appState)
+
+	if len(v.yearSelect.Options) != 2 || v.yearSelect.Selected != "2018" {
+		t.Fatalf("Expected the 2010 and 2018 drafts with 2018 selected, got %v (%s)", v.yearSelect.Options, v.yearSelect.Selected)
+	}
+	if v.cellText(0, 2) != "Josh Allen" || v.cellText(0, 4) != "BUF" || v.cellText(0, 5) != "" {
+		t.Errorf("Unexpected first pick: %s %s '%s'", v.cellText(0, 2), v.cellText(0, 4), v.cellText(0, 5))
+	}
+	want := "drafted in round 1 of the 2018 draft but ORIGINALTEAM is 0; selection 1 of the 2018 draft is also held by Josh Allen"
+	if v.cellText(1, 3) != "K" || v.cellText(1, 5) != want {
+		t.Errorf("Unexpected second pick: %s '%s'", v.cellText(1, 3), v.cellText(1, 5))
+	}
+
+	v.yearSelect.SetSelectedIndex(0)
+	if v.cellText(0, 0) != "3" || v.cellText(0, 1) != "2" || v.cellText(0, 4) != "MIA" {
+		t.Errorf("Unexpected 2010 pick: %s %s %s", v.cellText(0, 0), v.cellText(0, 1), v.cellText(0, 4))
+	}
+}
@@ -7,8 +7,11 @@ import (
 	"strconv"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/models"
 )
 
 // FieldType represents the type of form field
@@ -33,9 +36,11 @@ type FieldDef struct {
 type FormView struct {
 	container    *fyne.Container
 	fields       map[string]fyne.CanvasObject
-	fieldEntries map[string]*widget.Entry  // Store entries for value retrieval
-	fieldSelects map[string]*widget.Select // Store selects for value retrieval
-	fieldErrors  map[string]*widget.Label  // Store error labels for each field
+	fieldEntries map[string]*widget.Entry   // Store entries for value retrieval
+	fieldSelects map[string]*widget.Select  // Store selects for value retrieval
+	fieldErrors  map[string]*widget.Label   // Store error labels for each field
+	fieldRows    map[string]*fyne.Container // Label and field row of each field
+	fieldLabels  map[string]*widget.Label
 	onSave       func()
 	onDelete     func()
 	onNext       func()
@@ -50,6 +55,8 @@ func NewFormView() *FormView {
 		fieldEntries: make(map[string]*widget.Entry),
 		fieldSelects: make(map[string]*widget.Select),
 		fieldErrors:  make(map[string]*widget.Label),
+		fieldRows:    make(map[string]*fyne.Container),
+		fieldLabels:  make(map[string]*widget.Label),
 	}
 
 	fv.container = container.NewVBox()
@@ -63,6 +70,8 @@ func (fv *FormView) SetFields(fieldDefs []FieldDef) {
 	fv.fieldEntries = make(map[string]*widget.Entry)
 	fv.fieldSelects = make(map[string]*widget.Select)
 	fv.fieldErrors = make(map[string]*widget.Label)
+	fv.fieldRows = make(map[string]*fyne.Container)
+	fv.fieldLabels = make(map[string]*widget.Label)
 
 	// Create form content
 	formContent := container.NewVBox()
@@ -123,6 +132,8 @@ func (fv *FormView) SetFields(fieldDefs []FieldDef) {
 
 		// Add label, field, and error label to form
 		fieldRow := container.NewBorder(nil, nil, label, nil, fieldWidget)
+		fv.fieldRows[def.Name] = fieldRow
+		fv.fieldLabels[def.Name] = label
 		formContent.Add(fieldRow)
 		formContent.Add(errorLabel)
 	}
@@ -135,6 +146,42 @@ func (fv *FormView) SetFields(fieldDefs []FieldDef) {
 	fv.container.Refresh()
 }
 
+// AddColorSwatch shows the color of three RGB number fields in a swatch at
+// the end of the red field's row, updated as the fields are edited. It
+// returns nil if the fields are not number or text fields of the form.
+func (fv *FormView) AddColorSwatch(redField, greenField, blueField string) *canvas.Rectangle {
+	row, label := fv.fieldRows[redField], fv.fieldLabels[redField]
+	entries := []*widget.Entry{fv.fieldEntries[redField], fv.fieldEntries[greenField], fv.fieldEntries[blueField]}
+	if row == nil || entries[0] == nil || entries[1] == nil || entries[2] == nil {
+		return nil
+	}
+
+	swatch := canvas.NewRectangle(models.RGB{}.Color())
+	swatch.SetMinSize(fyne.NewSize(60, 0))
+	update := func() {
+		components := make([]int, len(entries))
+		for i, entry := range entries {
+			components[i], _ = strconv.Atoi(entry.Text)
+		}
+		swatch.FillColor = models.RGB{Red: components[0], Green: components[1], Blue: components[2]}.Color()
+		swatch.Refresh()
+	}
+	for _, entry := range entries {
+		previous := entry.OnChanged
+		entry.OnChanged = func(text string) {
+			if previous != nil {
+				previous(text)
+			}
+			update()
+		}
+	}
+	update()
+
+	row.Layout = layout.NewBorderLayout(nil, nil, label, swatch)
+	row.Add(swatch)
+	return swatch
+}
+
 // AddButtons creates the button bar with navigation and action buttons
 func (fv *FormView) AddButtons() {
 	// Create buttons
@@ -78,6 +78,35 @@ func TestFormView_SetFields(t *testing.T) {
 	}
 }
 
+func TestFormView_AddColorSwatch(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	fv := NewFormView()
+	fv.SetFields([]FieldDef{
+		{Name: "red", Label: "Red", Type: FieldTypeNumber, Value: "255"},
+		{Name: "green", Label: "Green", Type: FieldTypeNumber, Value: "0"},
+		{Name: "blue", Label: "Blue", Type: FieldTypeNumber, Value: "255"},
+	})
+
+	swatch := fv.AddColorSwatch("red", "green", "blue")
+	if swatch == nil {
+		t.Fatal("AddColorSwatch returned nil")
+	}
+	if r, g, b, _ := swatch.FillColor.RGBA(); r>>8 != 255 || g>>8 != 0 || b>>8 != 255 {
+		t.Errorf("Expected a magenta swatch, got %d,%d,%d", r>>8, g>>8, b>>8)
+	}
+
+	fv.fieldEntries["green"].SetText("128")
+	if _, g, _, _ := swatch.FillColor.RGBA(); g>>8 != 128 {
+		t.Errorf("Expected the swatch to follow the green field, got %d", g>>8)
+	}
+
+	if fv.AddColorSwatch("red", "green", "missing") != nil {
+		t.Error("Expected no swatch for a missing field")
+	}
+}
+
 func TestFormView_GetFieldValue(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
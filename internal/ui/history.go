@@ -0,0 +1,148 @@
+// ABOUTME: History window for FOF9 Editor
+// ABOUTME: Lists the recorded edits for undo and redo and moves the data to any point of the history
+
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+// historyDepths are the history depths offered, in edits kept
+var historyDepths = []string{"25", "50", "100", "250", "500"}
+
+// HistoryView lists the edits of the undo history in its own window. The
+// first entry is the data before any recorded edit; clicking an entry undoes
+// or redoes the edits up to it.
+type HistoryView struct {
+	window       fyne.Window
+	state        *state.AppState
+	onMoved      func()
+	entries      []state.HistoryEntry
+	list         *widget.List
+	depthSelect  *widget.Select
+	summaryLabel *widget.Label
+	undoButton   *widget.Button
+	redoButton   *widget.Button
+}
+
+// NewHistoryView creates the history window. onMoved is called after edits
+// were undone or redone from the window.
+func NewHistoryView(app fyne.App, appState *state.AppState, onMoved func()) *HistoryView {
+	v := &HistoryView{
+		window:  app.NewWindow("History"),
+		state:   appState,
+		onMoved: onMoved,
+	}
+
+	v.setupContent()
+	return v
+}
+
+// setupContent builds the window layout and follows the history as it changes
+func (v *HistoryView) setupContent() {
+	v.list = widget.NewList(
+		func() int {
+			return len(v.entries) + 1
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("100. Delete Player Tom Brady (undone)")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(v.itemText(id))
+		},
+	)
+	// Clicking an entry moves to it; unselect so it can be clicked again
+	v.list.OnSelected = func(id widget.ListItemID) {
+		v.list.Unselect(id)
+		v.GoTo(id)
+	}
+
+	v.depthSelect = widget.NewSelect(historyDepths, func(selected string) {
+		if depth, err := strconv.Atoi(selected); err == nil && depth != v.state.HistoryDepth() {
+			v.state.SetHistoryDepth(depth)
+			v.refresh()
+		}
+	})
+	v.depthSelect.SetSelected(strconv.Itoa(v.state.HistoryDepth()))
+	v.summaryLabel = widget.NewLabel("")
+	v.undoButton = widget.NewButton("Undo", func() {
+		if _, ok := v.state.Undo(); ok {
+			v.moved()
+		}
+	})
+	v.redoButton = widget.NewButton("Redo", func() {
+		if _, ok := v.state.Redo(); ok {
+			v.moved()
+		}
+	})
+
+	depth := container.NewHBox(widget.NewLabel("Keep the last"), v.depthSelect, widget.NewLabel("edits"))
+	bottom := container.NewVBox(widget.NewSeparator(), v.summaryLabel,
+		container.NewHBox(v.undoButton, v.redoButton, widget.NewSeparator(), depth))
+	v.window.SetContent(container.NewBorder(nil, bottom, nil, nil, v.list))
+	v.window.Resize(fyne.NewSize(500, 500))
+
+	remove := v.state.AddChangeListener(v.refresh)
+	v.window.SetOnClosed(remove)
+	v.refresh()
+}
+
+// Show displays the history window
+func (v *HistoryView) Show() {
+	v.window.Show()
+}
+
+// itemText returns an entry's list text: its number and description, and
+// whether it was undone
+func (v *HistoryView) itemText(id int) string {
+	if id == 0 {
+		return "Start"
+	}
+	if id > len(v.entries) {
+		return ""
+	}
+	entry := v.entries[id-1]
+	if entry.Undone {
+		return fmt.Sprintf("%d. %s (undone)", id, entry.Description)
+	}
+	return fmt.Sprintf("%d. %s", id, entry.Description)
+}
+
+// GoTo undoes or redoes edits until the entry is the last one applied
+func (v *HistoryView) GoTo(id int) {
+	before := v.state.Revision()
+	v.state.GoToHistory(id)
+	if v.state.Revision() != before {
+		v.moved()
+	}
+}
+
+// moved tells the main window the data changed from here. The window itself
+// follows the history through its change listener.
+func (v *HistoryView) moved() {
+	if v.onMoved != nil {
+		v.onMoved()
+	}
+}
+
+// refresh lists the history and enables the moves it allows
+func (v *HistoryView) refresh() {
+	v.entries = v.state.History()
+	v.list.Refresh()
+
+	applied := 0
+	for _, entry := range v.entries {
+		if !entry.Undone {
+			applied++
+		}
+	}
+	v.summaryLabel.SetText(fmt.Sprintf("%d edits, %d undone", len(v.entries), len(v.entries)-applied))
+	setEnabled(v.undoButton, applied > 0)
+	setEnabled(v.redoButton, applied < len(v.entries))
+}
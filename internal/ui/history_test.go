@@ -0,0 +1,62 @@
+// ABOUTME: Tests for the history window
+// ABOUTME: Validates listing recorded edits, moving through the history and the history depth
+
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+func TestHistoryView(t *testing.T) {
+	appState := state.GetInstance()
+	appState.Reset()
+	defer appState.Reset()
+	defer appState.SetHistoryDepth(state.DefaultHistoryDepth)
+	appState.Players = []models.Player{{PlayerID: 1000, FirstName: "Tom", LastName: "Brady"}}
+	appState.Teams = []models.Team{{TeamID: 1, TeamName: "Boston"}}
+	appState.SetProject(models.NewProject("Test", "test", "", 2024))
+
+	moves := 0
+	v := NewHistoryView(test.NewApp(), appState, func() { moves++ })
+	if v.list.Length() != 1 || v.itemText(0) != "Start" || !v.undoButton.Disabled() || !v.redoButton.Disabled() {
+		t.Fatal("Expected an empty history")
+	}
+
+	// Edits elsewhere show up as they are recorded
+	appState.GetPlayers()[0].Weight = 220
+	appState.Commit("Save Player Tom Brady")
+	appState.GetTeams()[0].TeamName = "New England"
+	appState.Commit("Save Team New England")
+	if v.list.Length() != 3 || v.itemText(2) != "2. Save Team New England" || v.undoButton.Disabled() {
+		t.Fatalf("Expected 2 edits listed, got %q", v.itemText(2))
+	}
+
+	// Clicking the start undoes both edits
+	v.list.Select(0)
+	if appState.GetPlayers()[0].Weight != 0 || appState.GetTeams()[0].TeamName != "Boston" || moves != 1 {
+		t.Error("Expected both edits undone")
+	}
+	if v.itemText(1) != "1. Save Player Tom Brady (undone)" || v.summaryLabel.Text != "2 edits, 2 undone" || v.redoButton.Disabled() {
+		t.Errorf("Expected the edits listed as undone, got %q", v.itemText(1))
+	}
+
+	// Clicking the current entry changes nothing
+	v.list.Select(0)
+	if moves != 1 {
+		t.Error("Expected no move to the current entry")
+	}
+
+	v.redoButton.OnTapped()
+	if appState.GetPlayers()[0].Weight != 220 || moves != 2 {
+		t.Error("Expected the first edit redone")
+	}
+
+	v.depthSelect.SetSelected("25")
+	if appState.HistoryDepth() != 25 {
+		t.Errorf("Expected a depth of 25, got %d", appState.HistoryDepth())
+	}
+}
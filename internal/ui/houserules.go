@@ -0,0 +1,321 @@
+// ABOUTME: House rules window for FOF9 Editor
+// ABOUTME: Edits the project's custom validation rules with a live syntax check of each rule
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+	"github.com/igorilic/fof9editor/internal/validation"
+)
+
+// ruleSeverities are the severities offered for a rule, most severe first
+var ruleSeverities = []string{"error", "warning", "info"}
+
+// HouseRulesView edits the project's custom validation rules in its own window
+type HouseRulesView struct {
+	window         fyne.Window
+	state          *state.AppState
+	onChanged      func()
+	selected       int
+	list           *widget.List
+	nameEntry      *widget.Entry
+	ruleEntry      *widget.Entry
+	severitySelect *widget.Select
+	messageEntry   *widget.Entry
+	syntaxLabel    *widget.Label
+	summaryLabel   *widget.Label
+	addButton      *widget.Button
+	updateButton   *widget.Button
+	deleteButton   *widget.Button
+}
+
+// NewHouseRulesView creates the house rules window. onChanged is called after
+// the project's rules have been added to, updated or deleted.
+func NewHouseRulesView(app fyne.App, appState *state.AppState, onChanged func()) *HouseRulesView {
+	v := &HouseRulesView{
+		window:    app.NewWindow("House Rules"),
+		state:     appState,
+		onChanged: onChanged,
+		selected:  -1,
+	}
+
+	v.setupContent()
+	return v
+}
+
+// setupContent builds the window layout
+func (v *HouseRulesView) setupContent() {
+	v.list = widget.NewList(
+		func() int {
+			return len(v.rules())
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("RULE_EVERY_TEAM_NEEDS_THREE_QBS")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(v.itemText(id))
+		},
+	)
+	v.list.OnSelected = func(id widget.ListItemID) {
+		v.Select(id)
+	}
+	v.list.OnUnselected = func(widget.ListItemID) {
+		v.selected = -1
+		v.updateButtons()
+	}
+
+	v.nameEntry = widget.NewEntry()
+	v.nameEntry.SetPlaceHolder("Three QBs")
+	v.ruleEntry = widget.NewEntry()
+	v.ruleEntry.SetPlaceHolder("per team: count(POSITION = 'QB') >= 3")
+	v.ruleEntry.OnChanged = func(string) {
+		v.checkSyntax()
+	}
+	v.severitySelect = widget.NewSelect(ruleSeverities, nil)
+	v.severitySelect.SetSelected("warning")
+	v.messageEntry = widget.NewEntry()
+	v.messageEntry.SetPlaceHolder("Optional, shown instead of the rule")
+	v.syntaxLabel = widget.NewLabel("")
+	v.syntaxLabel.Wrapping = fyne.TextWrapWord
+	v.summaryLabel = widget.NewLabel("")
+	v.summaryLabel.Wrapping = fyne.TextWrapWord
+
+	v.addButton = widget.NewButton("Add", func() {
+		v.showError(v.Add())
+	})
+	v.updateButton = widget.NewButton("Update", func() {
+		v.showError(v.Update())
+	})
+	v.deleteButton = widget.NewButton("Delete", func() {
+		v.confirmDelete()
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem("Name", v.nameEntry),
+		widget.NewFormItem("Rule", v.ruleEntry),
+		widget.NewFormItem("Severity", v.severitySelect),
+		widget.NewFormItem("Message", v.messageEntry),
+	)
+	help := widget.NewLabel("Rules start with \"each player|coach|team\" or \"per team|position\", " +
+		"optionally followed by \"where\" and a condition, then \":\" and what must hold. " +
+		"Per rules use count(...), sum, avg, min and max, e.g. per team: count(OVERALLRATING >= 8) <= 2.")
+	help.Wrapping = fyne.TextWrapWord
+
+	buttons := container.NewHBox(v.addButton, v.updateButton, v.deleteButton)
+	editor := container.NewVBox(form, v.syntaxLabel, buttons, widget.NewSeparator(), help)
+	split := container.NewHSplit(v.list, container.NewVScroll(editor))
+	split.SetOffset(0.35)
+
+	bottom := container.NewVBox(widget.NewSeparator(), v.summaryLabel)
+	v.window.SetContent(container.NewBorder(nil, bottom, nil, nil, split))
+	v.window.Resize(fyne.NewSize(900, 500))
+
+	v.checkSyntax()
+	v.refresh()
+}
+
+// Show displays the house rules window
+func (v *HouseRulesView) Show() {
+	v.window.Show()
+}
+
+// rules returns the open project's rules
+func (v *HouseRulesView) rules() []models.ValidationRule {
+	if project := v.state.GetProject(); project != nil {
+		return project.ValidationRules
+	}
+	return nil
+}
+
+// itemText returns a rule's list entry: its code and rule
+func (v *HouseRulesView) itemText(index int) string {
+	rules := v.rules()
+	if index < 0 || index >= len(rules) {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s", validation.RuleCode(rules[index], index), rules[index].Rule)
+}
+
+// Select loads a rule into the editor
+func (v *HouseRulesView) Select(index int) {
+	rules := v.rules()
+	if index < 0 || index >= len(rules) {
+		return
+	}
+	v.selected = index
+	rule := rules[index]
+	v.nameEntry.SetText(rule.Name)
+	v.ruleEntry.SetText(rule.Rule)
+	severity := rule.Severity
+	if severity == "" {
+		severity = "warning"
+	}
+	v.severitySelect.SetSelected(severity)
+	v.messageEntry.SetText(rule.Message)
+	v.updateButtons()
+}
+
+// checkSyntax shows whether the rule being edited is well formed
+func (v *HouseRulesView) checkSyntax() {
+	text := strings.TrimSpace(v.ruleEntry.Text)
+	switch err := validation.ParseRule(text); {
+	case text == "":
+		v.syntaxLabel.SetText("Enter a rule, e.g. each player: AGE <= 35")
+	case err != nil:
+		v.syntaxLabel.SetText("Malformed rule: " + err.Error())
+	default:
+		v.syntaxLabel.SetText("Rule is well formed.")
+	}
+	v.updateButtons()
+}
+
+// editedRule returns the rule in the editor, or an error if it is malformed
+func (v *HouseRulesView) editedRule() (models.ValidationRule, error) {
+	rule := models.ValidationRule{
+		Name:     strings.TrimSpace(v.nameEntry.Text),
+		Rule:     strings.TrimSpace(v.ruleEntry.Text),
+		Severity: v.severitySelect.Selected,
+		Message:  strings.TrimSpace(v.messageEntry.Text),
+	}
+	if rule.Severity == "warning" {
+		rule.Severity = ""
+	}
+	if err := validation.ParseRule(rule.Rule); err != nil {
+		return rule, fmt.Errorf("the rule is malformed: %w", err)
+	}
+	return rule, nil
+}
+
+// Add appends the rule in the editor to the project
+func (v *HouseRulesView) Add() error {
+	project := v.state.GetProject()
+	if project == nil {
+		return fmt.Errorf("open or create a project to store house rules")
+	}
+	rule, err := v.editedRule()
+	if err != nil {
+		return err
+	}
+
+	project.ValidationRules = append(project.ValidationRules, rule)
+	v.changed("Add house rule " + validation.RuleCode(rule, len(project.ValidationRules)-1))
+	v.list.Select(len(project.ValidationRules) - 1)
+	return nil
+}
+
+// Update replaces the selected rule with the rule in the editor
+func (v *HouseRulesView) Update() error {
+	project := v.state.GetProject()
+	if project == nil || v.selected < 0 || v.selected >= len(project.ValidationRules) {
+		return fmt.Errorf("select a rule to update")
+	}
+	rule, err := v.editedRule()
+	if err != nil {
+		return err
+	}
+
+	project.ValidationRules[v.selected] = rule
+	v.changed("Update house rule " + validation.RuleCode(rule, v.selected))
+	return nil
+}
+
+// confirmDelete asks before deleting the selected rule
+func (v *HouseRulesView) confirmDelete() {
+	if v.selected < 0 {
+		return
+	}
+	message := fmt.Sprintf("Delete %s?", v.itemText(v.selected))
+	dialog.ShowConfirm("Delete House Rule", message, func(confirmed bool) {
+		if confirmed {
+			v.Delete()
+		}
+	}, v.window)
+}
+
+// Delete removes the selected rule from the project
+func (v *HouseRulesView) Delete() {
+	project := v.state.GetProject()
+	if project == nil || v.selected < 0 || v.selected >= len(project.ValidationRules) {
+		return
+	}
+
+	description := "Delete house rule " + validation.RuleCode(project.ValidationRules[v.selected], v.selected)
+	project.ValidationRules = append(project.ValidationRules[:v.selected], project.ValidationRules[v.selected+1:]...)
+	if len(project.ValidationRules) == 0 {
+		project.ValidationRules = nil
+	}
+	v.selected = -1
+	v.list.UnselectAll()
+	v.changed(description)
+}
+
+// changed records an edit of the rules for undo, marking the project dirty,
+// and refreshes the list and summary
+func (v *HouseRulesView) changed(description string) {
+	v.state.Commit(description)
+	v.refresh()
+	if v.onChanged != nil {
+		v.onChanged()
+	}
+}
+
+// refresh redraws the list and counts the findings of the rules
+func (v *HouseRulesView) refresh() {
+	v.list.Refresh()
+	v.updateButtons()
+
+	rules := v.rules()
+	if v.state.GetProject() == nil {
+		v.summaryLabel.SetText("Open or create a project to store house rules.")
+		return
+	}
+	if len(rules) == 0 {
+		v.summaryLabel.SetText("The project has no house rules.")
+		return
+	}
+
+	findings := 0
+	for _, finding := range validation.ValidateProject(v.state, v.state.ReferenceData).Errors {
+		if strings.HasPrefix(finding.Code, "RULE_") {
+			findings++
+		}
+	}
+	v.summaryLabel.SetText(fmt.Sprintf("%d house rules, %d findings. Save the project to keep them.", len(rules), findings))
+}
+
+// updateButtons enables the actions that apply to the editor and selection
+func (v *HouseRulesView) updateButtons() {
+	if v.addButton == nil {
+		return
+	}
+	valid := validation.ParseRule(strings.TrimSpace(v.ruleEntry.Text)) == nil && v.state.GetProject() != nil
+	selected := v.selected >= 0 && v.selected < len(v.rules())
+
+	setEnabled(v.addButton, valid)
+	setEnabled(v.updateButton, valid && selected)
+	setEnabled(v.deleteButton, selected)
+}
+
+// showError shows an error in a dialog, if there is one
+func (v *HouseRulesView) showError(err error) {
+	if err != nil {
+		dialog.ShowError(err, v.window)
+	}
+}
+
+// setEnabled enables or disables a button
+func setEnabled(button *widget.Button, enabled bool) {
+	if enabled {
+		button.Enable()
+	} else {
+		button.Disable()
+	}
+}
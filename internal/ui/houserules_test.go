@@ -0,0 +1,74 @@
+// ABOUTME: Tests for the house rules window
+// ABOUTME: Validates the syntax check and adding, updating and deleting project rules
+
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+func TestHouseRulesView_EditRules(t *testing.T) {
+	appState := state.GetInstance()
+	appState.Reset()
+	defer appState.Reset()
+	appState.SetPlayers([]models.Player{
+		{PlayerID: 1000, FirstName: "Old", LastName: "Timer", Team: 1, BirthYear: 1930},
+	})
+	project := models.NewProject("Test", "test", "", 1975)
+	appState.SetProject(project)
+
+	changes := 0
+	v := NewHouseRulesView(test.NewApp(), appState, func() { changes++ })
+	if !v.addButton.Disabled() || !v.deleteButton.Disabled() {
+		t.Error("Expected no actions without a rule")
+	}
+
+	// Malformed rules are reported as they are typed and cannot be added
+	v.ruleEntry.SetText("each player: AGEE <= 35")
+	if !strings.Contains(v.syntaxLabel.Text, "column 14: player has no column AGEE") || !v.addButton.Disabled() {
+		t.Errorf("Expected a syntax error, got %q", v.syntaxLabel.Text)
+	}
+	if err := v.Add(); err == nil {
+		t.Error("Expected adding a malformed rule to fail")
+	}
+
+	v.nameEntry.SetText("No veterans")
+	v.ruleEntry.SetText("each player: AGE <= 35")
+	if v.syntaxLabel.Text != "Rule is well formed." || v.addButton.Disabled() {
+		t.Errorf("Expected the rule to be accepted, got %q", v.syntaxLabel.Text)
+	}
+	if err := v.Add(); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if len(project.ValidationRules) != 1 || project.ValidationRules[0].Severity != "" || !appState.IsDirtyState() || changes != 1 {
+		t.Fatalf("Expected a warning rule in the dirty project, got %+v", project.ValidationRules)
+	}
+	if v.itemText(0) != "RULE_NO_VETERANS: each player: AGE <= 35" || v.selected != 0 {
+		t.Errorf("Expected the new rule to be selected, got %q", v.itemText(0))
+	}
+	if !strings.HasPrefix(v.summaryLabel.Text, "1 house rules, 1 findings") {
+		t.Errorf("Expected the old player to break the rule, got %q", v.summaryLabel.Text)
+	}
+
+	v.severitySelect.SetSelected("error")
+	v.ruleEntry.SetText("each player: AGE <= 50")
+	if err := v.Update(); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if rule := project.ValidationRules[0]; rule.Severity != "error" || rule.Rule != "each player: AGE <= 50" {
+		t.Errorf("Expected the rule to be updated, got %+v", rule)
+	}
+
+	v.Delete()
+	if project.ValidationRules != nil || v.selected != -1 || changes != 3 {
+		t.Errorf("Expected the rule to be deleted, got %+v", project.ValidationRules)
+	}
+	if v.summaryLabel.Text != "The project has no house rules." {
+		t.Errorf("Unexpected summary %q", v.summaryLabel.Text)
+	}
+}
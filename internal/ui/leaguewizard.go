@@ -0,0 +1,662 @@
+// ABOUTME: League structure creation wizard for FOF9 Editor
+// ABOUTME: Guides through format, names, team assignment and schedule, then writes all league files together
+
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/league"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+	"github.com/igorilic/fof9editor/internal/validation"
+)
+
+// Wizard steps
+const (
+	wizardStepFormat = iota
+	wizardStepNames
+	wizardStepTeams
+	wizardStepSchedule
+)
+
+// Schedule source options for the last wizard step
+const (
+	scheduleOptionGenerate = "Generate round-robin schedule"
+	scheduleOptionExisting = "Use existing schedule template"
+)
+
+// wizardTeamRow holds the entries used to edit one team in the wizard
+type wizardTeamRow struct {
+	name, nickname, abbreviation, city *widget.Entry
+	division                           *widget.Select
+}
+
+// LeagueWizard creates a new league structure in four steps
+type LeagueWizard struct {
+	window    fyne.Window
+	state     *state.AppState
+	plan      *league.Plan
+	step      int
+	stepLabel *widget.Label
+	body      *fyne.Container
+	backBtn   *widget.Button
+	nextBtn   *widget.Button
+
+	// Step 1: format
+	teamsEntry, divisionsEntry, playoffEntry *widget.Entry
+	gamesEntry, weeksEntry                   *widget.Entry
+	exGamesEntry, exWeeksEntry               *widget.Entry
+
+	// Step 2: names
+	conf1Entry, conf1AbbrEntry *widget.Entry
+	conf2Entry, conf2AbbrEntry *widget.Entry
+	championshipEntry          *widget.Entry
+	divisionNames              []*widget.Entry
+	divisionTeams              []*widget.Entry
+	playoffPreview             *widget.Label
+
+	// Step 3: teams
+	teamRows []wizardTeamRow
+
+	// Step 4: schedule and output
+	scheduleIDEntry *widget.Entry
+	scheduleSource  *widget.RadioGroup
+	templatePath    string
+	outputDir       string
+	infoPath        string
+	scheduleLabel   *widget.Label
+}
+
+// NewLeagueWizard creates the league structure wizard window
+func NewLeagueWizard(app fyne.App, appState *state.AppState) *LeagueWizard {
+	w := &LeagueWizard{
+		window: app.NewWindow("New League Structure"),
+		state:  appState,
+		plan:   league.NewPlan(models.NewLeagueStructure(32, 8, 17), time.Now().Year()),
+	}
+
+	w.setupContent()
+	return w
+}
+
+// setupContent builds the window layout with navigation buttons
+func (w *LeagueWizard) setupContent() {
+	w.stepLabel = widget.NewLabel("")
+	w.stepLabel.TextStyle = fyne.TextStyle{Bold: true}
+	w.body = container.NewMax()
+
+	w.backBtn = widget.NewButton("Back", func() {
+		w.goToStep(w.step - 1)
+	})
+	w.nextBtn = widget.NewButton("Next", func() {
+		w.onNext()
+	})
+	cancelBtn := widget.NewButton("Cancel", func() {
+		w.window.Close()
+	})
+
+	buttons := container.NewHBox(cancelBtn, widget.NewSeparator(), w.backBtn, w.nextBtn)
+	top := container.NewVBox(w.stepLabel, widget.NewSeparator())
+	bottom := container.NewVBox(widget.NewSeparator(), container.NewCenter(buttons))
+
+	w.window.SetContent(container.NewBorder(top, bottom, nil, nil, w.body))
+	w.window.Resize(fyne.NewSize(900, 700))
+	w.goToStep(wizardStepFormat)
+}
+
+// Show displays the wizard window
+func (w *LeagueWizard) Show() {
+	w.window.Show()
+}
+
+// goToStep rebuilds the body for a step from the current plan
+func (w *LeagueWizard) goToStep(step int) {
+	if step < wizardStepFormat || step > wizardStepSchedule {
+		return
+	}
+	w.step = step
+
+	var title string
+	var content fyne.CanvasObject
+	switch step {
+	case wizardStepFormat:
+		title, content = "Step 1 of 4: League Format", w.buildFormatStep()
+	case wizardStepNames:
+		title, content = "Step 2 of 4: Conferences and Divisions", w.buildNamesStep()
+	case wizardStepTeams:
+		title, content = "Step 3 of 4: Assign Teams", w.buildTeamsStep()
+	case wizardStepSchedule:
+		title, content = "Step 4 of 4: Schedule", w.buildScheduleStep()
+	}
+
+	w.stepLabel.SetText(title)
+	w.body.Objects = []fyne.CanvasObject{container.NewVScroll(content)}
+	w.body.Refresh()
+
+	if step == wizardStepFormat {
+		w.backBtn.Disable()
+	} else {
+		w.backBtn.Enable()
+	}
+	if step == wizardStepSchedule {
+		w.nextBtn.SetText("Create League")
+	} else {
+		w.nextBtn.SetText("Next")
+	}
+}
+
+// onNext applies the current step and moves on, or finishes on the last step
+func (w *LeagueWizard) onNext() {
+	var err error
+	switch w.step {
+	case wizardStepFormat:
+		err = w.applyFormat()
+	case wizardStepNames:
+		err = w.applyNames()
+	case wizardStepTeams:
+		err = w.applyTeams()
+	case wizardStepSchedule:
+		w.finish()
+		return
+	}
+
+	if err != nil {
+		dialog.ShowError(err, w.window)
+		return
+	}
+	w.goToStep(w.step + 1)
+}
+
+// buildFormatStep creates the entries for team, division, playoff, game and week counts
+func (w *LeagueWizard) buildFormatStep() fyne.CanvasObject {
+	s := w.plan.Structure
+	newEntry := func(value int) *widget.Entry {
+		entry := widget.NewEntry()
+		entry.SetText(strconv.Itoa(value))
+		return entry
+	}
+
+	w.teamsEntry = newEntry(s.Teams)
+	w.divisionsEntry = newEntry(s.Divisions)
+	w.playoffEntry = newEntry(s.PlayoffTeams)
+	w.gamesEntry = newEntry(s.Games)
+	w.weeksEntry = newEntry(s.Weeks)
+	w.exGamesEntry = newEntry(s.ExGames)
+	w.exWeeksEntry = newEntry(s.ExWeeks)
+
+	help := widget.NewLabel("Divisions must be even (at most 8) with at most 8 teams each. " +
+		"Playoff teams must be even and less than the number of teams.")
+	help.Wrapping = fyne.TextWrapWord
+
+	return container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem("Teams", w.teamsEntry),
+			widget.NewFormItem("Divisions", w.divisionsEntry),
+			widget.NewFormItem("Playoff Teams", w.playoffEntry),
+			widget.NewFormItem("Regular Season Games", w.gamesEntry),
+			widget.NewFormItem("Regular Season Weeks", w.weeksEntry),
+			widget.NewFormItem("Exhibition Games", w.exGamesEntry),
+			widget.NewFormItem("Exhibition Weeks", w.exWeeksEntry),
+		),
+		help,
+	)
+}
+
+// applyFormat updates the plan from the format step
+func (w *LeagueWizard) applyFormat() error {
+	values := make(map[string]int)
+	for _, field := range []struct {
+		label string
+		entry *widget.Entry
+	}{
+		{"Teams", w.teamsEntry},
+		{"Divisions", w.divisionsEntry},
+		{"Playoff Teams", w.playoffEntry},
+		{"Regular Season Games", w.gamesEntry},
+		{"Regular Season Weeks", w.weeksEntry},
+		{"Exhibition Games", w.exGamesEntry},
+		{"Exhibition Weeks", w.exWeeksEntry},
+	} {
+		value, err := strconv.Atoi(strings.TrimSpace(field.entry.Text))
+		if err != nil {
+			return fmt.Errorf("%s must be a number", field.label)
+		}
+		values[field.label] = value
+	}
+
+	old := w.plan.Structure
+	structure := models.NewLeagueStructure(values["Teams"], values["Divisions"], values["Regular Season Games"])
+	structure.PlayoffTeams = values["Playoff Teams"]
+	structure.Weeks = values["Regular Season Weeks"]
+	structure.ExGames = values["Exhibition Games"]
+	structure.ExWeeks = values["Exhibition Weeks"]
+
+	// Keep names entered earlier when the layout is unchanged
+	structure.Conf1, structure.Conf1Abbr = old.Conf1, old.Conf1Abbr
+	structure.Conf2, structure.Conf2Abbr = old.Conf2, old.Conf2Abbr
+	structure.Championship = old.Championship
+	if structure.Teams == old.Teams && structure.Divisions == old.Divisions {
+		for i := 1; i <= structure.Divisions; i++ {
+			structure.SetDivision(i, old.GetDivisionName(i), old.GetDivisionTeams(i))
+		}
+	}
+
+	if result := validation.ValidateLeagueStructure(structure); !result.Valid {
+		return validationErrorSummary(result)
+	}
+
+	teamsChanged := structure.Teams != old.Teams || structure.Divisions != old.Divisions
+	w.plan.Structure = structure
+	w.plan.Schedule = nil
+	if teamsChanged || len(w.plan.Teams) != structure.Teams {
+		w.plan.Teams = w.initialTeams(structure.Teams)
+		league.AssignDivisions(w.plan.Teams, structure)
+	} else {
+		w.plan.SetScheduleID(structure.ScheduleID)
+	}
+
+	return nil
+}
+
+// initialTeams uses the loaded teams when their count matches, or placeholders
+func (w *LeagueWizard) initialTeams(count int) []models.DefaultTeam {
+	loaded := w.state.GetTeams()
+	loaded = models.TeamsForYear(loaded, models.LatestTeamYear(loaded))
+	if len(loaded) != count {
+		return league.PlaceholderTeams(count)
+	}
+
+	teams := make([]models.DefaultTeam, count)
+	for i, team := range loaded {
+		teams[i] = models.DefaultTeamFromTeam("", team)
+	}
+	return teams
+}
+
+// buildNamesStep creates the conference and division entries
+func (w *LeagueWizard) buildNamesStep() fyne.CanvasObject {
+	s := w.plan.Structure
+	newEntry := func(value string) *widget.Entry {
+		entry := widget.NewEntry()
+		entry.SetText(value)
+		return entry
+	}
+
+	w.conf1Entry, w.conf1AbbrEntry = newEntry(s.Conf1), newEntry(s.Conf1Abbr)
+	w.conf2Entry, w.conf2AbbrEntry = newEntry(s.Conf2), newEntry(s.Conf2Abbr)
+	w.championshipEntry = newEntry(s.Championship)
+
+	form := widget.NewForm(
+		widget.NewFormItem("Conference 1", container.NewGridWithColumns(2, w.conf1Entry, w.conf1AbbrEntry)),
+		widget.NewFormItem("Conference 2", container.NewGridWithColumns(2, w.conf2Entry, w.conf2AbbrEntry)),
+		widget.NewFormItem("Championship", w.championshipEntry),
+	)
+
+	w.divisionNames = make([]*widget.Entry, s.Divisions)
+	w.divisionTeams = make([]*widget.Entry, s.Divisions)
+	dpc := s.DivisionsPerConference()
+	for i := 0; i < s.Divisions; i++ {
+		w.divisionNames[i] = newEntry(s.GetDivisionName(i + 1))
+		w.divisionTeams[i] = newEntry(strconv.Itoa(s.GetDivisionTeams(i + 1)))
+		label := fmt.Sprintf("Conference %d, Division %d", i/dpc+1, i%dpc+1)
+		form.Append(label, container.NewGridWithColumns(2, w.divisionNames[i], w.divisionTeams[i]))
+	}
+
+	w.playoffPreview = widget.NewLabel("")
+	w.playoffPreview.TextStyle = fyne.TextStyle{Monospace: true}
+	previewBtn := widget.NewButton("Update Playoff Preview", func() {
+		w.updatePlayoffPreview()
+	})
+	w.updatePlayoffPreview()
+
+	return container.NewVBox(
+		form,
+		widget.NewSeparator(),
+		boldLabel(fmt.Sprintf("Playoff Preview (%d teams)", s.PlayoffTeams)),
+		previewBtn,
+		w.playoffPreview,
+	)
+}
+
+// namesStructure returns a copy of the plan's structure with the names step's
+// entries applied, and whether any division team counts changed
+func (w *LeagueWizard) namesStructure() (*models.LeagueStructure, bool, error) {
+	s := *w.plan.Structure
+	s.Conf1, s.Conf1Abbr = strings.TrimSpace(w.conf1Entry.Text), strings.TrimSpace(w.conf1AbbrEntry.Text)
+	s.Conf2, s.Conf2Abbr = strings.TrimSpace(w.conf2Entry.Text), strings.TrimSpace(w.conf2AbbrEntry.Text)
+	s.Championship = strings.TrimSpace(w.championshipEntry.Text)
+
+	countsChanged := false
+	for i := range w.divisionNames {
+		teams, err := strconv.Atoi(strings.TrimSpace(w.divisionTeams[i].Text))
+		if err != nil {
+			return nil, false, fmt.Errorf("team count for division %d must be a number", i+1)
+		}
+		if teams != s.GetDivisionTeams(i+1) {
+			countsChanged = true
+		}
+		s.SetDivision(i+1, strings.TrimSpace(w.divisionNames[i].Text), teams)
+	}
+
+	return &s, countsChanged, nil
+}
+
+// applyNames updates the plan from the names step
+func (w *LeagueWizard) applyNames() error {
+	s, countsChanged, err := w.namesStructure()
+	if err != nil {
+		return err
+	}
+
+	if result := validation.ValidateLeagueStructure(s); !result.Valid {
+		return validationErrorSummary(result)
+	}
+
+	*w.plan.Structure = *s
+	if countsChanged {
+		league.AssignDivisions(w.plan.Teams, w.plan.Structure)
+		w.plan.Schedule = nil
+	}
+	return nil
+}
+
+// updatePlayoffPreview renders the playoff bracket for the entered structure,
+// using the teams in team ID order as hypothetical standings
+func (w *LeagueWizard) updatePlayoffPreview() {
+	structure, countsChanged, err := w.namesStructure()
+	if err != nil {
+		w.playoffPreview.SetText(err.Error())
+		return
+	}
+
+	if result := validation.ValidatePlayoffSpots(structure); !result.Valid {
+		w.playoffPreview.SetText("Impossible playoff setup:\n" + validationErrorSummary(result).Error())
+		return
+	}
+
+	teams := append([]models.DefaultTeam(nil), w.plan.Teams...)
+	if countsChanged {
+		league.AssignDivisions(teams, structure)
+	}
+	bracket, err := league.BuildPlayoffBracket(structure, teams)
+	if err != nil {
+		w.playoffPreview.SetText("Impossible playoff setup:\n" + err.Error())
+		return
+	}
+
+	w.playoffPreview.SetText("Seeds assume teams finish in team ID order.\n\n" + bracket.Render())
+}
+
+// divisionOptions returns the division choices for team assignment, in division index order
+func (w *LeagueWizard) divisionOptions() []string {
+	s := w.plan.Structure
+	dpc := s.DivisionsPerConference()
+	options := make([]string, s.Divisions)
+	for i := range options {
+		conference := i/dpc + 1
+		abbr := s.Conf1Abbr
+		if conference == 2 {
+			abbr = s.Conf2Abbr
+		}
+		options[i] = fmt.Sprintf("%d. %s %s", i+1, abbr, s.GetDivisionName(i+1))
+	}
+	return options
+}
+
+// buildTeamsStep creates one row of entries per team
+func (w *LeagueWizard) buildTeamsStep() fyne.CanvasObject {
+	options := w.divisionOptions()
+	grid := container.NewGridWithColumns(6,
+		boldLabel("ID"), boldLabel("Name"), boldLabel("Nickname"),
+		boldLabel("Abbr."), boldLabel("City ID"), boldLabel("Division"),
+	)
+
+	w.teamRows = make([]wizardTeamRow, len(w.plan.Teams))
+	for i, team := range w.plan.Teams {
+		row := wizardTeamRow{
+			name:         widget.NewEntry(),
+			nickname:     widget.NewEntry(),
+			abbreviation: widget.NewEntry(),
+			city:         widget.NewEntry(),
+			division:     widget.NewSelect(options, nil),
+		}
+		row.name.SetText(team.TeamName)
+		row.nickname.SetText(team.NickName)
+		row.abbreviation.SetText(team.Abbreviation)
+		row.city.SetText(strconv.Itoa(team.City))
+		if index := w.plan.Structure.DivisionIndex(team.Conference, team.Division); index >= 1 && index <= len(options) {
+			row.division.SetSelected(options[index-1])
+		}
+
+		w.teamRows[i] = row
+		grid.Add(widget.NewLabel(strconv.Itoa(team.TeamID)))
+		grid.Add(row.name)
+		grid.Add(row.nickname)
+		grid.Add(row.abbreviation)
+		grid.Add(row.city)
+		grid.Add(row.division)
+	}
+
+	return grid
+}
+
+// applyTeams updates the plan's teams from the team rows
+func (w *LeagueWizard) applyTeams() error {
+	dpc := w.plan.Structure.DivisionsPerConference()
+	teams := make([]models.DefaultTeam, len(w.plan.Teams))
+	copy(teams, w.plan.Teams)
+
+	for i, row := range w.teamRows {
+		team := &teams[i]
+		team.TeamName = strings.TrimSpace(row.name.Text)
+		team.NickName = strings.TrimSpace(row.nickname.Text)
+		team.Abbreviation = strings.TrimSpace(row.abbreviation.Text)
+
+		city, err := strconv.Atoi(strings.TrimSpace(row.city.Text))
+		if err != nil {
+			return fmt.Errorf("city ID for team %d must be a number", team.TeamID)
+		}
+		team.City = city
+
+		index := row.division.SelectedIndex() + 1
+		if index < 1 {
+			return fmt.Errorf("team %d has no division", team.TeamID)
+		}
+		team.Conference = (index-1)/dpc + 1
+		team.Division = (index-1)%dpc + 1
+	}
+
+	if result := validation.ValidateDefaultTeams(w.plan.Structure, teams); !result.Valid {
+		return validationErrorSummary(result)
+	}
+
+	w.plan.Teams = teams
+	return nil
+}
+
+// buildScheduleStep creates the schedule source and output location controls
+func (w *LeagueWizard) buildScheduleStep() fyne.CanvasObject {
+	w.scheduleIDEntry = widget.NewEntry()
+	w.scheduleIDEntry.SetText(w.plan.Structure.ScheduleID)
+
+	w.scheduleLabel = widget.NewLabel("")
+	w.scheduleSource = widget.NewRadioGroup([]string{scheduleOptionGenerate, scheduleOptionExisting}, func(string) {
+		w.updateScheduleLabel()
+	})
+	if w.templatePath != "" {
+		w.scheduleSource.SetSelected(scheduleOptionExisting)
+	} else {
+		w.scheduleSource.SetSelected(scheduleOptionGenerate)
+	}
+
+	templateBtn := widget.NewButton("Choose Template...", func() {
+		w.chooseFile(func(path string) {
+			w.templatePath = path
+			w.scheduleSource.SetSelected(scheduleOptionExisting)
+			w.updateScheduleLabel()
+		})
+	})
+	outputBtn := widget.NewButton("Choose Folder...", func() {
+		w.chooseFolder()
+	})
+	infoBtn := widget.NewButton("Choose Info File...", func() {
+		w.chooseFile(func(path string) {
+			w.infoPath = path
+			w.updateScheduleLabel()
+		})
+	})
+
+	help := widget.NewLabel("league_info.csv, default_teams.csv and the schedule template are written to the " +
+		"game data folder. When an info file (xxxx_info.csv) is chosen its SCHEDULEID is updated too.")
+	help.Wrapping = fyne.TextWrapWord
+
+	w.updateScheduleLabel()
+
+	return container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem("Schedule ID", w.scheduleIDEntry),
+			widget.NewFormItem("Schedule", container.NewVBox(w.scheduleSource, templateBtn)),
+			widget.NewFormItem("Game Data Folder", outputBtn),
+			widget.NewFormItem("League Info File", infoBtn),
+		),
+		help,
+		widget.NewSeparator(),
+		w.scheduleLabel,
+	)
+}
+
+// updateScheduleLabel summarizes the chosen schedule and output locations
+func (w *LeagueWizard) updateScheduleLabel() {
+	if w.scheduleLabel == nil {
+		return
+	}
+
+	source := "generated round robin (1 rotation)"
+	if w.scheduleSource != nil && w.scheduleSource.Selected == scheduleOptionExisting {
+		source = "no template chosen"
+		if w.templatePath != "" {
+			source = filepath.Base(w.templatePath)
+		}
+	}
+	output := "not chosen"
+	if w.outputDir != "" {
+		output = w.outputDir
+	}
+	info := "not updated"
+	if w.infoPath != "" {
+		info = filepath.Base(w.infoPath)
+	}
+
+	w.scheduleLabel.SetText(fmt.Sprintf("Schedule: %s\nGame data folder: %s\nLeague info file: %s", source, output, info))
+}
+
+// applySchedule updates the plan's schedule ID and schedule from the last step
+func (w *LeagueWizard) applySchedule() error {
+	scheduleID := strings.TrimSpace(w.scheduleIDEntry.Text)
+	if scheduleID == "" {
+		return fmt.Errorf("schedule ID is required")
+	}
+	w.plan.SetScheduleID(scheduleID)
+
+	if w.scheduleSource.Selected == scheduleOptionExisting {
+		if w.templatePath == "" {
+			return fmt.Errorf("choose a schedule template file")
+		}
+		return w.plan.LoadSchedule(w.templatePath)
+	}
+	return w.plan.GenerateSchedule()
+}
+
+// finish validates every artifact together and writes them
+func (w *LeagueWizard) finish() {
+	if err := w.applySchedule(); err != nil {
+		dialog.ShowError(err, w.window)
+		return
+	}
+	if w.outputDir == "" {
+		dialog.ShowError(fmt.Errorf("choose the game data folder to write league files to"), w.window)
+		return
+	}
+	if result := w.plan.Validate(); !result.Valid {
+		dialog.ShowError(validationErrorSummary(result), w.window)
+		return
+	}
+
+	if err := w.plan.WriteArtifacts(w.outputDir, w.infoPath); err != nil {
+		dialog.ShowError(err, w.window)
+		return
+	}
+
+	dialog.ShowInformation("League Created",
+		fmt.Sprintf("Created league structure %s with %d teams and %d scheduled games.",
+			w.plan.Structure.ScheduleID, len(w.plan.Teams), len(w.plan.Schedule)), w.window)
+}
+
+// chooseFile shows a CSV file picker and passes the chosen path to onChosen
+func (w *LeagueWizard) chooseFile(onChosen func(string)) {
+	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w.window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer reader.Close()
+		onChosen(reader.URI().Path())
+	}, w.window)
+
+	// Set default location to FOF9 installation folder
+	if defaultLocation := getDefaultCSVPath(); defaultLocation != nil {
+		fileDialog.SetLocation(defaultLocation)
+	}
+
+	fileDialog.Show()
+}
+
+// chooseFolder shows a folder picker for the game data output folder
+func (w *LeagueWizard) chooseFolder() {
+	folderDialog := dialog.NewFolderOpen(func(folder fyne.ListableURI, err error) {
+		if err != nil {
+			dialog.ShowError(err, w.window)
+			return
+		}
+		if folder == nil {
+			return
+		}
+		w.outputDir = folder.Path()
+		w.updateScheduleLabel()
+	}, w.window)
+
+	// Set default location to FOF9 installation folder
+	if defaultLocation := getDefaultCSVPath(); defaultLocation != nil {
+		folderDialog.SetLocation(defaultLocation)
+	}
+
+	folderDialog.Show()
+}
+
+// boldLabel creates a bold label used for column headers
+func boldLabel(text string) *widget.Label {
+	label := widget.NewLabel(text)
+	label.TextStyle = fyne.TextStyle{Bold: true}
+	return label
+}
+
+// validationErrorSummary converts a validation result into a single error listing its messages
+func validationErrorSummary(result *validation.ValidationResult) error {
+	lines := make([]string, 0, len(result.Errors))
+	for _, verr := range result.Errors {
+		lines = append(lines, verr.Error())
+	}
+	return fmt.Errorf("%s", truncatedLines(lines, maxDialogLines))
+}
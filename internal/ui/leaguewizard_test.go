@@ -0,0 +1,205 @@
+// ABOUTME: Tests for the league structure creation wizard
+// ABOUTME: Validates step application, team assignment and writing league files
+
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/state"
+	"github.com/igorilic/fof9editor/internal/validation"
+)
+
+func newTestLeagueWizard(t *testing.T) *LeagueWizard {
+	t.Helper()
+
+	appState := state.GetInstance()
+	appState.Reset()
+
+	return NewLeagueWizard(test.NewApp(), appState)
+}
+
+func TestLeagueWizard_ApplyFormat(t *testing.T) {
+	w := newTestLeagueWizard(t)
+
+	w.teamsEntry.SetText("12")
+	w.divisionsEntry.SetText("2")
+	w.playoffEntry.SetText("4")
+	w.gamesEntry.SetText("11")
+	w.weeksEntry.SetText("12")
+	w.exGamesEntry.SetText("2")
+	w.exWeeksEntry.SetText("2")
+
+	if err := w.applyFormat(); err != nil {
+		t.Fatalf("applyFormat failed: %v", err)
+	}
+
+	if w.plan.Structure.ScheduleID != "12_2_11" {
+		t.Errorf("Expected schedule ID '12_2_11', got '%s'", w.plan.Structure.ScheduleID)
+	}
+	if len(w.plan.Teams) != 12 {
+		t.Fatalf("Expected 12 teams, got %d", len(w.plan.Teams))
+	}
+	if w.plan.Teams[11].Conference != 2 || w.plan.Teams[11].Division != 1 {
+		t.Errorf("Expected last team in conference 2 division 1, got %d/%d",
+			w.plan.Teams[11].Conference, w.plan.Teams[11].Division)
+	}
+}
+
+func TestLeagueWizard_ApplyFormat_Invalid(t *testing.T) {
+	w := newTestLeagueWizard(t)
+
+	w.divisionsEntry.SetText("3")
+	if err := w.applyFormat(); err == nil {
+		t.Error("Expected error for odd division count")
+	}
+
+	w.divisionsEntry.SetText("abc")
+	if err := w.applyFormat(); err == nil {
+		t.Error("Expected error for non-numeric division count")
+	}
+}
+
+func TestLeagueWizard_ApplyFormat_UsesLoadedTeams(t *testing.T) {
+	w := newTestLeagueWizard(t)
+
+	teams, err := data.LoadTeams("../../testdata/fixtures/csv/teams_league4.csv")
+	if err != nil {
+		t.Fatalf("LoadTeams failed: %v", err)
+	}
+	w.state.SetTeams(teams)
+
+	w.teamsEntry.SetText("4")
+	w.divisionsEntry.SetText("2")
+	w.playoffEntry.SetText("2")
+	w.gamesEntry.SetText("3")
+	w.weeksEntry.SetText("3")
+	w.exGamesEntry.SetText("1")
+	w.exWeeksEntry.SetText("1")
+
+	if err := w.applyFormat(); err != nil {
+		t.Fatalf("applyFormat failed: %v", err)
+	}
+	if w.plan.Teams[0].Abbreviation != "BOS" {
+		t.Errorf("Expected loaded team 'BOS', got '%s'", w.plan.Teams[0].Abbreviation)
+	}
+}
+
+func TestLeagueWizard_ApplyNamesAndTeams(t *testing.T) {
+	w := newTestLeagueWizard(t)
+
+	w.teamsEntry.SetText("4")
+	w.divisionsEntry.SetText("2")
+	w.playoffEntry.SetText("2")
+	w.gamesEntry.SetText("3")
+	w.weeksEntry.SetText("3")
+	w.exGamesEntry.SetText("1")
+	w.exWeeksEntry.SetText("1")
+	if err := w.applyFormat(); err != nil {
+		t.Fatalf("applyFormat failed: %v", err)
+	}
+
+	w.goToStep(wizardStepNames)
+	w.divisionNames[0].SetText("North")
+	w.divisionTeams[0].SetText("3")
+	w.divisionTeams[1].SetText("1")
+	if err := w.applyNames(); err != nil {
+		t.Fatalf("applyNames failed: %v", err)
+	}
+	if w.plan.Structure.Div1 != "North" {
+		t.Errorf("Expected division 1 'North', got '%s'", w.plan.Structure.Div1)
+	}
+	if w.plan.Teams[2].Conference != 1 {
+		t.Errorf("Expected third team reassigned to conference 1, got %d", w.plan.Teams[2].Conference)
+	}
+
+	// Moving a team leaves division counts unbalanced
+	w.goToStep(wizardStepTeams)
+	w.teamRows[0].division.SetSelected(w.teamRows[0].division.Options[1])
+	if err := w.applyTeams(); err == nil {
+		t.Error("Expected error when division counts do not match")
+	}
+
+	w.teamRows[0].division.SetSelected(w.teamRows[0].division.Options[0])
+	w.teamRows[0].name.SetText("Boston")
+	if err := w.applyTeams(); err != nil {
+		t.Fatalf("applyTeams failed: %v", err)
+	}
+	if w.plan.Teams[0].TeamName != "Boston" {
+		t.Errorf("Expected team name 'Boston', got '%s'", w.plan.Teams[0].TeamName)
+	}
+}
+
+func TestLeagueWizard_PlayoffPreview(t *testing.T) {
+	w := newTestLeagueWizard(t)
+	w.goToStep(wizardStepNames)
+
+	// Default 32 teams with 12 playoff spots: seeds 1 and 2 get byes
+	if text := w.playoffPreview.Text; !strings.Contains(text, "First-round bye: (1), (2)") {
+		t.Errorf("Expected byes for seeds 1 and 2, got:\n%s", text)
+	}
+
+	// Shrinking conference 2 to 4 teams leaves too few teams for 6 spots
+	for i := 0; i < 8; i++ {
+		if i < 4 {
+			w.divisionTeams[i].SetText("7")
+		} else {
+			w.divisionTeams[i].SetText("1")
+		}
+	}
+	w.updatePlayoffPreview()
+	if text := w.playoffPreview.Text; !strings.Contains(text, "Impossible playoff setup") {
+		t.Errorf("Expected impossible playoff setup, got:\n%s", text)
+	}
+}
+
+func TestLeagueWizard_Finish(t *testing.T) {
+	w := newTestLeagueWizard(t)
+	dir := t.TempDir()
+
+	w.goToStep(wizardStepSchedule)
+	w.scheduleIDEntry.SetText("32_8_99")
+	w.outputDir = dir
+	w.infoPath = filepath.Join(dir, "test_info.csv")
+	w.finish()
+
+	structures, err := data.LoadLeagueStructures(filepath.Join(dir, "league_info.csv"))
+	if err != nil {
+		t.Fatalf("LoadLeagueStructures failed: %v", err)
+	}
+	if len(structures) != 1 || structures[0].ScheduleID != "32_8_99" {
+		t.Errorf("Expected one structure '32_8_99', got %+v", structures)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "32_8_99_schedule.csv")); err != nil {
+		t.Errorf("Expected schedule template to be written: %v", err)
+	}
+
+	info, err := data.LoadLeagueInfo(w.infoPath)
+	if err != nil {
+		t.Fatalf("LoadLeagueInfo failed: %v", err)
+	}
+	if info.ScheduleID != "32_8_99" {
+		t.Errorf("Expected info SCHEDULEID '32_8_99', got '%s'", info.ScheduleID)
+	}
+}
+
+func TestValidationErrorSummary(t *testing.T) {
+	result := validation.NewValidationResult()
+	for i := 0; i < maxDialogLines+2; i++ {
+		result.AddError(fmt.Sprintf("Field%d", i), "is invalid")
+	}
+
+	summary := validationErrorSummary(result).Error()
+	if lines := strings.Split(summary, "\n"); len(lines) != maxDialogLines+1 {
+		t.Errorf("Expected %d lines, got %d", maxDialogLines+1, len(lines))
+	}
+	if !strings.HasSuffix(summary, "... and 2 more") {
+		t.Errorf("Expected truncation note, got '%s'", summary)
+	}
+}
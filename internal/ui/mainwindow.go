@@ -8,19 +8,40 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 	"github.com/igorilic/fof9editor/internal/data"
 	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
 	"github.com/igorilic/fof9editor/internal/state"
 	"github.com/igorilic/fof9editor/internal/validation"
 	"github.com/igorilic/fof9editor/internal/version"
 )
 
+// Undo and redo shortcuts: Ctrl+Z and Ctrl+Y, or Cmd on macOS
+var (
+	undoShortcut = &desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: fyne.KeyModifierShortcutDefault}
+	redoShortcut = &desktop.CustomShortcut{KeyName: fyne.KeyY, Modifier: fyne.KeyModifierShortcutDefault}
+)
+
+// maxDialogLines limits how many items a dialog lists
+const maxDialogLines = 10
+
+// truncatedLines joins items into lines, listing at most max of them followed
+// by a count of the rest, e.g. "... and 3 more"
+func truncatedLines(items []string, max int) string {
+	if len(items) <= max {
+		return strings.Join(items, "\n")
+	}
+	return strings.Join(items[:max], "\n") + fmt.Sprintf("\n... and %d more", len(items)-max)
+}
+
 // getDefaultCSVPath returns the default folder location for CSV file dialogs
 // Uses the FOF9 installation folder if it exists, otherwise falls back to home directory
 func getDefaultCSVPath() fyne.ListableURI {
@@ -66,6 +87,11 @@ type MainWindow struct {
 	playerForm   *FormView
 	coachForm    *FormView
 	teamForm     *FormView
+	rostersView  *RostersView
+	problems     *ProblemsPanel
+	center       *fyne.Container // Content, with the problems panel when docked
+	undoItem     *fyne.MenuItem
+	redoItem     *fyne.MenuItem
 }
 
 // NewMainWindow creates a new main window
@@ -83,6 +109,8 @@ func NewMainWindow(app fyne.App) *MainWindow {
 		playerForm:   NewFormView(),
 		coachForm:    NewFormView(),
 		teamForm:     NewFormView(),
+		rostersView:  NewRostersView(window, state.GetInstance()),
+		problems:     NewProblemsPanel(state.GetInstance()),
 	}
 
 	mw.setupWindow()
@@ -120,19 +148,39 @@ func (mw *MainWindow) setupWindow() {
 		mw.onSectionChange(section)
 	})
 
+	// Keep the problems panel and status bar current as data changes
+	mw.problems.SetOnSelect(mw.showFinding)
+	mw.problems.SetOnFix(mw.reviewFixes)
+	mw.problems.SetOnValidated(func(result *validation.ValidationResult) {
+		mw.statusBar.SetValidationStatus(result.Count(validation.SeverityError))
+	})
+	removeProblems := mw.state.AddChangeListener(mw.problems.Schedule)
+	mw.center = container.NewMax(mw.content)
+
 	// Create main layout with sidebar on left and status bar at bottom
 	mainLayout := container.NewBorder(
 		nil,                        // top
 		mw.statusBar.GetContainer(), // bottom
 		mw.sidebar.GetContainer(),  // left
 		nil,                        // right
-		mw.content,                 // center
+		mw.center,                  // center
 	)
 
 	mw.window.SetContent(mainLayout)
 
-	// Setup menu bar
+	// Setup menu bar, naming the edits Undo and Redo apply to as data changes
 	mw.setupMenuBar()
+	mw.window.Canvas().AddShortcut(undoShortcut, func(fyne.Shortcut) { mw.undo() })
+	mw.window.Canvas().AddShortcut(redoShortcut, func(fyne.Shortcut) { mw.redo() })
+	removeEditMenu := mw.state.AddChangeListener(mw.updateEditMenu)
+	mw.updateEditMenu()
+
+	// Stop following the data once the window is closed
+	mw.window.SetOnClosed(func() {
+		removeProblems()
+		removeEditMenu()
+		mw.problems.Stop()
+	})
 
 	// Setup close intercept for unsaved changes prompt
 	mw.window.SetCloseIntercept(func() {
@@ -164,6 +212,11 @@ func (mw *MainWindow) setupMenuBar() {
 		mw.saveTeamsCSV()
 	})
 
+	// Reference data
+	loadReferenceItem := fyne.NewMenuItem("Load Reference Data...", func() {
+		mw.loadReferenceData()
+	})
+
 	exitItem := fyne.NewMenuItem("Exit", func() {
 		mw.app.Quit()
 	})
@@ -173,20 +226,26 @@ func (mw *MainWindow) setupMenuBar() {
 		fyne.NewMenuItemSeparator(),
 		savePlayersItem, saveCoachesItem, saveTeamsItem,
 		fyne.NewMenuItemSeparator(),
+		loadReferenceItem,
+		fyne.NewMenuItemSeparator(),
 		exitItem)
 
 	// Edit menu
-	undoItem := fyne.NewMenuItem("Undo", func() {
-		// Placeholder for future implementation
+	mw.undoItem = fyne.NewMenuItem("Undo", func() {
+		mw.undo()
 	})
-	undoItem.Disabled = true
+	mw.undoItem.Shortcut = undoShortcut
 
-	redoItem := fyne.NewMenuItem("Redo", func() {
-		// Placeholder for future implementation
+	mw.redoItem = fyne.NewMenuItem("Redo", func() {
+		mw.redo()
 	})
-	redoItem.Disabled = true
+	mw.redoItem.Shortcut = redoShortcut
 
-	editMenu := fyne.NewMenu("Edit", undoItem, redoItem)
+	historyItem := fyne.NewMenuItem("History...", func() {
+		NewHistoryView(mw.app, mw.state, mw.showHistoryMove).Show()
+	})
+
+	editMenu := fyne.NewMenu("Edit", mw.undoItem, mw.redoItem, fyne.NewMenuItemSeparator(), historyItem)
 
 	// View menu
 	refreshItem := fyne.NewMenuItem("Refresh", func() {
@@ -197,7 +256,81 @@ func (mw *MainWindow) setupMenuBar() {
 		mw.themeManager.ToggleTheme()
 	})
 
-	viewMenu := fyne.NewMenu("View", refreshItem, fyne.NewMenuItemSeparator(), toggleThemeItem)
+	problemsItem := fyne.NewMenuItem("Problems Panel", func() {
+		mw.toggleProblemsPanel()
+	})
+
+	viewMenu := fyne.NewMenu("View", refreshItem, fyne.NewMenuItemSeparator(), toggleThemeItem, problemsItem)
+
+	// Tools menu
+	scheduleAnalysisItem := fyne.NewMenuItem("Schedule Analysis...", func() {
+		NewScheduleAnalysisView(mw.app, mw.state).Show()
+	})
+
+	seasonScheduleItem := fyne.NewMenuItem("Season Schedule Export...", func() {
+		NewSeasonScheduleView(mw.app, mw.state).Show()
+	})
+
+	leagueWizardItem := fyne.NewMenuItem("League Structure Wizard...", func() {
+		NewLeagueWizard(mw.app, mw.state).Show()
+	})
+
+	realignmentItem := fyne.NewMenuItem("Geographic Realignment...", func() {
+		NewRealignmentView(mw.app, mw.state, func() {
+			mw.updateContentArea(mw.state.GetCurrentSection())
+			mw.statusBar.SetProjectStatus("Teams Realigned")
+		}).Show()
+	})
+
+	validateProjectItem := fyne.NewMenuItem("Validate Project", func() {
+		mw.validateProject()
+	})
+
+	exportReportItem := fyne.NewMenuItem("Export Validation Report...", func() {
+		mw.exportValidationReport()
+	})
+	houseRulesItem := fyne.NewMenuItem("House Rules...", func() {
+		NewHouseRulesView(mw.app, mw.state, func() {
+			mw.statusBar.SetProjectStatus("House Rules Changed")
+		}).Show()
+	})
+
+	fixUniformsItem := fyne.NewMenuItem("Fix Uniform Conflicts...", func() {
+		mw.fixUniformConflicts()
+	})
+	rescaleItem := fyne.NewMenuItem("Rescale Salary Minimums...", func() {
+		mw.rescaleSalaryMinimums()
+	})
+	clearPlansItem := fyne.NewMenuItem("Clear Stale Stadium Plans...", func() {
+		mw.clearStaleStadiumPlans()
+	})
+
+	renumberItem := fyne.NewMenuItem("Renumber Player IDs...", func() {
+		NewRenumberView(mw.app, mw.state, func() {
+			mw.updateContentArea(mw.state.GetCurrentSection())
+			mw.statusBar.SetProjectStatus("Player IDs Renumbered")
+		}).Show()
+	})
+
+	agesItem := fyne.NewMenuItem("Age Distribution...", func() {
+		NewAgesView(mw.app, mw.state).Show()
+	})
+
+	draftHistoryItem := fyne.NewMenuItem("Draft History...", func() {
+		NewDraftHistoryView(mw.app, mw.state).Show()
+	})
+
+	draftClassesItem := fyne.NewMenuItem("Draft Classes...", func() {
+		NewDraftClassesView(mw.app, mw.state).Show()
+	})
+
+	ratingsItem := fyne.NewMenuItem("Rating Distribution...", func() {
+		NewRatingsView(mw.app, mw.state).Show()
+	})
+
+	toolsMenu := fyne.NewMenu("Tools", scheduleAnalysisItem, seasonScheduleItem, leagueWizardItem, realignmentItem,
+		fyne.NewMenuItemSeparator(), validateProjectItem, exportReportItem, houseRulesItem, fixUniformsItem, rescaleItem, clearPlansItem, renumberItem, agesItem, draftHistoryItem,
+		draftClassesItem, ratingsItem)
 
 	// Help menu
 	aboutItem := fyne.NewMenuItem("About", func() {
@@ -207,7 +340,7 @@ func (mw *MainWindow) setupMenuBar() {
 	helpMenu := fyne.NewMenu("Help", aboutItem)
 
 	// Set main menu
-	mainMenu := fyne.NewMainMenu(fileMenu, editMenu, viewMenu, helpMenu)
+	mainMenu := fyne.NewMainMenu(fileMenu, editMenu, viewMenu, toolsMenu, helpMenu)
 	mw.window.SetMainMenu(mainMenu)
 }
 
@@ -246,6 +379,7 @@ func (mw *MainWindow) updateContentArea(section string) {
 	case "Players":
 		// Load players from state and display in list
 		players := mw.state.GetPlayers()
+		mw.playerList.SetBaseYear(validation.LeagueBaseYear(mw.state))
 		mw.playerList.SetPlayers(players)
 
 		// Set callback to update form on row selection
@@ -309,6 +443,13 @@ func (mw *MainWindow) updateContentArea(section string) {
 		mw.content.Objects = []fyne.CanvasObject{container.NewMax(split)}
 		mw.statusBar.SetRecordCount("Teams", len(teams))
 
+	case "Rosters":
+		if err := mw.rostersView.Refresh(); err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+		mw.content.Objects = []fyne.CanvasObject{container.NewMax(mw.rostersView.GetContainer())}
+		mw.statusBar.SetRecordCount("Teams", len(mw.rostersView.rosters))
+
 	default:
 		// Create section-specific placeholder for other sections
 		title := widget.NewLabel(fmt.Sprintf("%s", section))
@@ -494,8 +635,8 @@ func (mw *MainWindow) savePlayerForm() {
 		return // Don't save if validation fails
 	}
 
-	// Mark as modified
-	mw.state.MarkDirty()
+	// Mark as modified, recording the edit for undo
+	mw.state.Commit("Save Player " + players[selectedIndex].GetDisplayName())
 	mw.statusBar.SetSavedStatus(true)
 
 	// Refresh player list
@@ -511,12 +652,14 @@ func (mw *MainWindow) deletePlayer() {
 		return
 	}
 
-	// Remove player
+	// Remove player, recording it for undo as one edit
+	mw.state.BeginGroup("Delete Player " + players[selectedIndex].GetDisplayName())
 	players = append(players[:selectedIndex], players[selectedIndex+1:]...)
 	mw.state.SetPlayers(players)
 
 	// Mark as modified
 	mw.state.MarkDirty()
+	mw.state.EndGroup()
 	mw.statusBar.SetSavedStatus(true)
 
 	// Refresh list and go back to list view
@@ -701,8 +844,8 @@ func (mw *MainWindow) saveCoachForm() {
 		return // Don't save if validation fails
 	}
 
-	// Mark as modified
-	mw.state.MarkDirty()
+	// Mark as modified, recording the edit for undo
+	mw.state.Commit("Save Coach " + coaches[selectedIndex].GetDisplayName())
 	mw.statusBar.SetSavedStatus(true)
 
 	// Refresh coach list
@@ -718,12 +861,14 @@ func (mw *MainWindow) deleteCoach() {
 		return
 	}
 
-	// Remove coach
+	// Remove coach, recording it for undo as one edit
+	mw.state.BeginGroup("Delete Coach " + coaches[selectedIndex].GetDisplayName())
 	coaches = append(coaches[:selectedIndex], coaches[selectedIndex+1:]...)
 	mw.state.SetCoaches(coaches)
 
 	// Mark as modified
 	mw.state.MarkDirty()
+	mw.state.EndGroup()
 	mw.statusBar.SetSavedStatus(true)
 
 	// Refresh list and go back to list view
@@ -793,11 +938,13 @@ func (mw *MainWindow) updateTeamForm() {
 		{Name: "condition", Label: "Condition (1-10)", Type: FieldTypeNumber, Value: fmt.Sprintf("%d", team.Condition)},
 
 		// Financial Data
-		{Name: "attendance", Label: "Average Attendance", Type: FieldTypeNumber, Value: fmt.Sprintf("%d", team.Attendance)},
+		{Name: "attendance", Label: "Attendance (% of capacity)", Type: FieldTypeNumber, Value: fmt.Sprintf("%d", team.Attendance)},
 		{Name: "support", Label: "Fan Support", Type: FieldTypeNumber, Value: fmt.Sprintf("%d", team.Support)},
 	}
 
 	mw.teamForm.SetFields(fields)
+	mw.teamForm.AddColorSwatch("primaryRed", "primaryGreen", "primaryBlue")
+	mw.teamForm.AddColorSwatch("secondaryRed", "secondaryGreen", "secondaryBlue")
 
 	// Wire callbacks
 	mw.teamForm.SetCallbacks(
@@ -868,6 +1015,7 @@ func (mw *MainWindow) saveTeamForm() {
 
 	// Validate team data
 	validationResult := validation.ValidateTeam(&teams[selectedIndex])
+	validationResult.Merge(validation.ValidateTeamColors(&teams[selectedIndex]))
 	if !validationResult.Valid {
 		// Display validation errors
 		for _, err := range validationResult.Errors {
@@ -877,8 +1025,8 @@ func (mw *MainWindow) saveTeamForm() {
 		return // Don't save if validation fails
 	}
 
-	// Mark as modified
-	mw.state.MarkDirty()
+	// Mark as modified, recording the edit for undo
+	mw.state.Commit("Save Team " + teams[selectedIndex].GetDisplayName())
 	mw.statusBar.SetSavedStatus(true)
 
 	// Refresh team list
@@ -894,12 +1042,14 @@ func (mw *MainWindow) deleteTeam() {
 		return
 	}
 
-	// Remove team
+	// Remove team, recording it for undo as one edit
+	mw.state.BeginGroup("Delete Team " + teams[selectedIndex].GetDisplayName())
 	teams = append(teams[:selectedIndex], teams[selectedIndex+1:]...)
 	mw.state.SetTeams(teams)
 
 	// Mark as modified
 	mw.state.MarkDirty()
+	mw.state.EndGroup()
 	mw.statusBar.SetSavedStatus(true)
 
 	// Refresh list and go back to list view
@@ -993,6 +1143,8 @@ func fieldNameToFormField(validationFieldName string) string {
 		"SecondaryRed":    "secondaryRed",
 		"SecondaryGreen":  "secondaryGreen",
 		"SecondaryBlue":   "secondaryBlue",
+		"PrimaryColor":    "primaryRed",
+		"SecondaryColor":  "secondaryRed",
 		"Roof":            "roof",
 		"Turf":            "turf",
 		"Built":           "built",
@@ -1236,8 +1388,10 @@ func (mw *MainWindow) loadPlayersCSV() {
 			return
 		}
 
-		// Update state
+		// Update state, recording the load for undo
+		mw.state.BeginGroup("Load players from " + filepath.Base(filePath))
 		mw.state.SetPlayers(players)
+		mw.state.EndGroup()
 
 		// Update UI
 		mw.sidebar.SetSelectedSection("Players")
@@ -1276,8 +1430,10 @@ func (mw *MainWindow) loadCoachesCSV() {
 			return
 		}
 
-		// Update state
+		// Update state, recording the load for undo
+		mw.state.BeginGroup("Load coaches from " + filepath.Base(filePath))
 		mw.state.SetCoaches(coaches)
+		mw.state.EndGroup()
 
 		// Update UI
 		mw.sidebar.SetSelectedSection("Coaches")
@@ -1316,8 +1472,10 @@ func (mw *MainWindow) loadTeamsCSV() {
 			return
 		}
 
-		// Update state
+		// Update state, recording the load for undo
+		mw.state.BeginGroup("Load teams from " + filepath.Base(filePath))
 		mw.state.SetTeams(teams)
+		mw.state.EndGroup()
 
 		// Refresh player/coach forms if they're currently displayed (to update dropdowns)
 		currentSection := mw.state.GetCurrentSection()
@@ -1343,6 +1501,341 @@ func (mw *MainWindow) loadTeamsCSV() {
 	fileDialog.Show()
 }
 
+// loadReferenceData loads game reference tables from a default_data folder
+func (mw *MainWindow) loadReferenceData() {
+	folderDialog := dialog.NewFolderOpen(func(folder fyne.ListableURI, err error) {
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		if folder == nil {
+			return
+		}
+
+		if err := mw.state.LoadReferenceData(folder.Path()); err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+
+		mw.statusBar.SetProjectStatus("Reference Data Loaded")
+		dialog.ShowInformation("Success", fmt.Sprintf("Loaded %d cities, %d colleges and %d league structures",
+			len(mw.state.GetCities()), len(mw.state.GetColleges()), len(mw.state.GetLeagueStructures())), mw.window)
+	}, mw.window)
+
+	// Set default location to FOF9 installation folder
+	if defaultLocation := getDefaultCSVPath(); defaultLocation != nil {
+		folderDialog.SetLocation(defaultLocation)
+	}
+
+	folderDialog.Show()
+}
+
+// validateProject runs every field, file and reference check over the loaded
+// files and lists the errors, or reports the number of findings if there are none
+func (mw *MainWindow) validateProject() {
+	result := validation.ValidateProject(mw.state, mw.state.ReferenceData)
+	summary := fmt.Sprintf("%d errors, %d warnings, %d info (%d suppressed)",
+		result.Count(validation.SeverityError), result.Count(validation.SeverityWarning),
+		result.Count(validation.SeverityInfo), result.Suppressed)
+	mw.statusBar.SetProjectStatus(summary)
+
+	if result.Valid {
+		dialog.ShowInformation("Validate Project", "No errors found: "+summary, mw.window)
+		return
+	}
+	dialog.ShowError(validationErrorSummary(result.WithSeverity(validation.SeverityError)), mw.window)
+}
+
+// exportValidationReport validates the project and saves the report in the
+// format of the chosen file's extension: .html, .md, .json or .xml (JUnit)
+func (mw *MainWindow) exportValidationReport() {
+	result := validation.ValidateProject(mw.state, mw.state.ReferenceData)
+	title := "Untitled League"
+	if project := mw.state.GetProject(); project != nil && project.LeagueName != "" {
+		title = project.LeagueName
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+
+		format, err := validation.ReportFormatForFile(writer.URI().Path())
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		if err := validation.WriteReport(writer, format, title, result); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to write report: %w", err), mw.window)
+			return
+		}
+		mw.statusBar.SetProjectStatus("Validation Report Exported")
+	}, mw.window)
+
+	saveDialog.SetFileName("validation_report.html")
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".html", ".htm", ".md", ".json", ".xml"}))
+	saveDialog.Show()
+}
+
+// toggleProblemsPanel docks the problems panel below the content, validating
+// the project when it opens, or removes it again
+func (mw *MainWindow) toggleProblemsPanel() {
+	if mw.ProblemsPanelVisible() {
+		mw.center.Objects = []fyne.CanvasObject{mw.content}
+	} else {
+		split := container.NewVSplit(mw.content, mw.problems.GetContainer())
+		split.SetOffset(0.75) // 75% content, 25% problems
+		mw.center.Objects = []fyne.CanvasObject{split}
+		mw.problems.Refresh()
+	}
+	mw.center.Refresh()
+}
+
+// ProblemsPanelVisible returns true if the problems panel is docked
+func (mw *MainWindow) ProblemsPanelVisible() bool {
+	return len(mw.center.Objects) == 1 && mw.center.Objects[0] != fyne.CanvasObject(mw.content)
+}
+
+// showFinding jumps to the record of a validation finding: it switches to
+// the finding's section, selects the record and marks the field in the
+// form. Findings in files without an editor are shown in the status bar.
+func (mw *MainWindow) showFinding(finding validation.ValidationError) {
+	project := mw.state.GetProject()
+	sections := map[string]string{
+		validation.ProjectFileName(project, "players"): "Players",
+		validation.ProjectFileName(project, "coaches"): "Coaches",
+		validation.ProjectFileName(project, "teams"):   "Teams",
+	}
+	section, ok := sections[finding.File]
+	if !ok || finding.Line < 2 {
+		mw.statusBar.SetProjectStatus(finding.Error())
+		return
+	}
+
+	mw.sidebar.SetSelectedSection(section)
+	mw.onSectionChange(section)
+
+	// Records are in file order after the header line; the lists find the
+	// row of the record however they are sorted or filtered
+	index := finding.Line - 2
+	var form *FormView
+	switch section {
+	case "Players":
+		mw.playerList.SelectRecord(index)
+		form = mw.playerForm
+		mw.state.SetSelectedIndex(index)
+		mw.updatePlayerForm()
+	case "Coaches":
+		mw.coachList.SelectRecord(index)
+		form = mw.coachForm
+		mw.state.SetSelectedIndex(index)
+		mw.updateCoachForm()
+	case "Teams":
+		mw.teamList.SelectRecord(index)
+		form = mw.teamForm
+		mw.state.SetSelectedIndex(index)
+		mw.updateTeamForm()
+	}
+
+	form.ClearAllErrors()
+	form.SetFieldError(fieldNameToFormField(finding.Field), finding.Message)
+	mw.statusBar.SetProjectStatus(finding.Error())
+}
+
+// reviewFixes lists the changes that fix the findings and applies them on
+// confirmation. Applied fixes can be undone from the Edit menu.
+func (mw *MainWindow) reviewFixes(findings []validation.ValidationError) {
+	changes := validation.ProposeFixes(mw.state, findings)
+	if len(changes) == 0 {
+		dialog.ShowInformation("Fix Problems", "No change is needed to fix these findings", mw.window)
+		return
+	}
+
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		lines = append(lines, change.String())
+	}
+
+	message := fmt.Sprintf("Apply %d changes?\n\n%s", len(changes), truncatedLines(lines, maxDialogLines))
+	dialog.ShowConfirm("Fix Problems", message, func(ok bool) {
+		if ok {
+			mw.applyFixes(changes)
+		}
+	}, mw.window)
+}
+
+// applyFixes applies reviewed changes as one edit that Undo reverts
+func (mw *MainWindow) applyFixes(changes []validation.FieldChange) {
+	applied := validation.ApplyFixes(mw.state, changes)
+	mw.updateContentArea(mw.state.GetCurrentSection())
+	mw.statusBar.SetProjectStatus(fmt.Sprintf("%d Fixes Applied", applied))
+}
+
+// undo reverts the last recorded edit
+func (mw *MainWindow) undo() {
+	if description, ok := mw.state.Undo(); ok {
+		mw.showHistoryMove()
+		mw.statusBar.SetProjectStatus("Undo: " + description)
+	}
+}
+
+// redo repeats the last undone edit
+func (mw *MainWindow) redo() {
+	if description, ok := mw.state.Redo(); ok {
+		mw.showHistoryMove()
+		mw.statusBar.SetProjectStatus("Redo: " + description)
+	}
+}
+
+// showHistoryMove shows the data after edits were undone or redone
+func (mw *MainWindow) showHistoryMove() {
+	mw.updateContentArea(mw.state.GetCurrentSection())
+	mw.statusBar.SetSavedStatus(mw.state.IsDirtyState())
+}
+
+// updateEditMenu names the edits Undo and Redo apply to, e.g.
+// "Undo Save Player Tom Brady", and disables them when there are none
+func (mw *MainWindow) updateEditMenu() {
+	undo, redo := mw.state.UndoDescription(), mw.state.RedoDescription()
+	mw.undoItem.Label, mw.undoItem.Disabled = strings.TrimSpace("Undo "+undo), undo == ""
+	mw.redoItem.Label, mw.redoItem.Disabled = strings.TrimSpace("Redo "+redo), redo == ""
+	if menu := mw.window.MainMenu(); menu != nil {
+		menu.Refresh()
+	}
+}
+
+// fixUniformConflicts proposes new numbers for teammates sharing a uniform
+// number across the player and quarterback files and applies them on confirmation
+func (mw *MainWindow) fixUniformConflicts() {
+	players := mw.state.GetPlayers()
+	quarterbacks := mw.state.GetQuarterbacks()
+	changes := roster.ProposeUniformFixes(players, quarterbacks)
+	if len(changes) == 0 {
+		dialog.ShowInformation("Fix Uniform Conflicts", "No teammates share a uniform number", mw.window)
+		return
+	}
+
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		lines = append(lines, fmt.Sprintf("Team %d: %s (%s) #%d -> #%d", change.Member.Team, change.Member.Name,
+			models.GetPositionAbbr(change.Member.PositionKey), change.Member.Uniform, change.To))
+	}
+
+	message := fmt.Sprintf("Renumber %d players?\n\n%s", len(changes), truncatedLines(lines, maxDialogLines))
+	dialog.ShowConfirm("Fix Uniform Conflicts", message, func(ok bool) {
+		if !ok {
+			return
+		}
+		updated := roster.ApplyUniformChanges(changes, players, quarterbacks)
+		mw.state.Commit(fmt.Sprintf("Renumber %d uniforms", updated))
+		mw.updateContentArea(mw.state.GetCurrentSection())
+		mw.statusBar.SetProjectStatus(fmt.Sprintf("%d Uniforms Renumbered", updated))
+	}, mw.window)
+}
+
+// clearStaleStadiumPlans proposes clearing the future stadium plans that
+// cannot take effect and clears them when confirmed
+func (mw *MainWindow) clearStaleStadiumPlans() {
+	teams := mw.state.GetTeams()
+	var stale []int
+	for i := range teams {
+		if teams[i].HasStaleFuturePlan() {
+			stale = append(stale, i)
+		}
+	}
+	if len(stale) == 0 {
+		dialog.ShowInformation("Clear Stale Stadium Plans", "No team has a stale future stadium plan", mw.window)
+		return
+	}
+
+	lines := make([]string, 0, len(stale))
+	for _, i := range stale {
+		team := &teams[i]
+		reason := "future fields without a plan"
+		if team.HasFuturePlan() {
+			reason = fmt.Sprintf("completes in %d", team.Completed)
+		}
+		lines = append(lines, fmt.Sprintf("%d %s: %s", team.Year, team.GetDisplayName(), reason))
+	}
+
+	message := fmt.Sprintf("Clear the future stadium plans of %d teams?\n\n%s", len(stale), truncatedLines(lines, maxDialogLines))
+	dialog.ShowConfirm("Clear Stale Stadium Plans", message, func(ok bool) {
+		if !ok {
+			return
+		}
+		for _, i := range stale {
+			teams[i].ClearFuturePlan()
+		}
+		mw.state.Commit(fmt.Sprintf("Clear %d stadium plans", len(stale)))
+		mw.updateContentArea(mw.state.GetCurrentSection())
+		mw.statusBar.SetProjectStatus(fmt.Sprintf("%d Stadium Plans Cleared", len(stale)))
+	}, mw.window)
+}
+
+// rescaleSalaryMinimums proposes the salary minimums of the league's
+// structure in league_info.csv scaled to its salary cap and applies them when confirmed
+func (mw *MainWindow) rescaleSalaryMinimums() {
+	info := mw.state.GetLeagueInfo()
+	if info == nil {
+		dialog.ShowInformation("Rescale Salary Minimums", "The project has no league info file", mw.window)
+		return
+	}
+	reference := models.FindLeagueStructure(mw.state.GetLeagueStructures(), info.ScheduleID)
+	if reference == nil {
+		dialog.ShowInformation("Rescale Salary Minimums",
+			fmt.Sprintf("No league structure %s in league_info.csv to rescale from", info.ScheduleID), mw.window)
+		return
+	}
+
+	scaled := info.ScaledMinimums(reference)
+	if scaled == nil {
+		dialog.ShowInformation("Rescale Salary Minimums",
+			fmt.Sprintf("League structure %s has no salary cap", reference.ScheduleID), mw.window)
+		return
+	}
+	var lines []string
+	for i, value := range info.SalaryMinimums() {
+		if value != scaled[i] {
+			lines = append(lines, fmt.Sprintf("%s: %d -> %d", models.SalaryMinimumColumns[i], value, scaled[i]))
+		}
+	}
+	if len(lines) == 0 {
+		dialog.ShowInformation("Rescale Salary Minimums", "The salary minimums already match the salary cap", mw.window)
+		return
+	}
+
+	message := fmt.Sprintf("Rescale %d salary minimums to the %s ratios for a cap of %d?\n\n%s",
+		len(lines), reference.ScheduleID, info.SalaryCap, strings.Join(lines, "\n"))
+	dialog.ShowConfirm("Rescale Salary Minimums", message, func(ok bool) {
+		if !ok {
+			return
+		}
+		info.RescaleMinimums(reference)
+		mw.state.Commit("Rescale salary minimums")
+		mw.updateContentArea(mw.state.GetCurrentSection())
+		mw.statusBar.SetProjectStatus(fmt.Sprintf("%d Salary Minimums Rescaled", len(lines)))
+	}, mw.window)
+}
+
+// exportBlocked reports whether the project has errors in a CSV file, showing
+// them if it does. Warnings and info do not block exporting the file.
+func (mw *MainWindow) exportBlocked(fileKey string) bool {
+	result := validation.ValidateProject(mw.state, mw.state.ReferenceData).
+		ForFile(validation.ProjectFileName(mw.state.GetProject(), fileKey))
+	if result.Valid {
+		return false
+	}
+
+	mw.statusBar.SetProjectStatus(fmt.Sprintf("Export Blocked: %d Errors", result.Count(validation.SeverityError)))
+	dialog.ShowError(validationErrorSummary(result.WithSeverity(validation.SeverityError)), mw.window)
+	return true
+}
+
 // newProject creates a new project
 func (mw *MainWindow) newProject() {
 	// Check for unsaved changes
@@ -1462,6 +1955,9 @@ func (mw *MainWindow) savePlayersCSV() {
 		dialog.ShowInformation("No Data", "No players to save.", mw.window)
 		return
 	}
+	if mw.exportBlocked("players") {
+		return
+	}
 
 	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
 		if err != nil {
@@ -1505,6 +2001,9 @@ func (mw *MainWindow) saveCoachesCSV() {
 		dialog.ShowInformation("No Data", "No coaches to save.", mw.window)
 		return
 	}
+	if mw.exportBlocked("coaches") {
+		return
+	}
 
 	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
 		if err != nil {
@@ -1548,6 +2047,9 @@ func (mw *MainWindow) saveTeamsCSV() {
 		dialog.ShowInformation("No Data", "No teams to save.", mw.window)
 		return
 	}
+	if mw.exportBlocked("teams") {
+		return
+	}
 
 	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
 		if err != nil {
@@ -4,16 +4,29 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
+	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/validation"
 )
 
+// newTestMainWindow creates a main window that is closed when the test ends,
+// so it stops validating the shared state in the background
+func newTestMainWindow(t *testing.T, app fyne.App) *MainWindow {
+	t.Helper()
+	mw := NewMainWindow(app)
+	t.Cleanup(mw.window.Close)
+	return mw
+}
+
 func TestNewMainWindow(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 	if mw == nil {
 		t.Fatal("NewMainWindow returned nil")
 	}
@@ -35,7 +48,7 @@ func TestMainWindow_GetWindow(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 	window := mw.GetWindow()
 
 	if window == nil {
@@ -51,7 +64,7 @@ func TestMainWindow_UpdateTitle(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Test with project name
 	mw.UpdateTitle("Test Project")
@@ -74,7 +87,7 @@ func TestMainWindow_SetContent(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Get initial content
 	initialContent := mw.window.Content()
@@ -91,7 +104,7 @@ func TestMainWindow_DefaultSize(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Verify window has reasonable size set
 	canvas := mw.window.Canvas()
@@ -110,7 +123,7 @@ func TestMainWindow_StatusBar(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Verify status bar is created
 	statusBar := mw.GetStatusBar()
@@ -127,7 +140,7 @@ func TestMainWindow_GetStatusBar(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	statusBar := mw.GetStatusBar()
 	if statusBar == nil {
@@ -143,7 +156,7 @@ func TestMainWindow_Sidebar(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Verify sidebar is created
 	sidebar := mw.GetSidebar()
@@ -162,7 +175,7 @@ func TestMainWindow_GetSidebar(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	sidebar := mw.GetSidebar()
 	if sidebar == nil {
@@ -178,7 +191,7 @@ func TestMainWindow_SectionChangeCallback(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Change section via sidebar
 	mw.sidebar.SetSelectedSection("Coaches")
@@ -202,10 +215,10 @@ func TestMainWindow_UpdateContentArea(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Test updating content area for different sections
-	sections := []string{"Players", "Coaches", "Teams", "League Info"}
+	sections := []string{"Players", "Coaches", "Teams", "Rosters", "League Info"}
 	for _, section := range sections {
 		mw.updateContentArea(section)
 		// Verify content was updated (just check no panic)
@@ -219,7 +232,7 @@ func TestMainWindow_RefreshLayout(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Should not panic
 	mw.RefreshLayout()
@@ -240,7 +253,7 @@ func TestMainWindow_MenuBar(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Verify menu bar is set
 	mainMenu := mw.window.MainMenu()
@@ -249,12 +262,12 @@ func TestMainWindow_MenuBar(t *testing.T) {
 	}
 
 	// Verify expected menus exist
-	if len(mainMenu.Items) < 4 {
-		t.Errorf("Expected at least 4 menus, got %d", len(mainMenu.Items))
+	if len(mainMenu.Items) < 5 {
+		t.Errorf("Expected at least 5 menus, got %d", len(mainMenu.Items))
 	}
 
 	// Verify menu names
-	expectedMenus := []string{"File", "Edit", "View", "Help"}
+	expectedMenus := []string{"File", "Edit", "View", "Tools", "Help"}
 	for i, expected := range expectedMenus {
 		if i >= len(mainMenu.Items) {
 			t.Errorf("Missing menu: %s", expected)
@@ -270,7 +283,7 @@ func TestMainWindow_FileMenu(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	mainMenu := mw.window.MainMenu()
 	if mainMenu == nil || len(mainMenu.Items) == 0 {
@@ -292,7 +305,7 @@ func TestMainWindow_ShowAboutDialog(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Should not panic
 	mw.showAboutDialog()
@@ -303,7 +316,7 @@ func TestMainWindow_ThemeManager(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Verify theme manager is created
 	tm := mw.GetThemeManager()
@@ -321,7 +334,7 @@ func TestMainWindow_ThemeToggle(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	tm := mw.GetThemeManager()
 
@@ -347,7 +360,7 @@ func TestMainWindow_ViewMenuTheme(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	mainMenu := mw.window.MainMenu()
 	if mainMenu == nil || len(mainMenu.Items) < 3 {
@@ -370,7 +383,7 @@ func TestMainWindow_PlayerList(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Verify player list is created
 	if mw.playerList == nil {
@@ -388,7 +401,7 @@ func TestMainWindow_CoachList(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Verify coach list is created
 	if mw.coachList == nil {
@@ -406,7 +419,7 @@ func TestMainWindow_TeamList(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
 
-	mw := NewMainWindow(app)
+	mw := newTestMainWindow(t, app)
 
 	// Verify team list is created
 	if mw.teamList == nil {
@@ -419,3 +432,143 @@ func TestMainWindow_TeamList(t *testing.T) {
 	// Verify status bar shows team count (should be 0 initially)
 	// Can't easily verify UI state, but we can check no panic
 }
+
+func TestMainWindow_ProblemsPanel(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	mw := newTestMainWindow(t, app)
+	mw.state.Reset()
+	defer mw.state.Reset()
+	mw.state.SetTeams([]models.Team{{TeamID: 1}, {TeamID: 2}})
+
+	mw.toggleProblemsPanel()
+	if !mw.ProblemsPanelVisible() || mw.problems.GetResult() == nil {
+		t.Fatal("Expected the docked panel to validate the project")
+	}
+
+	// Without a project, findings carry the file keys as file names
+	mw.showFinding(validation.ValidationError{Field: "Capacity", Message: "too big", File: "teams", Line: 3})
+	if mw.state.GetCurrentSection() != "Teams" || mw.state.GetSelectedIndex() != 1 {
+		t.Errorf("Expected the second team selected, got %s %d", mw.state.GetCurrentSection(), mw.state.GetSelectedIndex())
+	}
+	if label := mw.teamForm.fieldErrors["capacity"]; label == nil || !label.Visible() || label.Text != "too big" {
+		t.Error("Expected the capacity field to show the finding")
+	}
+
+	// Files without an editor only report the finding
+	mw.showFinding(validation.ValidationError{Field: "Week", Message: "bad", File: "schedule", Line: 5})
+	if mw.state.GetCurrentSection() != "Teams" || !strings.Contains(mw.statusBar.projectLabel.Text, "schedule:5") {
+		t.Errorf("Expected the schedule finding in the status bar, got %s", mw.statusBar.projectLabel.Text)
+	}
+
+	mw.toggleProblemsPanel()
+	if mw.ProblemsPanelVisible() {
+		t.Error("Expected the panel to be removed again")
+	}
+}
+
+func TestMainWindow_ShowFindingInSortedList(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	mw := newTestMainWindow(t, app)
+	mw.state.Reset()
+	defer mw.state.Reset()
+	mw.state.SetPlayers([]models.Player{
+		{PlayerID: 1000, FirstName: "Tom", LastName: "Brady"},
+		{PlayerID: 1001, FirstName: "Joe", LastName: "Montana"},
+		{PlayerID: 1002, FirstName: "Dan", LastName: "Marino"},
+	})
+	mw.sidebar.SetSelectedSection("Players")
+	mw.onSectionChange("Players")
+
+	// Sorted by last name, Brady is the first row and Montana the last
+	mw.playerList.SortByColumn(2)
+	mw.showFinding(validation.ValidationError{Field: "LastName", Message: "bad", File: "players", Line: 3})
+	if mw.state.GetSelectedIndex() != 1 || mw.playerForm.GetFieldValue("lastName") != "Montana" {
+		t.Errorf("Expected Montana selected, got index %d (%s)", mw.state.GetSelectedIndex(), mw.playerForm.GetFieldValue("lastName"))
+	}
+	if selected := mw.playerList.GetSelectedPlayer(); selected == nil || selected.PlayerID != 1001 {
+		t.Errorf("Expected Montana's row selected in the list, got %+v", selected)
+	}
+	if mw.state.GetPlayers()[0].LastName != "Brady" || mw.state.GetPlayers()[1].LastName != "Montana" {
+		t.Error("Expected sorting the list to leave the players in file order")
+	}
+}
+
+func TestMainWindow_FixAndUndo(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	mw := newTestMainWindow(t, app)
+	mw.state.Reset()
+	defer mw.state.Reset()
+	mw.state.Players = []models.Player{{PlayerID: 1000, FirstName: "Tom", LastName: "Brady", Weight: 120}}
+	mw.state.SetProject(models.NewProject("Test", "test", "", 2024))
+	if !mw.undoItem.Disabled || !mw.redoItem.Disabled {
+		t.Error("Expected nothing to undo or redo")
+	}
+
+	var findings []validation.ValidationError
+	for _, finding := range validation.ValidateProject(mw.state, nil).Errors {
+		if finding.Code == "PLAYER_WEIGHT" {
+			findings = append(findings, finding)
+		}
+	}
+	mw.applyFixes(validation.ProposeFixes(mw.state, findings))
+	if mw.state.GetPlayers()[0].Weight != 150 || mw.undoItem.Disabled || mw.undoItem.Label != "Undo Apply 1 fixes" {
+		t.Fatalf("Expected the weight fixed and undoable, got %d and %q", mw.state.GetPlayers()[0].Weight, mw.undoItem.Label)
+	}
+
+	mw.undo()
+	if mw.state.GetPlayers()[0].Weight != 120 || !mw.undoItem.Disabled || mw.redoItem.Label != "Redo Apply 1 fixes" {
+		t.Errorf("Expected the fix undone, got %d", mw.state.GetPlayers()[0].Weight)
+	}
+	if mw.statusBar.projectLabel.Text != "Project: Undo: Apply 1 fixes" || mw.statusBar.savedLabel.Text == "● Unsaved changes" {
+		t.Errorf("Unexpected status %q, %q", mw.statusBar.projectLabel.Text, mw.statusBar.savedLabel.Text)
+	}
+
+	mw.redo()
+	if mw.state.GetPlayers()[0].Weight != 150 || !mw.redoItem.Disabled {
+		t.Errorf("Expected the fix redone, got %d", mw.state.GetPlayers()[0].Weight)
+	}
+}
+
+func TestMainWindow_UndoShortcuts(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	mw := newTestMainWindow(t, app)
+	mw.state.Reset()
+	defer mw.state.Reset()
+	mw.state.Coaches = []models.Coach{{FirstName: "Bill", LastName: "Belichick"}}
+	mw.state.SetProject(models.NewProject("Test", "test", "", 2024))
+
+	// Deleting a coach is one edit, though it sets the coaches and marks them dirty
+	mw.state.SetCurrentSection("Coaches")
+	mw.state.SetSelectedIndex(0)
+	mw.deleteCoach()
+	if history := mw.state.History(); len(history) != 1 || history[0].Description != "Delete Coach Bill Belichick" {
+		t.Fatalf("Expected one deletion, got %v", history)
+	}
+
+	mw.window.Canvas().(fyne.Shortcutable).TypedShortcut(undoShortcut)
+	if len(mw.state.GetCoaches()) != 1 {
+		t.Error("Expected Ctrl+Z to undo the deletion")
+	}
+	mw.window.Canvas().(fyne.Shortcutable).TypedShortcut(redoShortcut)
+	if len(mw.state.GetCoaches()) != 0 {
+		t.Error("Expected Ctrl+Y to redo the deletion")
+	}
+}
+
+func TestTruncatedLines(t *testing.T) {
+	items := []string{"a", "b", "c"}
+	if got := truncatedLines(items, 3); got != "a\nb\nc" {
+		t.Errorf("Expected every item listed, got %q", got)
+	}
+	if got := truncatedLines(items, 2); got != "a\nb\n... and 1 more" {
+		t.Errorf("Expected the rest counted, got %q", got)
+	}
+}
@@ -5,12 +5,21 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/widget"
 	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+)
+
+// Player class filter options
+const (
+	classFilterAll    = "All Players"
+	classFilterRoster = "Roster Players"
+	classFilterDraft  = "Draft Classes"
 )
 
 // PlayerList represents a list view for players
@@ -19,6 +28,7 @@ type PlayerList struct {
 	table           *widget.Table
 	players         []models.Player
 	filteredPlayers []models.Player
+	rows            []int // Index in players of each filtered player
 	headers         []string
 	selectedRow     int
 	onSelectChange  func(int)
@@ -26,6 +36,9 @@ type PlayerList struct {
 	sortAscending   bool
 	filterText      string
 	searchEntry     *widget.Entry
+	classFilter     string
+	classSelect     *widget.Select
+	baseYear        int // League BASE_YEAR; later BASE_YEAR entries are draftees
 }
 
 // NewPlayerList creates a new player list view
@@ -33,11 +46,12 @@ func NewPlayerList() *PlayerList {
 	pl := &PlayerList{
 		players:         []models.Player{},
 		filteredPlayers: []models.Player{},
-		headers:         []string{"ID", "First Name", "Last Name", "Position", "Team", "Overall"},
+		headers:         []string{"ID", "First Name", "Last Name", "Position", "Team", "Overall", "Class"},
 		selectedRow:     -1,
 		sortColumn:      -1,
 		sortAscending:   true,
 		filterText:      "",
+		classFilter:     classFilterAll,
 	}
 
 	pl.setupUI()
@@ -54,13 +68,20 @@ func (pl *PlayerList) setupUI() {
 		pl.applyFilter()
 	}
 
+	// Roster players and future draft classes can be listed apart
+	pl.classSelect = widget.NewSelect([]string{classFilterAll, classFilterRoster, classFilterDraft}, func(selected string) {
+		pl.classFilter = selected
+		pl.applyFilter()
+	})
+	pl.classSelect.Selected = classFilterAll
+
 	// Create search bar with label and entry in an HBox for better layout
 	searchLabel := widget.NewLabel("Search:")
 	searchBar := container.NewBorder(
 		nil, // top
 		nil, // bottom
 		searchLabel, // left
-		nil, // right
+		pl.classSelect, // right
 		pl.searchEntry, // center
 	)
 
@@ -127,6 +148,8 @@ func (pl *PlayerList) setupTable() {
 				label.SetText(fmt.Sprintf("%d", player.Team))
 			case 5:
 				label.SetText(fmt.Sprintf("%d", player.OverallRating))
+			case 6:
+				label.SetText(pl.classText(player))
 			default:
 				label.SetText("")
 			}
@@ -141,6 +164,7 @@ func (pl *PlayerList) setupTable() {
 	pl.table.SetColumnWidth(3, 80)  // Position
 	pl.table.SetColumnWidth(4, 80)  // Team
 	pl.table.SetColumnWidth(5, 80)  // Overall
+	pl.table.SetColumnWidth(6, 100) // Class
 
 	// Set up selection callback
 	pl.table.OnSelected = func(id widget.TableCellID) {
@@ -150,8 +174,8 @@ func (pl *PlayerList) setupTable() {
 		} else {
 			// Data row clicked - select player
 			pl.selectedRow = id.Row - 1
-			if pl.onSelectChange != nil {
-				pl.onSelectChange(pl.selectedRow)
+			if pl.onSelectChange != nil && pl.selectedRow < len(pl.rows) {
+				pl.onSelectChange(pl.rows[pl.selectedRow])
 			}
 		}
 	}
@@ -163,6 +187,25 @@ func (pl *PlayerList) SetPlayers(players []models.Player) {
 	pl.applyFilter()
 }
 
+// SetBaseYear sets the league BASE_YEAR that tells roster players from draftees
+func (pl *PlayerList) SetBaseYear(baseYear int) {
+	pl.baseYear = baseYear
+	pl.applyFilter()
+}
+
+// isDraftee returns true if the player joins a later season's draft pool
+func (pl *PlayerList) isDraftee(player models.Player) bool {
+	return roster.IsDraftee(player.BaseYear, pl.baseYear)
+}
+
+// classText returns "Roster", or the draft class of a draftee, e.g. "Draft 2025"
+func (pl *PlayerList) classText(player models.Player) string {
+	if pl.isDraftee(player) {
+		return fmt.Sprintf("Draft %d", player.BaseYear)
+	}
+	return "Roster"
+}
+
 // GetPlayers returns the current list of players
 func (pl *PlayerList) GetPlayers() []models.Player {
 	return pl.players
@@ -181,15 +224,35 @@ func (pl *PlayerList) GetSelectedPlayer() *models.Player {
 	return &pl.filteredPlayers[pl.selectedRow]
 }
 
-// SetOnSelectChange sets the callback for when a player is selected
+// SetOnSelectChange sets the callback for when a player is selected. It is
+// passed the player's index in the players set, not the list row.
 func (pl *PlayerList) SetOnSelectChange(callback func(int)) {
 	pl.onSelectChange = callback
 }
 
+// SelectRecord selects the player at an index of the players set as if
+// clicked, scrolling it into view; an already selected row is left as is.
+// The search and class filter are cleared if they hide the player.
+func (pl *PlayerList) SelectRecord(index int) {
+	if index < 0 || index >= len(pl.players) {
+		return
+	}
+	row := slices.Index(pl.rows, index)
+	if row < 0 {
+		pl.searchEntry.SetText("")
+		pl.classSelect.SetSelected(classFilterAll)
+		row = slices.Index(pl.rows, index)
+	}
+	if row >= 0 {
+		pl.table.Select(widget.TableCellID{Row: row + 1, Col: 0})
+	}
+}
+
 // Clear removes all players from the list
 func (pl *PlayerList) Clear() {
 	pl.players = []models.Player{}
 	pl.filteredPlayers = []models.Player{}
+	pl.rows = nil
 	pl.selectedRow = -1
 	pl.filterText = ""
 	if pl.searchEntry != nil {
@@ -216,7 +279,8 @@ func (pl *PlayerList) SortByColumn(column int) {
 	pl.table.Refresh()
 }
 
-// sortPlayers sorts the filtered players based on current sort settings
+// sortPlayers sorts the filtered players based on current sort settings,
+// leaving the players set in its file order
 func (pl *PlayerList) sortPlayers() {
 	if pl.sortColumn < 0 || len(pl.filteredPlayers) == 0 {
 		return
@@ -227,6 +291,7 @@ func (pl *PlayerList) sortPlayers() {
 		for j := i + 1; j < len(pl.filteredPlayers); j++ {
 			if pl.comparePlayer(i, j) {
 				pl.filteredPlayers[i], pl.filteredPlayers[j] = pl.filteredPlayers[j], pl.filteredPlayers[i]
+				pl.rows[i], pl.rows[j] = pl.rows[j], pl.rows[i]
 			}
 		}
 	}
@@ -250,6 +315,8 @@ func (pl *PlayerList) comparePlayer(i, j int) bool {
 		result = p1.Team > p2.Team
 	case 5: // Overall
 		result = p1.OverallRating > p2.OverallRating
+	case 6: // Class
+		result = pl.classText(p1) > pl.classText(p2)
 	default:
 		return false
 	}
@@ -262,16 +329,12 @@ func (pl *PlayerList) comparePlayer(i, j int) bool {
 
 // applyFilter applies the current filter text to the player list
 func (pl *PlayerList) applyFilter() {
-	if pl.filterText == "" {
-		// No filter - show all players
-		pl.filteredPlayers = pl.players
-	} else {
-		// Filter players based on search text
-		pl.filteredPlayers = []models.Player{}
-		for _, player := range pl.players {
-			if pl.matchesFilter(player) {
-				pl.filteredPlayers = append(pl.filteredPlayers, player)
-			}
+	pl.filteredPlayers = []models.Player{}
+	pl.rows = []int{}
+	for i, player := range pl.players {
+		if pl.matchesFilter(player) {
+			pl.filteredPlayers = append(pl.filteredPlayers, player)
+			pl.rows = append(pl.rows, i)
 		}
 	}
 
@@ -285,6 +348,17 @@ func (pl *PlayerList) applyFilter() {
 
 // matchesFilter checks if a player matches the current filter text
 func (pl *PlayerList) matchesFilter(player models.Player) bool {
+	switch pl.classFilter {
+	case classFilterRoster:
+		if pl.isDraftee(player) {
+			return false
+		}
+	case classFilterDraft:
+		if !pl.isDraftee(player) {
+			return false
+		}
+	}
+
 	if pl.filterText == "" {
 		return true
 	}
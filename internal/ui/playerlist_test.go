@@ -115,7 +115,7 @@ func TestPlayerList_EmptyList(t *testing.T) {
 func TestPlayerList_Headers(t *testing.T) {
 	pl := NewPlayerList()
 
-	expectedHeaders := []string{"ID", "First Name", "Last Name", "Position", "Team", "Overall"}
+	expectedHeaders := []string{"ID", "First Name", "Last Name", "Position", "Team", "Overall", "Class"}
 
 	if len(pl.headers) != len(expectedHeaders) {
 		t.Errorf("Expected %d headers, got %d", len(expectedHeaders), len(pl.headers))
@@ -141,8 +141,8 @@ func TestPlayerList_SortByColumn(t *testing.T) {
 
 	// Sort by ID (column 0) ascending
 	pl.SortByColumn(0)
-	if pl.players[0].PlayerID != 1 {
-		t.Errorf("After sorting by ID asc, expected first ID 1, got %d", pl.players[0].PlayerID)
+	if pl.filteredPlayers[0].PlayerID != 1 {
+		t.Errorf("After sorting by ID asc, expected first ID 1, got %d", pl.filteredPlayers[0].PlayerID)
 	}
 	if pl.sortColumn != 0 {
 		t.Errorf("Expected sortColumn 0, got %d", pl.sortColumn)
@@ -153,12 +153,15 @@ func TestPlayerList_SortByColumn(t *testing.T) {
 
 	// Click same column to toggle descending
 	pl.SortByColumn(0)
-	if pl.players[0].PlayerID != 3 {
-		t.Errorf("After sorting by ID desc, expected first ID 3, got %d", pl.players[0].PlayerID)
+	if pl.filteredPlayers[0].PlayerID != 3 {
+		t.Errorf("After sorting by ID desc, expected first ID 3, got %d", pl.filteredPlayers[0].PlayerID)
 	}
 	if pl.sortAscending {
 		t.Error("Expected sortAscending false after toggle")
 	}
+	if players[0].PlayerID != 3 || players[1].PlayerID != 1 {
+		t.Errorf("Expected sorting to leave the players in file order, got %+v", players)
+	}
 }
 
 func TestPlayerList_SortByFirstName(t *testing.T) {
@@ -175,11 +178,11 @@ func TestPlayerList_SortByFirstName(t *testing.T) {
 	// Sort by First Name (column 1)
 	pl.SortByColumn(1)
 
-	if pl.players[0].FirstName != "Alice" {
-		t.Errorf("After sorting by first name, expected first 'Alice', got '%s'", pl.players[0].FirstName)
+	if pl.filteredPlayers[0].FirstName != "Alice" {
+		t.Errorf("After sorting by first name, expected first 'Alice', got '%s'", pl.filteredPlayers[0].FirstName)
 	}
-	if pl.players[2].FirstName != "Charlie" {
-		t.Errorf("After sorting by first name, expected last 'Charlie', got '%s'", pl.players[2].FirstName)
+	if pl.filteredPlayers[2].FirstName != "Charlie" {
+		t.Errorf("After sorting by first name, expected last 'Charlie', got '%s'", pl.filteredPlayers[2].FirstName)
 	}
 }
 
@@ -197,11 +200,11 @@ func TestPlayerList_SortByOverallRating(t *testing.T) {
 	// Sort by Overall (column 5) ascending
 	pl.SortByColumn(5)
 
-	if pl.players[0].OverallRating != 85 {
-		t.Errorf("After sorting by overall asc, expected first 85, got %d", pl.players[0].OverallRating)
+	if pl.filteredPlayers[0].OverallRating != 85 {
+		t.Errorf("After sorting by overall asc, expected first 85, got %d", pl.filteredPlayers[0].OverallRating)
 	}
-	if pl.players[2].OverallRating != 90 {
-		t.Errorf("After sorting by overall asc, expected last 90, got %d", pl.players[2].OverallRating)
+	if pl.filteredPlayers[2].OverallRating != 90 {
+		t.Errorf("After sorting by overall asc, expected last 90, got %d", pl.filteredPlayers[2].OverallRating)
 	}
 }
 
@@ -222,3 +225,64 @@ func TestPlayerList_SortInvalidColumn(t *testing.T) {
 		t.Errorf("Expected sortColumn to remain -1, got %d", pl.sortColumn)
 	}
 }
+
+func TestPlayerList_ClassFilter(t *testing.T) {
+	pl := NewPlayerList()
+	pl.SetBaseYear(2024)
+	pl.SetPlayers([]models.Player{
+		{PlayerID: 1, FirstName: "Alice", LastName: "Smith", Team: 1, BaseYear: 2024},
+		{PlayerID: 2, FirstName: "Bob", LastName: "Johnson", BaseYear: 2025},
+		{PlayerID: 3, FirstName: "Carol", LastName: "Jones", Team: 2},
+	})
+
+	if pl.classText(pl.players[0]) != "Roster" || pl.classText(pl.players[1]) != "Draft 2025" {
+		t.Errorf("Unexpected classes: %s, %s", pl.classText(pl.players[0]), pl.classText(pl.players[1]))
+	}
+
+	pl.classSelect.SetSelected(classFilterDraft)
+	if len(pl.filteredPlayers) != 1 || pl.filteredPlayers[0].PlayerID != 2 {
+		t.Errorf("Expected only the 2025 draftee, got %+v", pl.filteredPlayers)
+	}
+
+	pl.classSelect.SetSelected(classFilterRoster)
+	if len(pl.filteredPlayers) != 2 {
+		t.Errorf("Expected 2 roster players, got %d", len(pl.filteredPlayers))
+	}
+
+	// Without a league base year every player is on the roster
+	pl.SetBaseYear(0)
+	if len(pl.filteredPlayers) != 3 {
+		t.Errorf("Expected every player without a base year, got %d", len(pl.filteredPlayers))
+	}
+}
+
+func TestPlayerList_SelectRecord(t *testing.T) {
+	pl := NewPlayerList()
+	pl.SetPlayers([]models.Player{
+		{PlayerID: 3, FirstName: "Charlie", LastName: "Wilson"},
+		{PlayerID: 1, FirstName: "Alice", LastName: "Smith"},
+		{PlayerID: 2, FirstName: "Bob", LastName: "Johnson"},
+	})
+	selected := -1
+	pl.SetOnSelectChange(func(index int) { selected = index })
+
+	// Rows sorted by ID select the player's index in file order
+	pl.SortByColumn(0)
+	pl.SelectRecord(0)
+	if selected != 0 || pl.GetSelectedPlayer().PlayerID != 3 || pl.selectedRow != 2 {
+		t.Errorf("Expected Charlie in the last row selected as player 0, got index %d row %d", selected, pl.selectedRow)
+	}
+
+	// A player hidden by the search clears it
+	pl.searchEntry.SetText("Alice")
+	pl.SelectRecord(2)
+	if selected != 2 || pl.GetSelectedPlayer().PlayerID != 2 || pl.searchEntry.Text != "" {
+		t.Errorf("Expected the search cleared and Bob selected, got index %d", selected)
+	}
+
+	selected = -1
+	pl.SelectRecord(3)
+	if selected != -1 {
+		t.Errorf("Expected an index past the end to be ignored, got %d", selected)
+	}
+}
@@ -0,0 +1,341 @@
+// ABOUTME: Problems panel for FOF9 Editor
+// ABOUTME: Re-runs project validation in the background as data changes, lists findings by severity and record and offers their fixes
+
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/state"
+	"github.com/igorilic/fof9editor/internal/validation"
+)
+
+// problemsDelay is how long the panel waits after the last change before
+// validating, so a burst of edits on a large league validates once
+const problemsDelay = 500 * time.Millisecond
+
+// problemSeverities is the order of the severity groups in the panel
+var problemSeverities = []validation.Severity{
+	validation.SeverityError, validation.SeverityWarning, validation.SeverityInfo,
+}
+
+// problemTree is a validation result grouped for the panel's tree: severity
+// nodes, then one node per record, then the findings of that record
+type problemTree struct {
+	children map[widget.TreeNodeID][]widget.TreeNodeID
+	labels   map[widget.TreeNodeID]string
+	findings map[widget.TreeNodeID]validation.ValidationError
+}
+
+// newProblemTree groups findings by severity, then by file and record in
+// the order they were found
+func newProblemTree(result *validation.ValidationResult) *problemTree {
+	tree := &problemTree{
+		children: map[widget.TreeNodeID][]widget.TreeNodeID{},
+		labels:   map[widget.TreeNodeID]string{},
+		findings: map[widget.TreeNodeID]validation.ValidationError{},
+	}
+	if result == nil {
+		return tree
+	}
+
+	for _, severity := range problemSeverities {
+		severityID := severity.String()
+		records := map[string]widget.TreeNodeID{}
+
+		for _, finding := range result.Errors {
+			if finding.Severity != severity {
+				continue
+			}
+			recordKey := finding.File + "\x00" + finding.Record
+			recordID, ok := records[recordKey]
+			if !ok {
+				recordID = fmt.Sprintf("%s/%d", severityID, len(records))
+				records[recordKey] = recordID
+				tree.children[severityID] = append(tree.children[severityID], recordID)
+			}
+			findingID := fmt.Sprintf("%s/%d", recordID, len(tree.children[recordID]))
+			tree.children[recordID] = append(tree.children[recordID], findingID)
+			tree.findings[findingID] = finding
+			tree.labels[findingID] = findingLabel(finding)
+		}
+
+		if len(records) == 0 {
+			continue
+		}
+		tree.children[""] = append(tree.children[""], severityID)
+		tree.labels[severityID] = fmt.Sprintf("%s (%d)", severityGroupName(severity), result.Count(severity))
+		for _, recordID := range tree.children[severityID] {
+			findings := tree.children[recordID]
+			tree.labels[recordID] = fmt.Sprintf("%s (%d)", recordLabel(tree.findings[findings[0]]), len(findings))
+		}
+	}
+
+	return tree
+}
+
+// severityGroupName returns the plural heading of a severity group
+func severityGroupName(severity validation.Severity) string {
+	switch severity {
+	case validation.SeverityWarning:
+		return "Warnings"
+	case validation.SeverityInfo:
+		return "Info"
+	default:
+		return "Errors"
+	}
+}
+
+// recordLabel names the record of a finding, e.g.
+// "2024_players.csv: Player 1000 (Tom Brady)"
+func recordLabel(finding validation.ValidationError) string {
+	switch {
+	case finding.File != "" && finding.Record != "":
+		return fmt.Sprintf("%s: %s", finding.File, finding.Record)
+	case finding.File != "":
+		return finding.File
+	case finding.Record != "":
+		return finding.Record
+	default:
+		return "Project"
+	}
+}
+
+// findingLabel describes a finding within its record, with its suggestion
+func findingLabel(finding validation.ValidationError) string {
+	label := fmt.Sprintf("%s: %s", finding.Field, finding.Message)
+	if finding.Suggestion != "" {
+		label += fmt.Sprintf(" (suggested: %s)", finding.Suggestion)
+	}
+	return label
+}
+
+// ProblemsPanel lists the findings of project validation and keeps them
+// current by validating again shortly after the data changes
+type ProblemsPanel struct {
+	container    *fyne.Container
+	tree         *widget.Tree
+	summaryLabel *widget.Label
+	state        *state.AppState
+	delay        time.Duration
+	problems     *problemTree
+	result       *validation.ValidationResult
+	onSelect     func(validation.ValidationError)
+	onValidated  func(*validation.ValidationResult)
+	onFix        func([]validation.ValidationError)
+	fixButton    *widget.Button
+	fixAllButton *widget.Button
+	current      *validation.ValidationError // Finding last clicked
+
+	mu      sync.Mutex // Guards timer and stopped, and shows background results
+	timer   *time.Timer
+	stopped bool // No longer validating in the background
+}
+
+// NewProblemsPanel creates an empty problems panel for the application state
+func NewProblemsPanel(appState *state.AppState) *ProblemsPanel {
+	p := &ProblemsPanel{
+		state:    appState,
+		delay:    problemsDelay,
+		problems: newProblemTree(nil),
+	}
+
+	p.setupContent()
+	return p
+}
+
+// setupContent builds the summary line and the findings tree
+func (p *ProblemsPanel) setupContent() {
+	p.summaryLabel = widget.NewLabel("Not validated yet")
+	p.summaryLabel.TextStyle = fyne.TextStyle{Bold: true}
+
+	p.tree = widget.NewTree(
+		func(id widget.TreeNodeID) []widget.TreeNodeID {
+			return p.problems.children[id]
+		},
+		func(id widget.TreeNodeID) bool {
+			return len(p.problems.children[id]) > 0
+		},
+		func(branch bool) fyne.CanvasObject {
+			return widget.NewLabel("2024_players.csv: Player 1000 (Tom Brady)")
+		},
+		func(id widget.TreeNodeID, branch bool, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			label.SetText(p.problems.labels[id])
+		},
+	)
+
+	// A finding jumps to its field; unselect so it can be clicked again
+	p.tree.OnSelected = func(id widget.TreeNodeID) {
+		finding, ok := p.problems.findings[id]
+		p.tree.Unselect(id)
+		if !ok {
+			return
+		}
+		p.current = &finding
+		p.updateFixButtons()
+		if p.onSelect != nil {
+			p.onSelect(finding)
+		}
+	}
+
+	p.fixButton = widget.NewButton("Fix", func() {
+		p.Fix()
+	})
+	p.fixAllButton = widget.NewButton("Fix All of Kind", func() {
+		p.FixAllOfKind()
+	})
+	p.updateFixButtons()
+
+	buttons := container.NewHBox(p.fixButton, p.fixAllButton)
+	p.container = container.NewBorder(p.summaryLabel, buttons, nil, nil, p.tree)
+}
+
+// GetContainer returns the panel container
+func (p *ProblemsPanel) GetContainer() *fyne.Container {
+	return p.container
+}
+
+// GetResult returns the findings shown, or nil before the first validation
+func (p *ProblemsPanel) GetResult() *validation.ValidationResult {
+	return p.result
+}
+
+// SetOnSelect sets the callback for when a finding is clicked
+func (p *ProblemsPanel) SetOnSelect(callback func(validation.ValidationError)) {
+	p.onSelect = callback
+}
+
+// SetOnValidated sets the callback for when new findings are shown
+func (p *ProblemsPanel) SetOnValidated(callback func(*validation.ValidationResult)) {
+	p.onValidated = callback
+}
+
+// SetOnFix sets the callback for fixing findings. It is handed the findings
+// to fix, which all offer a fix.
+func (p *ProblemsPanel) SetOnFix(callback func([]validation.ValidationError)) {
+	p.onFix = callback
+}
+
+// Fix fixes the finding last clicked
+func (p *ProblemsPanel) Fix() {
+	if p.current == nil || !validation.HasFix(*p.current) || p.onFix == nil {
+		return
+	}
+	p.onFix([]validation.ValidationError{*p.current})
+}
+
+// FixAllOfKind fixes every finding shown with the code of the finding last
+// clicked
+func (p *ProblemsPanel) FixAllOfKind() {
+	if findings := p.findingsOfKind(); len(findings) > 0 && p.onFix != nil {
+		p.onFix(findings)
+	}
+}
+
+// findingsOfKind returns the findings shown that offer a fix and have the
+// code of the finding last clicked
+func (p *ProblemsPanel) findingsOfKind() []validation.ValidationError {
+	if p.current == nil || p.result == nil {
+		return nil
+	}
+	var findings []validation.ValidationError
+	for _, finding := range p.result.Errors {
+		if finding.Code == p.current.Code && validation.HasFix(finding) {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// updateFixButtons enables the fixes the finding last clicked offers
+func (p *ProblemsPanel) updateFixButtons() {
+	setEnabled(p.fixButton, p.current != nil && validation.HasFix(*p.current))
+	setEnabled(p.fixAllButton, len(p.findingsOfKind()) > 0)
+}
+
+// Schedule validates a copy of the project in the background once no change
+// has been scheduled for a short while. The copy is taken now, so call it from
+// the goroutine that edits the data, as the change listeners are.
+func (p *ProblemsPanel) Schedule() {
+	data := p.state.Copy()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return
+	}
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	p.timer = time.AfterFunc(p.delay, func() { p.validateInBackground(data) })
+}
+
+// Stop cancels the scheduled validation and ignores later ones, waiting for
+// a result being shown
+func (p *ProblemsPanel) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopped = true
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+}
+
+// validateInBackground validates a copy of the project on the timer's
+// goroutine and shows the result on the UI goroutine. A result the data
+// changed since is dropped, as the change has already scheduled another run.
+func (p *ProblemsPanel) validateInBackground(data *state.AppState) {
+	result := validation.ValidateProject(data, data.ReferenceData)
+	if p.state.Revision() != data.Revision() {
+		return
+	}
+	fyne.Do(func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if !p.stopped {
+			p.setResult(result)
+		}
+	})
+}
+
+// Refresh validates the project now and shows the findings
+func (p *ProblemsPanel) Refresh() {
+	p.setResult(validation.ValidateProject(p.state, p.state.ReferenceData))
+}
+
+// setResult shows a validation result, opening the error group. The
+// finding last clicked is kept if it is still found.
+func (p *ProblemsPanel) setResult(result *validation.ValidationResult) {
+	p.result = result
+	p.problems = newProblemTree(result)
+	if p.current != nil && !containsFinding(result, *p.current) {
+		p.current = nil
+	}
+	p.updateFixButtons()
+	p.summaryLabel.SetText(fmt.Sprintf("%d errors, %d warnings, %d info (%d suppressed)",
+		result.Count(validation.SeverityError), result.Count(validation.SeverityWarning),
+		result.Count(validation.SeverityInfo), result.Suppressed))
+
+	p.tree.Refresh()
+	p.tree.OpenBranch(validation.SeverityError.String())
+
+	if p.onValidated != nil {
+		p.onValidated(result)
+	}
+}
+
+// containsFinding returns true if a result has a finding of the same code
+// and field at the same line of the same file
+func containsFinding(result *validation.ValidationResult, finding validation.ValidationError) bool {
+	for _, found := range result.Errors {
+		if found.Code == finding.Code && found.File == finding.File && found.Line == finding.Line && found.Field == finding.Field {
+			return true
+		}
+	}
+	return false
+}
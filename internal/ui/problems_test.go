@@ -0,0 +1,159 @@
+// ABOUTME: Tests for the problems panel
+// ABOUTME: Validates grouping by severity and record, jump and fix callbacks and debounced background validation
+
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+	"github.com/igorilic/fof9editor/internal/validation"
+)
+
+func TestNewProblemTree(t *testing.T) {
+	result := validation.NewValidationResult()
+	result.AddFinding(validation.ValidationError{Field: "OverallRating", Message: "too high", Severity: validation.SeverityError,
+		File: "2024_players.csv", Line: 2, Record: "Player 1000 (Tom Brady)"})
+	result.AddFinding(validation.ValidationError{Field: "Uniform", Message: "shared", Severity: validation.SeverityWarning,
+		File: "2024_players.csv", Line: 2, Record: "Player 1000 (Tom Brady)", Suggestion: "UNIFORM 13"})
+	result.AddFinding(validation.ValidationError{Field: "Height", Message: "too short", Severity: validation.SeverityError,
+		File: "2024_players.csv", Line: 2, Record: "Player 1000 (Tom Brady)"})
+	result.AddFinding(validation.ValidationError{Field: "City", Message: "unknown", Severity: validation.SeverityError,
+		File: "team_info.csv", Line: 3, Record: "Team 2"})
+
+	tree := newProblemTree(result)
+
+	// Info has no findings and no group
+	if roots := tree.children[""]; len(roots) != 2 || roots[0] != "error" || roots[1] != "warning" {
+		t.Fatalf("Expected error and warning groups, got %v", roots)
+	}
+	if tree.labels["error"] != "Errors (3)" {
+		t.Errorf("Unexpected error group label: %s", tree.labels["error"])
+	}
+
+	records := tree.children["error"]
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records with errors, got %v", records)
+	}
+	if got := tree.labels[records[0]]; got != "2024_players.csv: Player 1000 (Tom Brady) (2)" {
+		t.Errorf("Unexpected record label: %s", got)
+	}
+	if got := tree.findings[tree.children[records[1]][0]]; got.Field != "City" {
+		t.Errorf("Expected the team finding under the second record, got %s", got.Field)
+	}
+
+	warning := tree.children[tree.children["warning"][0]][0]
+	if got := tree.labels[warning]; got != "Uniform: shared (suggested: UNIFORM 13)" {
+		t.Errorf("Unexpected finding label: %s", got)
+	}
+}
+
+func TestProblemsPanel(t *testing.T) {
+	test.NewApp()
+	appState := state.GetInstance()
+	appState.Reset()
+
+	player := models.Player{PlayerID: 1000, FirstName: "Tom", LastName: "Brady", OverallRating: 500}
+	appState.SetPlayers([]models.Player{player})
+
+	panel := NewProblemsPanel(appState)
+	var validated *validation.ValidationResult
+	panel.SetOnValidated(func(result *validation.ValidationResult) { validated = result })
+	var selected validation.ValidationError
+	panel.SetOnSelect(func(finding validation.ValidationError) { selected = finding })
+
+	panel.Refresh()
+	if validated == nil || validated.Count(validation.SeverityError) == 0 {
+		t.Fatal("Expected the invalid player to be reported")
+	}
+	if panel.summaryLabel.Text == "Not validated yet" {
+		t.Error("Expected the summary to be updated")
+	}
+
+	// Clicking a finding hands it to the callback
+	findingID := panel.problems.children[panel.problems.children["error"][0]][0]
+	panel.tree.Select(findingID)
+	if selected.File == "" || selected.Line != 2 {
+		t.Errorf("Expected the selected finding on line 2, got %+v", selected)
+	}
+
+	// A change validates again after the delay
+	panel.delay = 10 * time.Millisecond
+	done := make(chan struct{}, 1)
+	panel.SetOnValidated(func(result *validation.ValidationResult) { done <- struct{}{} })
+	remove := appState.AddChangeListener(panel.Schedule)
+	defer remove()
+	player.OverallRating = 50
+	appState.SetPlayers([]models.Player{player})
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a background validation after the change")
+	}
+	appState.Reset()
+}
+
+func TestProblemsPanel_Fix(t *testing.T) {
+	test.NewApp()
+	appState := state.GetInstance()
+	appState.Reset()
+	defer appState.Reset()
+
+	appState.SetPlayers([]models.Player{
+		{PlayerID: 1000, FirstName: "Tom", LastName: "Brady", Weight: 120, Team: 1, Uniform: 12},
+		{PlayerID: 1001, FirstName: "Joe", LastName: "Montana", Weight: 100, Team: 1, Uniform: 12},
+	})
+
+	panel := NewProblemsPanel(appState)
+	var fixed []validation.ValidationError
+	panel.SetOnFix(func(findings []validation.ValidationError) { fixed = findings })
+	panel.Refresh()
+	if !panel.fixButton.Disabled() || !panel.fixAllButton.Disabled() {
+		t.Error("Expected no fix before a finding is clicked")
+	}
+
+	// Click the first player's weight finding
+	var weightID string
+	for id, finding := range panel.problems.findings {
+		if finding.Code == "PLAYER_WEIGHT" && finding.Line == 2 {
+			weightID = id
+		}
+	}
+	if weightID == "" {
+		t.Fatal("Expected a weight finding for the first player")
+	}
+	panel.tree.Select(weightID)
+	if panel.fixButton.Disabled() || panel.fixAllButton.Disabled() {
+		t.Fatal("Expected the weight finding to offer its fixes")
+	}
+
+	panel.Fix()
+	if len(fixed) != 1 || fixed[0].Line != 2 {
+		t.Errorf("Expected the clicked finding to be fixed, got %v", fixed)
+	}
+	panel.FixAllOfKind()
+	if len(fixed) != 2 || fixed[1].Code != "PLAYER_WEIGHT" {
+		t.Errorf("Expected both weight findings to be fixed, got %v", fixed)
+	}
+
+	// The clicked finding is forgotten once it is no longer found
+	appState.GetPlayers()[0].Weight = 200
+	panel.Refresh()
+	if panel.current != nil || !panel.fixButton.Disabled() {
+		t.Error("Expected the fixed finding to be forgotten")
+	}
+
+	// Warnings are only fixed when asked for by code
+	for id, finding := range panel.problems.findings {
+		if finding.Code == "PLAYER_UNIFORM_DUP" {
+			panel.tree.Select(id)
+		}
+	}
+	if panel.current == nil || !panel.fixButton.Disabled() || !panel.fixAllButton.Disabled() {
+		t.Error("Expected the shared uniform warning to offer no fix")
+	}
+}
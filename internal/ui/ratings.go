@@ -0,0 +1,261 @@
+// ABOUTME: Overall rating distribution window for FOF9 Editor
+// ABOUTME: Compares OVERALLRATING by position and team with the shipped player file as a table and bar chart
+
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+// ratingChartWidth is the bar length of a 100% share
+const ratingChartWidth = 400
+
+// RatingsView displays the OVERALLRATING distribution next to the baseline in its own window
+type RatingsView struct {
+	window       fyne.Window
+	state        *state.AppState
+	baseline     []models.Player
+	report       *roster.RatingReport
+	rows         []*roster.RatingComparison // Whole file, positions, then teams
+	selected     int
+	headers      []string
+	table        *widget.Table
+	chart        *fyne.Container
+	chartLabel   *widget.Label
+	summaryLabel *widget.Label
+	capLabel     *widget.Label
+}
+
+// NewRatingsView creates the rating distribution window
+func NewRatingsView(app fyne.App, appState *state.AppState) *RatingsView {
+	v := &RatingsView{
+		window:   app.NewWindow("Rating Distribution"),
+		state:    appState,
+		baseline: appState.GetRatingBaseline(),
+		headers:  []string{"Group", "Players", "Baseline", "Average", "Base Avg", "Divergence"},
+	}
+
+	v.setupContent()
+	return v
+}
+
+// setupContent builds the window layout
+func (v *RatingsView) setupContent() {
+	v.summaryLabel = widget.NewLabel("")
+	v.summaryLabel.Wrapping = fyne.TextWrapWord
+	v.capLabel = widget.NewLabel("")
+	v.capLabel.Wrapping = fyne.TextWrapWord
+	v.chartLabel = widget.NewLabel("")
+	v.chart = container.NewVBox()
+
+	v.table = widget.NewTable(
+		func() (int, int) {
+			return len(v.rows) + 1, len(v.headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("All Players")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(v.headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			label.SetText(v.cellText(id.Row-1, id.Col))
+			label.TextStyle = fyne.TextStyle{Bold: id.Row-1 < len(v.rows) && v.rows[id.Row-1].Notable()}
+		},
+	)
+	v.table.SetColumnWidth(0, 110)
+	for col := 1; col < len(v.headers); col++ {
+		v.table.SetColumnWidth(col, 85)
+	}
+	v.table.OnSelected = func(id widget.TableCellID) {
+		if id.Row > 0 {
+			v.showChart(id.Row - 1)
+		}
+	}
+
+	baselineButton := widget.NewButton("Open Baseline...", func() {
+		v.showBaselineDialog()
+	})
+
+	toolbar := container.NewHBox(baselineButton)
+	top := container.NewVBox(toolbar, v.summaryLabel, widget.NewSeparator())
+	bottom := container.NewVBox(widget.NewSeparator(), v.capLabel)
+	chart := container.NewBorder(v.chartLabel, nil, nil, nil, container.NewVScroll(v.chart))
+
+	split := container.NewHSplit(v.table, chart)
+	split.SetOffset(0.45)
+
+	v.window.SetContent(container.NewBorder(top, bottom, nil, nil, split))
+	v.window.Resize(fyne.NewSize(1200, 700))
+
+	v.Refresh()
+}
+
+// Show displays the rating distribution window
+func (v *RatingsView) Show() {
+	v.window.Show()
+}
+
+// SetBaseline replaces the baseline players and refreshes the comparison
+func (v *RatingsView) SetBaseline(baseline []models.Player) {
+	v.baseline = baseline
+	v.Refresh()
+}
+
+// Refresh compares the loaded players with the baseline
+func (v *RatingsView) Refresh() {
+	v.report = roster.AnalyzeRatings(v.state.GetPlayers(), v.baseline)
+
+	v.rows = []*roster.RatingComparison{&v.report.Overall}
+	for i := range v.report.Positions {
+		v.rows = append(v.rows, &v.report.Positions[i])
+	}
+	for i := range v.report.Teams {
+		v.rows = append(v.rows, &v.report.Teams[i])
+	}
+
+	notable := 0
+	for _, row := range v.rows {
+		if row.Notable() {
+			notable++
+		}
+	}
+	switch {
+	case v.report.Overall.Project.Total == 0:
+		v.summaryLabel.SetText("Load players, or open a project, to compare overall ratings.")
+	case len(v.baseline) == 0:
+		v.summaryLabel.SetText(fmt.Sprintf("No baseline: load reference data from the game's default_data folder or open %s.",
+			state.RatingBaselineFile))
+	default:
+		v.summaryLabel.SetText(fmt.Sprintf("%d players against %d baseline players: divergence %.2f, %d groups at %.2f or more (bold)",
+			v.report.Overall.Project.Total, v.report.Overall.Baseline.Total, v.report.Overall.Divergence,
+			notable, roster.NotableDivergence))
+	}
+
+	if len(v.report.OverCap) == 0 {
+		v.capLabel.SetText(fmt.Sprintf("No FB, P, K or LS above OVERALLRATING %d.", roster.LowValueRatingCap))
+	} else {
+		messages := make([]string, len(v.report.OverCap))
+		for i, issue := range v.report.OverCap {
+			messages[i] = issue.Message()
+		}
+		v.capLabel.SetText(fmt.Sprintf("%d over the cap: %s", len(messages), strings.Join(messages, "; ")))
+	}
+
+	v.table.Refresh()
+	v.showChart(0)
+}
+
+// showChart draws the bar chart of a row, player shares above baseline shares
+func (v *RatingsView) showChart(row int) {
+	v.selected = row
+	v.chart.RemoveAll()
+	if row < 0 || row >= len(v.rows) {
+		v.chartLabel.SetText("")
+		return
+	}
+
+	comparison := v.rows[row]
+	v.chartLabel.SetText(fmt.Sprintf("%s: players (top) and baseline (bottom) by OVERALLRATING", comparison.Label))
+	for _, rating := range roster.RatingOrder {
+		project := comparison.Project.Share(rating)
+		baseline := comparison.Baseline.Share(rating)
+		bars := container.NewVBox(
+			ratingBar(project, theme.Color(theme.ColorNamePrimary)),
+			ratingBar(baseline, theme.Color(theme.ColorNameDisabled)),
+		)
+		v.chart.Add(container.NewHBox(
+			widget.NewLabel(ratingLabel(rating)),
+			bars,
+			widget.NewLabel(fmt.Sprintf("%.1f%% / %.1f%%", project*100, baseline*100)),
+		))
+	}
+}
+
+// ratingBar returns a bar for a share of the chart width
+func ratingBar(share float64, fill color.Color) fyne.CanvasObject {
+	bar := canvas.NewRectangle(fill)
+	bar.SetMinSize(fyne.NewSize(float32(share*ratingChartWidth)+1, 10))
+	return container.NewHBox(bar)
+}
+
+// ratingLabel names a rating, marking the below replacement 10
+func ratingLabel(rating int) string {
+	if rating == roster.BelowReplacementRating {
+		return "10 (below repl.)"
+	}
+	return strconv.Itoa(rating)
+}
+
+// cellText returns the text for a table cell of the comparison
+func (v *RatingsView) cellText(row, col int) string {
+	if row >= len(v.rows) {
+		return ""
+	}
+	comparison := v.rows[row]
+
+	switch col {
+	case 0:
+		return comparison.Label
+	case 1:
+		return strconv.Itoa(comparison.Project.Total)
+	case 2:
+		return strconv.Itoa(comparison.Baseline.Total)
+	case 3:
+		return fmt.Sprintf("%.2f", comparison.Project.Average())
+	case 4:
+		return fmt.Sprintf("%.2f", comparison.Baseline.Average())
+	case 5:
+		if comparison.Project.Total == 0 || comparison.Baseline.Total == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.2f", comparison.Divergence)
+	default:
+		return ""
+	}
+}
+
+// showBaselineDialog lets the user pick the player file to compare with
+func (v *RatingsView) showBaselineDialog() {
+	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, v.window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer reader.Close()
+
+		baseline, err := data.LoadPlayers(reader.URI().Path())
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to load baseline: %w", err), v.window)
+			return
+		}
+		v.SetBaseline(baseline)
+	}, v.window)
+
+	// Set default location to FOF9 installation folder
+	if defaultLocation := getDefaultCSVPath(); defaultLocation != nil {
+		fileDialog.SetLocation(defaultLocation)
+	}
+
+	fileDialog.Show()
+}
@@ -0,0 +1,55 @@
+// ABOUTME: Tests for the overall rating distribution window
+// ABOUTME: Validates the comparison rows, the chart and the low-value position cap summary
+
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+func TestRatingsView(t *testing.T) {
+	appState := state.GetInstance()
+	appState.Reset()
+	appState.SetPlayers([]models.Player{
+		{PlayerID: 1000, PositionKey: models.PositionRB, Team: 1, OverallRating: 3},
+		{PlayerID: 1001, FirstName: "Big", LastName: "Leg", PositionKey: models.PositionK, Team: 1, OverallRating: 4},
+	})
+
+	v := NewRatingsView(test.NewApp(), appState)
+	if !strings.HasPrefix(v.summaryLabel.Text, "No baseline") {
+		t.Errorf("Expected a missing baseline summary, got %s", v.summaryLabel.Text)
+	}
+	if !strings.Contains(v.capLabel.Text, "Player 1001 (Big Leg)") {
+		t.Errorf("Expected the kicker over the cap, got %s", v.capLabel.Text)
+	}
+
+	v.SetBaseline([]models.Player{
+		{PositionKey: models.PositionRB, Team: 2, OverallRating: 3},
+		{PositionKey: models.PositionK, Team: 2, OverallRating: 1},
+	})
+
+	// All players, RB, K, then team 1
+	if len(v.rows) != 4 {
+		t.Fatalf("Expected 4 rows, got %d", len(v.rows))
+	}
+	if v.cellText(0, 0) != "All Players" || v.cellText(0, 1) != "2" || v.cellText(0, 5) != "0.50" {
+		t.Errorf("Unexpected overall row: %s %s %s", v.cellText(0, 0), v.cellText(0, 1), v.cellText(0, 5))
+	}
+	if v.cellText(1, 0) != "RB" || v.cellText(1, 5) != "0.00" || v.cellText(3, 0) != "Team 1" {
+		t.Errorf("Unexpected rows: %s %s %s", v.cellText(1, 0), v.cellText(1, 5), v.cellText(3, 0))
+	}
+
+	v.showChart(2)
+	if v.selected != 2 || len(v.chart.Objects) != len(roster.RatingOrder) {
+		t.Errorf("Expected a bar per rating for K, got %d", len(v.chart.Objects))
+	}
+	if !strings.HasPrefix(v.chartLabel.Text, "K:") {
+		t.Errorf("Expected the K chart, got %s", v.chartLabel.Text)
+	}
+}
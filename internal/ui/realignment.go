@@ -0,0 +1,286 @@
+// ABOUTME: Geographic realignment window for FOF9 Editor
+// ABOUTME: Proposes conference and division assignments by travel and applies them to the loaded teams
+
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/league"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+// RealignmentView proposes and applies a geographic division layout in its own window
+type RealignmentView struct {
+	window          fyne.Window
+	state           *state.AppState
+	onApplied       func()
+	structures      []*models.LeagueStructure
+	structureSelect *widget.Select
+	alignment       *league.Alignment
+	cityNames       []string // City display names in proposal order
+	headers         []string
+	table           *widget.Table
+	summaryLabel    *widget.Label
+	applyButton     *widget.Button
+}
+
+// NewRealignmentView creates the realignment window. onApplied is called after
+// a proposal has been applied to the loaded teams.
+func NewRealignmentView(app fyne.App, appState *state.AppState, onApplied func()) *RealignmentView {
+	v := &RealignmentView{
+		window:    app.NewWindow("Geographic Realignment"),
+		state:     appState,
+		onApplied: onApplied,
+		headers:   []string{"Team", "City", "Current", "Proposed", "Moved"},
+	}
+
+	v.setupContent()
+	return v
+}
+
+// setupContent builds the window layout
+func (v *RealignmentView) setupContent() {
+	v.summaryLabel = widget.NewLabel("")
+	v.summaryLabel.Wrapping = fyne.TextWrapWord
+
+	v.structureSelect = widget.NewSelect([]string{}, func(string) {
+		v.alignment = nil
+		v.applyButton.Disable()
+		v.table.Refresh()
+		v.summaryLabel.SetText("")
+	})
+
+	v.table = widget.NewTable(
+		func() (int, int) {
+			rows := 1
+			if v.alignment != nil {
+				rows += len(v.alignment.Assignments)
+			}
+			return rows, len(v.headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Conference Division")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(v.headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			label.SetText(v.cellText(id.Row-1, id.Col))
+			label.TextStyle = fyne.TextStyle{}
+		},
+	)
+	v.table.SetColumnWidth(0, 80)
+	v.table.SetColumnWidth(1, 180)
+	v.table.SetColumnWidth(2, 200)
+	v.table.SetColumnWidth(3, 200)
+	v.table.SetColumnWidth(4, 70)
+
+	proposeButton := widget.NewButton("Propose", func() {
+		if err := v.Propose(); err != nil {
+			dialog.ShowError(err, v.window)
+		}
+	})
+	v.applyButton = widget.NewButton("Apply to Teams", func() {
+		v.confirmApply()
+	})
+	v.applyButton.Disable()
+
+	toolbar := container.NewHBox(
+		widget.NewLabel("League Structure:"),
+		v.structureSelect,
+		proposeButton,
+		v.applyButton,
+	)
+	top := container.NewVBox(toolbar, widget.NewSeparator())
+	bottom := container.NewVBox(widget.NewSeparator(), v.summaryLabel)
+
+	v.window.SetContent(container.NewBorder(top, bottom, nil, nil, v.table))
+	v.window.Resize(fyne.NewSize(900, 700))
+
+	v.loadStructures()
+}
+
+// Show displays the realignment window
+func (v *RealignmentView) Show() {
+	v.window.Show()
+}
+
+// currentTeams returns the latest season's teams
+func (v *RealignmentView) currentTeams() []models.Team {
+	teams := v.state.GetTeams()
+	return models.TeamsForYear(teams, models.LatestTeamYear(teams))
+}
+
+// loadStructures lists the league formats from league_info.csv that fit the
+// loaded teams, followed by even splits for every valid division count
+func (v *RealignmentView) loadStructures() {
+	teamCount := len(v.currentTeams())
+	v.structures = nil
+
+	for _, structure := range v.state.GetLeagueStructures() {
+		if structure.Teams == teamCount {
+			s := structure
+			v.structures = append(v.structures, &s)
+		}
+	}
+	for divisions := 2; divisions <= models.MaxDivisions; divisions += 2 {
+		if teamCount >= divisions && teamCount <= divisions*models.MaxTeamsPerDivision {
+			structure := models.NewLeagueStructure(teamCount, divisions, 0)
+			structure.ScheduleID = ""
+			v.structures = append(v.structures, structure)
+		}
+	}
+
+	options := make([]string, len(v.structures))
+	for i, structure := range v.structures {
+		options[i] = structureLabel(structure)
+	}
+	v.structureSelect.Options = options
+	if len(options) > 0 {
+		v.structureSelect.SetSelectedIndex(0)
+	} else {
+		v.summaryLabel.SetText("Load teams first; realignment needs between 2 and 64 teams.")
+	}
+	v.structureSelect.Refresh()
+}
+
+// structureLabel describes a league structure in the selector
+func structureLabel(structure *models.LeagueStructure) string {
+	if structure.ScheduleID == "" {
+		return fmt.Sprintf("Even split: %d divisions", structure.Divisions)
+	}
+
+	sizes := ""
+	for i := 1; i <= structure.Divisions; i++ {
+		if i > 1 {
+			sizes += "/"
+		}
+		sizes += strconv.Itoa(structure.GetDivisionTeams(i))
+	}
+	return fmt.Sprintf("%s (%d divisions: %s)", structure.ScheduleID, structure.Divisions, sizes)
+}
+
+// selectedStructure returns the chosen league structure, or nil
+func (v *RealignmentView) selectedStructure() *models.LeagueStructure {
+	index := v.structureSelect.SelectedIndex()
+	if index < 0 || index >= len(v.structures) {
+		return nil
+	}
+	return v.structures[index]
+}
+
+// Propose computes a realignment of the loaded teams for the selected structure
+func (v *RealignmentView) Propose() error {
+	structure := v.selectedStructure()
+	if structure == nil {
+		return fmt.Errorf("no league structure selected")
+	}
+
+	cities := v.state.GetCities()
+	if len(cities) == 0 {
+		return fmt.Errorf("no cities loaded; use File > Load Reference Data first")
+	}
+
+	teams := v.currentTeams()
+	alignment, err := league.ProposeAlignment(teams, cities, structure)
+	if err != nil {
+		return err
+	}
+
+	v.alignment = alignment
+	v.cityNames = make([]string, len(teams))
+	for i, team := range teams {
+		city := cities[team.City]
+		v.cityNames[i] = city.GetDisplayName()
+	}
+	v.table.Refresh()
+	v.applyButton.Enable()
+	v.summaryLabel.SetText(fmt.Sprintf(
+		"Division travel: %.0f mi current, %.0f mi proposed (%+.0f mi). %d of %d teams move.",
+		alignment.CurrentTravelMiles, alignment.TravelMiles,
+		alignment.TravelMiles-alignment.CurrentTravelMiles,
+		alignment.ChangedCount(), len(alignment.Assignments)))
+
+	return nil
+}
+
+// cellText returns the text for a table cell of the proposal
+func (v *RealignmentView) cellText(row, col int) string {
+	if v.alignment == nil || row >= len(v.alignment.Assignments) {
+		return ""
+	}
+	a := &v.alignment.Assignments[row]
+
+	switch col {
+	case 0:
+		return a.Abbreviation
+	case 1:
+		return v.cityNames[row]
+	case 2:
+		return v.divisionText(a.CurrentConference, a.CurrentDivision)
+	case 3:
+		return v.divisionText(a.Conference, a.Division)
+	case 4:
+		if a.Changed() {
+			return "Yes"
+		}
+		return ""
+	default:
+		return ""
+	}
+}
+
+// divisionText names a conference and division using the proposal's structure
+func (v *RealignmentView) divisionText(conference, division int) string {
+	structure := v.alignment.Structure
+	if structure.ScheduleID != "" && conference >= 1 && conference <= 2 &&
+		division >= 1 && division <= structure.DivisionsPerConference() {
+		return fmt.Sprintf("%s %s", structure.GetConferenceName(conference),
+			structure.GetDivisionName(structure.DivisionIndex(conference, division)))
+	}
+	return fmt.Sprintf("Conf %d, Div %d", conference, division)
+}
+
+// confirmApply asks before applying the proposal to the loaded teams
+func (v *RealignmentView) confirmApply() {
+	if v.alignment == nil {
+		return
+	}
+
+	message := fmt.Sprintf("Move %d teams to their proposed conference and division?", v.alignment.ChangedCount())
+	dialog.ShowConfirm("Apply Realignment", message, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		updated := v.Apply()
+		dialog.ShowInformation("Success", fmt.Sprintf("Updated %d teams", updated), v.window)
+	}, v.window)
+}
+
+// Apply copies the proposal onto the loaded teams and returns the number updated
+func (v *RealignmentView) Apply() int {
+	if v.alignment == nil {
+		return 0
+	}
+
+	teams := append([]models.Team(nil), v.state.GetTeams()...)
+	updated := v.alignment.Apply(teams)
+	v.state.BeginGroup("Realign teams")
+	v.state.SetTeams(teams)
+	v.state.EndGroup()
+
+	if v.onApplied != nil {
+		v.onApplied()
+	}
+	return updated
+}
@@ -0,0 +1,97 @@
+// ABOUTME: Tests for the geographic realignment window
+// ABOUTME: Validates structure options, proposals and applying them to loaded teams
+
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+func newRealignmentFixtureView(t *testing.T, onApplied func()) *RealignmentView {
+	t.Helper()
+
+	appState := state.GetInstance()
+	appState.Reset()
+
+	teams, err := data.LoadTeams("../../testdata/fixtures/csv/teams_league4.csv")
+	if err != nil {
+		t.Fatalf("LoadTeams failed: %v", err)
+	}
+	// Pair Boston with Los Angeles and New York with Miami
+	teams[1].Conference = 2
+	teams[3].Conference = 1
+	appState.SetTeams(teams)
+
+	cities, err := data.LoadCities("../../testdata/fixtures/csv/cities_simple.csv")
+	if err != nil {
+		t.Fatalf("LoadCities failed: %v", err)
+	}
+	appState.ReferenceData.SetCities(cities)
+
+	structure := models.NewLeagueStructure(4, 2, 3)
+	structure.Conf1, structure.Conf2 = "AFC", "NFC"
+	structure.Div1, structure.Div2 = "East", "West"
+	appState.ReferenceData.LeagueStructures = []models.LeagueStructure{*structure}
+
+	return NewRealignmentView(test.NewApp(), appState, onApplied)
+}
+
+func TestRealignmentView_StructureOptions(t *testing.T) {
+	v := newRealignmentFixtureView(t, nil)
+
+	// 4_2_3 from league_info.csv plus even two- and four-division splits
+	if len(v.structureSelect.Options) != 3 {
+		t.Fatalf("Expected 3 structure options, got %v", v.structureSelect.Options)
+	}
+	if v.structureSelect.Selected != "4_2_3 (2 divisions: 2/2)" {
+		t.Errorf("Expected 4_2_3 selected, got '%s'", v.structureSelect.Selected)
+	}
+	if v.structureSelect.Options[1] != "Even split: 2 divisions" {
+		t.Errorf("Expected even split option, got '%s'", v.structureSelect.Options[1])
+	}
+}
+
+func TestRealignmentView_ProposeAndApply(t *testing.T) {
+	applied := false
+	v := newRealignmentFixtureView(t, func() { applied = true })
+
+	if err := v.Propose(); err != nil {
+		t.Fatalf("Propose failed: %v", err)
+	}
+	if text := v.cellText(1, 1); text != "New York, NY" {
+		t.Errorf("Expected city 'New York, NY', got '%s'", text)
+	}
+	if text := v.cellText(1, 2); text != "NFC West" {
+		t.Errorf("Expected current 'NFC West', got '%s'", text)
+	}
+	if text := v.cellText(1, 3); text != "AFC East" {
+		t.Errorf("Expected proposed 'AFC East', got '%s'", text)
+	}
+	if text := v.cellText(1, 4); text != "Yes" {
+		t.Errorf("Expected New York to move, got '%s'", text)
+	}
+
+	if updated := v.Apply(); updated != 4 {
+		t.Errorf("Expected 4 teams updated, got %d", updated)
+	}
+	if !applied {
+		t.Error("Expected onApplied callback")
+	}
+	if teams := v.state.GetTeams(); teams[1].Conference != 1 || teams[3].Conference != 2 {
+		t.Errorf("Expected realigned conferences, got %d and %d", teams[1].Conference, teams[3].Conference)
+	}
+}
+
+func TestRealignmentView_ProposeWithoutCities(t *testing.T) {
+	v := newRealignmentFixtureView(t, nil)
+	v.state.ReferenceData.SetCities(nil)
+
+	if err := v.Propose(); err == nil {
+		t.Error("Expected error without cities")
+	}
+}
@@ -0,0 +1,308 @@
+// ABOUTME: Player ID renumbering window for FOF9 Editor
+// ABOUTME: Previews old to new ID mappings for the player and quarterback files and applies them
+
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+// Renumbering modes offered in the selector, in RenumberMode order
+var renumberModes = []string{
+	"Sort: fix duplicate and out-of-range IDs only",
+	"Compact: number each file sequentially",
+}
+
+// RenumberView previews and applies new player IDs in its own window
+type RenumberView struct {
+	window       fyne.Window
+	state        *state.AppState
+	onApplied    func()
+	modeSelect   *widget.Select
+	changes      []roster.IDChange
+	portraits    []string // Portrait moved by each change, or ""
+	headers      []string
+	table        *widget.Table
+	summaryLabel *widget.Label
+	applyButton  *widget.Button
+
+	portraitsLabel  *widget.Label
+	portraitsButton *widget.Button
+}
+
+// NewRenumberView creates the renumbering window. onApplied is called after
+// new IDs have been applied to the loaded players.
+func NewRenumberView(app fyne.App, appState *state.AppState, onApplied func()) *RenumberView {
+	v := &RenumberView{
+		window:    app.NewWindow("Renumber Player IDs"),
+		state:     appState,
+		onApplied: onApplied,
+		headers:   []string{"File", "Player", "Team", "Old ID", "New ID", "Portrait"},
+	}
+
+	v.setupContent()
+	return v
+}
+
+// setupContent builds the window layout
+func (v *RenumberView) setupContent() {
+	v.summaryLabel = widget.NewLabel("")
+	v.summaryLabel.Wrapping = fyne.TextWrapWord
+
+	v.table = widget.NewTable(
+		func() (int, int) {
+			return len(v.changes) + 1, len(v.headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Quarterbacks")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(v.headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			label.SetText(v.cellText(id.Row-1, id.Col))
+			label.TextStyle = fyne.TextStyle{}
+		},
+	)
+	v.table.SetColumnWidth(0, 120)
+	v.table.SetColumnWidth(1, 220)
+	v.table.SetColumnWidth(2, 60)
+	v.table.SetColumnWidth(3, 80)
+	v.table.SetColumnWidth(4, 80)
+	v.table.SetColumnWidth(5, 180)
+
+	v.modeSelect = widget.NewSelect(renumberModes, func(string) {
+		if err := v.Preview(); err != nil {
+			dialog.ShowError(err, v.window)
+		}
+	})
+	v.applyButton = widget.NewButton("Apply", func() {
+		v.confirmApply()
+	})
+	v.applyButton.Disable()
+
+	v.portraitsLabel = widget.NewLabel("")
+	v.portraitsButton = widget.NewButton("Choose Folder...", func() {
+		v.choosePortraitsFolder()
+	})
+	v.updatePortraitsFolder()
+
+	toolbar := container.NewHBox(widget.NewLabel("Mode:"), v.modeSelect, v.applyButton)
+	portraits := container.NewHBox(widget.NewLabel("Portraits:"), v.portraitsLabel, v.portraitsButton)
+	top := container.NewVBox(toolbar, portraits, widget.NewSeparator())
+	bottom := container.NewVBox(widget.NewSeparator(), v.summaryLabel)
+
+	v.window.SetContent(container.NewBorder(top, bottom, nil, nil, v.table))
+	v.window.Resize(fyne.NewSize(700, 600))
+
+	v.modeSelect.SetSelectedIndex(int(roster.RenumberSort))
+}
+
+// Show displays the renumbering window
+func (v *RenumberView) Show() {
+	v.window.Show()
+}
+
+// portraitsFolder returns the project's folder of custom portraits, or ""
+func (v *RenumberView) portraitsFolder() string {
+	if project := v.state.GetProject(); project != nil {
+		return project.PortraitsPath
+	}
+	return ""
+}
+
+// updatePortraitsFolder shows the project's portraits folder
+func (v *RenumberView) updatePortraitsFolder() {
+	switch {
+	case v.state.GetProject() == nil:
+		v.portraitsLabel.SetText("no project loaded")
+		v.portraitsButton.Disable()
+	case v.portraitsFolder() == "":
+		v.portraitsLabel.SetText("not set")
+		v.portraitsButton.Enable()
+	default:
+		v.portraitsLabel.SetText(v.portraitsFolder())
+		v.portraitsButton.Enable()
+	}
+}
+
+// choosePortraitsFolder asks for the folder of the project's custom portraits
+func (v *RenumberView) choosePortraitsFolder() {
+	folderDialog := dialog.NewFolderOpen(func(folder fyne.ListableURI, err error) {
+		if err != nil {
+			dialog.ShowError(err, v.window)
+			return
+		}
+		if folder == nil {
+			return
+		}
+		if err := v.SetPortraitsFolder(folder.Path()); err != nil {
+			dialog.ShowError(err, v.window)
+		}
+	}, v.window)
+	folderDialog.Show()
+}
+
+// SetPortraitsFolder sets the folder of the project's custom portraits, saved
+// with the project, and previews the portraits renamed with the IDs
+func (v *RenumberView) SetPortraitsFolder(dir string) error {
+	project := v.state.GetProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+	project.PortraitsPath = dir
+	v.state.Commit("Set portraits folder")
+	v.updatePortraitsFolder()
+	return v.Preview()
+}
+
+// Preview plans new IDs for the selected mode and lists the mapping and the
+// portraits renamed with it
+func (v *RenumberView) Preview() error {
+	v.changes = nil
+	v.portraits = nil
+	v.applyButton.Disable()
+	defer v.table.Refresh()
+
+	changes, err := roster.RenumberPlayerIDs(v.state.GetPlayers(), v.state.GetQuarterbacks(),
+		roster.RenumberMode(v.modeSelect.SelectedIndex()))
+	if err != nil {
+		v.summaryLabel.SetText("")
+		return err
+	}
+
+	v.changes = changes
+	v.portraits = roster.PortraitMoves(v.portraitsFolder(), changes)
+	if len(changes) == 0 {
+		v.summaryLabel.SetText("All player IDs are unique, in range and sorted.")
+		return nil
+	}
+	v.applyButton.Enable()
+
+	summary := fmt.Sprintf("%d players get new IDs. Both files will be sorted by ID.", len(changes))
+	if v.portraitsFolder() == "" {
+		summary += " No portraits folder is set, so portraits named by ID are not renamed."
+	} else {
+		renamed := 0
+		for _, portrait := range v.portraits {
+			if portrait != "" {
+				renamed++
+			}
+		}
+		summary += fmt.Sprintf(" %d portraits are renamed.", renamed)
+	}
+	v.summaryLabel.SetText(summary)
+	return nil
+}
+
+// cellText returns the text for a table cell of the mapping
+func (v *RenumberView) cellText(row, col int) string {
+	if row >= len(v.changes) {
+		return ""
+	}
+	change := &v.changes[row]
+
+	switch col {
+	case 0:
+		if change.Member.Quarterback {
+			return "Quarterbacks"
+		}
+		return "Players"
+	case 1:
+		return change.Member.Name
+	case 2:
+		return strconv.Itoa(change.Member.Team)
+	case 3:
+		if change.SharedID {
+			return fmt.Sprintf("%d (dup)", change.Member.PlayerID)
+		}
+		return strconv.Itoa(change.Member.PlayerID)
+	case 4:
+		return strconv.Itoa(change.To)
+	case 5:
+		if v.portraits[row] == "" {
+			return ""
+		}
+		return fmt.Sprintf("%s -> %s", v.portraits[row], roster.PortraitFile(change.To))
+	default:
+		return ""
+	}
+}
+
+// confirmApply asks before applying the new IDs
+func (v *RenumberView) confirmApply() {
+	if len(v.changes) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("Give %d players new IDs? Portraits and suppressions named by the old IDs are updated.", len(v.changes))
+	dialog.ShowConfirm("Renumber Player IDs", message, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		updated, err := v.Apply()
+		if err != nil {
+			dialog.ShowError(err, v.window)
+			return
+		}
+		dialog.ShowInformation("Success", fmt.Sprintf("Renumbered %d players", updated), v.window)
+	}, v.window)
+}
+
+// Apply sets the new IDs on copies of the loaded files, sorts them, moves the
+// project's portraits and suppressions to the new IDs and returns the number
+// of players renumbered. IDs are applied even if moving a portrait fails.
+func (v *RenumberView) Apply() (int, error) {
+	if len(v.changes) == 0 {
+		return 0, nil
+	}
+
+	players := append([]models.Player(nil), v.state.GetPlayers()...)
+	quarterbacks := append([]models.Quarterback(nil), v.state.GetQuarterbacks()...)
+	updated := roster.ApplyPlayerIDs(v.changes, players, quarterbacks)
+	// The suppressions and portraits follow the IDs in the same command, so
+	// undoing the renumbering moves them back too
+	var err error
+	v.state.BeginGroup(fmt.Sprintf("Renumber %d player IDs", updated))
+	v.state.SetPlayers(players)
+	v.state.SetQuarterbacks(quarterbacks)
+	if project := v.state.GetProject(); project != nil {
+		project.RenameSuppressionRecords(roster.SuppressionKeys(v.changes))
+		if dir := project.PortraitsPath; dir != "" {
+			var moved int
+			moved, err = roster.RenamePortraits(dir, v.changes)
+			if moved > 0 && err == nil {
+				changes, reversed := v.changes, roster.ReverseIDChanges(v.changes)
+				v.state.RecordEffect("Rename portraits", func(undo bool) {
+					if undo {
+						roster.RenamePortraits(dir, reversed)
+					} else {
+						roster.RenamePortraits(dir, changes)
+					}
+				})
+			}
+		}
+	}
+	v.state.EndGroup()
+
+	v.changes = nil
+	v.portraits = nil
+	v.applyButton.Disable()
+	v.table.Refresh()
+	if v.onApplied != nil {
+		v.onApplied()
+	}
+	return updated, err
+}
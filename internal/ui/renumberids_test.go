@@ -0,0 +1,149 @@
+// ABOUTME: Tests for the player ID renumbering window
+// ABOUTME: Validates the mapping preview and applying it to players, portraits and suppressions
+
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+func newRenumberFixtureView(t *testing.T, onApplied func()) *RenumberView {
+	t.Helper()
+
+	appState := state.GetInstance()
+	appState.Reset()
+	appState.SetQuarterbacks([]models.Quarterback{
+		{PlayerID: 501, FirstName: "Aaron", LastName: "Rodgers", Team: 20},
+	})
+	appState.SetPlayers([]models.Player{
+		{PlayerID: 1001, FirstName: "John", LastName: "Doe", Team: 3},
+		{PlayerID: 1000, FirstName: "Jim", LastName: "Roe", Team: 4},
+		{PlayerID: 999, FirstName: "Low", LastName: "Number", Team: 5},
+	})
+
+	return NewRenumberView(test.NewApp(), appState, onApplied)
+}
+
+func TestRenumberView_Preview(t *testing.T) {
+	v := newRenumberFixtureView(t, nil)
+
+	// Sort mode is previewed on open
+	if len(v.changes) != 1 {
+		t.Fatalf("Expected 1 change in sort mode, got %+v", v.changes)
+	}
+	if v.cellText(0, 1) != "Low Number" || v.cellText(0, 3) != "999" || v.cellText(0, 4) != "1002" {
+		t.Errorf("Unexpected mapping row: %s %s -> %s", v.cellText(0, 1), v.cellText(0, 3), v.cellText(0, 4))
+	}
+
+	v.modeSelect.SetSelectedIndex(1)
+	if len(v.changes) != 4 {
+		t.Fatalf("Expected 4 changes in compact mode, got %+v", v.changes)
+	}
+	if v.cellText(0, 0) != "Quarterbacks" || v.cellText(0, 4) != "500" {
+		t.Errorf("Expected Rodgers to move to 500, got %s -> %s", v.cellText(0, 0), v.cellText(0, 4))
+	}
+	if v.cellText(1, 0) != "Players" || v.cellText(1, 3) != "1001" || v.cellText(1, 4) != "1002" {
+		t.Errorf("Unexpected mapping row: %s %s -> %s", v.cellText(1, 0), v.cellText(1, 3), v.cellText(1, 4))
+	}
+}
+
+func TestRenumberView_Apply(t *testing.T) {
+	applied := false
+	v := newRenumberFixtureView(t, func() { applied = true })
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "999.bmp"), []byte("portrait"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	project := models.NewProject("Test League", "test", dir, 2024)
+	project.PortraitsPath = dir
+	project.AddValidationSuppression("PLAYER_UNIFORM_POSITION@player:999")
+	v.state.SetProject(project)
+
+	updated, err := v.Apply()
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if updated != 1 || !applied {
+		t.Errorf("Expected 1 player renumbered and the callback, got %d (%v)", updated, applied)
+	}
+
+	players := v.state.GetPlayers()
+	if players[0].PlayerID != 1000 || players[2].PlayerID != 1002 || players[2].LastName != "Number" {
+		t.Errorf("Expected players sorted with Low Number at 1002, got %+v", players)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "1002.bmp")); err != nil {
+		t.Errorf("Expected portrait moved to 1002.bmp: %v", err)
+	}
+	if got := project.ValidationSuppressions(); got[0] != "PLAYER_UNIFORM_POSITION@player:1002" {
+		t.Errorf("Expected suppression moved to player:1002, got %v", got)
+	}
+	if !v.applyButton.Disabled() {
+		t.Error("Expected Apply to be disabled after applying")
+	}
+
+	// Undoing the renumbering moves the suppression and portrait back
+	if desc, ok := v.state.Undo(); !ok || desc != "Renumber 1 player IDs" {
+		t.Fatalf("Expected the renumbering undone as one command, got %q (%v)", desc, ok)
+	}
+	if players := v.state.GetPlayers(); players[2].PlayerID != 999 {
+		t.Errorf("Expected Low Number back at 999, got %d", players[2].PlayerID)
+	}
+	if got := project.ValidationSuppressions(); len(got) != 1 || got[0] != "PLAYER_UNIFORM_POSITION@player:999" {
+		t.Errorf("Expected suppression back on player:999, got %v", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "999.bmp")); err != nil {
+		t.Errorf("Expected portrait back at 999.bmp: %v", err)
+	}
+
+	if _, ok := v.state.Redo(); !ok {
+		t.Fatal("Expected the renumbering redone")
+	}
+	if got := project.ValidationSuppressions(); got[0] != "PLAYER_UNIFORM_POSITION@player:1002" {
+		t.Errorf("Expected suppression moved to player:1002 again, got %v", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "1002.bmp")); err != nil {
+		t.Errorf("Expected portrait moved to 1002.bmp again: %v", err)
+	}
+}
+
+func TestRenumberView_Portraits(t *testing.T) {
+	v := newRenumberFixtureView(t, nil)
+	if !v.portraitsButton.Disabled() {
+		t.Error("Expected no portraits folder without a project")
+	}
+
+	v.state.SetProject(models.NewProject("Test League", "test", "", 2024))
+	v.updatePortraitsFolder()
+	if err := v.Preview(); err != nil {
+		t.Fatalf("Preview failed: %v", err)
+	}
+	if !strings.Contains(v.summaryLabel.Text, "No portraits folder is set") || v.cellText(0, 5) != "" {
+		t.Errorf("Expected a warning that portraits are not renamed, got %q", v.summaryLabel.Text)
+	}
+
+	// Choosing the folder previews the portraits renamed with the IDs
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "999.bmp"), []byte("portrait"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetPortraitsFolder(dir); err != nil {
+		t.Fatalf("SetPortraitsFolder failed: %v", err)
+	}
+	if v.state.GetProject().PortraitsPath != dir || v.portraitsLabel.Text != dir || !v.state.IsDirtyState() {
+		t.Errorf("Expected the folder set on the project, got %q", v.portraitsLabel.Text)
+	}
+	if got := v.cellText(0, 5); got != "999.bmp -> 1002.bmp" {
+		t.Errorf("Expected the portrait in the mapping, got %q", got)
+	}
+	if !strings.Contains(v.summaryLabel.Text, "1 portraits are renamed") {
+		t.Errorf("Expected the renamed portraits counted, got %q", v.summaryLabel.Text)
+	}
+}
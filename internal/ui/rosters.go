@@ -0,0 +1,249 @@
+// ABOUTME: Roster composition view for FOF9 Editor
+// ABOUTME: Shows each team's player counts by position and flags counts outside the roster limits
+
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+// rosterTypes are the position type columns of the rosters table
+var rosterTypes = []string{"Offense", "Defense", "Special Teams"}
+
+// RostersView shows the composition of every team's roster
+type RostersView struct {
+	container   *fyne.Container
+	window      fyne.Window
+	state       *state.AppState
+	table       *widget.Table
+	issuesLabel *widget.Label
+	headers     []string
+	rosters     []roster.TeamRoster
+	teamNames   map[int]string
+	limits      roster.Limits
+	flagged     map[[2]int]bool // Team ID and position key (0 for the total) outside the limits
+	issues      []roster.RosterIssue
+}
+
+// NewRostersView creates the rosters view. Dialogs are shown on window.
+func NewRostersView(window fyne.Window, appState *state.AppState) *RostersView {
+	v := &RostersView{
+		window:  window,
+		state:   appState,
+		headers: []string{"Team", "Total", "Off", "Def", "ST"},
+		limits:  roster.DefaultLimits(),
+	}
+	for _, pos := range models.DefaultPositions() {
+		v.headers = append(v.headers, pos.Abbreviation)
+	}
+
+	v.setupUI()
+	return v
+}
+
+// setupUI builds the view layout
+func (v *RostersView) setupUI() {
+	v.issuesLabel = widget.NewLabel("")
+	v.issuesLabel.Wrapping = fyne.TextWrapWord
+
+	v.table = widget.NewTable(
+		func() (int, int) {
+			return len(v.rosters) + 1, len(v.headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Team 99")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(v.headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			label.SetText(v.cellText(id.Row-1, id.Col))
+			label.TextStyle = fyne.TextStyle{Bold: v.isFlagged(id.Row-1, id.Col)}
+		},
+	)
+	v.table.SetColumnWidth(0, 90)
+	for col := 1; col < len(v.headers); col++ {
+		v.table.SetColumnWidth(col, 55)
+	}
+
+	limitsButton := widget.NewButton("Edit Limits...", func() {
+		v.showLimitsDialog()
+	})
+	toolbar := container.NewHBox(widget.NewLabel("Rosters"), limitsButton)
+
+	issuesScroll := container.NewVScroll(v.issuesLabel)
+	issuesScroll.SetMinSize(fyne.NewSize(0, 120))
+
+	v.container = container.NewBorder(
+		container.NewVBox(toolbar, widget.NewSeparator()),
+		container.NewVBox(widget.NewSeparator(), issuesScroll),
+		nil, nil,
+		v.table,
+	)
+}
+
+// GetContainer returns the view container
+func (v *RostersView) GetContainer() *fyne.Container {
+	return v.container
+}
+
+// Refresh recounts the rosters from the loaded files and the project's limits
+func (v *RostersView) Refresh() error {
+	limits, err := roster.LimitsFromProject(v.state.GetProject())
+	v.limits = limits
+
+	teams := v.state.GetTeams()
+	teams = models.TeamsForYear(teams, models.LatestTeamYear(teams))
+	v.teamNames = make(map[int]string, len(teams))
+	teamIDs := make([]int, 0, len(teams))
+	for _, team := range teams {
+		v.teamNames[team.TeamID] = team.Abbreviation
+		teamIDs = append(teamIDs, team.TeamID)
+	}
+
+	v.rosters = roster.Compose(teamIDs, v.state.GetPlayers(), v.state.GetQuarterbacks())
+	v.issues = v.limits.Check(v.rosters)
+	v.flagged = make(map[[2]int]bool, len(v.issues))
+	lines := make([]string, 0, len(v.issues))
+	for _, issue := range v.issues {
+		v.flagged[[2]int{issue.TeamID, issue.PositionKey}] = true
+		lines = append(lines, fmt.Sprintf("%s: %s", v.teamName(issue.TeamID), issue.Message()))
+	}
+
+	switch {
+	case len(v.rosters) == 0:
+		v.issuesLabel.SetText("Load players to see team rosters.")
+	case len(v.issues) == 0:
+		v.issuesLabel.SetText(fmt.Sprintf("All %d rosters are within the limits.", len(v.rosters)))
+	default:
+		v.issuesLabel.SetText(fmt.Sprintf("%d roster issues:\n%s", len(v.issues), strings.Join(lines, "\n")))
+	}
+	v.table.Refresh()
+	return err
+}
+
+// teamName returns a team's abbreviation, or "Team N" if the team is not loaded
+func (v *RostersView) teamName(teamID int) string {
+	if name := v.teamNames[teamID]; name != "" {
+		return name
+	}
+	return fmt.Sprintf("Team %d", teamID)
+}
+
+// positionColumn returns the POSITION_KEY shown in a column, 0 for the total
+// and -1 for the other columns
+func positionColumn(col int) int {
+	switch {
+	case col == 1:
+		return 0
+	case col >= 2+len(rosterTypes):
+		return models.DefaultPositions()[col-2-len(rosterTypes)].ID
+	default:
+		return -1
+	}
+}
+
+// cellText returns the text for a table cell of a roster
+func (v *RostersView) cellText(row, col int) string {
+	if row >= len(v.rosters) {
+		return ""
+	}
+	r := &v.rosters[row]
+
+	switch {
+	case col == 0:
+		return v.teamName(r.TeamID)
+	case col == 1:
+		return strconv.Itoa(r.Total)
+	case col < 2+len(rosterTypes):
+		return strconv.Itoa(r.ByType[rosterTypes[col-2]])
+	default:
+		return strconv.Itoa(r.ByPosition[positionColumn(col)])
+	}
+}
+
+// isFlagged returns true if a cell's count is outside the limits
+func (v *RostersView) isFlagged(row, col int) bool {
+	position := positionColumn(col)
+	if row >= len(v.rosters) || position < 0 {
+		return false
+	}
+	return v.flagged[[2]int{v.rosters[row].TeamID, position}]
+}
+
+// showLimitsDialog edits the roster limits as "min-max" or "min+" per position
+func (v *RostersView) showLimitsDialog() {
+	project := v.state.GetProject()
+	if project == nil {
+		dialog.ShowInformation("Roster Limits", "Open a project to change its roster limits.", v.window)
+		return
+	}
+
+	totalEntry := widget.NewEntry()
+	totalEntry.SetText(v.limits.Total.String())
+	items := []*widget.FormItem{widget.NewFormItem("Total", totalEntry)}
+
+	positions := models.DefaultPositions()
+	entries := make([]*widget.Entry, len(positions))
+	for i, pos := range positions {
+		entries[i] = widget.NewEntry()
+		entries[i].SetPlaceHolder("no limit")
+		if limit, ok := v.limits.Positions[pos.ID]; ok {
+			entries[i].SetText(limit.String())
+		}
+		items = append(items, widget.NewFormItem(pos.Abbreviation, entries[i]))
+	}
+
+	form := dialog.NewForm("Roster Limits", "Save", "Cancel", items, func(save bool) {
+		if !save {
+			return
+		}
+
+		limits := roster.Limits{Positions: make(map[int]roster.Limit)}
+		var err error
+		if limits.Total, err = roster.ParseLimit(totalEntry.Text); err != nil {
+			dialog.ShowError(fmt.Errorf("Total: %w", err), v.window)
+			return
+		}
+		for i, pos := range positions {
+			if strings.TrimSpace(entries[i].Text) == "" {
+				continue
+			}
+			limit, err := roster.ParseLimit(entries[i].Text)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("%s: %w", pos.Abbreviation, err), v.window)
+				return
+			}
+			limits.Positions[pos.ID] = limit
+		}
+
+		v.SetLimits(limits)
+	}, v.window)
+	form.Resize(fyne.NewSize(360, 600))
+	form.Show()
+}
+
+// SetLimits stores new roster limits in the project and rechecks the rosters
+func (v *RostersView) SetLimits(limits roster.Limits) {
+	if project := v.state.GetProject(); project != nil {
+		limits.SaveToProject(project)
+		v.state.MarkDirty()
+	}
+	v.limits = limits
+	if err := v.Refresh(); err != nil {
+		dialog.ShowError(err, v.window)
+	}
+}
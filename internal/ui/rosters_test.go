@@ -0,0 +1,88 @@
+// ABOUTME: Tests for the roster composition view
+// ABOUTME: Validates team counts, flagged cells and saving roster limits to the project
+
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+func newRostersFixtureView(t *testing.T) *RostersView {
+	t.Helper()
+
+	appState := state.GetInstance()
+	appState.Reset()
+	appState.SetTeams([]models.Team{
+		{Year: 2024, TeamID: 1, Abbreviation: "BUF"},
+		{Year: 2024, TeamID: 2, Abbreviation: "MIA"},
+	})
+	appState.SetQuarterbacks([]models.Quarterback{
+		{PlayerID: 500, FirstName: "Josh", LastName: "Allen", Team: 1},
+	})
+	appState.SetPlayers([]models.Player{
+		{PlayerID: 1000, FirstName: "Run", LastName: "Back", Team: 1, PositionKey: 2},
+		{PlayerID: 1001, FirstName: "Free", LastName: "Agent", Team: 0, PositionKey: 2},
+		{PlayerID: 1002, FirstName: "Back", LastName: "Up", Team: 2, PositionKey: 1},
+	})
+
+	window := test.NewApp().NewWindow("Rosters")
+	v := NewRostersView(window, appState)
+	if err := v.Refresh(); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	return v
+}
+
+func TestRostersView_Refresh(t *testing.T) {
+	v := newRostersFixtureView(t)
+
+	if len(v.rosters) != 2 {
+		t.Fatalf("Expected 2 team rosters, got %d", len(v.rosters))
+	}
+	if v.cellText(0, 0) != "BUF" || v.cellText(0, 1) != "2" || v.cellText(0, 2) != "2" {
+		t.Errorf("Unexpected BUF row: %s %s %s", v.cellText(0, 0), v.cellText(0, 1), v.cellText(0, 2))
+	}
+
+	// QB is the first position column after Team, Total and the three types
+	qbCol := 2 + len(rosterTypes)
+	if v.cellText(0, qbCol) != "1" || v.cellText(1, qbCol) != "1" {
+		t.Errorf("Expected 1 QB per team, got %s and %s", v.cellText(0, qbCol), v.cellText(1, qbCol))
+	}
+	if !v.isFlagged(0, qbCol) || !v.isFlagged(0, 1) {
+		t.Error("Expected BUF's QB and total counts to be flagged by the default limits")
+	}
+	if v.isFlagged(0, 0) || v.isFlagged(0, 2) {
+		t.Error("Expected team and type columns never to be flagged")
+	}
+}
+
+func TestRostersView_SetLimits(t *testing.T) {
+	v := newRostersFixtureView(t)
+	project := models.NewProject("Test League", "test", t.TempDir(), 2024)
+	v.state.SetProject(project)
+
+	v.SetLimits(roster.Limits{
+		Total:     roster.Limit{Min: 1},
+		Positions: map[int]roster.Limit{1: {Min: 1, Max: 2}},
+	})
+
+	if len(v.issues) != 0 {
+		t.Errorf("Expected no issues with relaxed limits, got %+v", v.issues)
+	}
+	if !v.state.IsDirtyState() {
+		t.Error("Expected project to be marked dirty")
+	}
+
+	limits, err := roster.LimitsFromProject(project)
+	if err != nil {
+		t.Fatalf("LimitsFromProject failed: %v", err)
+	}
+	if limits.Total.String() != "1+" || limits.Positions[1].String() != "1-2" || len(limits.Positions) != 1 {
+		t.Errorf("Expected saved limits, got %+v", limits)
+	}
+}
@@ -0,0 +1,339 @@
+// ABOUTME: Schedule analysis window for FOF9 Editor
+// ABOUTME: Shows per-team schedule balance statistics for a template and exports them as CSV
+
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/schedule"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+// cycleOption is the rotation selector entry for the full-cycle totals
+const cycleOption = "Full Cycle"
+
+// ScheduleAnalysisView displays schedule balance statistics in its own window
+type ScheduleAnalysisView struct {
+	window         fyne.Window
+	state          *state.AppState
+	analysis       *schedule.Analysis
+	rotation       int
+	headers        []string
+	table          *widget.Table
+	rotationSelect *widget.Select
+	templateLabel  *widget.Label
+	summaryLabel   *widget.Label
+	warningsLabel  *widget.Label
+}
+
+// NewScheduleAnalysisView creates the schedule analysis window
+func NewScheduleAnalysisView(app fyne.App, appState *state.AppState) *ScheduleAnalysisView {
+	v := &ScheduleAnalysisView{
+		window:   app.NewWindow("Schedule Analysis"),
+		state:    appState,
+		rotation: schedule.CycleRotation,
+		headers: []string{
+			"Team", "Games", "Home", "Away", "Division", "Conference",
+			"Interconf.", "Road Stretches", "Longest Road", "Travel (mi)",
+		},
+	}
+
+	v.setupContent()
+	return v
+}
+
+// setupContent builds the window layout
+func (v *ScheduleAnalysisView) setupContent() {
+	v.templateLabel = widget.NewLabel("No schedule template loaded")
+	v.summaryLabel = widget.NewLabel("")
+	v.warningsLabel = widget.NewLabel("")
+	v.warningsLabel.Wrapping = fyne.TextWrapWord
+
+	v.rotationSelect = widget.NewSelect([]string{}, func(selected string) {
+		v.onRotationSelected(selected)
+	})
+	v.rotationSelect.Disable()
+
+	v.table = widget.NewTable(
+		func() (int, int) {
+			rows := 1
+			if stats := v.currentStats(); stats != nil {
+				rows += len(stats.Teams)
+			}
+			return rows, len(v.headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Template")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(v.headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			label.SetText(v.cellText(id.Row-1, id.Col))
+			label.TextStyle = fyne.TextStyle{}
+		},
+	)
+	v.table.SetColumnWidth(0, 80)
+	for col := 1; col < len(v.headers); col++ {
+		v.table.SetColumnWidth(col, 110)
+	}
+
+	openButton := widget.NewButton("Open Template...", func() {
+		v.showOpenDialog()
+	})
+	exportStatsButton := widget.NewButton("Export Stats CSV...", func() {
+		v.showExportDialog(false)
+	})
+	exportMatrixButton := widget.NewButton("Export Opponent Matrix CSV...", func() {
+		v.showExportDialog(true)
+	})
+
+	toolbar := container.NewHBox(
+		openButton,
+		widget.NewLabel("Rotation:"),
+		v.rotationSelect,
+		exportStatsButton,
+		exportMatrixButton,
+	)
+
+	top := container.NewVBox(toolbar, v.templateLabel, widget.NewSeparator())
+	bottom := container.NewVBox(widget.NewSeparator(), v.summaryLabel, v.warningsLabel)
+
+	v.window.SetContent(container.NewBorder(top, bottom, nil, nil, v.table))
+	v.window.Resize(fyne.NewSize(1100, 700))
+}
+
+// Show displays the analysis window
+func (v *ScheduleAnalysisView) Show() {
+	v.window.Show()
+}
+
+// LoadTemplate analyzes a schedule template file against the loaded teams
+func (v *ScheduleAnalysisView) LoadTemplate(path string) error {
+	games, err := data.LoadScheduleTemplate(path)
+	if err != nil {
+		return err
+	}
+
+	teams := v.state.GetTeams()
+	if len(teams) == 0 {
+		return fmt.Errorf("no teams loaded; load a team file first")
+	}
+	teams = models.TeamsForYear(teams, models.LatestTeamYear(teams))
+
+	analysis, err := schedule.Analyze(schedule.ScheduleIDFromPath(path), games, teams, v.state.GetCities())
+	if err != nil {
+		return err
+	}
+
+	v.SetAnalysis(analysis)
+	v.templateLabel.SetText(fmt.Sprintf("Template: %s (%d teams, %d rotations)",
+		filepath.Base(path), len(analysis.Teams), len(analysis.Rotations)))
+	return nil
+}
+
+// SetAnalysis displays an analysis result, starting with the full-cycle totals
+func (v *ScheduleAnalysisView) SetAnalysis(analysis *schedule.Analysis) {
+	v.analysis = analysis
+	v.rotation = schedule.CycleRotation
+
+	options := []string{cycleOption}
+	for _, rotation := range analysis.Rotations {
+		options = append(options, fmt.Sprintf("Rotation %d", rotation.Rotation))
+	}
+	v.rotationSelect.Options = options
+	v.rotationSelect.Enable()
+	v.rotationSelect.SetSelected(cycleOption)
+
+	warnings := analysis.Warnings
+	if !v.state.ReferenceData.HasCities() {
+		// One hint replaces the warnings of every team without coordinates,
+		// after the standings warning that comes first
+		warnings = []string{"Travel distances need city data: use File > Load Reference Data..."}
+		if analysis.StandingsGames > 0 {
+			warnings = append(warnings, analysis.Warnings[0])
+		}
+	}
+	if len(warnings) > 0 {
+		v.warningsLabel.SetText(fmt.Sprintf("%d warning(s): %s", len(warnings), warnings[0]))
+	} else {
+		v.warningsLabel.SetText("")
+	}
+
+	v.refresh()
+}
+
+// onRotationSelected switches the displayed rotation
+func (v *ScheduleAnalysisView) onRotationSelected(selected string) {
+	if v.analysis == nil {
+		return
+	}
+
+	v.rotation = schedule.CycleRotation
+	if selected != cycleOption {
+		var rotation int
+		if _, err := fmt.Sscanf(selected, "Rotation %d", &rotation); err == nil {
+			v.rotation = rotation
+		}
+	}
+
+	v.refresh()
+}
+
+// refresh redraws the table and summary for the current rotation
+func (v *ScheduleAnalysisView) refresh() {
+	if stats := v.currentStats(); stats != nil {
+		summary := stats.Summarize()
+		v.summaryLabel.SetText(fmt.Sprintf(
+			"Home games: %d-%d   Away games: %d-%d   Longest road stretch: %d   Travel: %.0f-%.0f mi",
+			summary.MinHome, summary.MaxHome, summary.MinAway, summary.MaxAway,
+			summary.MaxRoadStretch, summary.MinTravel, summary.MaxTravel))
+	}
+	v.table.Refresh()
+}
+
+// currentStats returns the statistics of the selected rotation
+func (v *ScheduleAnalysisView) currentStats() *schedule.RotationStats {
+	if v.analysis == nil {
+		return nil
+	}
+	stats, ok := v.analysis.GetRotation(v.rotation)
+	if !ok {
+		return nil
+	}
+	return stats
+}
+
+// cellText returns the display text of a table cell
+func (v *ScheduleAnalysisView) cellText(row, col int) string {
+	stats := v.currentStats()
+	if stats == nil || row >= len(stats.Teams) {
+		return ""
+	}
+
+	team := stats.Teams[row]
+	switch col {
+	case 0:
+		return team.Abbreviation
+	case 1:
+		return strconv.Itoa(team.Games)
+	case 2:
+		return strconv.Itoa(team.Home)
+	case 3:
+		return strconv.Itoa(team.Away)
+	case 4:
+		return strconv.Itoa(team.Division)
+	case 5:
+		return strconv.Itoa(team.Conference)
+	case 6:
+		return strconv.Itoa(team.Interconference)
+	case 7:
+		return strconv.Itoa(team.RoadStretches)
+	case 8:
+		return strconv.Itoa(team.LongestRoadStretch)
+	case 9:
+		return fmt.Sprintf("%.0f", team.TravelMiles)
+	default:
+		return ""
+	}
+}
+
+// ExportStats writes the per-team statistics of every rotation to a CSV file
+func (v *ScheduleAnalysisView) ExportStats(path string) error {
+	if v.analysis == nil {
+		return fmt.Errorf("no schedule analyzed")
+	}
+	return data.NewCSVWriter(path).WriteAllFromSlice(schedule.StatsHeaders(), v.analysis.StatsRows())
+}
+
+// ExportOpponentMatrix writes the opponent matrix of the selected rotation to a CSV file
+func (v *ScheduleAnalysisView) ExportOpponentMatrix(path string) error {
+	if v.analysis == nil {
+		return fmt.Errorf("no schedule analyzed")
+	}
+	headers, rows, err := v.analysis.OpponentMatrix(v.rotation)
+	if err != nil {
+		return err
+	}
+	return data.NewCSVWriter(path).WriteAllFromSlice(headers, rows)
+}
+
+// showOpenDialog lets the user pick a schedule template file
+func (v *ScheduleAnalysisView) showOpenDialog() {
+	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, v.window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer reader.Close()
+
+		if err := v.LoadTemplate(reader.URI().Path()); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to analyze schedule: %w", err), v.window)
+		}
+	}, v.window)
+
+	// Set default location to FOF9 installation folder
+	if defaultLocation := getDefaultCSVPath(); defaultLocation != nil {
+		fileDialog.SetLocation(defaultLocation)
+	}
+
+	fileDialog.Show()
+}
+
+// showExportDialog asks for a destination and exports statistics or the opponent matrix
+func (v *ScheduleAnalysisView) showExportDialog(matrix bool) {
+	if v.analysis == nil {
+		dialog.ShowInformation("No Data", "Open a schedule template first.", v.window)
+		return
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, v.window)
+			return
+		}
+		if writer == nil {
+			return
+		}
+
+		filePath := writer.URI().Path()
+
+		// Close the writer immediately to release the file lock
+		writer.Close()
+
+		if matrix {
+			err = v.ExportOpponentMatrix(filePath)
+		} else {
+			err = v.ExportStats(filePath)
+		}
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to export: %w", err), v.window)
+			return
+		}
+
+		dialog.ShowInformation("Success", fmt.Sprintf("Exported to %s", filepath.Base(filePath)), v.window)
+	}, v.window)
+
+	if matrix {
+		saveDialog.SetFileName(v.analysis.ScheduleID + "_opponents.csv")
+	} else {
+		saveDialog.SetFileName(v.analysis.ScheduleID + "_analysis.csv")
+	}
+
+	saveDialog.Show()
+}
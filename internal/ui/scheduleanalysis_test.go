@@ -0,0 +1,103 @@
+// ABOUTME: Tests for the schedule analysis window
+// ABOUTME: Validates template loading, rotation switching and CSV export
+
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+func newAnalysisFixtureView(t *testing.T) *ScheduleAnalysisView {
+	t.Helper()
+
+	appState := state.GetInstance()
+	appState.Reset()
+
+	teams, err := data.LoadTeams("../../testdata/fixtures/csv/teams_league4.csv")
+	if err != nil {
+		t.Fatalf("LoadTeams failed: %v", err)
+	}
+	appState.SetTeams(teams)
+
+	return NewScheduleAnalysisView(test.NewApp(), appState)
+}
+
+func TestScheduleAnalysisView_LoadTemplate(t *testing.T) {
+	v := newAnalysisFixtureView(t)
+
+	if err := v.LoadTemplate("../../testdata/fixtures/csv/4_2_3_schedule.csv"); err != nil {
+		t.Fatalf("LoadTemplate failed: %v", err)
+	}
+
+	if len(v.rotationSelect.Options) != 3 {
+		t.Fatalf("Expected 3 rotation options, got %d", len(v.rotationSelect.Options))
+	}
+	if v.rotationSelect.Selected != cycleOption {
+		t.Errorf("Expected '%s' selected, got '%s'", cycleOption, v.rotationSelect.Selected)
+	}
+
+	// Full cycle: every team plays 3 home games
+	if text := v.cellText(0, 2); text != "3" {
+		t.Errorf("Expected 3 home games for first team, got '%s'", text)
+	}
+
+	v.rotationSelect.SetSelected("Rotation 1")
+	if v.rotation != 1 {
+		t.Errorf("Expected rotation 1, got %d", v.rotation)
+	}
+	if text := v.cellText(0, 2); text != "2" {
+		t.Errorf("Expected 2 home games in rotation 1, got '%s'", text)
+	}
+}
+
+func TestScheduleAnalysisView_LoadTemplate_NoTeams(t *testing.T) {
+	appState := state.GetInstance()
+	appState.Reset()
+
+	v := NewScheduleAnalysisView(test.NewApp(), appState)
+	if err := v.LoadTemplate("../../testdata/fixtures/csv/4_2_3_schedule.csv"); err == nil {
+		t.Error("Expected error when no teams are loaded")
+	}
+}
+
+func TestScheduleAnalysisView_Export(t *testing.T) {
+	v := newAnalysisFixtureView(t)
+
+	tempDir := t.TempDir()
+	if err := v.ExportStats(filepath.Join(tempDir, "none.csv")); err == nil {
+		t.Error("Expected error when exporting without an analysis")
+	}
+
+	if err := v.LoadTemplate("../../testdata/fixtures/csv/4_2_3_schedule.csv"); err != nil {
+		t.Fatalf("LoadTemplate failed: %v", err)
+	}
+
+	statsPath := filepath.Join(tempDir, "stats.csv")
+	if err := v.ExportStats(statsPath); err != nil {
+		t.Fatalf("ExportStats failed: %v", err)
+	}
+	records, err := data.NewCSVReader(statsPath).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read exported stats: %v", err)
+	}
+	if len(records) != 12 {
+		t.Errorf("Expected 12 exported rows, got %d", len(records))
+	}
+
+	matrixPath := filepath.Join(tempDir, "matrix.csv")
+	if err := v.ExportOpponentMatrix(matrixPath); err != nil {
+		t.Fatalf("ExportOpponentMatrix failed: %v", err)
+	}
+	records, err = data.NewCSVReader(matrixPath).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read exported matrix: %v", err)
+	}
+	if len(records) != 4 || records[0]["NYE"] != "2" {
+		t.Errorf("Unexpected opponent matrix: %v", records)
+	}
+}
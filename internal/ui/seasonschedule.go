@@ -0,0 +1,237 @@
+// ABOUTME: Season schedule window for FOF9 Editor
+// ABOUTME: Shows a dated xxxx_schedule.csv and exports it as an iCalendar file or static HTML page
+
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/schedule"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+// Season schedule export formats
+const (
+	exportICalendar = "ics"
+	exportHTML      = "html"
+)
+
+// SeasonScheduleView displays a dated season schedule in its own window
+type SeasonScheduleView struct {
+	window        fyne.Window
+	state         *state.AppState
+	season        *schedule.SeasonSchedule
+	baseName      string // Schedule file name without extension, used for export names
+	headers       []string
+	table         *widget.Table
+	scheduleLabel *widget.Label
+	warningsLabel *widget.Label
+}
+
+// NewSeasonScheduleView creates the season schedule window
+func NewSeasonScheduleView(app fyne.App, appState *state.AppState) *SeasonScheduleView {
+	v := &SeasonScheduleView{
+		window:  app.NewWindow("Season Schedule"),
+		state:   appState,
+		headers: []string{"Week", "Date", "Visitor", "Home", "Location"},
+	}
+
+	v.setupContent()
+	return v
+}
+
+// setupContent builds the window layout
+func (v *SeasonScheduleView) setupContent() {
+	v.scheduleLabel = widget.NewLabel("No season schedule loaded")
+	v.warningsLabel = widget.NewLabel("")
+	v.warningsLabel.Wrapping = fyne.TextWrapWord
+
+	v.table = widget.NewTable(
+		func() (int, int) {
+			rows := 1
+			if v.season != nil {
+				rows += len(v.season.Games)
+			}
+			return rows, len(v.headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Exhibition Week 1")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(v.headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			label.SetText(v.cellText(id.Row-1, id.Col))
+			label.TextStyle = fyne.TextStyle{}
+		},
+	)
+	v.table.SetColumnWidth(0, 150)
+	v.table.SetColumnWidth(1, 110)
+	v.table.SetColumnWidth(2, 220)
+	v.table.SetColumnWidth(3, 220)
+	v.table.SetColumnWidth(4, 200)
+
+	openButton := widget.NewButton("Open Schedule...", func() {
+		v.showOpenDialog()
+	})
+	icsButton := widget.NewButton("Export iCalendar...", func() {
+		v.showExportDialog(exportICalendar)
+	})
+	htmlButton := widget.NewButton("Export HTML...", func() {
+		v.showExportDialog(exportHTML)
+	})
+
+	toolbar := container.NewHBox(openButton, icsButton, htmlButton)
+	top := container.NewVBox(toolbar, v.scheduleLabel, widget.NewSeparator())
+	bottom := container.NewVBox(widget.NewSeparator(), v.warningsLabel)
+
+	v.window.SetContent(container.NewBorder(top, bottom, nil, nil, v.table))
+	v.window.Resize(fyne.NewSize(1000, 700))
+}
+
+// Show displays the season schedule window
+func (v *SeasonScheduleView) Show() {
+	v.window.Show()
+}
+
+// LoadSchedule reads a dated season schedule file and resolves it against the loaded teams
+func (v *SeasonScheduleView) LoadSchedule(path string) error {
+	games, err := data.LoadSeasonSchedule(path)
+	if err != nil {
+		return err
+	}
+	if len(v.state.GetTeams()) == 0 {
+		return fmt.Errorf("no teams loaded; load a team file first")
+	}
+
+	v.season = schedule.NewSeasonSchedule(games, v.state.GetTeams(), v.state.GetCities())
+	v.baseName = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	v.scheduleLabel.SetText(fmt.Sprintf("Schedule: %s (%d season, %d games, %d weeks)",
+		filepath.Base(path), v.season.Year, len(v.season.Games), len(v.season.Weeks())))
+
+	if !v.state.ReferenceData.HasCities() {
+		v.warningsLabel.SetText("Game locations need city data: use File > Load Reference Data...")
+	} else {
+		v.warningsLabel.SetText("")
+	}
+
+	v.table.Refresh()
+	return nil
+}
+
+// cellText returns the display text of a table cell
+func (v *SeasonScheduleView) cellText(row, col int) string {
+	if v.season == nil || row >= len(v.season.Games) {
+		return ""
+	}
+
+	game := &v.season.Games[row]
+	switch col {
+	case 0:
+		return v.season.WeekLabel(game.Week)
+	case 1:
+		return game.Date().Format("Jan 2, 2006")
+	case 2:
+		return v.season.TeamName(game.Visitor)
+	case 3:
+		return v.season.TeamName(game.Home)
+	case 4:
+		location := v.season.Location(game)
+		if v.season.IsNeutralSite(game) {
+			location += " (neutral)"
+		}
+		return location
+	default:
+		return ""
+	}
+}
+
+// Export writes the loaded schedule as an iCalendar file or HTML page
+func (v *SeasonScheduleView) Export(format, path string) error {
+	if v.season == nil {
+		return fmt.Errorf("no season schedule loaded")
+	}
+
+	switch format {
+	case exportICalendar:
+		return schedule.SaveICalendar(path, v.season)
+	case exportHTML:
+		return schedule.SaveScheduleHTML(path, v.season)
+	default:
+		return fmt.Errorf("unknown export format '%s'", format)
+	}
+}
+
+// showOpenDialog lets the user pick a season schedule file
+func (v *SeasonScheduleView) showOpenDialog() {
+	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, v.window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer reader.Close()
+
+		if err := v.LoadSchedule(reader.URI().Path()); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to load schedule: %w", err), v.window)
+		}
+	}, v.window)
+
+	// Set default location to FOF9 installation folder
+	if defaultLocation := getDefaultCSVPath(); defaultLocation != nil {
+		fileDialog.SetLocation(defaultLocation)
+	}
+
+	fileDialog.Show()
+}
+
+// showExportDialog asks for a destination and exports the schedule
+func (v *SeasonScheduleView) showExportDialog(format string) {
+	if v.season == nil {
+		dialog.ShowInformation("No Data", "Open a season schedule first.", v.window)
+		return
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, v.window)
+			return
+		}
+		if writer == nil {
+			return
+		}
+
+		filePath := writer.URI().Path()
+
+		// Close the writer immediately to release the file lock
+		writer.Close()
+
+		if err := v.Export(format, filePath); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to export: %w", err), v.window)
+			return
+		}
+
+		dialog.ShowInformation("Success", fmt.Sprintf("Exported to %s", filepath.Base(filePath)), v.window)
+	}, v.window)
+
+	saveDialog.SetFileName(v.baseName + "." + format)
+
+	// Set default location to FOF9 installation folder
+	if defaultLocation := getDefaultCSVPath(); defaultLocation != nil {
+		saveDialog.SetLocation(defaultLocation)
+	}
+
+	saveDialog.Show()
+}
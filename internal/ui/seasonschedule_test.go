@@ -0,0 +1,101 @@
+// ABOUTME: Tests for the season schedule window
+// ABOUTME: Validates schedule loading, table text and iCalendar/HTML export
+
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+func newSeasonFixtureView(t *testing.T) *SeasonScheduleView {
+	t.Helper()
+
+	appState := state.GetInstance()
+	appState.Reset()
+
+	teams, err := data.LoadTeams("../../testdata/fixtures/csv/teams_league4.csv")
+	if err != nil {
+		t.Fatalf("LoadTeams failed: %v", err)
+	}
+	appState.SetTeams(teams)
+
+	cities, err := data.LoadCities("../../testdata/fixtures/csv/cities_simple.csv")
+	if err != nil {
+		t.Fatalf("LoadCities failed: %v", err)
+	}
+	appState.ReferenceData.SetCities(cities)
+
+	return NewSeasonScheduleView(test.NewApp(), appState)
+}
+
+func TestSeasonScheduleView_LoadSchedule(t *testing.T) {
+	v := newSeasonFixtureView(t)
+
+	if err := v.LoadSchedule("../../testdata/fixtures/csv/2024_league4_schedule.csv"); err != nil {
+		t.Fatalf("LoadSchedule failed: %v", err)
+	}
+
+	if text := v.cellText(0, 0); text != "Exhibition Week 1" {
+		t.Errorf("Expected 'Exhibition Week 1', got '%s'", text)
+	}
+	if text := v.cellText(0, 1); text != "Aug 11, 2024" {
+		t.Errorf("Expected 'Aug 11, 2024', got '%s'", text)
+	}
+	if text := v.cellText(5, 4); text != "Los Angeles, CA (neutral)" {
+		t.Errorf("Expected neutral site location, got '%s'", text)
+	}
+	if v.baseName != "2024_league4_schedule" {
+		t.Errorf("Expected base name '2024_league4_schedule', got '%s'", v.baseName)
+	}
+}
+
+func TestSeasonScheduleView_LoadSchedule_NoTeams(t *testing.T) {
+	appState := state.GetInstance()
+	appState.Reset()
+	v := NewSeasonScheduleView(test.NewApp(), appState)
+
+	if err := v.LoadSchedule("../../testdata/fixtures/csv/2024_league4_schedule.csv"); err == nil {
+		t.Error("Expected error without teams")
+	}
+}
+
+func TestSeasonScheduleView_Export(t *testing.T) {
+	v := newSeasonFixtureView(t)
+	dir := t.TempDir()
+
+	if err := v.Export(exportICalendar, filepath.Join(dir, "schedule.ics")); err == nil {
+		t.Error("Expected error before a schedule is loaded")
+	}
+
+	if err := v.LoadSchedule("../../testdata/fixtures/csv/2024_league4_schedule.csv"); err != nil {
+		t.Fatalf("LoadSchedule failed: %v", err)
+	}
+
+	for format, want := range map[string]string{
+		exportICalendar: "BEGIN:VCALENDAR",
+		exportHTML:      "<!DOCTYPE html>",
+	} {
+		path := filepath.Join(dir, "schedule."+format)
+		if err := v.Export(format, path); err != nil {
+			t.Fatalf("Export %s failed: %v", format, err)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read export: %v", err)
+		}
+		if !strings.HasPrefix(string(content), want) {
+			t.Errorf("Expected %s export to start with '%s'", format, want)
+		}
+	}
+
+	if err := v.Export("pdf", filepath.Join(dir, "schedule.pdf")); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
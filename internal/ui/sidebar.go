@@ -25,6 +25,7 @@ func NewSidebar(onSectionChange func(section string)) *Sidebar {
 			"Players",
 			"Coaches",
 			"Teams",
+			"Rosters",
 			"League Info",
 		},
 		selectedIndex:   0,
@@ -35,7 +35,7 @@ func TestSidebar_GetSections(t *testing.T) {
 		t.Fatal("GetSections returned empty slice")
 	}
 
-	expectedSections := []string{"Players", "Coaches", "Teams", "League Info"}
+	expectedSections := []string{"Players", "Coaches", "Teams", "Rosters", "League Info"}
 	if len(sections) != len(expectedSections) {
 		t.Fatalf("Expected %d sections, got %d", len(expectedSections), len(sections))
 	}
@@ -5,6 +5,7 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -17,6 +18,7 @@ type TeamList struct {
 	container      *fyne.Container
 	table          *widget.Table
 	teams          []models.Team
+	rows           []int // Index in teams of each row, in display order
 	headers        []string
 	selectedRow    int
 	onSelectChange func(int)
@@ -42,7 +44,7 @@ func NewTeamList() *TeamList {
 func (tl *TeamList) setupTable() {
 	tl.table = widget.NewTable(
 		func() (int, int) {
-			return len(tl.teams) + 1, len(tl.headers) // +1 for header row
+			return len(tl.rows) + 1, len(tl.headers) // +1 for header row
 		},
 		func() fyne.CanvasObject {
 			return widget.NewLabel("Template")
@@ -67,12 +69,12 @@ func (tl *TeamList) setupTable() {
 
 			// Data rows
 			teamIdx := id.Row - 1
-			if teamIdx >= len(tl.teams) {
+			if teamIdx >= len(tl.rows) {
 				label.SetText("")
 				return
 			}
 
-			team := tl.teams[teamIdx]
+			team := tl.teams[tl.rows[teamIdx]]
 			switch id.Col {
 			case 0:
 				label.SetText(fmt.Sprintf("%d", team.TeamID))
@@ -109,8 +111,8 @@ func (tl *TeamList) setupTable() {
 		} else {
 			// Data row clicked - select team
 			tl.selectedRow = id.Row - 1
-			if tl.onSelectChange != nil {
-				tl.onSelectChange(tl.selectedRow)
+			if tl.onSelectChange != nil && tl.selectedRow < len(tl.rows) {
+				tl.onSelectChange(tl.rows[tl.selectedRow])
 			}
 		}
 	}
@@ -121,6 +123,11 @@ func (tl *TeamList) setupTable() {
 // SetTeams updates the displayed teams
 func (tl *TeamList) SetTeams(teams []models.Team) {
 	tl.teams = teams
+	tl.rows = make([]int, len(teams))
+	for i := range tl.rows {
+		tl.rows[i] = i
+	}
+	tl.sortTeams()
 	tl.table.Refresh()
 }
 
@@ -136,20 +143,30 @@ func (tl *TeamList) GetContainer() *fyne.Container {
 
 // GetSelectedTeam returns the currently selected team, or nil if none selected
 func (tl *TeamList) GetSelectedTeam() *models.Team {
-	if tl.selectedRow < 0 || tl.selectedRow >= len(tl.teams) {
+	if tl.selectedRow < 0 || tl.selectedRow >= len(tl.rows) {
 		return nil
 	}
-	return &tl.teams[tl.selectedRow]
+	return &tl.teams[tl.rows[tl.selectedRow]]
 }
 
-// SetOnSelectChange sets the callback for when a team is selected
+// SetOnSelectChange sets the callback for when a team is selected. It is
+// passed the team's index in the teams set, not the list row.
 func (tl *TeamList) SetOnSelectChange(callback func(int)) {
 	tl.onSelectChange = callback
 }
 
+// SelectRecord selects the team at an index of the teams set as if clicked,
+// scrolling it into view; an already selected row is left as is
+func (tl *TeamList) SelectRecord(index int) {
+	if row := slices.Index(tl.rows, index); row >= 0 {
+		tl.table.Select(widget.TableCellID{Row: row + 1, Col: 0})
+	}
+}
+
 // Clear removes all teams from the list
 func (tl *TeamList) Clear() {
 	tl.teams = []models.Team{}
+	tl.rows = nil
 	tl.selectedRow = -1
 	tl.table.Refresh()
 }
@@ -171,24 +188,25 @@ func (tl *TeamList) SortByColumn(column int) {
 	tl.table.Refresh()
 }
 
-// sortTeams sorts the teams based on current sort settings
+// sortTeams sorts the rows based on current sort settings, leaving the
+// teams set in its file order
 func (tl *TeamList) sortTeams() {
-	if tl.sortColumn < 0 || len(tl.teams) == 0 {
+	if tl.sortColumn < 0 || len(tl.rows) == 0 {
 		return
 	}
 
-	for i := 0; i < len(tl.teams)-1; i++ {
-		for j := i + 1; j < len(tl.teams); j++ {
+	for i := 0; i < len(tl.rows)-1; i++ {
+		for j := i + 1; j < len(tl.rows); j++ {
 			if tl.compareTeam(i, j) {
-				tl.teams[i], tl.teams[j] = tl.teams[j], tl.teams[i]
+				tl.rows[i], tl.rows[j] = tl.rows[j], tl.rows[i]
 			}
 		}
 	}
 }
 
-// compareTeam compares two teams based on current sort column
+// compareTeam compares the teams in two rows based on current sort column
 func (tl *TeamList) compareTeam(i, j int) bool {
-	t1, t2 := tl.teams[i], tl.teams[j]
+	t1, t2 := tl.teams[tl.rows[i]], tl.teams[tl.rows[j]]
 
 	var result bool
 	switch tl.sortColumn {
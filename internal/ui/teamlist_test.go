@@ -67,6 +67,32 @@ func TestTeamList_GetTeams(t *testing.T) {
 	}
 }
 
+func TestTeamList_SelectRecord(t *testing.T) {
+	tl := NewTeamList()
+	teams := []models.Team{{TeamID: 1}, {TeamID: 2}, {TeamID: 3}}
+	tl.SetTeams(teams)
+
+	selected := -1
+	tl.SetOnSelectChange(func(index int) { selected = index })
+
+	// Sorting by ID descending puts team 2 in the second row either way
+	tl.SortByColumn(0)
+	tl.SortByColumn(0)
+	tl.SelectRecord(0)
+	if selected != 0 || tl.GetSelectedTeam().TeamID != 1 || tl.selectedRow != 2 {
+		t.Errorf("Expected team 1 in the last row selected, got index %d row %d", selected, tl.selectedRow)
+	}
+	if teams[0].TeamID != 1 {
+		t.Errorf("Expected sorting to leave the teams in file order, got %+v", teams)
+	}
+
+	selected = -1
+	tl.SelectRecord(3)
+	if selected != -1 {
+		t.Errorf("Expected an index past the end to be ignored, got index %d", selected)
+	}
+}
+
 func TestTeamList_GetContainer(t *testing.T) {
 	tl := NewTeamList()
 
@@ -0,0 +1,109 @@
+// ABOUTME: Team color validation for the teams file
+// ABOUTME: Checks colors against the reserved transparent magenta, primary darkness and primary/secondary contrast
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// Team color limits
+const (
+	// magentaTolerance is the RGB distance within which a color is close
+	// enough to the reserved magenta to be mistaken for it
+	magentaTolerance = 32
+
+	// minColorContrast is the lowest WCAG contrast ratio between the primary
+	// and secondary colors. WCAG asks 3:1 of graphics, but the secondary is a
+	// highlight never used for text and the shipped teams go down to 1.2:1;
+	// below 1.5:1 the two colors blend together.
+	minColorContrast = 1.5
+)
+
+// ValidateTeamColors checks the primary and secondary colors of a team:
+//   - TEAM_PRIMARY_MAGENTA, TEAM_SECONDARY_MAGENTA: the reserved magenta
+//     RGB(255,0,255) (error) or a color within 32 of it (warning)
+//   - TEAM_PRIMARY_DARKNESS (warning): a primary with a team_colors.csv
+//     DARKNESS below 50, too light for the light text drawn on it
+//   - TEAM_COLOR_CONTRAST (warning): a WCAG contrast ratio below 1.5:1
+//     between the primary and secondary colors
+//
+// Each finding suggests the nearest darker or lighter shade that passes.
+func ValidateTeamColors(team *models.Team) *ValidationResult {
+	result := NewValidationResult()
+	primary, secondary := team.PrimaryRGB(), team.SecondaryRGB()
+
+	primaryOK := func(c models.RGB) bool {
+		return !nearMagenta(c) && c.Darkness() >= models.MinPrimaryDarkness
+	}
+	secondaryOK := func(c models.RGB) bool {
+		return !nearMagenta(c) && models.ContrastRatio(primary, c) >= minColorContrast
+	}
+
+	for _, check := range []struct {
+		name, field, column string
+		color               models.RGB
+		acceptable          func(models.RGB) bool
+	}{
+		{"primary", "PrimaryColor", "PRIMARY", primary, primaryOK},
+		{"secondary", "SecondaryColor", "SECONDARY", secondary, secondaryOK},
+	} {
+		if !nearMagenta(check.color) {
+			continue
+		}
+		finding := ValidationError{
+			Field:      check.field,
+			Message:    fmt.Sprintf("%s color %s is the magenta the game draws as transparent", check.name, check.color),
+			Severity:   SeverityError,
+			Code:       "TEAM_" + check.column + "_MAGENTA",
+			Suggestion: colorSuggestion(check.column, check.color, check.acceptable),
+		}
+		if check.color != models.ReservedMagenta {
+			finding.Message = fmt.Sprintf("%s color %s is close to the magenta %s the game draws as transparent",
+				check.name, check.color, models.ReservedMagenta)
+			finding.Severity = SeverityWarning
+		}
+		result.AddFinding(finding)
+	}
+
+	if darkness := primary.Darkness(); darkness < models.MinPrimaryDarkness {
+		result.AddFinding(ValidationError{
+			Field: "PrimaryColor",
+			Message: fmt.Sprintf("primary color %s has a DARKNESS of %d; below %d the light text drawn on it is hard to read",
+				primary, darkness, models.MinPrimaryDarkness),
+			Severity:   SeverityWarning,
+			Code:       "TEAM_PRIMARY_DARKNESS",
+			Suggestion: colorSuggestion("PRIMARY", primary, primaryOK),
+		})
+	}
+
+	if ratio := models.ContrastRatio(primary, secondary); ratio < minColorContrast {
+		result.AddFinding(ValidationError{
+			Field: "SecondaryColor",
+			Message: fmt.Sprintf("primary %s and secondary %s have a contrast ratio of %.2f:1; expected at least %.1f:1",
+				primary, secondary, ratio, minColorContrast),
+			Severity:   SeverityWarning,
+			Code:       "TEAM_COLOR_CONTRAST",
+			Suggestion: colorSuggestion("SECONDARY", secondary, secondaryOK),
+		})
+	}
+
+	return result
+}
+
+// nearMagenta returns true if a color is the reserved magenta or close to it
+func nearMagenta(c models.RGB) bool {
+	return c.Distance(models.ReservedMagenta) <= magentaTolerance
+}
+
+// colorSuggestion proposes the nearest acceptable shade of a color as
+// "PRIMARYRED 214, PRIMARYGREEN 0, PRIMARYBLUE 214", or nothing if none is
+func colorSuggestion(column string, c models.RGB, acceptable func(models.RGB) bool) string {
+	shade, ok := c.NearestShade(acceptable)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%sRED %d, %sGREEN %d, %sBLUE %d", column, shade.Red, column, shade.Green, column, shade.Blue)
+}
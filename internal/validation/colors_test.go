@@ -0,0 +1,68 @@
+// ABOUTME: Tests for team color validation
+// ABOUTME: Covers the reserved magenta, primary darkness, contrast and the shipped team_info.csv
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestValidateTeamColors(t *testing.T) {
+	tests := []struct {
+		name       string
+		primary    models.RGB
+		secondary  models.RGB
+		code       string
+		severity   Severity
+		suggestion string
+	}{
+		{"magenta primary", models.RGB{Red: 255, Green: 0, Blue: 255}, models.RGB{Red: 255, Green: 255, Blue: 255}, "TEAM_PRIMARY_MAGENTA", SeverityError,
+			"PRIMARYRED 232, PRIMARYGREEN 0, PRIMARYBLUE 232"},
+		{"near magenta secondary", models.RGB{Red: 0, Green: 0, Blue: 0}, models.RGB{Red: 250, Green: 10, Blue: 240}, "TEAM_SECONDARY_MAGENTA", SeverityWarning,
+			"SECONDARYRED 238, SECONDARYGREEN 10, SECONDARYBLUE 228"},
+		{"light primary", models.RGB{Red: 162, Green: 141, Blue: 91}, models.RGB{Red: 0, Green: 0, Blue: 0}, "TEAM_PRIMARY_DARKNESS", SeverityWarning,
+			"PRIMARYRED 147, PRIMARYGREEN 128, PRIMARYBLUE 83"},
+		{"low contrast", models.RGB{Red: 0, Green: 142, Blue: 151}, models.RGB{Red: 252, Green: 76, Blue: 2}, "TEAM_COLOR_CONTRAST", SeverityWarning,
+			"SECONDARYRED 253, SECONDARYGREEN 121, SECONDARYBLUE 65"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team := &models.Team{
+				PrimaryRed: tt.primary.Red, PrimaryGreen: tt.primary.Green, PrimaryBlue: tt.primary.Blue,
+				SecondaryRed: tt.secondary.Red, SecondaryGreen: tt.secondary.Green, SecondaryBlue: tt.secondary.Blue,
+			}
+			result := ValidateTeamColors(team)
+			if len(result.Errors) != 1 {
+				t.Fatalf("Expected 1 finding, got %v", result.Errors)
+			}
+			got := result.Errors[0]
+			if got.Code != tt.code || got.Severity != tt.severity || got.Suggestion != tt.suggestion {
+				t.Errorf("Expected %s (%s) suggesting %q, got %s (%s) suggesting %q",
+					tt.code, tt.severity, tt.suggestion, got.Code, got.Severity, got.Suggestion)
+			}
+		})
+	}
+}
+
+func TestValidateTeamColors_DefaultData(t *testing.T) {
+	teams, err := data.LoadTeams("../../default_data/team_info.csv")
+	if err != nil {
+		t.Fatalf("LoadTeams failed: %v", err)
+	}
+
+	// The Saints' gold primary is lighter than the docs allow in every
+	// season, and the Dolphins' orange on aqua blends in some
+	counts := make(map[string]int)
+	for i := range teams {
+		for _, finding := range ValidateTeamColors(&teams[i]).Errors {
+			counts[finding.Code]++
+		}
+	}
+	if len(counts) != 2 || counts["TEAM_PRIMARY_DARKNESS"] != 23 || counts["TEAM_COLOR_CONTRAST"] != 7 {
+		t.Errorf("Expected 23 darkness and 7 contrast warnings, got %v", counts)
+	}
+}
@@ -0,0 +1,76 @@
+// ABOUTME: Contract and salary cap validation across the player and quarterback files
+// ABOUTME: Turns the roster package's contract checks into located findings
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+)
+
+// ValidateContracts checks the contracts of both files against each other
+// and, when info is not nil, against the league's salary cap and minimums:
+//   - a SALARYYEARS that differs from the number of years with a salary is
+//     a PLAYER_SALARYYEARS or QB_SALARYYEARS error suggesting that number;
+//   - a first-year salary below the minimum for the player's experience is a
+//     PLAYER_SALARY_MINIMUM or QB_SALARY_MINIMUM warning;
+//   - a free agent (TEAM 0) with a contract is a PLAYER_FREE_AGENT_CONTRACT
+//     or QB_FREE_AGENT_CONTRACT warning;
+//   - a team whose salaries plus bonuses exceed the cap in a contract year
+//     is a TEAM_SALARY_CAP warning for that year, located at the team's
+//     entry for season in the team file.
+//
+// Salaries and bonuses of 10,000 or more are reported by the field rules of
+// each file.
+func ValidateContracts(playersFile string, players []models.Player, quarterbacksFile string, quarterbacks []models.Quarterback,
+	teamsFile string, teams []models.Team, season int, info *models.LeagueInfo) *ValidationResult {
+	result := NewValidationResult()
+
+	for _, issue := range roster.CheckContracts(players, quarterbacks, info) {
+		found := NewValidationResult()
+		add := func(field string, severity Severity, code, suggestion string) {
+			found.AddFinding(ValidationError{Field: field, Message: issue.Message(), Severity: severity, Code: code,
+				Suggestion: suggestion})
+		}
+
+		member := issue.Member
+		prefix, file := "PLAYER", playersFile
+		if member.Quarterback {
+			prefix, file = "QB", quarterbacksFile
+		}
+
+		switch issue.Problem {
+		case roster.ContractYearsMismatch:
+			add("SalaryYears", SeverityError, prefix+"_SALARYYEARS", fmt.Sprintf("SALARYYEARS %d", issue.Limit))
+		case roster.ContractBelowMinimum:
+			add("SalaryYear1", SeverityWarning, prefix+"_SALARY_MINIMUM", "")
+		case roster.ContractFreeAgent:
+			add("Team", SeverityWarning, prefix+"_FREE_AGENT_CONTRACT", "")
+		case roster.ContractOverCap:
+			add("Team", SeverityWarning, "TEAM_SALARY_CAP", "")
+			line, record, key := teamLocation(teams, season, issue.TeamID)
+			found.locate(teamsFile, line, record, key)
+			result.Merge(found)
+			continue
+		}
+
+		found.locate(file, member.Index+2, fmt.Sprintf("Player %d (%s)", member.PlayerID, member.Name),
+			roster.PlayerKey(member.PlayerID))
+		result.Merge(found)
+	}
+
+	return result
+}
+
+// teamLocation returns the line, description and suppression key of a
+// team's entry for a season in the team file, or line 0 if it is not loaded
+func teamLocation(teams []models.Team, season, teamID int) (int, string, string) {
+	for i := range teams {
+		if teams[i].TeamID == teamID && teams[i].Year == season {
+			return i + 2, teamRecord(&teams[i]), teamKey(&teams[i])
+		}
+	}
+	return 0, fmt.Sprintf("Team %d", teamID), fmt.Sprintf("team:%d:%d", season, teamID)
+}
@@ -0,0 +1,72 @@
+// ABOUTME: Tests for contract and salary cap validation
+// ABOUTME: Checks codes, severities and locations of contract findings in both files and the team file
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestValidateContracts(t *testing.T) {
+	info := models.NewDefaultLeagueInfo(2024)
+	info.SalaryCap = 3 // $300,000
+
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 501, FirstName: "Aaron", LastName: "Rodgers", Team: 20, Experience: 6, SalaryYears: 1, SalaryYear1: 120},
+	}
+	players := []models.Player{
+		{PlayerID: 1000, FirstName: "Davante", LastName: "Adams", Team: 20, SalaryYears: 2, SalaryYear1: 70, BonusYear2: 40},
+		{PlayerID: 1001, FirstName: "Free", LastName: "Agent", Team: 0, SalaryYears: 1, SalaryYear1: 70},
+		{PlayerID: 1002, FirstName: "Big", LastName: "Bonus", Team: 20, SalaryYears: 1, SalaryYear1: 70, BonusYear1: 10000},
+	}
+	teams := []models.Team{
+		{Year: 2023, TeamID: 20, Abbreviation: "NYJ"},
+		{Year: 2024, TeamID: 20, Abbreviation: "NYJ"},
+	}
+
+	result := ValidateContracts("2024_players.csv", players, "2024_quarterbacks.csv", quarterbacks,
+		"team_info.csv", teams, 2024, info)
+
+	expected := []struct {
+		file     string
+		line     int
+		code     string
+		severity Severity
+		key      string
+	}{
+		{"2024_quarterbacks.csv", 2, "QB_SALARY_MINIMUM", SeverityWarning, "player:501"},
+		{"2024_players.csv", 2, "PLAYER_SALARYYEARS", SeverityError, "player:1000"},
+		{"2024_players.csv", 3, "PLAYER_FREE_AGENT_CONTRACT", SeverityWarning, "player:1001"},
+		{"team_info.csv", 3, "TEAM_SALARY_CAP", SeverityWarning, "team:2024:20"},
+		{"team_info.csv", 3, "TEAM_SALARY_CAP", SeverityWarning, "team:2024:20"},
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), result.Errors)
+	}
+	for i, want := range expected {
+		got := result.Errors[i]
+		if got.File != want.file || got.Line != want.line || got.Code != want.code ||
+			got.Severity != want.severity || got.RecordKey != want.key {
+			t.Errorf("Finding %d: expected %s:%d %s %s (%s), got %s:%d %s %s (%s)", i,
+				want.file, want.line, want.code, want.severity, want.key,
+				got.File, got.Line, got.Code, got.Severity, got.RecordKey)
+		}
+	}
+	if got := result.Errors[1].Suggestion; got != "SALARYYEARS 1" {
+		t.Errorf("Expected the salaried years to be suggested, got %q", got)
+	}
+	// Every contract year is held to the cap; the bonus of 10000 is left to
+	// the field rules
+	if got := result.Errors[4].Message; got != "team 20 commits $400,000 in contract year 2, over the $300,000 salary cap" {
+		t.Errorf("Expected the second year over the cap, got %q", got)
+	}
+
+	// Without league info the cap and minimums are not checked
+	result = ValidateContracts("2024_players.csv", players, "2024_quarterbacks.csv", quarterbacks,
+		"team_info.csv", teams, 2024, nil)
+	if len(result.Errors) != 2 {
+		t.Errorf("Expected 2 findings without league info, got %v", result.Errors)
+	}
+}
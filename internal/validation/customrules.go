@@ -0,0 +1,414 @@
+// ABOUTME: Custom validation rules a project stores next to its files
+// ABOUTME: Checks the house rules of each record or each team and position group and reports malformed rules
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// RuleData holds the files custom rules are checked against
+type RuleData struct {
+	ProjectFile      string // Project file name that malformed rules are reported in
+	PlayersFile      string
+	Players          []models.Player
+	QuarterbacksFile string
+	Quarterbacks     []models.Quarterback
+	CoachesFile      string
+	Coaches          []models.Coach
+	TeamsFile        string
+	Teams            []models.Team
+	TeamYear         int // Season of the player and coach files, whose teams per team rules group by
+	BaseYear         int // League BASE_YEAR that AGE is counted at
+}
+
+// ruleColumns maps the CSV columns of a record type to its struct fields
+type ruleColumns struct {
+	index  map[string]int      // Struct field index by column
+	fields map[string]string   // Struct field name by column, for jumping to the form field
+	types  map[string]ruleType // Column types
+}
+
+// newRuleColumns reads the csv tags of a record type
+func newRuleColumns(t reflect.Type) ruleColumns {
+	columns := ruleColumns{index: map[string]int{}, fields: map[string]string{}, types: map[string]ruleType{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		column := field.Tag.Get("csv")
+		if column == "" {
+			continue
+		}
+		columns.index[column] = i
+		columns.fields[column] = field.Name
+		columns.types[column] = ruleNumber
+		if field.Type.Kind() == reflect.String {
+			columns.types[column] = ruleString
+		}
+	}
+	return columns
+}
+
+// Columns of the records rules run over
+var (
+	playerRuleColumns      = newRuleColumns(reflect.TypeOf(models.Player{}))
+	quarterbackRuleColumns = newRuleColumns(reflect.TypeOf(models.Quarterback{}))
+	coachRuleColumns       = newRuleColumns(reflect.TypeOf(models.Coach{}))
+	teamRuleColumns        = newRuleColumns(reflect.TypeOf(models.Team{}))
+)
+
+// ruleComputedFields are the columns rules add to the files' own, with the
+// struct field a finding on them points at
+var ruleComputedFields = map[string]string{"AGE": "BirthYear", "POSITION": "PositionKey"}
+
+// ruleFieldTypes returns the columns a rule can use for players, coaches or
+// teams. Players have the columns of the player and quarterback files.
+func ruleFieldTypes(entity string) map[string]ruleType {
+	types := map[string]ruleType{}
+	switch entity {
+	case "player":
+		for _, columns := range []ruleColumns{playerRuleColumns, quarterbackRuleColumns} {
+			for column, typ := range columns.types {
+				types[column] = typ
+			}
+		}
+		types["AGE"], types["POSITION"] = ruleNumber, ruleString
+	case "coach":
+		for column, typ := range coachRuleColumns.types {
+			types[column] = typ
+		}
+		types["AGE"] = ruleNumber
+	case "team":
+		for column, typ := range teamRuleColumns.types {
+			types[column] = typ
+		}
+	}
+	return types
+}
+
+// newRuleRecord looks up the columns of a struct value and computed columns
+func newRuleRecord(value reflect.Value, columns ruleColumns, computed map[string]ruleValue) ruleRecord {
+	return func(column string) (ruleValue, bool) {
+		if v, ok := computed[column]; ok {
+			return v, true
+		}
+		i, ok := columns.index[column]
+		if !ok {
+			return ruleValue{}, false
+		}
+		field := value.Field(i)
+		switch field.Kind() {
+		case reflect.String:
+			return ruleValue{str: field.String()}, true
+		case reflect.Float64:
+			return ruleValue{num: field.Float()}, true
+		default:
+			return ruleValue{num: float64(field.Int())}, true
+		}
+	}
+}
+
+// ageColumns returns AGE at the base year, leaving it out without a birth year
+func ageColumns(birthYear, baseYear int) map[string]ruleValue {
+	computed := map[string]ruleValue{}
+	if birthYear > 0 && baseYear > 0 {
+		computed["AGE"] = ruleValue{num: float64(baseYear - birthYear)}
+	}
+	return computed
+}
+
+// ruleTarget is a record or group a rule is checked on, with its location
+type ruleTarget struct {
+	record   ruleRecord
+	group    []ruleRecord
+	file     string
+	line     int
+	name     string
+	key      string
+	team     int // Team and position of a player, for per rules
+	position int
+}
+
+// playerTargets returns the players of both files, quarterbacks first
+func playerTargets(data RuleData) []ruleTarget {
+	targets := make([]ruleTarget, 0, len(data.Players)+len(data.Quarterbacks))
+	for i := range data.Quarterbacks {
+		qb := &data.Quarterbacks[i]
+		computed := ageColumns(qb.BirthYear, data.BaseYear)
+		computed["POSITION_KEY"] = ruleValue{num: models.PositionQB}
+		computed["POSITION"] = ruleValue{str: models.GetPositionAbbr(models.PositionQB)}
+		targets = append(targets, ruleTarget{
+			record: newRuleRecord(reflect.ValueOf(qb).Elem(), quarterbackRuleColumns, computed),
+			file:   data.QuarterbacksFile, line: i + 2,
+			name: fmt.Sprintf("Quarterback %d (%s)", qb.PlayerID, qb.GetDisplayName()), key: fmt.Sprintf("player:%d", qb.PlayerID),
+			team: qb.Team, position: models.PositionQB,
+		})
+	}
+	for i := range data.Players {
+		player := &data.Players[i]
+		computed := ageColumns(player.BirthYear, data.BaseYear)
+		computed["POSITION"] = ruleValue{str: models.GetPositionAbbr(player.PositionKey)}
+		targets = append(targets, ruleTarget{
+			record: newRuleRecord(reflect.ValueOf(player).Elem(), playerRuleColumns, computed),
+			file:   data.PlayersFile, line: i + 2, name: playerRecord(player), key: playerKey(player),
+			team: player.Team, position: player.PositionKey,
+		})
+	}
+	return targets
+}
+
+// recordTargets returns the records an each rule runs over
+func recordTargets(entity string, data RuleData) []ruleTarget {
+	switch entity {
+	case "coach":
+		targets := make([]ruleTarget, 0, len(data.Coaches))
+		for i := range data.Coaches {
+			coach := &data.Coaches[i]
+			targets = append(targets, ruleTarget{
+				record: newRuleRecord(reflect.ValueOf(coach).Elem(), coachRuleColumns, ageColumns(coach.BirthYear, data.BaseYear)),
+				file:   data.CoachesFile, line: i + 2, name: coachRecord(coach), key: coachKey(coach),
+			})
+		}
+		return targets
+	case "team":
+		targets := make([]ruleTarget, 0, len(data.Teams))
+		for i := range data.Teams {
+			team := &data.Teams[i]
+			targets = append(targets, ruleTarget{
+				record: newRuleRecord(reflect.ValueOf(team).Elem(), teamRuleColumns, nil),
+				file:   data.TeamsFile, line: i + 2, name: teamRecord(team), key: teamKey(team),
+			})
+		}
+		return targets
+	}
+	return playerTargets(data)
+}
+
+// groupTargets groups the players that pass the where filter by team or
+// position. Every team of the league season and every position is a group,
+// even without players, so rules such as count(...) >= 3 catch empty ones.
+func groupTargets(rule *parsedRule, data RuleData) []ruleTarget {
+	groups := map[int][]ruleRecord{}
+	for _, player := range playerTargets(data) {
+		if !ruleApplies(rule, player.record) {
+			continue
+		}
+		key := player.position
+		if rule.entity == "team" {
+			key = player.team
+		}
+		groups[key] = append(groups[key], player.record)
+	}
+
+	var targets []ruleTarget
+	if rule.entity == "position" {
+		for _, position := range models.DefaultPositions() {
+			targets = append(targets, ruleTarget{
+				group: groups[position.ID], file: data.PlayersFile,
+				name: "Position " + position.Abbreviation, key: "position:" + position.Abbreviation,
+			})
+		}
+		return targets
+	}
+
+	teamIDs := []int{}
+	rows := map[int]int{}
+	for i, team := range data.Teams {
+		if team.Year == 0 || team.Year == data.TeamYear {
+			teamIDs = append(teamIDs, team.TeamID)
+			rows[team.TeamID] = i
+		}
+	}
+	if len(teamIDs) == 0 {
+		for teamID := range groups {
+			if teamID != 0 {
+				teamIDs = append(teamIDs, teamID)
+			}
+		}
+		sort.Ints(teamIDs)
+	}
+	for _, teamID := range teamIDs {
+		target := ruleTarget{
+			group: groups[teamID], file: data.PlayersFile,
+			name: fmt.Sprintf("Team %d", teamID), key: fmt.Sprintf("team:%d:%d", data.TeamYear, teamID),
+		}
+		if i, ok := rows[teamID]; ok {
+			team := &data.Teams[i]
+			target.file, target.line, target.name, target.key = data.TeamsFile, i+2, teamRecord(team), teamKey(team)
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// ruleApplies returns true if a record passes the rule's where filter
+func ruleApplies(rule *parsedRule, record ruleRecord) bool {
+	if rule.where == nil {
+		return true
+	}
+	v, ok := rule.where.eval(record, nil)
+	return ok && v.ok
+}
+
+// ValidateCustomRules checks a project's house rules. Each rule reports the
+// records or groups that break it with the code RULE_ and its name in
+// capitals (RULE_ and its number without a name), at the severity the rule
+// gives, warning by default. Records that lack a column the rule uses, such
+// as a quarterback's OVERALLRATING, and undefined values, such as the
+// average of an empty group, are skipped. A malformed rule is reported as an
+// error with the code RULE_SYNTAX and the column of the problem, and a rule
+// with an unknown severity as an error with the code RULE_SEVERITY.
+func ValidateCustomRules(rules []models.ValidationRule, data RuleData) *ValidationResult {
+	result := NewValidationResult()
+
+	for i, rule := range rules {
+		code := RuleCode(rule, i)
+		ruleName := fmt.Sprintf("Rule %d", i+1)
+		if rule.Name != "" {
+			ruleName = fmt.Sprintf("Rule %d (%s)", i+1, rule.Name)
+		}
+
+		severity, err := ParseSeverity(rule.Severity)
+		if err != nil {
+			result.AddFinding(ValidationError{
+				Field: "Severity", Message: fmt.Sprintf("%q has an invalid severity: %v", rule.Rule, err), Severity: SeverityError,
+				Code: "RULE_SEVERITY", File: data.ProjectFile, Record: ruleName, RecordKey: "rule:" + code,
+			})
+			continue
+		}
+		parsed, err := parseRule(rule.Rule)
+		if err != nil {
+			result.AddFinding(ValidationError{
+				Field: "Rule", Message: fmt.Sprintf("%q is malformed: %v", rule.Rule, err), Severity: SeverityError,
+				Code: "RULE_SYNTAX", File: data.ProjectFile, Record: ruleName, RecordKey: "rule:" + code,
+			})
+			continue
+		}
+
+		var targets []ruleTarget
+		if parsed.per {
+			targets = groupTargets(parsed, data)
+		} else {
+			for _, target := range recordTargets(parsed.entity, data) {
+				if ruleApplies(parsed, target.record) {
+					targets = append(targets, target)
+				}
+			}
+		}
+
+		for _, target := range targets {
+			record := target.record
+			if record == nil {
+				record = func(string) (ruleValue, bool) { return ruleValue{}, false }
+			}
+			v, ok := parsed.body.eval(record, target.group)
+			if !ok || v.ok {
+				continue
+			}
+
+			message := fmt.Sprintf("breaks %s", rule.Rule)
+			if rule.Name != "" {
+				message = fmt.Sprintf("breaks %q (%s)", rule.Name, rule.Rule)
+			}
+			if rule.Message != "" {
+				message = rule.Message
+			}
+			if detail := parsed.describe(record, target.group); detail != "" {
+				message += ": " + detail
+			}
+
+			result.AddFinding(ValidationError{
+				Field: parsed.findingField(), Message: message, Severity: severity, Code: code,
+				File: target.file, Line: target.line, Record: target.name, RecordKey: target.key,
+			})
+		}
+	}
+
+	return result
+}
+
+// describe gives the values of the non-literal sides of a rule that is a
+// comparison, e.g. "AGE is 37"
+func (r *parsedRule) describe(record ruleRecord, group []ruleRecord) string {
+	if !isComparison(r.body.op) {
+		return ""
+	}
+	parts := []string{}
+	for _, side := range []*ruleExpr{r.body.left, r.body.right} {
+		if side.op == "number" || side.op == "string" {
+			continue
+		}
+		if v, ok := side.eval(record, group); ok {
+			parts = append(parts, fmt.Sprintf("%s is %s", r.source(side), formatRuleValue(v, side.typ)))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// findingField returns the struct field a finding points at: the group's key
+// for per rules, else that of the first column the rule checks
+func (r *parsedRule) findingField() string {
+	switch {
+	case r.per && r.entity == "position":
+		return "PositionKey"
+	case r.per:
+		return "Team"
+	}
+
+	sources := []ruleColumns{playerRuleColumns, quarterbackRuleColumns}
+	if r.entity == "coach" {
+		sources = []ruleColumns{coachRuleColumns}
+	} else if r.entity == "team" {
+		sources = []ruleColumns{teamRuleColumns}
+	}
+
+	for _, column := range r.fields {
+		if field, ok := ruleComputedFields[column]; ok {
+			return field
+		}
+		for _, columns := range sources {
+			if field, ok := columns.fields[column]; ok {
+				return field
+			}
+		}
+	}
+	return "Rule"
+}
+
+// RuleCode returns the code of a rule's findings: RULE_ and the name in
+// capitals with other characters as underscores, e.g. RULE_MAX_2_STARS,
+// or RULE_ and the rule's 1-based number if it has no name
+func RuleCode(rule models.ValidationRule, index int) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(strings.TrimSpace(rule.Name)) {
+		switch {
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "_"):
+			b.WriteByte('_')
+		}
+	}
+	name := strings.TrimSuffix(b.String(), "_")
+	if name == "" {
+		return fmt.Sprintf("RULE_%d", index+1)
+	}
+	return "RULE_" + name
+}
+
+// ParseSeverity returns the severity named "error", "warning" or "info";
+// an empty name is a warning
+func ParseSeverity(name string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "warning":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	case "info":
+		return SeverityInfo, nil
+	}
+	return SeverityWarning, fmt.Errorf("severity %q must be error, warning or info", name)
+}
@@ -0,0 +1,166 @@
+// ABOUTME: Tests for custom house rules stored in the project
+// ABOUTME: Covers record and group rules, finding locations, malformed rules and project validation
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// ruleData returns two teams, three players and a quarterback in 1975
+func ruleData() RuleData {
+	return RuleData{
+		ProjectFile: "league.fof9proj",
+		PlayersFile: "1975_players.csv",
+		Players: []models.Player{
+			{PlayerID: 1000, FirstName: "Old", LastName: "Timer", Team: 1, PositionKey: models.PositionQB, BirthYear: 1938, OverallRating: 8},
+			{PlayerID: 1001, FirstName: "Star", LastName: "One", Team: 1, PositionKey: models.PositionRB, BirthYear: 1950, OverallRating: 9},
+			{PlayerID: 1002, FirstName: "Star", LastName: "Two", Team: 1, PositionKey: models.PositionTE, BirthYear: 1951, OverallRating: 8},
+		},
+		QuarterbacksFile: "1975_quarterbacks.csv",
+		Quarterbacks:     []models.Quarterback{{PlayerID: 1003, FirstName: "Young", LastName: "Gun", Team: 2, BirthYear: 1953}},
+		TeamsFile:        "team_info.csv",
+		Teams:            []models.Team{{Year: 1975, TeamID: 1, TeamName: "Boston", NickName: "Minutemen"}, {Year: 1975, TeamID: 2, TeamName: "Miami", NickName: "Dolphins"}},
+		TeamYear:         1975,
+		BaseYear:         1975,
+	}
+}
+
+func TestValidateCustomRules(t *testing.T) {
+	rules := []models.ValidationRule{
+		{Name: "No veterans", Rule: "each player: AGE <= 35", Severity: "error"},
+		{Name: "Three QBs", Rule: "per team: count(POSITION = 'QB') >= 3", Message: "every team needs 3 QBs"},
+		{Name: "Max 2 stars", Rule: "per team: count(OVERALLRATING >= 8) <= 2", Severity: "info"},
+		{Rule: "each player where POSITION = 'QB': TEAM = 1"},
+	}
+
+	result := ValidateCustomRules(rules, ruleData())
+
+	expected := []struct {
+		code     string
+		severity Severity
+		file     string
+		line     int
+		record   string
+		field    string
+		message  string
+	}{
+		{"RULE_NO_VETERANS", SeverityError, "1975_players.csv", 2, "Player 1000 (Old Timer)", "BirthYear",
+			`breaks "No veterans" (each player: AGE <= 35): AGE is 37`},
+		{"RULE_THREE_QBS", SeverityWarning, "team_info.csv", 2, "Team 1 (Boston Minutemen, 1975)", "Team",
+			"every team needs 3 QBs: count(POSITION = 'QB') is 1"},
+		{"RULE_THREE_QBS", SeverityWarning, "team_info.csv", 3, "Team 2 (Miami Dolphins, 1975)", "Team",
+			"every team needs 3 QBs: count(POSITION = 'QB') is 1"},
+		{"RULE_MAX_2_STARS", SeverityInfo, "team_info.csv", 2, "Team 1 (Boston Minutemen, 1975)", "Team",
+			`breaks "Max 2 stars" (per team: count(OVERALLRATING >= 8) <= 2): count(OVERALLRATING >= 8) is 3`},
+		{"RULE_4", SeverityWarning, "1975_quarterbacks.csv", 2, "Quarterback 1003 (Young Gun)", "Team",
+			"breaks each player where POSITION = 'QB': TEAM = 1: TEAM is 2"},
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Expected %d findings, got %d: %v", len(expected), len(result.Errors), result.Errors)
+	}
+	for i, want := range expected {
+		got := result.Errors[i]
+		if got.Code != want.code || got.Severity != want.severity || got.File != want.file || got.Line != want.line ||
+			got.Record != want.record || got.Field != want.field || got.Message != want.message {
+			t.Errorf("Finding %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+	if result.Errors[1].RecordKey != "team:1975:1" {
+		t.Errorf("Expected the team's suppression key, got %s", result.Errors[1].RecordKey)
+	}
+}
+
+func TestValidateCustomRules_Groups(t *testing.T) {
+	data := ruleData()
+	data.Teams = nil
+
+	// Without a team file the teams of the players are the groups
+	result := ValidateCustomRules([]models.ValidationRule{{Rule: "per team: count() >= 2"}}, data)
+	if len(result.Errors) != 1 || result.Errors[0].Record != "Team 2" || result.Errors[0].File != "1975_players.csv" ||
+		result.Errors[0].Line != 0 || result.Errors[0].RecordKey != "team:1975:2" {
+		t.Errorf("Expected team 2 to have too few players, got %v", result.Errors)
+	}
+
+	// Every position is a group; empty groups have no average
+	result = ValidateCustomRules([]models.ValidationRule{
+		{Rule: "per position: count() >= 1"},
+		{Rule: "per position where TEAM = 1: avg(OVERALLRATING) <= 8"},
+	}, data)
+	if got := len(result.WithSeverity(SeverityWarning).Errors); got != len(models.DefaultPositions())-3+1 {
+		t.Errorf("Expected the empty positions and RB, got %d findings", got)
+	}
+	last := result.Errors[len(result.Errors)-1]
+	if last.Record != "Position RB" || last.RecordKey != "position:RB" || last.Field != "PositionKey" || last.Message != "breaks per position where TEAM = 1: avg(OVERALLRATING) <= 8: avg(OVERALLRATING) is 9" {
+		t.Errorf("Unexpected position finding: %+v", last)
+	}
+}
+
+func TestValidateCustomRules_Malformed(t *testing.T) {
+	rules := []models.ValidationRule{
+		{Name: "Typo", Rule: "each player: AGEE <= 35"},
+		{Name: "Loud", Rule: "each player: AGE <= 35", Severity: "fatal"},
+	}
+
+	result := ValidateCustomRules(rules, ruleData())
+	if len(result.Errors) != 2 || result.Valid {
+		t.Fatalf("Expected 2 malformed rule errors, got %v", result.Errors)
+	}
+	got := result.Errors[0]
+	if got.Code != "RULE_SYNTAX" || got.File != "league.fof9proj" || got.Record != "Rule 1 (Typo)" || got.RecordKey != "rule:RULE_TYPO" ||
+		got.Message != `"each player: AGEE <= 35" is malformed: column 14: player has no column AGEE` {
+		t.Errorf("Unexpected syntax finding: %+v", got)
+	}
+	got = result.Errors[1]
+	if got.Code != "RULE_SEVERITY" || got.Field != "Severity" || got.Record != "Rule 2 (Loud)" ||
+		got.Message != `"each player: AGE <= 35" has an invalid severity: severity "fatal" must be error, warning or info` {
+		t.Errorf("Unexpected severity finding: %+v", got)
+	}
+}
+
+func TestRuleCode(t *testing.T) {
+	tests := map[string]string{
+		"Max 2 stars":          "RULE_MAX_2_STARS",
+		"  no players > 35! ":  "RULE_NO_PLAYERS_35",
+		"":                     "RULE_3",
+		"---":                  "RULE_3",
+		"Every team: three QB": "RULE_EVERY_TEAM_THREE_QB",
+	}
+	for name, want := range tests {
+		if got := RuleCode(models.ValidationRule{Name: name}, 2); got != want {
+			t.Errorf("%q: expected %s, got %s", name, want, got)
+		}
+	}
+}
+
+func TestValidateProject_CustomRules(t *testing.T) {
+	appState, refs := loadProjectFixtures(t)
+	project := models.NewProject("Test", "test", "", 2024)
+	project.ValidationRules = []models.ValidationRule{{Name: "Young league", Rule: "each player where TEAM != 0: PLAYERID < 1000"}}
+	appState.SetProject(project)
+	defer appState.Reset()
+
+	found := ValidateProject(appState, refs).ForFile("test_players.csv")
+	count := 0
+	for _, finding := range found.Errors {
+		if finding.Code == "RULE_YOUNG_LEAGUE" {
+			count++
+			if finding.Record != "Player 1000 (Tom Brady)" {
+				t.Errorf("Expected Tom Brady to break the rule, got %s", finding.Record)
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 custom rule finding, got %d", count)
+	}
+
+	// Custom rule findings are suppressed like built-in ones
+	project.AddValidationSuppression("RULE_YOUNG_LEAGUE")
+	for _, finding := range ValidateProject(appState, refs).Errors {
+		if finding.Code == "RULE_YOUNG_LEAGUE" {
+			t.Error("Expected the suppressed rule to be removed")
+		}
+	}
+}
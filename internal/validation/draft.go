@@ -0,0 +1,58 @@
+// ABOUTME: Draft history validation across the player and quarterback files
+// ABOUTME: Turns the roster package's draft board checks into located findings
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+)
+
+// ValidateDrafts rebuilds the draft of every YEARENTRY from both files and
+// checks that it is consistent, as the docs warn unusual values "could cause
+// instability in areas where past draft results can be viewed". Every check
+// is a warning:
+//   - PLAYER_DRAFT_DUP: an overall selection already held by another player
+//     of the same draft class
+//   - PLAYER_DRAFT_ROUND: a round that cannot hold the overall selection in a
+//     league of teams teams, allowing for forfeited and compensatory picks
+//   - PLAYER_DRAFT_ORIGINALTEAM: a drafted player with ORIGINALTEAM 0
+//   - PLAYER_DRAFT_SELECTION: an undrafted player with a selection, or a
+//     drafted player without one
+//
+// Quarterback findings use the QB prefix. A team count of 0 skips the round check.
+func ValidateDrafts(playersFile string, players []models.Player, quarterbacksFile string, quarterbacks []models.Quarterback, teams int) *ValidationResult {
+	result := NewValidationResult()
+
+	for _, issue := range roster.CheckDrafts(players, quarterbacks, teams) {
+		member := issue.Pick.Member
+		prefix, file := "PLAYER", playersFile
+		if member.Quarterback {
+			prefix, file = "QB", quarterbacksFile
+		}
+
+		var field, code string
+		switch issue.Problem {
+		case roster.DraftDuplicateSelection:
+			field, code = "SelectionDrafted", "_DRAFT_DUP"
+		case roster.DraftRoundMismatch:
+			field, code = "RoundDrafted", "_DRAFT_ROUND"
+		case roster.DraftNoOriginalTeam:
+			field, code = "OriginalTeam", "_DRAFT_ORIGINALTEAM"
+		case roster.DraftSelectionMismatch:
+			field, code = "SelectionDrafted", "_DRAFT_SELECTION"
+		default:
+			continue
+		}
+
+		found := NewValidationResult()
+		found.AddFinding(ValidationError{Field: field, Message: issue.Message(), Severity: SeverityWarning, Code: prefix + code})
+		found.locate(file, member.Index+2, fmt.Sprintf("Player %d (%s)", member.PlayerID, member.Name),
+			roster.PlayerKey(member.PlayerID))
+		result.Merge(found)
+	}
+
+	return result
+}
@@ -0,0 +1,50 @@
+// ABOUTME: Tests for draft history validation
+// ABOUTME: Checks codes, severities and locations of draft findings in both files
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestValidateDrafts(t *testing.T) {
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 501, FirstName: "Joe", LastName: "Burrow", YearEntry: 2020, RoundDrafted: 1, SelectionDrafted: 1},
+	}
+	players := []models.Player{
+		{PlayerID: 1000, FirstName: "Chase", LastName: "Young", YearEntry: 2020, RoundDrafted: 4, SelectionDrafted: 1, OriginalTeam: 31},
+		{PlayerID: 1001, FirstName: "Free", LastName: "Agent", YearEntry: 2020, SelectionDrafted: 90},
+	}
+
+	result := ValidateDrafts("2024_players.csv", players, "2024_quarterbacks.csv", quarterbacks, 32)
+
+	expected := []struct {
+		file string
+		line int
+		code string
+		key  string
+	}{
+		{"2024_quarterbacks.csv", 2, "QB_DRAFT_ORIGINALTEAM", "player:501"},
+		{"2024_players.csv", 2, "PLAYER_DRAFT_DUP", "player:1000"},
+		{"2024_players.csv", 2, "PLAYER_DRAFT_ROUND", "player:1000"},
+		{"2024_players.csv", 3, "PLAYER_DRAFT_SELECTION", "player:1001"},
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), result.Errors)
+	}
+	for i, want := range expected {
+		got := result.Errors[i]
+		if got.File != want.file || got.Line != want.line || got.Code != want.code || got.RecordKey != want.key {
+			t.Errorf("Finding %d: expected %s:%d %s (%s), got %s:%d %s (%s)",
+				i, want.file, want.line, want.code, want.key, got.File, got.Line, got.Code, got.RecordKey)
+		}
+		if got.Severity != SeverityWarning {
+			t.Errorf("Finding %d: expected a warning, got %s", i, got.Severity)
+		}
+	}
+	if !result.Valid {
+		t.Error("Expected draft findings not to make the result invalid")
+	}
+}
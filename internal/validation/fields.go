@@ -0,0 +1,243 @@
+// ABOUTME: Declarative field rules for the game's player, quarterback and coach files
+// ABOUTME: Each rule table mirrors the allowed values documented in default_data/*.txt
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// FieldRule constrains one CSV column of a record. Rules are matched to
+// struct fields through their csv tags and report findings under the struct
+// field name. A column can have several rules with different codes.
+type FieldRule struct {
+	Column     string
+	Code       string // Rule code; tables prefix it with the record kind, e.g. "PLAYER_UNIFORM"
+	Severity   Severity
+	Validators []FieldValidator
+	Skip       func(record interface{}) bool // Optional: true if the rule does not apply to a record
+}
+
+// rule builds an error rule for a column, coded by the column name
+func rule(column string, validators ...FieldValidator) FieldRule {
+	return FieldRule{Column: column, Validators: validators}
+}
+
+// advisory builds a rule with its own code and a severity below error
+func advisory(column, code string, severity Severity, validators ...FieldValidator) FieldRule {
+	return FieldRule{Column: column, Code: code, Severity: severity, Validators: validators}
+}
+
+// skillRating is a rating the game generates when it is -1 (players.txt,
+// quarterbacks.txt: "If not -1, this field should range from 0-250")
+var skillRating = Optional(-1, IntRange(0, 250))
+
+// salaryUnits is a salary or bonus in units of $10,000 ("Salaries and bonuses
+// must be below 10,000 ($1 billion) in a player file")
+var salaryUnits = IntRange(0, 9999)
+
+// unclampedColumns are the identity, reference, year and category columns:
+// the nearest value in range would be another record or a guess, not a fix
+var unclampedColumns = map[string]bool{
+	"PLAYERID": true, "TEAM": true, "ORIGINALTEAM": true, "BIRTHYEAR": true,
+	"YEARENTRY": true, "YEARSIGNED": true, "POSITION_KEY": true, "POSITIONGROUP": true,
+	"OFFENSIVESTYLE": true, "DEFENSIVESTYLE": true,
+}
+
+// nameRules are the name columns shared by every person file
+var nameRules = []FieldRule{
+	rule("LASTNAME", Required("Last name is required")),
+	advisory("LASTNAME", "LASTNAME_LENGTH", SeverityWarning, MaxLength(18)),
+	rule("FIRSTNAME", Required("First name is required")),
+	advisory("FIRSTNAME", "FIRSTNAME_LENGTH", SeverityWarning, MaxLength(16)),
+}
+
+// birthRules are the birth and college columns shared by every person file.
+// BIRTHCITY and COLLEGE are not read by the game.
+var birthRules = []FieldRule{
+	rule("BIRTHMONTH", MonthRange()),
+	rule("BIRTHDAY", DayRange()),
+	rule("BIRTHYEAR", YearRange(1900, 2100)),
+	rule("BIRTHCITY", MaxLength(50)),
+	rule("CITYID", IntNonNegative()),
+	rule("COLLEGE", MaxLength(50)),
+	rule("COLLEGEID", IntNonNegative()),
+}
+
+// careerRules are the draft, career and contract columns shared by the
+// player and quarterback files
+var careerRules = []FieldRule{
+	rule("PLAYERID", IntPositive()),
+	rule("TEAM", IntRange(0, models.MaxTeams)),
+	// Free agents (TEAM 0) have no uniform, written as -1 in the shipped files
+	{Column: "UNIFORM", Validators: []FieldValidator{IntRange(0, 99)}, Skip: isFreeAgentWithoutUniform},
+	rule("HEIGHT", HeightInches(60, 90)),
+	rule("HANDSIZE", Optional(0, EighthsOfInch(7, 12))),
+	rule("ARMLENGTH", Optional(0, EighthsOfInch(28, 38))),
+	rule("WEIGHT", IntRange(150, 400)),
+	rule("YEARENTRY", Optional(0, YearRange(1920, 2100))),
+	rule("ROUNDDRAFTED", IntNonNegative()),
+	rule("SELECTIONDRAFTED", IntNonNegative()),
+	rule("SUPPLEMENTAL", IntRange(0, 1)),
+	rule("ORIGINALTEAM", IntRange(0, models.MaxTeams)),
+	rule("EXPERIENCE", IntRange(0, 23)),
+	rule("YEARSIGNED", Optional(0, YearRange(1920, 2100))),
+	rule("PLAYPERCENTAGE", IntRange(0, 100)),
+	rule("HALLOFFAMEPOINTS", IntRange(0, 9999)),
+	rule("SALARYYEARS", IntRange(0, 5)),
+	rule("SALARYYEAR1", salaryUnits),
+	rule("BONUSYEAR1", salaryUnits),
+	rule("SALARYYEAR2", salaryUnits),
+	rule("BONUSYEAR2", salaryUnits),
+	rule("SALARYYEAR3", salaryUnits),
+	rule("BONUSYEAR3", salaryUnits),
+	rule("SALARYYEAR4", salaryUnits),
+	rule("BONUSYEAR4", salaryUnits),
+	rule("SALARYYEAR5", salaryUnits),
+	rule("BONUSYEAR5", salaryUnits),
+	rule("OVERALLRATING", IntRange(0, 10)),
+}
+
+// PlayerFieldRules are the documented constraints of xxxx_players.csv
+var PlayerFieldRules = codeRules("PLAYER", nameRules, birthRules, careerRules, []FieldRule{
+	rule("POSITION_KEY", IntRange(models.PositionQB, models.PositionLS)),
+}, skillRules(
+	"SKILL_SPEED", "SKILL_POWER", "HOLE_RECOGNITION", "ELUSIVENESS", "BLITZ_PICKUP",
+	"CATCH_HANDS", "ADJUST_TO_BALL", "ROUTE_RUNNING", "CATCH_IN_TRAFFIC", "DEFEAT_BLOCKERS",
+	"SECURE_HANDLING", "RUN_BLOCK_TECHNIQUE", "PASS_BLOCK_TECHNIQUE", "BLOCKING_STRENGTH",
+	"SCHEME_ACQUISITION", "PUNT_DISTANCE", "PUNT_HANG_TIME", "PUNT_DIRECTIONAL",
+	"KICKOFF_HANG_TIME", "FIELD_GOAL_ACCURACY", "FIELD_GOAL_DISTANCE", "RUN_DEFENSE",
+	"PASS_RUSH_TECHNIQUE", "PASS_RUSH_STRENGTH", "PASS_DEFENSE_MAN", "PASS_DEFENSE_PHYSICAL",
+	"PASS_DEFENSE_ZONE", "PASS_DEFENSE_HANDS", "DEFENSIVE_DIAGNOSIS", "SPECIAL_TEAMS",
+	"PUNT_RETURNS", "KICK_RETURNS", "LONG_SNAPPING", "KICK_HOLDING", "ENDURANCE",
+))
+
+// QuarterbackFieldRules are the documented constraints of xxxx_quarterbacks.csv
+var QuarterbackFieldRules = codeRules("QB", nameRules, birthRules, careerRules, skillRules(
+	"TOUCH", "QUALITY", "ARM_STRENGTH", "SCRAMBLE", "DECISIONS", "ACCURACY", "TIMING",
+	"SENSE_RUSH", "READ_DEFENSE", "TWO_MINUTE", "FOOTWORK", "IMPROVISATION", "CONFIDENCE",
+	"SKILL_SPEED", "HOLE_RECOGNITION", "SECURE_HANDLING",
+))
+
+// CoachFieldRules are the documented constraints of xxxx_coaches.csv
+var CoachFieldRules = codeRules("COACH", nameRules, birthRules, []FieldRule{
+	rule("TEAM", IntRange(0, models.MaxTeams)),
+	rule("POSITION", OneOf(models.CoachPositions...)),
+	rule("POSITIONGROUP", IntRange(models.CoachGroupQuarterbacks, models.CoachGroupStrength)),
+	rule("OFFENSIVESTYLE", IntRange(0, 6)),
+	rule("DEFENSIVESTYLE", IntRange(0, 4)),
+	rule("PAYSCALE", IntRange(50, 300)),
+})
+
+// skillRules builds skill rating rules for the given columns. Ratings above
+// the usual 0-100 are left to the rating distribution analysis, as the shipped
+// quarterbacks routinely exceed it.
+func skillRules(columns ...string) []FieldRule {
+	rules := make([]FieldRule, 0, len(columns))
+	for _, column := range columns {
+		rules = append(rules, rule(column, skillRating))
+	}
+	return rules
+}
+
+// codeRules concatenates rule tables into a new table and prefixes each rule
+// code (or the column, for rules without a code) with the record kind
+func codeRules(prefix string, tables ...[]FieldRule) []FieldRule {
+	var rules []FieldRule
+	for _, table := range tables {
+		for _, r := range table {
+			code := r.Code
+			if code == "" {
+				code = r.Column
+			}
+			r.Code = prefix + "_" + code
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// isFreeAgentWithoutUniform returns true for a player or quarterback with
+// TEAM 0 and UNIFORM -1
+func isFreeAgentWithoutUniform(record interface{}) bool {
+	switch r := record.(type) {
+	case *models.Player:
+		return r.Team == 0 && r.Uniform == -1
+	case *models.Quarterback:
+		return r.Team == 0 && r.Uniform == -1
+	}
+	return false
+}
+
+// ValidateRecord runs a rule table against a pointer to a struct with csv
+// tags. Columns the struct does not have are ignored.
+func ValidateRecord(record interface{}, rules []FieldRule) *ValidationResult {
+	result := NewValidationResult()
+
+	value := reflect.ValueOf(record).Elem()
+	fields := csvFieldIndex(value.Type())
+	for _, r := range rules {
+		index, ok := fields[r.Column]
+		if !ok || (r.Skip != nil && r.Skip(record)) {
+			continue
+		}
+		r.check(result, value.Type().Field(index).Name, value.Field(index).Interface())
+	}
+
+	return result
+}
+
+// validateRecordField validates a single value of a record type's struct
+// field against the rules for the field's column
+func validateRecordField(recordType reflect.Type, rules []FieldRule, fieldName string, value interface{}) *ValidationResult {
+	result := NewValidationResult()
+
+	field, ok := recordType.FieldByName(fieldName)
+	if !ok {
+		return result
+	}
+
+	column := field.Tag.Get("csv")
+	for _, r := range rules {
+		if r.Column == column {
+			r.check(result, fieldName, value)
+		}
+	}
+	return result
+}
+
+// check runs a rule's validators on a value and adds a finding for each
+// failure. Values out of range of an error rule suggest the nearest value in
+// range, except in the unclamped columns.
+func (r *FieldRule) check(result *ValidationResult, field string, value interface{}) {
+	for _, validator := range r.Validators {
+		if err := validator(value); err != nil {
+			finding := ValidationError{
+				Field:    field,
+				Message:  err.Error(),
+				Severity: r.Severity,
+				Code:     r.Code,
+			}
+			var rangeErr *RangeError
+			if num, ok := value.(int); ok && r.Severity == SeverityError && !unclampedColumns[r.Column] && errors.As(err, &rangeErr) {
+				finding.Suggestion = fmt.Sprintf("%s %d", r.Column, rangeErr.Clamp(num))
+			}
+			result.AddFinding(finding)
+		}
+	}
+}
+
+// csvFieldIndex maps csv tags to struct field indexes
+func csvFieldIndex(t reflect.Type) map[string]int {
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("csv"); tag != "" {
+			fields[tag] = i
+		}
+	}
+	return fields
+}
@@ -0,0 +1,205 @@
+// ABOUTME: Tests for the declarative player, quarterback and coach field rules
+// ABOUTME: Runs every shipped default_data person file through the rule tables
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// shippedDataErrors are values in the game's own files that break the
+// documented rules. They are real mistakes in the data, not rule errors.
+var shippedDataErrors = []string{
+	"2023_players.csv 1922 OriginalTeam",        // ORIGINALTEAM 232
+	"2024_players.csv 1922 OriginalTeam",        // ORIGINALTEAM 232
+	"2024_players.csv 6423 BirthYear",           // BIRTHYEAR 200
+	"2024_quarterbacks.csv 532 ReadDefense",     // READ_DEFENSE 251
+	"2024_quarterbacks.csv 545 HoleRecognition", // HOLE_RECOGNITION 252
+	"2024_quarterbacks.csv 652 ArmLength",       // ARMLENGTH 907
+}
+
+func TestFieldRules_DefaultData(t *testing.T) {
+	var found []string
+	report := func(file string, id int, result *ValidationResult) {
+		for _, err := range result.WithSeverity(SeverityError).Errors {
+			found = append(found, fmt.Sprintf("%s %d %s", file, id, err.Field))
+		}
+	}
+
+	for _, year := range []string{"2023", "2024"} {
+		file := year + "_players.csv"
+		players, err := data.LoadPlayers("../../default_data/" + file)
+		if err != nil {
+			t.Fatalf("LoadPlayers(%s) failed: %v", file, err)
+		}
+		for i := range players {
+			report(file, players[i].PlayerID, ValidatePlayer(&players[i]))
+		}
+
+		file = year + "_quarterbacks.csv"
+		quarterbacks, err := data.LoadQuarterbacks("../../default_data/" + file)
+		if err != nil {
+			t.Fatalf("LoadQuarterbacks(%s) failed: %v", file, err)
+		}
+		for i := range quarterbacks {
+			report(file, quarterbacks[i].PlayerID, ValidateQuarterback(&quarterbacks[i]))
+		}
+
+		file = year + "_coaches.csv"
+		coaches, err := data.LoadCoaches("../../default_data/" + file)
+		if err != nil {
+			t.Fatalf("LoadCoaches(%s) failed: %v", file, err)
+		}
+		for i := range coaches {
+			report(file, i, ValidateCoach(&coaches[i]))
+		}
+	}
+
+	expected := append([]string(nil), shippedDataErrors...)
+	sort.Strings(expected)
+	sort.Strings(found)
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected only the known shipped data errors\nexpected: %v\ngot:      %v", expected, found)
+	}
+}
+
+func TestFieldRules_ColumnsExist(t *testing.T) {
+	tables := []struct {
+		name   string
+		record interface{}
+		rules  []FieldRule
+	}{
+		{"player", models.Player{}, PlayerFieldRules},
+		{"quarterback", models.Quarterback{}, QuarterbackFieldRules},
+		{"coach", models.Coach{}, CoachFieldRules},
+	}
+
+	for _, table := range tables {
+		fields := csvFieldIndex(reflect.TypeOf(table.record))
+		seen := make(map[string]bool)
+		for _, r := range table.rules {
+			if _, ok := fields[r.Column]; !ok {
+				t.Errorf("%s rule for %s has no matching field", table.name, r.Column)
+			}
+			if seen[r.Column+" "+r.Code] {
+				t.Errorf("%s has more than one %s rule for %s", table.name, r.Code, r.Column)
+			}
+			seen[r.Column+" "+r.Code] = true
+		}
+	}
+}
+
+func TestValidatePlayer_Skills(t *testing.T) {
+	player := validPlayer()
+	player.SkillSpeed = -1
+	player.CatchHands = 250
+	if result := ValidatePlayer(player); !result.Valid {
+		t.Errorf("Expected -1 and 250 skill ratings to be valid, got %v", result.Errors)
+	}
+
+	player.RunDefense = 251
+	player.Endurance = -2
+	result := ValidatePlayer(player)
+	if !result.HasError("RunDefense") || !result.HasError("Endurance") {
+		t.Errorf("Expected errors on RunDefense and Endurance, got %v", result.Errors)
+	}
+}
+
+func TestValidatePlayer_RangeSuggestions(t *testing.T) {
+	player := validPlayer()
+	player.RunDefense = 251
+	player.Weight = 120
+	player.PositionKey = 30
+	player.Team = 99
+	player.BirthYear = 1850
+
+	suggestions := map[string]string{}
+	for _, err := range ValidatePlayer(player).Errors {
+		suggestions[err.Field+" "+err.Code] = err.Suggestion
+	}
+	// Categories, teams and years have no nearest value
+	expected := map[string]string{
+		"RunDefense PLAYER_RUN_DEFENSE":   "RUN_DEFENSE 250",
+		"Weight PLAYER_WEIGHT":            "WEIGHT 150",
+		"PositionKey PLAYER_POSITION_KEY": "",
+		"Team PLAYER_TEAM":                "",
+		"BirthYear PLAYER_BIRTHYEAR":      "",
+	}
+	if !reflect.DeepEqual(suggestions, expected) {
+		t.Errorf("Expected %v, got %v", expected, suggestions)
+	}
+}
+
+func TestValidatePlayer_Contract(t *testing.T) {
+	player := validPlayer()
+	player.SalaryYears = 6
+	player.SalaryYear1 = 10000
+	player.BonusYear5 = -1
+
+	result := ValidatePlayer(player)
+	for _, field := range []string{"SalaryYears", "SalaryYear1", "BonusYear5"} {
+		if !result.HasError(field) {
+			t.Errorf("Expected error on %s, got %v", field, result.Errors)
+		}
+	}
+}
+
+func TestValidatePlayer_FreeAgentUniform(t *testing.T) {
+	player := validPlayer()
+	player.Team = 0
+	player.Uniform = -1
+	if result := ValidatePlayer(player); !result.Valid {
+		t.Errorf("Expected free agent without a uniform to be valid, got %v", result.Errors)
+	}
+
+	player.Team = 3
+	if result := ValidatePlayer(player); !result.HasError("Uniform") {
+		t.Error("Expected error for a rostered player without a uniform")
+	}
+}
+
+func TestValidateCoach_Documented(t *testing.T) {
+	coach := &models.Coach{
+		FirstName:     "Bill",
+		LastName:      "Walsh",
+		BirthMonth:    11,
+		BirthDay:      30,
+		BirthYear:     1931,
+		Team:          4,
+		Position:      12,
+		PositionGroup: 3,
+		PayScale:      100,
+	}
+	if result := ValidateCoach(coach); !result.Valid {
+		t.Errorf("Expected valid coach, got %v", result.Errors)
+	}
+
+	coach.Position = 5
+	coach.PositionGroup = 2
+	coach.PayScale = 301
+	result := ValidateCoach(coach)
+	for _, field := range []string{"Position", "PositionGroup", "PayScale"} {
+		if !result.HasError(field) {
+			t.Errorf("Expected error on %s, got %v", field, result.Errors)
+		}
+	}
+
+	if result := ValidateCoachField("PayScale", 49); !result.HasError("PayScale") {
+		t.Error("Expected error for pay scale below 50")
+	}
+}
+
+func TestValidateQuarterbackField(t *testing.T) {
+	if result := ValidateQuarterbackField("Scramble", -1); !result.Valid {
+		t.Errorf("Expected -1 scramble to be valid, got %v", result.Errors)
+	}
+	if result := ValidateQuarterbackField("ArmLength", 907); !result.HasError("ArmLength") {
+		t.Error("Expected error for arm length 90 7/8 inches")
+	}
+}
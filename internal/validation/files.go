@@ -0,0 +1,98 @@
+// ABOUTME: File-level validation for player, quarterback, coach and team CSV files
+// ABOUTME: Runs the field rules over every record and locates the findings in the file
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+)
+
+// ValidatePlayerFile validates every player of a player file. Findings carry
+// the file, line and record identity.
+func ValidatePlayerFile(file string, players []models.Player) *ValidationResult {
+	result := NewValidationResult()
+	for i := range players {
+		found := ValidateRecord(&players[i], PlayerFieldRules)
+		found.locate(file, i+2, playerRecord(&players[i]), playerKey(&players[i]))
+		result.Merge(found)
+	}
+	return result
+}
+
+// ValidateQuarterbackFile validates every quarterback of a quarterback file.
+// Findings carry the file, line and record identity.
+func ValidateQuarterbackFile(file string, quarterbacks []models.Quarterback) *ValidationResult {
+	result := NewValidationResult()
+	for i := range quarterbacks {
+		qb := &quarterbacks[i]
+		found := ValidateRecord(qb, QuarterbackFieldRules)
+		found.locate(file, i+2, quarterbackRecord(qb), roster.PlayerKey(qb.PlayerID))
+		result.Merge(found)
+	}
+	return result
+}
+
+// ValidateCoachFile validates every coach of a coach file. Findings carry the
+// file, line and record identity.
+func ValidateCoachFile(file string, coaches []models.Coach) *ValidationResult {
+	result := NewValidationResult()
+	for i := range coaches {
+		found := ValidateRecord(&coaches[i], CoachFieldRules)
+		found.locate(file, i+2, coachRecord(&coaches[i]), coachKey(&coaches[i]))
+		result.Merge(found)
+	}
+	return result
+}
+
+// ValidateTeamFile validates every team of a team file. Findings carry the
+// file, line and record identity.
+func ValidateTeamFile(file string, teams []models.Team) *ValidationResult {
+	result := NewValidationResult()
+	for i := range teams {
+		found := ValidateTeam(&teams[i])
+		found.locate(file, i+2, teamRecord(&teams[i]), teamKey(&teams[i]))
+		result.Merge(found)
+	}
+	return result
+}
+
+// playerRecord describes a player, e.g. "Player 1000 (Tom Brady)"
+func playerRecord(player *models.Player) string {
+	return fmt.Sprintf("Player %d (%s)", player.PlayerID, player.GetDisplayName())
+}
+
+// playerKey is a player's suppression key, e.g. "player:1000"
+func playerKey(player *models.Player) string {
+	return roster.PlayerKey(player.PlayerID)
+}
+
+// quarterbackRecord describes a quarterback like a player, e.g.
+// "Player 501 (Aaron Rodgers)"
+func quarterbackRecord(qb *models.Quarterback) string {
+	return fmt.Sprintf("Player %d (%s)", qb.PlayerID, qb.GetDisplayName())
+}
+
+// coachRecord describes a coach, e.g. "Coach Bill Belichick"
+func coachRecord(coach *models.Coach) string {
+	return "Coach " + coach.GetDisplayName()
+}
+
+// coachKey is a coach's suppression key: the name and birth date, which stay
+// with the coach when coaches are deleted or reordered, e.g.
+// "coach:Bill Belichick:1952-04-16"
+func coachKey(coach *models.Coach) string {
+	return fmt.Sprintf("coach:%s:%04d-%02d-%02d", coach.GetDisplayName(), coach.BirthYear, coach.BirthMonth, coach.BirthDay)
+}
+
+// teamRecord describes a team, e.g. "Team 1 (Boston Minutemen, 2024)"
+func teamRecord(team *models.Team) string {
+	return fmt.Sprintf("Team %d (%s, %d)", team.TeamID, team.GetDisplayName(), team.Year)
+}
+
+// teamKey is a team's suppression key, e.g. "team:2024:1"
+func teamKey(team *models.Team) string {
+	return fmt.Sprintf("team:%d:%d", team.Year, team.TeamID)
+}
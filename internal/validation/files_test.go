@@ -0,0 +1,59 @@
+// ABOUTME: Tests for player, coach and team file validation
+// ABOUTME: Verifies record locations and the advisory checks across records of a file
+
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestValidateQuarterbackFile(t *testing.T) {
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 501, FirstName: "Aaron", LastName: "Rodgers", Team: 20, SalaryYear1: 10000},
+	}
+
+	result := ValidateQuarterbackFile("2024_quarterbacks.csv", quarterbacks)
+	var found *ValidationError
+	for i := range result.Errors {
+		if result.Errors[i].Code == "QB_SALARYYEAR1" {
+			found = &result.Errors[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("Expected a QB_SALARYYEAR1 error, got %v", result.Errors)
+	}
+	if found.File != "2024_quarterbacks.csv" || found.Line != 2 || found.RecordKey != "player:501" {
+		t.Errorf("Unexpected location %s:%d (%s)", found.File, found.Line, found.RecordKey)
+	}
+}
+
+func TestValidateTeamFile_Codes(t *testing.T) {
+	teams := []models.Team{{TeamID: 3, Year: 2024, TeamName: "Miami", NickName: "Surf", Abbreviation: "MIA"}}
+
+	result := ValidateTeamFile("team_info.csv", teams)
+	if result.Valid {
+		t.Fatal("Expected a team without a stadium to be invalid")
+	}
+	for _, err := range result.Errors {
+		if err.Code != "TEAM_"+strings.ToUpper(err.Field) || err.RecordKey != "team:2024:3" || err.Line != 2 {
+			t.Errorf("Unexpected code or location for %v (%s, %s)", err, err.Code, err.RecordKey)
+		}
+	}
+}
+
+func TestValidateTeamFile_DefaultData(t *testing.T) {
+	teams, err := data.LoadTeams("../../default_data/team_info.csv")
+	if err != nil {
+		t.Fatalf("Failed to load team_info.csv: %v", err)
+	}
+
+	// The shipped teams number up to 32 and are all valid
+	result := ValidateTeamFile("team_info.csv", teams).WithSeverity(SeverityError)
+	if len(result.Errors) != 0 {
+		t.Errorf("Expected no errors in the shipped teams, got %d: %v", len(result.Errors), result.Errors[0])
+	}
+}
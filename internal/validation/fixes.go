@@ -0,0 +1,319 @@
+// ABOUTME: Fixes for validation findings with an obvious correction
+// ABOUTME: Turns suggestions into reviewable field changes applied to the loaded project as one undoable command
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/igorilic/fof9editor/internal/roster"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+// FieldChange is one field edit that fixes a finding. Fixes are listed for
+// review before they are applied, and applied fixes are undone like any edit.
+type FieldChange struct {
+	Code   string // Rule code of the finding the change fixes
+	File   string // CSV file name, e.g. "2024_players.csv"
+	Line   int    // 1-based line in the file; the header is line 1
+	Record string // Record identity, e.g. "Player 1000 (Tom Brady)"
+	Column string // CSV column, e.g. "UNIFORM"
+	From   string // Value before the fix
+	To     string // Value after the fix
+
+	target string // Project file key of the record, e.g. "players"
+	index  int    // Index of the record in its file
+}
+
+// String describes the change, e.g.
+// "2024_players.csv:5 Player 1000 (Tom Brady): UNIFORM 12 -> 14"
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s:%d %s: %s %s -> %s", c.File, c.Line, c.Record, c.Column, displayValue(c.From), displayValue(c.To))
+}
+
+// displayValue quotes empty values so they show in change lists
+func displayValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+	return value
+}
+
+// fixer proposes the changes that fix a finding at a record of the project
+type fixer func(finding ValidationError, files fixFiles) []FieldChange
+
+// fixers fix findings whose suggestion cannot, by rule code. Every other
+// finding is fixed by setting the column values of its suggestion.
+var fixers = map[string]fixer{
+	"PLAYER_UNIFORM_DUP": fixUniformDup,
+	"QB_UNIFORM_DUP":     fixUniformDup,
+}
+
+// HasFix returns true if a finding offers a fix: an error that CanFix. Warnings
+// and info are only fixed when asked for by code.
+func HasFix(finding ValidationError) bool {
+	return finding.Severity == SeverityError && CanFix(finding)
+}
+
+// CanFix returns true if a finding has a fix: a suggestion of column values,
+// such as "TEAM 0", or a fixer of its own. The fix may still propose no
+// change if the record has changed since it was validated.
+func CanFix(finding ValidationError) bool {
+	if finding.Line < 2 {
+		return false
+	}
+	if _, ok := fixers[finding.Code]; ok {
+		return true
+	}
+	return len(suggestedValues(finding.Suggestion)) > 0
+}
+
+// ProposeFixes returns the changes that fix the findings that have a fix, of
+// any severity, in finding order. A field changed by several fixes is changed by the first.
+func ProposeFixes(appState *state.AppState, findings []ValidationError) []FieldChange {
+	files := newFixFiles(appState)
+	seen := make(map[string]bool)
+
+	var changes []FieldChange
+	for _, finding := range findings {
+		if !CanFix(finding) {
+			continue
+		}
+		fix, ok := fixers[finding.Code]
+		if !ok {
+			fix = fixSuggestion
+		}
+		for _, change := range fix(finding, files) {
+			key := fmt.Sprintf("%s:%d:%s", change.target, change.index, change.Column)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// ApplyFixes sets the new value of each change, recording them as one
+// undoable command, and returns the number applied. A field whose value is
+// no longer the one the change was proposed for is left alone.
+func ApplyFixes(appState *state.AppState, changes []FieldChange) int {
+	files := newFixFiles(appState)
+	applied := 0
+	for _, change := range changes {
+		if files.set(change) {
+			applied++
+		}
+	}
+	if applied > 0 {
+		appState.Commit(fmt.Sprintf("Apply %d fixes", applied))
+	}
+	return applied
+}
+
+// fixFiles locates the records of the loaded project by CSV file name
+type fixFiles struct {
+	appState *state.AppState
+	keys     map[string]string // File name to project file key
+}
+
+// newFixFiles maps the project's file names to the loaded files
+func newFixFiles(appState *state.AppState) fixFiles {
+	project := appState.GetProject()
+	files := fixFiles{appState: appState, keys: make(map[string]string)}
+	for _, key := range []string{playersFile, quarterbacksFile, coachesFile, teamsFile, infoFile} {
+		files.keys[ProjectFileName(project, key)] = key
+	}
+	return files
+}
+
+// record returns the project file key, index and struct of the record at a
+// line of a file
+func (f fixFiles) record(file string, line int) (string, int, reflect.Value, bool) {
+	key, index := f.keys[file], line-2
+	var records reflect.Value
+	switch key {
+	case playersFile:
+		records = reflect.ValueOf(f.appState.GetPlayers())
+	case quarterbacksFile:
+		records = reflect.ValueOf(f.appState.GetQuarterbacks())
+	case coachesFile:
+		records = reflect.ValueOf(f.appState.GetCoaches())
+	case teamsFile:
+		records = reflect.ValueOf(f.appState.GetTeams())
+	case infoFile:
+		if info := f.appState.GetLeagueInfo(); info != nil && index == 0 {
+			return key, index, reflect.ValueOf(info).Elem(), true
+		}
+		return "", 0, reflect.Value{}, false
+	default:
+		return "", 0, reflect.Value{}, false
+	}
+	if index < 0 || index >= records.Len() {
+		return "", 0, reflect.Value{}, false
+	}
+	return key, index, records.Index(index), true
+}
+
+// change proposes setting a column of the record at a line of a file, or
+// returns false if the record has no such column, already has the value or
+// the value does not fit the column
+func (f fixFiles) change(code, file string, line int, recordName, column, to string) (FieldChange, bool) {
+	key, index, record, ok := f.record(file, line)
+	if !ok {
+		return FieldChange{}, false
+	}
+	field, ok := columnField(record, column)
+	if !ok || !fitsField(field, to) {
+		return FieldChange{}, false
+	}
+	from := fieldText(field)
+	if from == to {
+		return FieldChange{}, false
+	}
+	return FieldChange{
+		Code: code, File: file, Line: line, Record: recordName, Column: column, From: from, To: to,
+		target: key, index: index,
+	}, true
+}
+
+// set applies a change, returning false if the record is gone or the field
+// no longer has the value the change was proposed for
+func (f fixFiles) set(change FieldChange) bool {
+	key, _, record, ok := f.record(change.File, change.Line)
+	if !ok || key != change.target {
+		return false
+	}
+	field, ok := columnField(record, change.Column)
+	if !ok || fieldText(field) != change.From {
+		return false
+	}
+	switch field.Kind() {
+	case reflect.Int:
+		value, err := strconv.Atoi(change.To)
+		if err != nil {
+			return false
+		}
+		field.SetInt(int64(value))
+	case reflect.String:
+		field.SetString(change.To)
+	default:
+		return false
+	}
+	return true
+}
+
+// columnField returns the struct field of a record with a csv tag
+func columnField(record reflect.Value, column string) (reflect.Value, bool) {
+	index, ok := csvFieldIndex(record.Type())[column]
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return record.Field(index), true
+}
+
+// fieldText returns a field's value as written to the CSV file
+func fieldText(field reflect.Value) string {
+	if field.Kind() == reflect.Int {
+		return strconv.FormatInt(field.Int(), 10)
+	}
+	return field.String()
+}
+
+// fitsField returns true if a value can be stored in a field
+func fitsField(field reflect.Value, value string) bool {
+	switch field.Kind() {
+	case reflect.Int:
+		_, err := strconv.Atoi(value)
+		return err == nil
+	case reflect.String:
+		return true
+	}
+	return false
+}
+
+// suggestedValues parses a suggestion of column values, e.g. "TEAM 0" or
+// "PRIMARYRED 1, PRIMARYGREEN 2, PRIMARYBLUE 3", into column and value pairs
+func suggestedValues(suggestion string) [][2]string {
+	var values [][2]string
+	for _, part := range strings.Split(suggestion, ", ") {
+		column, value, ok := strings.Cut(strings.TrimSpace(part), " ")
+		if !ok || column == "" || strings.ToUpper(column) != column {
+			return nil
+		}
+		values = append(values, [2]string{column, value})
+	}
+	return values
+}
+
+// fixSuggestion sets the column values a finding suggests on its record. A
+// suggestion naming a column the record does not have proposes nothing.
+func fixSuggestion(finding ValidationError, files fixFiles) []FieldChange {
+	_, _, record, ok := files.record(finding.File, finding.Line)
+	if !ok {
+		return nil
+	}
+
+	var changes []FieldChange
+	for _, value := range suggestedValues(finding.Suggestion) {
+		if field, ok := columnField(record, value[0]); !ok || !fitsField(field, value[1]) {
+			return nil
+		}
+		if change, ok := files.change(finding.Code, finding.File, finding.Line, finding.Record, value[0], value[1]); ok {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// fixUniformDup gives a free number to the teammates sharing the finding's
+// uniform number, except the one roster.ProposeUniformFixes lets keep it
+func fixUniformDup(finding ValidationError, files fixFiles) []FieldChange {
+	key, index, _, ok := files.record(finding.File, finding.Line)
+	if !ok {
+		return nil
+	}
+	players := files.appState.GetPlayers()
+	quarterbacks := files.appState.GetQuarterbacks()
+
+	var team, uniform int
+	switch key {
+	case playersFile:
+		team, uniform = players[index].Team, players[index].Uniform
+	case quarterbacksFile:
+		team, uniform = quarterbacks[index].Team, quarterbacks[index].Uniform
+	default:
+		return nil
+	}
+
+	var changes []FieldChange
+	for _, proposed := range roster.ProposeUniformFixes(players, quarterbacks) {
+		member := proposed.Member
+		if member.Team != team || member.Uniform != uniform {
+			continue
+		}
+		file := files.fileName(playersFile)
+		if member.Quarterback {
+			file = files.fileName(quarterbacksFile)
+		}
+		record := fmt.Sprintf("Player %d (%s)", member.PlayerID, member.Name)
+		if change, ok := files.change(finding.Code, file, member.Index+2, record, "UNIFORM", strconv.Itoa(proposed.To)); ok {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// fileName returns the file name of a project file key
+func (f fixFiles) fileName(key string) string {
+	for name, k := range f.keys {
+		if k == key {
+			return name
+		}
+	}
+	return key
+}
@@ -0,0 +1,174 @@
+// ABOUTME: Tests for fixes of validation findings
+// ABOUTME: Covers proposing, applying and undoing suggested values, clamps and uniform renumbering
+
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+// fixFixtureState loads two teammates sharing a uniform, one too light and
+// one with the wrong number of contract years
+func fixFixtureState(t *testing.T) *state.AppState {
+	t.Helper()
+
+	appState := state.GetInstance()
+	appState.Reset()
+	light := validPlayer()
+	light.Weight = 120
+	contract := validPlayer()
+	contract.PlayerID, contract.FirstName = 1001, "Jim"
+	contract.SalaryYears, contract.SalaryYear1 = 3, 100
+	appState.SetPlayers([]models.Player{*light, *contract})
+	appState.MarkClean()
+	return appState
+}
+
+// findingsWithCodes returns the findings of a result with the given codes
+func findingsWithCodes(result *ValidationResult, codes ...string) []ValidationError {
+	var found []ValidationError
+	for _, finding := range result.Errors {
+		for _, code := range codes {
+			if finding.Code == code {
+				found = append(found, finding)
+			}
+		}
+	}
+	return found
+}
+
+func TestProposeFixes(t *testing.T) {
+	appState := fixFixtureState(t)
+	defer appState.Reset()
+
+	findings := findingsWithCodes(ValidateProject(appState, nil), "PLAYER_WEIGHT", "PLAYER_SALARYYEARS", "PLAYER_UNIFORM_DUP")
+	if len(findings) != 3 {
+		t.Fatalf("Expected 3 findings, got %v", findings)
+	}
+	for _, finding := range findings {
+		if !CanFix(finding) {
+			t.Errorf("Expected %s to have a fix", finding.Code)
+		}
+		// Only errors offer their fix unasked
+		if HasFix(finding) != (finding.Severity == SeverityError) {
+			t.Errorf("Expected %s %s to offer a fix only as an error", finding.Severity, finding.Code)
+		}
+	}
+
+	changes := ProposeFixes(appState, findings)
+	expected := []string{
+		"players:2 Player 1000 (John Doe): WEIGHT 120 -> 150",
+		"players:3 Player 1001 (Jim Doe): UNIFORM 12 -> ",
+		"players:3 Player 1001 (Jim Doe): SALARYYEARS 3 -> 1",
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %v", len(expected), changes)
+	}
+	for i, want := range expected {
+		if got := changes[i].String(); !strings.HasPrefix(got, want) {
+			t.Errorf("Change %d: expected %q, got %q", i, want, got)
+		}
+	}
+	if changes[1].Code != "PLAYER_UNIFORM_DUP" || changes[1].To == "12" {
+		t.Errorf("Expected a free uniform number, got %+v", changes[1])
+	}
+
+	// Proposing does not change the data
+	if appState.GetPlayers()[0].Weight != 120 || appState.IsDirtyState() {
+		t.Error("Expected proposing fixes to leave the players alone")
+	}
+}
+
+func TestApplyFixes_Undo(t *testing.T) {
+	appState := fixFixtureState(t)
+	defer appState.Reset()
+
+	findings := findingsWithCodes(ValidateProject(appState, nil), "PLAYER_WEIGHT", "PLAYER_SALARYYEARS", "PLAYER_UNIFORM_DUP")
+	changes := ProposeFixes(appState, findings)
+
+	if applied := ApplyFixes(appState, changes); applied != 3 || !appState.IsDirtyState() {
+		t.Fatalf("Expected 3 fixes applied, got %d", applied)
+	}
+	players := appState.GetPlayers()
+	if players[0].Weight != 150 || players[1].SalaryYears != 1 || players[1].Uniform == 12 {
+		t.Errorf("Expected the fixes to be applied, got %+v", players)
+	}
+	if left := findingsWithCodes(ValidateProject(appState, nil), "PLAYER_WEIGHT", "PLAYER_SALARYYEARS", "PLAYER_UNIFORM_DUP"); len(left) != 0 {
+		t.Errorf("Expected the findings to be fixed, got %v", left)
+	}
+
+	// Applying again changes nothing, as the values are no longer the old ones
+	if applied := ApplyFixes(appState, changes); applied != 0 {
+		t.Errorf("Expected stale changes to be skipped, got %d", applied)
+	}
+
+	// The fixes are undone as one command
+	if description, ok := appState.Undo(); !ok || description != "Apply 3 fixes" {
+		t.Errorf("Expected the fixes recorded for undo, got %q", description)
+	}
+	players = appState.GetPlayers()
+	if players[0].Weight != 120 || players[1].SalaryYears != 3 || players[1].Uniform != 12 {
+		t.Errorf("Expected the fixes to be undone, got %+v", players)
+	}
+}
+
+func TestHasFix(t *testing.T) {
+	tests := []struct {
+		finding ValidationError
+		canFix  bool
+		hasFix  bool
+	}{
+		{ValidationError{Code: "PLAYER_DRAFTEE_TEAM", Line: 2, Suggestion: "TEAM 0"}, true, true},
+		{ValidationError{Code: "PLAYER_WEIGHT", Line: 2, Suggestion: "WEIGHT 150"}, true, true},
+		{ValidationError{Code: "TEAM_PRIMARY_DARKNESS", Line: 2, Severity: SeverityWarning, Suggestion: "PRIMARYRED 1, PRIMARYGREEN 2, PRIMARYBLUE 3"}, true, false},
+		{ValidationError{Code: "PLAYER_UNIFORM_DUP", Line: 3, Severity: SeverityWarning}, true, false},
+		{ValidationError{Code: "PLAYER_BIRTHCITY_CITYID", Line: 2, Severity: SeverityInfo, Suggestion: "BIRTHCITY Boston_MA"}, true, false},
+		{ValidationError{Code: "PLAYER_TEAM", Line: 2}, false, false},
+		{ValidationError{Code: "PLAYER_DRAFTEE_TEAM", Suggestion: "TEAM 0"}, false, false}, // Not located
+		{ValidationError{Code: "RULE_X", Line: 2, Suggestion: "use a smaller number"}, false, false},
+	}
+	for _, tt := range tests {
+		if got := CanFix(tt.finding); got != tt.canFix {
+			t.Errorf("%s %q: expected CanFix %v, got %v", tt.finding.Code, tt.finding.Suggestion, tt.canFix, got)
+		}
+		if got := HasFix(tt.finding); got != tt.hasFix {
+			t.Errorf("%s %q: expected HasFix %v, got %v", tt.finding.Code, tt.finding.Suggestion, tt.hasFix, got)
+		}
+	}
+}
+
+func TestProposeFixes_Suggestions(t *testing.T) {
+	appState := state.GetInstance()
+	appState.Reset()
+	defer appState.Reset()
+	appState.SetTeams([]models.Team{{Year: 2024, TeamID: 1, PrimaryRed: 10}})
+	appState.SetCoaches([]models.Coach{{FirstName: "Bill", LastName: "Belichick"}})
+
+	findings := []ValidationError{
+		{Code: "TEAM_PRIMARY_DARKNESS", File: "teams", Line: 2, Record: "Team 1",
+			Suggestion: "PRIMARYRED 40, PRIMARYGREEN 0, PRIMARYBLUE 30"},
+		{Code: "COACH_BIRTHCITY_CITYID", File: "coaches", Line: 2, Record: "Coach Bill Belichick",
+			Suggestion: "BIRTHCITY New York_NY"},
+		{Code: "COACH_X", File: "coaches", Line: 2, Suggestion: "NOSUCHCOLUMN 1"},
+		{Code: "COACH_Y", File: "coaches", Line: 2, Suggestion: "TEAM many"},
+		{Code: "COACH_Z", File: "coaches", Line: 3, Suggestion: "TEAM 1"},
+	}
+	changes := ProposeFixes(appState, findings)
+	expected := []string{
+		"teams:2 Team 1: PRIMARYRED 10 -> 40",
+		"teams:2 Team 1: PRIMARYBLUE 0 -> 30",
+		`coaches:2 Coach Bill Belichick: BIRTHCITY "" -> New York_NY`,
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %v", len(expected), changes)
+	}
+	for i, want := range expected {
+		if got := changes[i].String(); got != want {
+			t.Errorf("Change %d: expected %q, got %q", i, want, got)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// ABOUTME: Validation rules for league structures and their related artifacts
+// ABOUTME: Checks league_info.csv rows, default teams and schedule templates against each other
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// ValidateLeagueStructure validates a league_info.csv row on its own
+func ValidateLeagueStructure(structure *models.LeagueStructure) *ValidationResult {
+	result := NewValidationResult()
+
+	result.Merge(ValidateField("ScheduleID", structure.ScheduleID,
+		Required("Schedule ID is required"),
+	))
+	if strings.ContainsAny(structure.ScheduleID, `/\,`) {
+		result.AddError("ScheduleID", "must not contain slashes or commas")
+	}
+
+	result.Merge(ValidateField("Teams", structure.Teams,
+		IntRange(2, models.MaxDivisions*models.MaxTeamsPerDivision),
+	))
+
+	result.Merge(ValidateField("Divisions", structure.Divisions,
+		IntRange(2, models.MaxDivisions),
+	))
+	if structure.Divisions%2 != 0 {
+		result.AddError("Divisions", "must be even (two conferences with equal divisions)")
+	}
+
+	result.Merge(ValidatePlayoffSpots(structure))
+
+	result.Merge(ValidateField("Games", structure.Games, IntPositive()))
+	if structure.Weeks < structure.Games {
+		result.AddError("Weeks", fmt.Sprintf("must be at least the number of games (%d)", structure.Games))
+	}
+	result.Merge(ValidateField("ExGames", structure.ExGames, IntNonNegative()))
+	if structure.ExWeeks < structure.ExGames {
+		result.AddError("ExWeeks", fmt.Sprintf("must be at least the number of exhibition games (%d)", structure.ExGames))
+	}
+
+	result.Merge(ValidateField("Conf1", structure.Conf1, Required("Conference 1 name is required")))
+	result.Merge(ValidateField("Conf1Abbr", structure.Conf1Abbr, Required("Conference 1 abbreviation is required")))
+	result.Merge(ValidateField("Conf2", structure.Conf2, Required("Conference 2 name is required")))
+	result.Merge(ValidateField("Conf2Abbr", structure.Conf2Abbr, Required("Conference 2 abbreviation is required")))
+	result.Merge(ValidateField("Championship", structure.Championship, Required("Championship name is required")))
+
+	// Divisions in use need a name and 1-8 teams; unused ones must be blank
+	totalTeams := 0
+	for i := 1; i <= models.MaxDivisions; i++ {
+		nameField := fmt.Sprintf("Div%d", i)
+		teamsField := fmt.Sprintf("Div%dTeams", i)
+		name, teams := structure.GetDivisionName(i), structure.GetDivisionTeams(i)
+
+		if i <= structure.Divisions {
+			result.Merge(ValidateField(nameField, name, Required(fmt.Sprintf("Division %d name is required", i))))
+			result.Merge(ValidateField(teamsField, teams, IntRange(1, models.MaxTeamsPerDivision)))
+			totalTeams += teams
+		} else if name != "" || teams != 0 {
+			result.AddError(teamsField, fmt.Sprintf("division %d is not used by a %d-division league and must be blank", i, structure.Divisions))
+		}
+	}
+	if totalTeams != structure.Teams {
+		result.AddError("Teams", fmt.Sprintf("divisions hold %d teams, expected %d", totalTeams, structure.Teams))
+	}
+
+	result.Merge(ValidateField("Rotations", structure.Rotations, IntPositive()))
+
+	return result
+}
+
+// ValidatePlayoffSpots checks that a structure's playoff spots fit its
+// conferences: the total must be even and below the team count, and each
+// conference's half must hold every division winner without exceeding the
+// conference's teams
+func ValidatePlayoffSpots(structure *models.LeagueStructure) *ValidationResult {
+	result := NewValidationResult()
+
+	if structure.PlayoffTeams < 2 || structure.PlayoffTeams%2 != 0 {
+		result.AddError("PlayoffTeams", "must be an even number of at least 2")
+		return result
+	}
+	if structure.PlayoffTeams >= structure.Teams {
+		result.AddError("PlayoffTeams", fmt.Sprintf("must be less than the number of teams (%d)", structure.Teams))
+	}
+
+	dpc := structure.DivisionsPerConference()
+	spots := structure.PlayoffTeams / 2
+	if spots < dpc {
+		result.AddError("PlayoffTeams", fmt.Sprintf("%d spots per conference cannot hold the %d division winners", spots, dpc))
+	}
+	for conference := 1; conference <= 2; conference++ {
+		teams := 0
+		for div := 1; div <= dpc; div++ {
+			teams += structure.GetDivisionTeams(structure.DivisionIndex(conference, div))
+		}
+		if spots > teams {
+			result.AddError("PlayoffTeams", fmt.Sprintf("%d spots per conference but %s has only %d teams",
+				spots, structure.GetConferenceName(conference), teams))
+		}
+	}
+
+	return result
+}
+
+// ValidateDefaultTeams validates a league structure's default teams against the structure
+func ValidateDefaultTeams(structure *models.LeagueStructure, teams []models.DefaultTeam) *ValidationResult {
+	result := NewValidationResult()
+
+	if len(teams) != structure.Teams {
+		result.AddError("DefaultTeams", fmt.Sprintf("expected %d teams, got %d", structure.Teams, len(teams)))
+	}
+
+	dpc := structure.DivisionsPerConference()
+	seenIDs := make(map[int]bool)
+	seenAbbrs := make(map[string]bool)
+	divisionCounts := make(map[int]int)
+
+	for i, team := range teams {
+		prefix := fmt.Sprintf("DefaultTeams[%d]", i)
+
+		if team.League != structure.ScheduleID {
+			result.AddError(prefix+".League", fmt.Sprintf("must match schedule ID '%s'", structure.ScheduleID))
+		}
+		if team.TeamID < 1 || team.TeamID > structure.Teams {
+			result.AddError(prefix+".TeamID", fmt.Sprintf("must be between 1 and %d", structure.Teams))
+		} else if seenIDs[team.TeamID] {
+			result.AddError(prefix+".TeamID", fmt.Sprintf("team ID %d is used more than once", team.TeamID))
+		}
+		seenIDs[team.TeamID] = true
+
+		result.Merge(ValidateField(prefix+".TeamName", team.TeamName, Required("Team name is required")))
+		result.Merge(ValidateField(prefix+".NickName", team.NickName, Required("Nickname is required")))
+		result.Merge(ValidateField(prefix+".Abbreviation", team.Abbreviation,
+			Required("Abbreviation is required"),
+			MaxLength(5),
+		))
+		if team.Abbreviation != "" && seenAbbrs[team.Abbreviation] {
+			result.AddError(prefix+".Abbreviation", fmt.Sprintf("abbreviation '%s' is used more than once", team.Abbreviation))
+		}
+		seenAbbrs[team.Abbreviation] = true
+
+		if team.Conference < 1 || team.Conference > 2 {
+			result.AddError(prefix+".Conference", "must be 1 or 2")
+			continue
+		}
+		if team.Division < 1 || team.Division > dpc {
+			result.AddError(prefix+".Division", fmt.Sprintf("must be between 1 and %d", dpc))
+			continue
+		}
+		divisionCounts[structure.DivisionIndex(team.Conference, team.Division)]++
+	}
+
+	for i := 1; i <= structure.Divisions; i++ {
+		if expected := structure.GetDivisionTeams(i); divisionCounts[i] != expected {
+			result.AddError(fmt.Sprintf("Div%dTeams", i),
+				fmt.Sprintf("division %s has %d teams assigned, expected %d", structure.GetDivisionName(i), divisionCounts[i], expected))
+		}
+	}
+
+	return result
+}
+
+// ValidateScheduleTemplate validates a schedule template against a league structure
+func ValidateScheduleTemplate(structure *models.LeagueStructure, games []models.ScheduleTemplateGame) *ValidationResult {
+	result := NewValidationResult()
+
+	if len(games) == 0 {
+		result.AddError("Schedule", "schedule template has no games")
+		return result
+	}
+
+	type slotKey struct{ division, team int }
+	type weekKey struct {
+		rotation, week int
+		slot           slotKey
+	}
+	type countKey struct {
+		rotation, season int
+		slot             slotKey
+	}
+
+	validSlot := func(s slotKey) bool {
+		return s.division >= 1 && s.division <= structure.Divisions &&
+			s.team >= 1 && s.team <= structure.GetDivisionTeams(s.division)
+	}
+
+	rotations := make(map[int]bool)
+	busy := make(map[weekKey]bool)
+	counts := make(map[countKey]int)
+
+	for i, game := range games {
+		field := fmt.Sprintf("Schedule[%d]", i)
+		rotations[game.Rotation] = true
+
+		if game.Rotation < 1 || game.Rotation > structure.Rotations {
+			result.AddError(field, fmt.Sprintf("rotation %d is outside 1-%d", game.Rotation, structure.Rotations))
+		}
+
+		switch game.Season {
+		case models.SeasonExhibition:
+			if game.Week < 1 || game.Week > structure.ExWeeks {
+				result.AddError(field, fmt.Sprintf("exhibition week %d is outside 1-%d", game.Week, structure.ExWeeks))
+			}
+		case models.SeasonRegular:
+			first, last := structure.ExWeeks+1, structure.ExWeeks+structure.Weeks
+			if game.Week < first || game.Week > last {
+				result.AddError(field, fmt.Sprintf("regular season week %d is outside %d-%d", game.Week, first, last))
+			}
+		default:
+			result.AddError(field, fmt.Sprintf("season must be 0 or 1, got %d", game.Season))
+			continue
+		}
+
+		home := slotKey{game.HomeDiv, game.HomeTeam}
+		visitor := slotKey{game.VisDiv, game.VisTeam}
+		for _, s := range []slotKey{home, visitor} {
+			if !validSlot(s) {
+				result.AddError(field, fmt.Sprintf("division %d team %d does not exist", s.division, s.team))
+				continue
+			}
+			key := weekKey{game.Rotation, game.Week, s}
+			if busy[key] {
+				result.AddError(field, fmt.Sprintf("division %d team %d plays twice in rotation %d week %d",
+					s.division, s.team, game.Rotation, game.Week))
+			}
+			busy[key] = true
+			counts[countKey{game.Rotation, game.Season, s}]++
+		}
+		if home == visitor {
+			result.AddError(field, "a team cannot play itself")
+		}
+	}
+
+	for rotation := 1; rotation <= structure.Rotations; rotation++ {
+		if !rotations[rotation] {
+			result.AddError("Schedule", fmt.Sprintf("rotation %d has no games", rotation))
+			continue
+		}
+		for div := 1; div <= structure.Divisions; div++ {
+			for team := 1; team <= structure.GetDivisionTeams(div); team++ {
+				s := slotKey{div, team}
+				if n := counts[countKey{rotation, models.SeasonRegular, s}]; n != structure.Games {
+					result.AddError("Schedule", fmt.Sprintf("rotation %d: division %d team %d plays %d regular season games, expected %d",
+						rotation, div, team, n, structure.Games))
+				}
+				if n := counts[countKey{rotation, models.SeasonExhibition, s}]; n != structure.ExGames {
+					result.AddError("Schedule", fmt.Sprintf("rotation %d: division %d team %d plays %d exhibition games, expected %d",
+						rotation, div, team, n, structure.ExGames))
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// ValidateLeagueArtifacts validates a league structure, its default teams and
+// its schedule template together
+func ValidateLeagueArtifacts(structure *models.LeagueStructure, teams []models.DefaultTeam, games []models.ScheduleTemplateGame) *ValidationResult {
+	result := ValidateLeagueStructure(structure)
+	if !result.Valid {
+		// Team and schedule checks depend on a consistent structure
+		return result
+	}
+
+	result.Merge(ValidateDefaultTeams(structure, teams))
+	result.Merge(ValidateScheduleTemplate(structure, games))
+	return result
+}
@@ -0,0 +1,190 @@
+// ABOUTME: Tests for league structure validation
+// ABOUTME: Validates league_info rows, default teams and schedule templates against each other
+
+package validation
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestValidateLeagueStructure_Valid(t *testing.T) {
+	structure := models.NewLeagueStructure(32, 8, 17)
+
+	result := ValidateLeagueStructure(structure)
+	if !result.Valid {
+		t.Errorf("Expected default structure to be valid, got %v", result.Errors)
+	}
+}
+
+func TestValidateLeagueStructure_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*models.LeagueStructure)
+		field  string
+	}{
+		{"odd divisions", func(s *models.LeagueStructure) { s.Divisions = 3 }, "Divisions"},
+		{"too many divisions", func(s *models.LeagueStructure) { s.Divisions = 10 }, "Divisions"},
+		{"odd playoff teams", func(s *models.LeagueStructure) { s.PlayoffTeams = 7 }, "PlayoffTeams"},
+		{"playoff teams not less than teams", func(s *models.LeagueStructure) { s.PlayoffTeams = 32 }, "PlayoffTeams"},
+		{"weeks less than games", func(s *models.LeagueStructure) { s.Weeks = 10 }, "Weeks"},
+		{"exhibition weeks", func(s *models.LeagueStructure) { s.ExWeeks = 1 }, "ExWeeks"},
+		{"too many teams in division", func(s *models.LeagueStructure) { s.Div1Teams = 9 }, "Div1Teams"},
+		{"team count mismatch", func(s *models.LeagueStructure) { s.Div2Teams = 3 }, "Teams"},
+		{"missing division name", func(s *models.LeagueStructure) { s.Div3 = "" }, "Div3"},
+		{"missing conference", func(s *models.LeagueStructure) { s.Conf2 = "" }, "Conf2"},
+	}
+
+	for _, tt := range tests {
+		structure := models.NewLeagueStructure(32, 8, 17)
+		tt.modify(structure)
+
+		result := ValidateLeagueStructure(structure)
+		if !result.HasError(tt.field) {
+			t.Errorf("%s: expected error on %s, got %v", tt.name, tt.field, result.Errors)
+		}
+	}
+}
+
+func TestValidatePlayoffSpots(t *testing.T) {
+	// 3 spots per conference cannot hold 4 division winners
+	structure := models.NewLeagueStructure(32, 8, 17)
+	structure.PlayoffTeams = 6
+	result := ValidatePlayoffSpots(structure)
+	if !result.HasError("PlayoffTeams") {
+		t.Error("Expected error for fewer spots per conference than division winners")
+	}
+
+	// 9 teams split 5/4: 10 spots would need 5 per conference
+	structure = models.NewLeagueStructure(9, 2, 18)
+	structure.PlayoffTeams = 10
+	if result := ValidatePlayoffSpots(structure); !result.HasError("PlayoffTeams") {
+		t.Error("Expected error for more spots than a conference has teams")
+	}
+
+	// Conference 2 holds 2 of 8 teams: 2 spots each fit, 3 do not
+	structure = models.NewLeagueStructure(8, 4, 10)
+	structure.SetDivision(1, "North", 3)
+	structure.SetDivision(2, "South", 3)
+	structure.SetDivision(3, "East", 1)
+	structure.SetDivision(4, "West", 1)
+	structure.PlayoffTeams = 4
+	if result := ValidatePlayoffSpots(structure); !result.Valid {
+		t.Errorf("Expected 2 spots per conference to be valid, got %v", result.Errors)
+	}
+	structure.PlayoffTeams = 6
+	result = ValidatePlayoffSpots(structure)
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "has only 2 teams") {
+		t.Errorf("Expected only the conference size error, got %v", result.Errors)
+	}
+}
+
+func TestValidateLeagueStructure_UnusedDivisions(t *testing.T) {
+	structure := models.NewLeagueStructure(16, 4, 16)
+	structure.SetDivision(5, "Leftover", 4)
+
+	result := ValidateLeagueStructure(structure)
+	if !result.HasError("Div5Teams") {
+		t.Errorf("Expected error for unused division 5, got %v", result.Errors)
+	}
+
+	structure.ClearUnusedDivisions()
+	if result := ValidateLeagueStructure(structure); !result.Valid {
+		t.Errorf("Expected structure to be valid after clearing, got %v", result.Errors)
+	}
+}
+
+func TestValidateDefaultTeams(t *testing.T) {
+	structure := models.NewLeagueStructure(4, 2, 6)
+	teams := []models.DefaultTeam{
+		{League: "4_2_6", TeamID: 1, TeamName: "A", NickName: "A", Abbreviation: "AAA", Conference: 1, Division: 1},
+		{League: "4_2_6", TeamID: 2, TeamName: "B", NickName: "B", Abbreviation: "BBB", Conference: 1, Division: 1},
+		{League: "4_2_6", TeamID: 3, TeamName: "C", NickName: "C", Abbreviation: "CCC", Conference: 2, Division: 1},
+		{League: "4_2_6", TeamID: 4, TeamName: "D", NickName: "D", Abbreviation: "DDD", Conference: 2, Division: 1},
+	}
+
+	if result := ValidateDefaultTeams(structure, teams); !result.Valid {
+		t.Errorf("Expected valid teams, got %v", result.Errors)
+	}
+
+	teams[3].TeamID = 3
+	teams[3].Abbreviation = "CCC"
+	teams[2].League = "other"
+	teams[1].Conference = 2
+
+	result := ValidateDefaultTeams(structure, teams)
+	for _, field := range []string{"DefaultTeams[3].TeamID", "DefaultTeams[3].Abbreviation", "DefaultTeams[2].League", "Div1Teams", "Div2Teams"} {
+		if !result.HasError(field) {
+			t.Errorf("Expected error on %s, got %v", field, result.Errors)
+		}
+	}
+}
+
+func TestValidateScheduleTemplate(t *testing.T) {
+	structure := models.NewLeagueStructure(4, 2, 3)
+	structure.ExGames, structure.ExWeeks, structure.Weeks, structure.Rotations = 1, 1, 3, 2
+
+	games, err := data.LoadScheduleTemplate("../../testdata/fixtures/csv/4_2_3_schedule.csv")
+	if err != nil {
+		t.Fatalf("LoadScheduleTemplate failed: %v", err)
+	}
+
+	if result := ValidateScheduleTemplate(structure, games); !result.Valid {
+		t.Errorf("Expected fixture schedule to be valid, got %v", result.Errors)
+	}
+
+	// Same team twice in a week, and a team that does not exist
+	games[3].HomeDiv, games[3].HomeTeam = 1, 1
+	games[4].VisDiv, games[4].VisTeam = 2, 5
+
+	result := ValidateScheduleTemplate(structure, games)
+	if !result.HasError("Schedule[3]") || !result.HasError("Schedule[4]") || !result.HasError("Schedule") {
+		t.Errorf("Expected errors for games 3 and 4 and game counts, got %v", result.Errors)
+	}
+}
+
+func TestValidateLeagueArtifacts_DefaultData(t *testing.T) {
+	structures, err := data.LoadLeagueStructures("../../default_data/league_info.csv")
+	if err != nil {
+		t.Fatalf("LoadLeagueStructures failed: %v", err)
+	}
+	defaultTeams, err := data.LoadDefaultTeams("../../default_data/default_teams.csv")
+	if err != nil {
+		t.Fatalf("LoadDefaultTeams failed: %v", err)
+	}
+
+	for i := range structures {
+		structure := &structures[i]
+		path := "../../default_data/" + structure.ScheduleID + "_schedule.csv"
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		games, err := data.LoadScheduleTemplate(path)
+		if err != nil {
+			t.Fatalf("LoadScheduleTemplate(%s) failed: %v", path, err)
+		}
+
+		var teams []models.DefaultTeam
+		for _, team := range defaultTeams {
+			if team.League == structure.ScheduleID {
+				teams = append(teams, team)
+			}
+		}
+
+		result := ValidateLeagueArtifacts(structure, teams, games)
+		// The shipped 12_2_16 template has an exhibition game against division 8 team 8
+		if structure.ScheduleID == "12_2_16" {
+			if result.Valid {
+				t.Error("Expected 12_2_16 to report its invalid exhibition game")
+			}
+			continue
+		}
+		if !result.Valid {
+			t.Errorf("%s: expected shipped league structure to be valid, got %v", structure.ScheduleID, result.Errors)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// ABOUTME: Economic sanity checks of a custom league's xxxx_info.csv
+// ABOUTME: Checks BASE_YEAR, the order of the salary minimums and their ratios to the salary cap
+
+package validation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// salaryRatioTolerance is how far a minimum-to-cap ratio may stray from the
+// reference structure's before it is reported. Rounding to whole $10,000
+// units alone moves the ratio of a small minimum by a few percent.
+const salaryRatioTolerance = 0.10
+
+// salaryMinimumFields are the LeagueInfo fields of models.SalaryMinimumColumns
+var salaryMinimumFields = []string{"Minimum", "Salary1", "Salary2", "Salary3", "Salary45", "Salary789", "Salary10"}
+
+// ValidateLeagueInfo checks the info file of a custom league against the
+// league_info.csv rows in structures:
+//   - INFO_BASE_YEAR (error): a BASE_YEAR outside 1900-2199, which the game refuses
+//   - INFO_SCHEDULEID_REF (error): a SCHEDULEID without a league_info.csv row
+//   - INFO_SALARYCAP, INFO_MINIMUM, INFO_SALARY1... (error): a cap or minimum below 1
+//   - INFO_SALARY_ORDER (warning): a minimum below the one for less experience
+//   - INFO_SALARY_RATIO (warning): a minimum whose ratio to the cap differs by
+//     more than 10% from the ratio of the structure's row, as the docs ask to
+//     preserve the ratio the game "makes heavy use of"; the suggestion is the
+//     minimum rescaled to the cap
+//
+// Without structures the reference checks are skipped.
+func ValidateLeagueInfo(file string, info *models.LeagueInfo, structures []models.LeagueStructure) *ValidationResult {
+	result := NewValidationResult()
+	if info == nil {
+		return result
+	}
+
+	add := func(field, code, message string, severity Severity, suggestion string) {
+		result.AddFinding(ValidationError{
+			Field: field, Message: message, Severity: severity, Code: "INFO_" + code, Suggestion: suggestion,
+		})
+	}
+
+	if info.BaseYear < models.MinBaseYear || info.BaseYear > models.MaxBaseYear {
+		add("BaseYear", "BASE_YEAR", fmt.Sprintf("must be between %d and %d", models.MinBaseYear, models.MaxBaseYear),
+			SeverityError, "")
+	}
+
+	var reference *models.LeagueStructure
+	if len(structures) > 0 {
+		if reference = models.FindLeagueStructure(structures, info.ScheduleID); reference == nil {
+			add("ScheduleID", "SCHEDULEID_REF", fmt.Sprintf("%q must match a league structure in league_info.csv", info.ScheduleID),
+				SeverityError, "")
+		}
+	}
+
+	if info.SalaryCap < 1 {
+		add("SalaryCap", "SALARYCAP", "must be at least 1", SeverityError, "")
+	}
+
+	minimums := info.SalaryMinimums()
+	scaled := info.ScaledMinimums(reference)
+	for i, value := range minimums {
+		column, field := models.SalaryMinimumColumns[i], salaryMinimumFields[i]
+		if value < 1 {
+			add(field, column, "must be at least 1", SeverityError, "")
+			continue
+		}
+		if i > 0 && value < minimums[i-1] {
+			add(field, "SALARY_ORDER", fmt.Sprintf("%d is below %s %d; minimums should rise with experience",
+				value, models.SalaryMinimumColumns[i-1], minimums[i-1]), SeverityWarning, "")
+		}
+
+		if scaled == nil || info.SalaryCap < 1 || reference.SalaryMinimums()[i] < 1 {
+			continue
+		}
+		ratio := float64(value) / float64(info.SalaryCap)
+		expected := float64(reference.SalaryMinimums()[i]) / float64(reference.SalaryCap)
+		if math.Abs(ratio/expected-1) > salaryRatioTolerance {
+			add(field, "SALARY_RATIO", fmt.Sprintf("%d is %.0f%% of what the %s ratio to the salary cap gives (%d)",
+				value, ratio/expected*100, reference.ScheduleID, scaled[i]), SeverityWarning,
+				fmt.Sprintf("%s %d", column, scaled[i]))
+		}
+	}
+
+	result.locate(file, 2, fmt.Sprintf("League info (%s, %d)", info.ScheduleID, info.BaseYear), "info")
+	return result
+}
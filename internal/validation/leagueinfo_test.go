@@ -0,0 +1,90 @@
+// ABOUTME: Tests for league info economic checks
+// ABOUTME: Covers base year bounds, minimum order, cap ratios and the custom example info file
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestValidateLeagueInfo(t *testing.T) {
+	structures := []models.LeagueStructure{{ScheduleID: "32_8_17", SalaryCap: 2000,
+		Minimum: 70, Salary1: 85, Salary2: 100, Salary3: 115, Salary45: 130, Salary789: 150, Salary10: 180}}
+	info := models.NewDefaultLeagueInfo(2024)
+
+	if result := ValidateLeagueInfo("2024_info.csv", info, structures); len(result.Errors) != 0 {
+		t.Errorf("Expected no findings for the default info, got %v", result.Errors)
+	}
+
+	// A quarter of the cap with the full-cap minimums, and a 2-year minimum below the 1-year one
+	info.BaseYear = 1899
+	info.SalaryCap = 500
+	info.Salary2 = 80
+	result := ValidateLeagueInfo("2024_info.csv", info, structures)
+
+	expected := []struct {
+		code     string
+		severity Severity
+	}{
+		{"INFO_BASE_YEAR", SeverityError},
+		{"INFO_SALARY_RATIO", SeverityWarning}, // MINIMUM
+		{"INFO_SALARY_RATIO", SeverityWarning}, // SALARY1
+		{"INFO_SALARY_ORDER", SeverityWarning}, // SALARY2
+		{"INFO_SALARY_RATIO", SeverityWarning},
+		{"INFO_SALARY_RATIO", SeverityWarning},
+		{"INFO_SALARY_RATIO", SeverityWarning},
+		{"INFO_SALARY_RATIO", SeverityWarning},
+		{"INFO_SALARY_RATIO", SeverityWarning},
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), result.Errors)
+	}
+	for i, want := range expected {
+		got := result.Errors[i]
+		if got.Code != want.code || got.Severity != want.severity {
+			t.Errorf("Finding %d: expected %s (%s), got %s (%s)", i, want.code, want.severity, got.Code, got.Severity)
+		}
+		if got.File != "2024_info.csv" || got.Line != 2 || got.RecordKey != "info" {
+			t.Errorf("Finding %d: expected 2024_info.csv:2 (info), got %s:%d (%s)", i, got.File, got.Line, got.RecordKey)
+		}
+	}
+	if got := result.Errors[1].Suggestion; got != "MINIMUM 18" {
+		t.Errorf("Expected suggestion MINIMUM 18, got %q", got)
+	}
+
+	info.ScheduleID = "8_2_14"
+	result = ValidateLeagueInfo("2024_info.csv", info, structures)
+	if len(result.Errors) != 3 || result.Errors[1].Code != "INFO_SCHEDULEID_REF" {
+		t.Errorf("Expected a missing structure and no ratio checks, got %v", result.Errors)
+	}
+
+	if result := ValidateLeagueInfo("info", nil, structures); len(result.Errors) != 0 {
+		t.Errorf("Expected no findings without an info file, got %v", result.Errors)
+	}
+}
+
+func TestValidateLeagueInfo_CustomExample(t *testing.T) {
+	info, err := data.LoadLeagueInfo("../../custom_example/example_info.csv")
+	if err != nil {
+		t.Fatalf("LoadLeagueInfo failed: %v", err)
+	}
+	structures, err := data.LoadLeagueStructures("../../default_data/league_info.csv")
+	if err != nil {
+		t.Fatalf("LoadLeagueStructures failed: %v", err)
+	}
+
+	// The example's minimums rise faster with experience than the 12_2_16
+	// row's do; only MINIMUM and SALARY1 stay within 10% of its ratios
+	result := ValidateLeagueInfo("example_info.csv", info, structures)
+	if len(result.Errors) != 5 || result.Count(SeverityWarning) != 5 {
+		t.Errorf("Expected 5 ratio warnings, got %v", result.Errors)
+	}
+
+	info.RescaleMinimums(models.FindLeagueStructure(structures, info.ScheduleID))
+	if result := ValidateLeagueInfo("example_info.csv", info, structures); len(result.Errors) != 0 {
+		t.Errorf("Expected no findings after rescaling, got %v", result.Errors)
+	}
+}
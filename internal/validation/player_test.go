@@ -4,31 +4,39 @@
 package validation
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/igorilic/fof9editor/internal/models"
 )
 
-func TestValidatePlayer_ValidPlayer(t *testing.T) {
-	player := &models.Player{
-		FirstName:         "John",
-		LastName:          "Doe",
-		Team:              15,
-		PositionKey:       10,
-		Uniform:           12,
-		OverallRating:     85,
-		Height:            72,
-		Weight:            210,
-		HandSize:          9,
-		ArmLength:         32,
-		Experience:        5,
-		College:           "State University",
+// validPlayer returns a player that follows every documented field rule
+func validPlayer() *models.Player {
+	return &models.Player{
+		PlayerID:         1000,
+		FirstName:        "John",
+		LastName:         "Doe",
+		Team:             15,
+		PositionKey:      10,
+		Uniform:          12,
+		OverallRating:    3,
+		Height:           72,
+		Weight:           210,
+		HandSize:         93,
+		ArmLength:        322,
+		BirthMonth:       6,
+		BirthDay:         15,
+		BirthYear:        1996,
+		Experience:       5,
+		College:          "State University",
 		YearEntry:        2018,
 		RoundDrafted:     3,
 		SelectionDrafted: 85,
 	}
+}
 
-	result := ValidatePlayer(player)
+func TestValidatePlayer_ValidPlayer(t *testing.T) {
+	result := ValidatePlayer(validPlayer())
 
 	if !result.Valid {
 		t.Errorf("Expected valid player, got errors: %v", result.Errors)
@@ -56,7 +64,7 @@ func TestValidatePlayer_InvalidTeam(t *testing.T) {
 	player := &models.Player{
 		FirstName: "John",
 		LastName:  "Doe",
-		Team:      50, // Invalid: > 31
+		Team:      65, // Invalid: > 64
 	}
 
 	result := ValidatePlayer(player)
@@ -128,7 +136,7 @@ func TestValidatePlayer_InvalidRating(t *testing.T) {
 	player := &models.Player{
 		FirstName:     "John",
 		LastName:      "Doe",
-		OverallRating: 100, // Invalid: > 99
+		OverallRating: 11, // Invalid: > 10
 	}
 
 	result := ValidatePlayer(player)
@@ -155,11 +163,11 @@ func TestValidatePlayerField_FirstName(t *testing.T) {
 		t.Error("Expected validation to fail for empty first name")
 	}
 
-	// Name too long
-	longName := string(make([]byte, 51))
+	// Name too long for the game's display is only a warning
+	longName := strings.Repeat("x", 17)
 	result = ValidatePlayerField("FirstName", longName)
-	if result.Valid {
-		t.Error("Expected validation to fail for name too long")
+	if !result.Valid || result.Count(SeverityWarning) != 1 || result.Errors[0].Code != "PLAYER_FIRSTNAME_LENGTH" {
+		t.Errorf("Expected a PLAYER_FIRSTNAME_LENGTH warning for name too long, got %v", result.Errors)
 	}
 }
 
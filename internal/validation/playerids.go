@@ -0,0 +1,63 @@
+// ABOUTME: Player ID validation across the player and quarterback files
+// ABOUTME: Checks IDs are unique in the universe, within each file's range and sorted
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+)
+
+// ValidatePlayerIDs checks the PLAYERID column of both files. The docs say
+// an ID "must be unique within the universe", so an ID used by an earlier
+// player in either file is a PLAYER_ID_DUP or QB_ID_DUP error. Players
+// "should have player IDs of at least 1000" (PLAYER_ID_LOW), quarterbacks
+// "should have player IDs between 500-999" (QB_ID_RANGE) and both files
+// "should be sorted, lowest to highest ID" (PLAYER_ID_ORDER, QB_ID_ORDER);
+// these are warnings.
+func ValidatePlayerIDs(playersFile string, players []models.Player, quarterbacksFile string, quarterbacks []models.Quarterback) *ValidationResult {
+	result := NewValidationResult()
+
+	files := map[bool]string{false: playersFile, true: quarterbacksFile}
+	firstHolder := make(map[int]roster.Member)
+	previous := make(map[bool]int) // Previous ID in each file
+	for _, member := range roster.Members(players, quarterbacks) {
+		prefix := "PLAYER"
+		if member.Quarterback {
+			prefix = "QB"
+		}
+		found := NewValidationResult()
+		add := func(severity Severity, code, message string) {
+			found.AddFinding(ValidationError{Field: "PlayerID", Message: message, Severity: severity, Code: prefix + code})
+		}
+
+		id := member.PlayerID
+		if other, ok := firstHolder[id]; ok {
+			add(SeverityError, "_ID_DUP", fmt.Sprintf("player ID %d is also used by %s on line %d of %s",
+				id, other.Name, other.Index+2, files[other.Quarterback]))
+		} else {
+			firstHolder[id] = member
+		}
+
+		if member.Quarterback && (id < roster.MinQuarterbackID || id > roster.MaxQuarterbackID) {
+			add(SeverityWarning, "_ID_RANGE", fmt.Sprintf("quarterback IDs should be between %d and %d",
+				roster.MinQuarterbackID, roster.MaxQuarterbackID))
+		} else if !member.Quarterback && id > 0 && id < roster.MinPlayerID {
+			add(SeverityWarning, "_ID_LOW", fmt.Sprintf("player IDs should be at least %d", roster.MinPlayerID))
+		}
+
+		if member.Index > 0 && id < previous[member.Quarterback] {
+			add(SeverityWarning, "_ID_ORDER", fmt.Sprintf("player %d follows player %d; entries should be sorted by ID",
+				id, previous[member.Quarterback]))
+		}
+		previous[member.Quarterback] = id
+
+		found.locate(files[member.Quarterback], member.Index+2,
+			fmt.Sprintf("Player %d (%s)", id, member.Name), roster.PlayerKey(id))
+		result.Merge(found)
+	}
+
+	return result
+}
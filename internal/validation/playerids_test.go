@@ -0,0 +1,72 @@
+// ABOUTME: Tests for player ID validation across the player and quarterback files
+// ABOUTME: Covers universe-wide duplicates, file ranges and sort order
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestValidatePlayerIDs(t *testing.T) {
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 501, FirstName: "Aaron", LastName: "Rodgers"},
+		{PlayerID: 1000, FirstName: "Big", LastName: "Number"},
+		{PlayerID: 502, FirstName: "Out", LastName: "OfOrder"},
+	}
+	players := []models.Player{
+		{PlayerID: 1000, FirstName: "John", LastName: "Doe"},
+		{PlayerID: 999, FirstName: "Low", LastName: "Number"},
+	}
+
+	result := ValidatePlayerIDs("2024_players.csv", players, "2024_quarterbacks.csv", quarterbacks)
+
+	expected := []struct {
+		file     string
+		line     int
+		code     string
+		severity Severity
+	}{
+		{"2024_quarterbacks.csv", 3, "QB_ID_RANGE", SeverityWarning},
+		{"2024_quarterbacks.csv", 4, "QB_ID_ORDER", SeverityWarning},
+		{"2024_players.csv", 2, "PLAYER_ID_DUP", SeverityError},
+		{"2024_players.csv", 3, "PLAYER_ID_LOW", SeverityWarning},
+		{"2024_players.csv", 3, "PLAYER_ID_ORDER", SeverityWarning},
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), result.Errors)
+	}
+	for i, want := range expected {
+		got := result.Errors[i]
+		if got.File != want.file || got.Line != want.line || got.Code != want.code || got.Severity != want.severity {
+			t.Errorf("Finding %d: expected %s:%d %s (%s), got %s:%d %s (%s)",
+				i, want.file, want.line, want.code, want.severity, got.File, got.Line, got.Code, got.Severity)
+		}
+	}
+	if got := result.Errors[2].Message; got != "player ID 1000 is also used by Big Number on line 3 of 2024_quarterbacks.csv" {
+		t.Errorf("Unexpected message: %s", got)
+	}
+	if result.Valid {
+		t.Error("Expected the duplicate ID to make the result invalid")
+	}
+}
+
+func TestValidatePlayerIDs_DefaultData(t *testing.T) {
+	for _, year := range []string{"2023", "2024"} {
+		players, err := data.LoadPlayers("../../default_data/" + year + "_players.csv")
+		if err != nil {
+			t.Fatalf("LoadPlayers failed: %v", err)
+		}
+		quarterbacks, err := data.LoadQuarterbacks("../../default_data/" + year + "_quarterbacks.csv")
+		if err != nil {
+			t.Fatalf("LoadQuarterbacks failed: %v", err)
+		}
+
+		result := ValidatePlayerIDs(year+"_players.csv", players, year+"_quarterbacks.csv", quarterbacks)
+		if len(result.Errors) != 0 {
+			t.Errorf("Expected the shipped %s IDs to follow the rules, got %v", year, result.Errors)
+		}
+	}
+}
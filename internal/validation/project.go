@@ -0,0 +1,277 @@
+// ABOUTME: Project-wide validation for FOF9 projects
+// ABOUTME: Runs file checks and resolves team, city and college IDs across files, then applies suppressions
+
+package validation
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+// Project file keys used when a project does not name its CSV files
+const (
+	playersFile      = "players"
+	quarterbacksFile = "quarterbacks"
+	coachesFile      = "coaches"
+	teamsFile        = "teams"
+	scheduleFile     = "schedule"
+	infoFile         = "info"
+)
+
+// projectRefs holds the lookup sets foreign keys are resolved against
+type projectRefs struct {
+	teamYears map[int]map[int]bool // Team IDs by season
+	teamYear  int                  // Season of the player and coach files
+	cities    map[int]models.City
+	colleges  map[int]models.College
+}
+
+// recordRef locates a record for reference findings
+type recordRef struct {
+	kind   string // Rule code prefix, e.g. "PLAYER"
+	file   string
+	line   int
+	record string
+	key    string
+}
+
+// ValidateProject runs every file, cross-file and house rule check over the
+// loaded project and removes the findings the project suppresses
+func ValidateProject(appState *state.AppState, refs *models.ReferenceData) *ValidationResult {
+	result := NewValidationResult()
+
+	project := appState.GetProject()
+	teams := appState.GetTeams()
+
+	r := projectRefs{teamYears: make(map[int]map[int]bool)}
+	for _, team := range teams {
+		if r.teamYears[team.Year] == nil {
+			r.teamYears[team.Year] = make(map[int]bool)
+		}
+		r.teamYears[team.Year][team.TeamID] = true
+	}
+	r.teamYear = models.LatestTeamYear(teams)
+	if project != nil && len(r.teamYears[project.BaseYear]) > 0 {
+		r.teamYear = project.BaseYear
+	}
+	if refs != nil && refs.HasCities() {
+		r.cities = refs.Cities
+	}
+	if refs != nil && refs.HasColleges() {
+		r.colleges = refs.Colleges
+	}
+
+	file := ProjectFileName(project, playersFile)
+	players := appState.GetPlayers()
+	result.Merge(ValidatePlayerFile(file, players))
+	for i := range players {
+		player := &players[i]
+		ref := recordRef{"PLAYER", file, i + 2, playerRecord(player), playerKey(player)}
+
+		r.checkTeam(result, ref, "TEAM", player.Team, r.teamYear)
+		r.checkTeam(result, ref, "ORIGINALTEAM", player.OriginalTeam, r.teamYear)
+		r.checkCity(result, ref, "CITYID", player.BirthCityID)
+		r.checkBirthCity(result, ref, player.BirthCity, player.BirthCityID)
+		r.checkCollege(result, ref, "COLLEGEID", player.CollegeID)
+	}
+
+	quarterbacks := appState.GetQuarterbacks()
+	qbFile := ProjectFileName(project, quarterbacksFile)
+	result.Merge(ValidateQuarterbackFile(qbFile, quarterbacks))
+	for i := range quarterbacks {
+		qb := &quarterbacks[i]
+		ref := recordRef{"QB", qbFile, i + 2, quarterbackRecord(qb), roster.PlayerKey(qb.PlayerID)}
+
+		r.checkTeam(result, ref, "TEAM", qb.Team, r.teamYear)
+		r.checkTeam(result, ref, "ORIGINALTEAM", qb.OriginalTeam, r.teamYear)
+		r.checkCity(result, ref, "CITYID", qb.BirthCityID)
+		r.checkBirthCity(result, ref, qb.BirthCity, qb.BirthCityID)
+		r.checkCollege(result, ref, "COLLEGEID", qb.CollegeID)
+	}
+	result.Merge(ValidatePlayerIDs(file, players, qbFile, quarterbacks))
+	result.Merge(ValidateUniforms(file, players, qbFile, quarterbacks))
+	result.Merge(ValidateTimeline(file, players, qbFile, quarterbacks, LeagueBaseYear(appState)))
+	result.Merge(ValidateDrafts(file, players, qbFile, quarterbacks, len(models.TeamsForYear(teams, r.teamYear))))
+	result.Merge(ValidateProspects(file, players, qbFile, quarterbacks, LeagueBaseYear(appState),
+		len(models.TeamsForYear(teams, r.teamYear))))
+	result.Merge(ValidateContracts(file, players, qbFile, quarterbacks,
+		ProjectFileName(project, teamsFile), teams, r.teamYear, appState.GetLeagueInfo()))
+
+	file = ProjectFileName(project, coachesFile)
+	coaches := appState.GetCoaches()
+	result.Merge(ValidateCoachFile(file, coaches))
+	result.Merge(ValidateCoachingStaff(file, coaches, ProjectFileName(project, teamsFile), teams, r.teamYear))
+	for i := range coaches {
+		coach := &coaches[i]
+		ref := recordRef{"COACH", file, i + 2, coachRecord(coach), coachKey(coach)}
+
+		r.checkTeam(result, ref, "TEAM", coach.Team, r.teamYear)
+		r.checkCity(result, ref, "CITYID", coach.BirthCityID)
+		r.checkBirthCity(result, ref, coach.BirthCity, coach.BirthCityID)
+		r.checkCollege(result, ref, "COLLEGEID", coach.CollegeID)
+	}
+
+	file = ProjectFileName(project, teamsFile)
+	result.Merge(ValidateTeamFile(file, teams))
+	result.Merge(ValidateStadiums(file, teams, refs))
+	for i := range teams {
+		team := &teams[i]
+		ref := recordRef{"TEAM", file, i + 2, teamRecord(team), teamKey(team)}
+		r.checkCity(result, ref, "CITY", team.City)
+
+		colors := ValidateTeamColors(team)
+		colors.locate(ref.file, ref.line, ref.record, ref.key)
+		result.Merge(colors)
+		if team.Future != 0 {
+			r.checkCity(result, ref, "FUTURE", team.Future)
+		}
+	}
+
+	file = ProjectFileName(project, scheduleFile)
+	for i, game := range appState.GetSchedule() {
+		ref := recordRef{
+			kind:   "SCHEDULE",
+			file:   file,
+			line:   i + 2,
+			record: fmt.Sprintf("Week %d game %d at %d", game.Week, game.Visitor, game.Home),
+			key:    fmt.Sprintf("game:%d:%d:%d@%d", game.Year, game.Week, game.Visitor, game.Home),
+		}
+
+		year := game.Year
+		if len(r.teamYears[year]) == 0 {
+			year = r.teamYear
+		}
+		r.checkTeam(result, ref, "HOME", game.Home, year)
+		r.checkTeam(result, ref, "VISITOR", game.Visitor, year)
+		if game.Location != 0 {
+			r.checkCity(result, ref, "LOCATION", game.Location)
+		}
+	}
+
+	result.Merge(ValidateLeagueInfo(ProjectFileName(project, infoFile), appState.GetLeagueInfo(), appState.GetLeagueStructures()))
+
+	if project != nil && len(project.ValidationRules) > 0 {
+		projectFile := "project"
+		if path := appState.GetProjectPath(); path != "" {
+			projectFile = filepath.Base(path)
+		}
+		result.Merge(ValidateCustomRules(project.ValidationRules, RuleData{
+			ProjectFile:      projectFile,
+			PlayersFile:      ProjectFileName(project, playersFile),
+			Players:          players,
+			QuarterbacksFile: qbFile,
+			Quarterbacks:     quarterbacks,
+			CoachesFile:      ProjectFileName(project, coachesFile),
+			Coaches:          coaches,
+			TeamsFile:        ProjectFileName(project, teamsFile),
+			Teams:            teams,
+			TeamYear:         r.teamYear,
+			BaseYear:         LeagueBaseYear(appState),
+		}))
+	}
+
+	if project != nil {
+		result.ApplySuppressions(project.ValidationSuppressions())
+	}
+
+	return result
+}
+
+// checkTeam reports a team ID that is neither 0 nor a team of the season
+func (r *projectRefs) checkTeam(result *ValidationResult, ref recordRef, field string, teamID, year int) {
+	if teamID == 0 || len(r.teamYears) == 0 {
+		return
+	}
+	if !r.teamYears[year][teamID] && !r.teamYears[0][teamID] {
+		ref.addMissing(result, field, fmt.Sprintf("team %d does not exist in %d", teamID, year))
+	}
+}
+
+// checkCity reports a city ID that is not in cities.csv
+func (r *projectRefs) checkCity(result *ValidationResult, ref recordRef, field string, cityID int) {
+	if r.cities == nil {
+		return
+	}
+	if _, ok := r.cities[cityID]; !ok {
+		ref.addMissing(result, field, fmt.Sprintf("city %d does not exist in cities.csv", cityID))
+	}
+}
+
+// checkBirthCity reports a BIRTHCITY that is empty or not in the state or
+// province of the US or Canadian city of CITYID, suggesting the city's own
+// name. The game does not read BIRTHCITY, so the finding is info.
+func (r *projectRefs) checkBirthCity(result *ValidationResult, ref recordRef, birthCity string, cityID int) {
+	city, ok := r.cities[cityID]
+	if !ok || !city.HasBirthCityRegion() {
+		return
+	}
+	message := fmt.Sprintf("is empty; CITYID %d is %s", cityID, city.GetDisplayName())
+	if birthCity != "" {
+		if region := birthCity[strings.LastIndex(birthCity, "_")+1:]; region == city.Region {
+			return
+		}
+		message = fmt.Sprintf("%q is not in %s, the region of CITYID %d (%s)", birthCity, city.Region, cityID, city.GetDisplayName())
+	}
+	result.AddFinding(ValidationError{
+		Field:      "BirthCity",
+		Message:    message,
+		Severity:   SeverityInfo,
+		Code:       ref.kind + "_BIRTHCITY_CITYID",
+		Suggestion: "BIRTHCITY " + city.BirthCity(),
+		File:       ref.file,
+		Line:       ref.line,
+		Record:     ref.record,
+		RecordKey:  ref.key,
+	})
+}
+
+// checkCollege reports a college ID that is not in colleges.csv
+func (r *projectRefs) checkCollege(result *ValidationResult, ref recordRef, field string, collegeID int) {
+	if r.colleges == nil {
+		return
+	}
+	if _, ok := r.colleges[collegeID]; !ok {
+		ref.addMissing(result, field, fmt.Sprintf("college %d does not exist in colleges.csv", collegeID))
+	}
+}
+
+// addMissing adds a dangling reference error coded KIND_FIELD_REF
+func (ref recordRef) addMissing(result *ValidationResult, field, message string) {
+	result.AddFinding(ValidationError{
+		Field:     field,
+		Message:   message,
+		Code:      ref.kind + "_" + field + "_REF",
+		File:      ref.file,
+		Line:      ref.line,
+		Record:    ref.record,
+		RecordKey: ref.key,
+	})
+}
+
+// ProjectFileName returns the name of a project CSV file, such as
+// "2024_players.csv", or its key (e.g. "players") if the project does not list it
+func ProjectFileName(project *models.Project, key string) string {
+	if project != nil {
+		if path := project.GetFullPath(key); path != "" {
+			return filepath.Base(path)
+		}
+	}
+	return key
+}
+
+// LeagueBaseYear returns the league's BASE_YEAR from the info file, else the
+// project's base year, else the latest season of the team file
+func LeagueBaseYear(appState *state.AppState) int {
+	if info := appState.GetLeagueInfo(); info != nil && info.BaseYear > 0 {
+		return info.BaseYear
+	}
+	if project := appState.GetProject(); project != nil && project.BaseYear > 0 {
+		return project.BaseYear
+	}
+	return models.LatestTeamYear(appState.GetTeams())
+}
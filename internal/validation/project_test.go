@@ -0,0 +1,206 @@
+// ABOUTME: Tests for cross-file referential integrity validation
+// ABOUTME: Checks dangling team, city and college references are reported with file, line and record
+
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/state"
+)
+
+// loadProjectFixtures fills the app state and reference data from the league4 fixtures
+func loadProjectFixtures(t *testing.T) (*state.AppState, *models.ReferenceData) {
+	t.Helper()
+
+	appState := state.GetInstance()
+	appState.Reset()
+
+	teams, err := data.LoadTeams("../../testdata/fixtures/csv/teams_league4.csv")
+	if err != nil {
+		t.Fatalf("LoadTeams failed: %v", err)
+	}
+	games, err := data.LoadSeasonSchedule("../../testdata/fixtures/csv/2024_league4_schedule.csv")
+	if err != nil {
+		t.Fatalf("LoadSeasonSchedule failed: %v", err)
+	}
+	cities, err := data.LoadCities("../../testdata/fixtures/csv/cities_simple.csv")
+	if err != nil {
+		t.Fatalf("LoadCities failed: %v", err)
+	}
+	colleges, err := data.LoadColleges("../../testdata/fixtures/csv/colleges_simple.csv")
+	if err != nil {
+		t.Fatalf("LoadColleges failed: %v", err)
+	}
+
+	appState.SetTeams(teams)
+	appState.SetSchedule(games)
+	brady := validPlayer()
+	brady.FirstName, brady.LastName = "Tom", "Brady"
+	brady.Team, brady.OriginalTeam, brady.Uniform = 1, 2, 72
+	brady.BirthCity, brady.BirthCityID, brady.CollegeID = "Boston_MA", 7215, 5678
+	brady.RoundDrafted, brady.SelectionDrafted = 1, 2
+	freeAgent := validPlayer()
+	freeAgent.PlayerID = 1001
+	freeAgent.FirstName, freeAgent.LastName = "Free", "Agent"
+	freeAgent.Team, freeAgent.Uniform = 0, -1
+	freeAgent.RoundDrafted, freeAgent.SelectionDrafted = 0, 0
+	appState.SetPlayers([]models.Player{*brady, *freeAgent})
+	appState.SetCoaches([]models.Coach{{
+		FirstName: "Bill", LastName: "Belichick", Team: 1, BirthCity: "New York_NY", BirthCityID: 13493, CollegeID: 9012,
+		BirthMonth: 4, BirthDay: 16, BirthYear: 1952, Position: 1, PositionGroup: 3, OffensiveStyle: 2, PayScale: 100,
+	}})
+
+	refs := models.NewReferenceData()
+	refs.SetCities(cities)
+	refs.SetColleges(colleges)
+	return appState, refs
+}
+
+// referenceFindings returns the dangling reference findings of a result.
+// The league4 team fixture only fills the league structure columns, so its
+// stadium columns fail the team rules.
+func referenceFindings(result *ValidationResult) []ValidationError {
+	var found []ValidationError
+	for _, err := range result.Errors {
+		if strings.HasSuffix(err.Code, "_REF") {
+			found = append(found, err)
+		}
+	}
+	return found
+}
+
+func TestValidateProject_Valid(t *testing.T) {
+	appState, refs := loadProjectFixtures(t)
+
+	result := ValidateProject(appState, refs)
+	if found := referenceFindings(result); len(found) != 0 {
+		t.Errorf("Expected all references to resolve, got %v", found)
+	}
+	for _, file := range []string{"players", "coaches", "schedule"} {
+		if found := result.ForFile(file); len(found.Errors) != 0 {
+			t.Errorf("Expected no findings in %s, got %v", file, found.Errors)
+		}
+	}
+}
+
+func TestValidateProject_BirthCity(t *testing.T) {
+	appState, refs := loadProjectFixtures(t)
+	appState.SetQuarterbacks([]models.Quarterback{{PlayerID: 501, FirstName: "Aaron", LastName: "Rodgers", BirthCityID: 7215}})
+	appState.GetCoaches()[0].BirthCity = "Nashville_TN"
+
+	var found []ValidationError
+	for _, err := range ValidateProject(appState, refs).Errors {
+		if strings.HasSuffix(err.Code, "_BIRTHCITY_CITYID") {
+			found = append(found, err)
+		}
+	}
+
+	// Brady's Boston_MA matches; Belichick's city is in another state and
+	// Rodgers has none
+	if len(found) != 2 {
+		t.Fatalf("Expected 2 birth city findings, got %v", found)
+	}
+	if got := found[0]; got.Code != "QB_BIRTHCITY_CITYID" || got.File != "quarterbacks" || got.Severity != SeverityInfo ||
+		got.Message != "is empty; CITYID 7215 is Boston, MA" || got.Suggestion != "BIRTHCITY Boston_MA" {
+		t.Errorf("Unexpected quarterback finding: %+v", got)
+	}
+	if got := found[1]; got.Code != "COACH_BIRTHCITY_CITYID" || got.RecordKey != "coach:Bill Belichick:1952-04-16" ||
+		got.Message != `"Nashville_TN" is not in NY, the region of CITYID 13493 (New York, NY)` || got.Suggestion != "BIRTHCITY New York_NY" {
+		t.Errorf("Unexpected coach finding: %+v", got)
+	}
+}
+
+func TestValidateProject_DanglingReferences(t *testing.T) {
+	appState, refs := loadProjectFixtures(t)
+
+	players := appState.GetPlayers()
+	players[1].Team = 40
+	players[1].OriginalTeam = 5
+	players[1].CollegeID = 1234
+	coaches := appState.GetCoaches()
+	coaches[0].BirthCityID = 99
+	teams := appState.GetTeams()
+	teams[2].City = 42
+	games := appState.GetSchedule()
+	games[3].Visitor = 9
+	games[5].Location = 7
+
+	found := referenceFindings(ValidateProject(appState, refs))
+
+	expected := []struct {
+		file string
+		line int
+		code string
+		key  string
+	}{
+		{"players", 3, "PLAYER_TEAM_REF", "player:1001"},
+		{"players", 3, "PLAYER_ORIGINALTEAM_REF", "player:1001"},
+		{"players", 3, "PLAYER_COLLEGEID_REF", "player:1001"},
+		{"coaches", 2, "COACH_CITYID_REF", "coach:Bill Belichick:1952-04-16"},
+		{"teams", 4, "TEAM_CITY_REF", "team:2024:3"},
+		{"schedule", 5, "SCHEDULE_VISITOR_REF", "game:2024:2:9@3"},
+		{"schedule", 7, "SCHEDULE_LOCATION_REF", ""},
+	}
+	if len(found) != len(expected) {
+		t.Fatalf("Expected %d errors, got %v", len(expected), found)
+	}
+	for i, want := range expected {
+		got := found[i]
+		if got.File != want.file || got.Line != want.line || got.Code != want.code || got.Severity != SeverityError {
+			t.Errorf("Error %d: expected %s:%d %s, got %s:%d %s (%s)", i, want.file, want.line, want.code, got.File, got.Line, got.Code, got.Severity)
+		}
+		if want.key != "" && got.RecordKey != want.key {
+			t.Errorf("Error %d: expected record key %s, got %s", i, want.key, got.RecordKey)
+		}
+	}
+
+	if got := found[0].Error(); got != "players:3: Player 1001 (Free Agent) TEAM: team 40 does not exist in 2024" {
+		t.Errorf("Unexpected error text: %s", got)
+	}
+	if !strings.Contains(found[4].Record, "Miami Surf") {
+		t.Errorf("Expected team record to name Miami Surf, got %s", found[4].Record)
+	}
+}
+
+func TestValidateProject_QuarterbackReferences(t *testing.T) {
+	appState, refs := loadProjectFixtures(t)
+	appState.SetQuarterbacks([]models.Quarterback{
+		{PlayerID: 501, FirstName: "Aaron", LastName: "Rodgers", Team: 1, OriginalTeam: 2, BirthCityID: 7215, CollegeID: 5678},
+		{PlayerID: 502, FirstName: "Lost", LastName: "Passer", Team: 40, OriginalTeam: 5, BirthCityID: 99, CollegeID: 1234},
+	})
+
+	found := referenceFindings(ValidateProject(appState, refs))
+	expected := []string{"QB_TEAM_REF", "QB_ORIGINALTEAM_REF", "QB_CITYID_REF", "QB_COLLEGEID_REF"}
+	if len(found) != len(expected) {
+		t.Fatalf("Expected %d errors, got %v", len(expected), found)
+	}
+	for i, code := range expected {
+		got := found[i]
+		if got.Code != code || got.File != "quarterbacks" || got.Line != 3 || got.RecordKey != "player:502" {
+			t.Errorf("Error %d: expected %s at quarterbacks:3, got %+v", i, code, got)
+		}
+	}
+	if got := found[0].Error(); got != "quarterbacks:3: Player 502 (Lost Passer) TEAM: team 40 does not exist in 2024" {
+		t.Errorf("Unexpected error text: %s", got)
+	}
+}
+
+func TestValidateProject_ProjectFileNamesAndMissingTables(t *testing.T) {
+	appState, _ := loadProjectFixtures(t)
+	appState.SetProject(models.NewProject("Test League", "test", "/tmp", 2024))
+	appState.GetPlayers()[0].Team = 40
+	appState.GetPlayers()[0].CollegeID = 1
+
+	// Without reference tables only team references are checked
+	found := referenceFindings(ValidateProject(appState, models.NewReferenceData()))
+	if len(found) != 1 {
+		t.Fatalf("Expected only the team error, got %v", found)
+	}
+	if found[0].File != "test_players.csv" {
+		t.Errorf("Expected project file name test_players.csv, got %s", found[0].File)
+	}
+}
@@ -0,0 +1,55 @@
+// ABOUTME: Draft class validation of future draftees across the player and quarterback files
+// ABOUTME: Turns the roster package's draft class checks into located findings
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+)
+
+// ValidateProspects checks the entries whose BASE_YEAR is later than the
+// league's, which the game adds to the draft pool of that season. Every
+// check is a warning:
+//   - PLAYER_DRAFTEE_TEAM: a draftee with a team instead of TEAM 0
+//   - PLAYER_DRAFTEE_CONTRACT: a draftee with a contract
+//   - PLAYER_DRAFT_CLASS_SIZE: a class filling more than 10% of the draft
+//     pool of a league of teams teams, located at its first draftee
+//
+// Quarterback findings use the QB prefix. A team count of 0 skips the class
+// size check and a league base year of 0 skips every check.
+func ValidateProspects(playersFile string, players []models.Player, quarterbacksFile string, quarterbacks []models.Quarterback, leagueBaseYear, teams int) *ValidationResult {
+	result := NewValidationResult()
+
+	for _, issue := range roster.CheckProspects(players, quarterbacks, leagueBaseYear, teams) {
+		member := issue.Member
+		prefix, file := "PLAYER", playersFile
+		if member.Quarterback {
+			prefix, file = "QB", quarterbacksFile
+		}
+		record, key := fmt.Sprintf("Player %d (%s)", member.PlayerID, member.Name), roster.PlayerKey(member.PlayerID)
+
+		var field, code, suggestion string
+		switch issue.Problem {
+		case roster.ProspectOnTeam:
+			field, code, suggestion = "Team", "_DRAFTEE_TEAM", "TEAM 0"
+		case roster.ProspectContract:
+			field, code = "SalaryYears", "_DRAFTEE_CONTRACT"
+		case roster.ProspectClassTooLarge:
+			field, code = "BaseYear", "_DRAFT_CLASS_SIZE"
+			record, key = fmt.Sprintf("Draft class %d", issue.Class.Year), fmt.Sprintf("draftclass:%d", issue.Class.Year)
+		default:
+			continue
+		}
+
+		found := NewValidationResult()
+		found.AddFinding(ValidationError{Field: field, Message: issue.Message(), Severity: SeverityWarning, Code: prefix + code,
+			Suggestion: suggestion})
+		found.locate(file, member.Index+2, record, key)
+		result.Merge(found)
+	}
+
+	return result
+}
@@ -0,0 +1,54 @@
+// ABOUTME: Tests for draft class validation
+// ABOUTME: Checks codes, locations and record keys of draftee and class size findings
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestValidateProspects(t *testing.T) {
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 501, FirstName: "Joe", LastName: "Prospect", BaseYear: 2025, Team: 4},
+	}
+	players := []models.Player{
+		{PlayerID: 1000, FirstName: "Roster", LastName: "Player", BaseYear: 2024, Team: 1, SalaryYears: 1, SalaryYear1: 100},
+		{PlayerID: 1001, FirstName: "Paid", LastName: "Prospect", BaseYear: 2025, SalaryYears: 1, SalaryYear1: 100},
+		{PlayerID: 1002, FirstName: "Clean", LastName: "Prospect", BaseYear: 2025},
+	}
+
+	result := ValidateProspects("2024_players.csv", players, "2024_quarterbacks.csv", quarterbacks, 2024, 1)
+
+	expected := []struct {
+		file string
+		line int
+		code string
+		key  string
+	}{
+		{"2024_quarterbacks.csv", 2, "QB_DRAFTEE_TEAM", "player:501"},
+		{"2024_players.csv", 3, "PLAYER_DRAFTEE_CONTRACT", "player:1001"},
+		{"2024_quarterbacks.csv", 2, "QB_DRAFT_CLASS_SIZE", "draftclass:2025"},
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), result.Errors)
+	}
+	for i, want := range expected {
+		got := result.Errors[i]
+		if got.File != want.file || got.Line != want.line || got.Code != want.code || got.RecordKey != want.key {
+			t.Errorf("Finding %d: expected %s:%d %s (%s), got %s:%d %s (%s)",
+				i, want.file, want.line, want.code, want.key, got.File, got.Line, got.Code, got.RecordKey)
+		}
+		if got.Severity != SeverityWarning {
+			t.Errorf("Finding %d: expected a warning, got %s", i, got.Severity)
+		}
+	}
+	if got := result.Errors[0].Suggestion; got != "TEAM 0" {
+		t.Errorf("Expected suggestion TEAM 0, got %q", got)
+	}
+
+	if result := ValidateProspects("players", players, "qbs", quarterbacks, 0, 1); len(result.Errors) != 0 {
+		t.Errorf("Expected no findings without a league base year, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,20 @@
+// ABOUTME: Validation rules specific to Quarterback data
+// ABOUTME: Validates quarterback fields according to FOF9 game constraints
+
+package validation
+
+import (
+	"reflect"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// ValidateQuarterback validates all fields of a quarterback against QuarterbackFieldRules
+func ValidateQuarterback(quarterback *models.Quarterback) *ValidationResult {
+	return ValidateRecord(quarterback, QuarterbackFieldRules)
+}
+
+// ValidateQuarterbackField validates a single quarterback field by struct field name
+func ValidateQuarterbackField(fieldName string, value interface{}) *ValidationResult {
+	return validateRecordField(reflect.TypeOf(models.Quarterback{}), QuarterbackFieldRules, fieldName, value)
+}
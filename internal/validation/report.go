@@ -0,0 +1,302 @@
+// ABOUTME: Validation report renderers for publishing and CI
+// ABOUTME: Writes a project validation result as JSON, Markdown, standalone HTML or JUnit XML
+
+package validation
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ReportFormat is a validation report file format
+type ReportFormat string
+
+const (
+	ReportJSON     ReportFormat = "json"     // Machine-readable findings and counts
+	ReportMarkdown ReportFormat = "markdown" // Tables for forum posts and READMEs
+	ReportHTML     ReportFormat = "html"     // Standalone page with inline styles
+	ReportJUnit    ReportFormat = "junit"    // JUnit XML for CI; errors are failures
+)
+
+// ReportFormats lists the report formats in menu order
+var ReportFormats = []ReportFormat{ReportHTML, ReportMarkdown, ReportJSON, ReportJUnit}
+
+// ReportFormatForFile returns the report format for a file name's
+// extension: .json, .md, .html/.htm or .xml
+func ReportFormatForFile(path string) (ReportFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ReportJSON, nil
+	case ".md", ".markdown":
+		return ReportMarkdown, nil
+	case ".html", ".htm":
+		return ReportHTML, nil
+	case ".xml":
+		return ReportJUnit, nil
+	}
+	return "", fmt.Errorf("unknown report format for %s: use .json, .md, .html or .xml", filepath.Base(path))
+}
+
+// ParseReportFormat returns the report format with the given name
+func ParseReportFormat(name string) (ReportFormat, error) {
+	for _, format := range ReportFormats {
+		if string(format) == strings.ToLower(name) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown report format %q: use json, markdown, html or junit", name)
+}
+
+// reportFinding is a finding as written to JSON reports
+type reportFinding struct {
+	Code       string `json:"code"`
+	Severity   string `json:"severity"`
+	File       string `json:"file,omitempty"`
+	Line       int    `json:"line,omitempty"`
+	Record     string `json:"record,omitempty"`
+	RecordKey  string `json:"recordKey,omitempty"`
+	Field      string `json:"field"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// reportCounts is the number of findings per severity
+type reportCounts struct {
+	Errors     int `json:"errors"`
+	Warnings   int `json:"warnings"`
+	Info       int `json:"info"`
+	Suppressed int `json:"suppressed"`
+}
+
+// report is the content shared by every format
+type report struct {
+	Title    string          `json:"title"`
+	Valid    bool            `json:"valid"`
+	Counts   reportCounts    `json:"counts"`
+	Findings []reportFinding `json:"findings"`
+}
+
+// newReport collects the findings of a result under a title such as the league name
+func newReport(title string, result *ValidationResult) report {
+	r := report{
+		Title: title,
+		Valid: result.Valid,
+		Counts: reportCounts{
+			Errors:     result.Count(SeverityError),
+			Warnings:   result.Count(SeverityWarning),
+			Info:       result.Count(SeverityInfo),
+			Suppressed: result.Suppressed,
+		},
+		Findings: make([]reportFinding, 0, len(result.Errors)),
+	}
+	for _, finding := range result.Errors {
+		r.Findings = append(r.Findings, reportFinding{
+			Code: finding.Code, Severity: finding.Severity.String(), File: finding.File, Line: finding.Line,
+			Record: finding.Record, RecordKey: finding.RecordKey, Field: finding.Field,
+			Message: finding.Message, Suggestion: finding.Suggestion,
+		})
+	}
+	return r
+}
+
+// Status returns "validates clean" or "fails validation"
+func (r report) Status() string {
+	if r.Valid {
+		return "validates clean"
+	}
+	return "fails validation"
+}
+
+// Summary returns the counts, e.g. "0 errors, 3 warnings, 0 info (1 suppressed)"
+func (r report) Summary() string {
+	return fmt.Sprintf("%d errors, %d warnings, %d info (%d suppressed)",
+		r.Counts.Errors, r.Counts.Warnings, r.Counts.Info, r.Counts.Suppressed)
+}
+
+// WriteReport writes a validation result in a report format. The title
+// names what was validated, usually the league name.
+func WriteReport(w io.Writer, format ReportFormat, title string, result *ValidationResult) error {
+	r := newReport(title, result)
+	switch format {
+	case ReportJSON:
+		return writeJSONReport(w, r)
+	case ReportMarkdown:
+		return writeMarkdownReport(w, r)
+	case ReportHTML:
+		return htmlReportTemplate.Execute(w, r)
+	case ReportJUnit:
+		return writeJUnitReport(w, r)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+// writeJSONReport writes the report as indented JSON
+func writeJSONReport(w io.Writer, r report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// writeMarkdownReport writes a status line and a table of findings
+func writeMarkdownReport(w io.Writer, r report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Validation report: %s\n\n", markdownCell(r.Title))
+	fmt.Fprintf(&b, "**%s %s**: %s\n", markdownCell(r.Title), r.Status(), r.Summary())
+
+	if len(r.Findings) > 0 {
+		b.WriteString("\n| Severity | Code | File | Line | Record | Field | Message | Suggestion |\n")
+		b.WriteString("|---|---|---|---|---|---|---|---|\n")
+		for _, f := range r.Findings {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+				f.Severity, markdownCell(f.Code), markdownCell(f.File), lineText(f.Line), markdownCell(f.Record),
+				markdownCell(f.Field), markdownCell(f.Message), markdownCell(f.Suggestion))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell escapes a value for a Markdown table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// lineText returns a line number, or nothing for findings without a file line
+func lineText(line int) string {
+	if line == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", line)
+}
+
+// htmlReportTemplate is a standalone page; html/template escapes the findings
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Validation report: {{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+.status { font-weight: bold; }
+.valid { color: #1a7f37; }
+.invalid, .error { color: #cf222e; }
+.warning { color: #9a6700; }
+.info { color: #0969da; }
+</style>
+</head>
+<body>
+<h1>Validation report: {{.Title}}</h1>
+<p class="status {{if .Valid}}valid{{else}}invalid{{end}}">{{.Title}} {{.Status}}: {{.Summary}}</p>
+{{- if .Findings}}
+<table>
+<tr><th>Severity</th><th>Code</th><th>File</th><th>Line</th><th>Record</th><th>Field</th><th>Message</th><th>Suggestion</th></tr>
+{{- range .Findings}}
+<tr class="{{.Severity}}"><td>{{.Severity}}</td><td>{{.Code}}</td><td>{{.File}}</td><td>{{if .Line}}{{.Line}}{{end}}</td><td>{{.Record}}</td><td>{{.Field}}</td><td>{{.Message}}</td><td>{{.Suggestion}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// JUnit XML elements. Each file is a test suite and each finding a test
+// case; errors fail, warnings and info pass with the finding as output.
+type (
+	junitSuites struct {
+		XMLName  xml.Name     `xml:"testsuites"`
+		Name     string       `xml:"name,attr"`
+		Tests    int          `xml:"tests,attr"`
+		Failures int          `xml:"failures,attr"`
+		Suites   []junitSuite `xml:"testsuite"`
+	}
+	junitSuite struct {
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Cases    []junitCase `xml:"testcase"`
+	}
+	junitCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+		SystemOut string        `xml:"system-out,omitempty"`
+	}
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// writeJUnitReport writes one test suite per file. A result without
+// findings is a single passing test, so CI always sees the check ran.
+func writeJUnitReport(w io.Writer, r report) error {
+	suites := junitSuites{Name: "Validation: " + r.Title}
+	index := map[string]int{}
+
+	add := func(suiteName string, c junitCase) {
+		i, ok := index[suiteName]
+		if !ok {
+			i = len(suites.Suites)
+			index[suiteName] = i
+			suites.Suites = append(suites.Suites, junitSuite{Name: suiteName})
+		}
+		suites.Suites[i].Cases = append(suites.Suites[i].Cases, c)
+		suites.Suites[i].Tests++
+		suites.Tests++
+		if c.Failure != nil {
+			suites.Suites[i].Failures++
+			suites.Failures++
+		}
+	}
+
+	for _, f := range r.Findings {
+		suiteName := f.File
+		if suiteName == "" {
+			suiteName = "project"
+		}
+		name := f.Code
+		if f.Line > 0 {
+			name = fmt.Sprintf("%s line %d", name, f.Line)
+		}
+		if f.Record != "" {
+			name = fmt.Sprintf("%s %s", name, f.Record)
+		}
+		detail := fmt.Sprintf("%s: %s", f.Field, f.Message)
+		if f.Suggestion != "" {
+			detail += fmt.Sprintf(" (suggested: %s)", f.Suggestion)
+		}
+
+		c := junitCase{Name: name, ClassName: suiteName}
+		if f.Severity == SeverityError.String() {
+			c.Failure = &junitFailure{Message: detail, Type: f.Code, Text: detail}
+		} else {
+			c.SystemOut = f.Severity + ": " + detail
+		}
+		add(suiteName, c)
+	}
+	if len(r.Findings) == 0 {
+		add("project", junitCase{Name: r.Title + " " + r.Status(), ClassName: "project"})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,142 @@
+// ABOUTME: Tests for the validation report renderers
+// ABOUTME: Checks each format carries code, severity, file, line and record and escapes its values
+
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+// reportResult returns an error and a warning for the renderer tests
+func reportResult() *ValidationResult {
+	result := NewValidationResult()
+	result.AddFinding(ValidationError{
+		Field: "Uniform", Message: "shared with <Player 1001> | twice", Severity: SeverityError, Code: "PLAYER_UNIFORM_DUP",
+		File: "2024_players.csv", Line: 2, Record: "Player 1000 (Tom Brady)", RecordKey: "player:1000", Suggestion: "UNIFORM 13",
+	})
+	result.AddFinding(ValidationError{
+		Field: "PrimaryColor", Message: "too light", Severity: SeverityWarning, Code: "TEAM_PRIMARY_DARKNESS",
+		File: "team_info.csv", Line: 3, Record: "Team 2 (Miami Dolphins)",
+	})
+	result.Suppressed = 1
+	return result
+}
+
+func TestWriteReport_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, ReportJSON, "My League", reportResult()); err != nil {
+		t.Fatalf("WriteReport failed: %v", err)
+	}
+
+	var r report
+	if err := json.Unmarshal(buf.Bytes(), &r); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if r.Title != "My League" || r.Valid || r.Counts.Errors != 1 || r.Counts.Warnings != 1 || r.Counts.Suppressed != 1 {
+		t.Errorf("Unexpected report header: %+v", r)
+	}
+	if len(r.Findings) != 2 {
+		t.Fatalf("Expected 2 findings, got %d", len(r.Findings))
+	}
+	f := r.Findings[0]
+	if f.Code != "PLAYER_UNIFORM_DUP" || f.Severity != "error" || f.File != "2024_players.csv" || f.Line != 2 ||
+		f.Record != "Player 1000 (Tom Brady)" || f.RecordKey != "player:1000" || f.Suggestion != "UNIFORM 13" {
+		t.Errorf("Unexpected finding: %+v", f)
+	}
+}
+
+func TestWriteReport_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, ReportMarkdown, "My League", reportResult()); err != nil {
+		t.Fatalf("WriteReport failed: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "**My League fails validation**: 1 errors, 1 warnings, 0 info (1 suppressed)") {
+		t.Errorf("Expected the status line, got:\n%s", out)
+	}
+	row := "| error | PLAYER_UNIFORM_DUP | 2024_players.csv | 2 | Player 1000 (Tom Brady) | Uniform | shared with <Player 1001> \\| twice | UNIFORM 13 |"
+	if !strings.Contains(out, row) {
+		t.Errorf("Expected an escaped row for the error, got:\n%s", out)
+	}
+
+	buf.Reset()
+	if err := WriteReport(&buf, ReportMarkdown, "My League", NewValidationResult()); err != nil {
+		t.Fatalf("WriteReport failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "**My League validates clean**") || strings.Contains(buf.String(), "|") {
+		t.Errorf("Expected a clean status without a table, got:\n%s", buf.String())
+	}
+}
+
+func TestWriteReport_HTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, ReportHTML, "My League", reportResult()); err != nil {
+		t.Fatalf("WriteReport failed: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "<!DOCTYPE html>") || !strings.Contains(out, "<style>") {
+		t.Error("Expected a standalone page")
+	}
+	if strings.Contains(out, "<Player 1001>") || !strings.Contains(out, "&lt;Player 1001&gt;") {
+		t.Error("Expected finding text to be escaped")
+	}
+	if !strings.Contains(out, `<tr class="warning"><td>warning</td><td>TEAM_PRIMARY_DARKNESS</td><td>team_info.csv</td><td>3</td>`) {
+		t.Errorf("Expected a row for the warning, got:\n%s", out)
+	}
+}
+
+func TestWriteReport_JUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, ReportJUnit, "My League", reportResult()); err != nil {
+		t.Fatalf("WriteReport failed: %v", err)
+	}
+
+	var suites junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("Invalid XML: %v\n%s", err, buf.String())
+	}
+	if suites.Tests != 2 || suites.Failures != 1 || len(suites.Suites) != 2 {
+		t.Fatalf("Expected 2 tests with 1 failure in 2 suites, got %+v", suites)
+	}
+	failed := suites.Suites[0].Cases[0]
+	if suites.Suites[0].Name != "2024_players.csv" || failed.Name != "PLAYER_UNIFORM_DUP line 2 Player 1000 (Tom Brady)" ||
+		failed.Failure == nil || failed.Failure.Type != "PLAYER_UNIFORM_DUP" {
+		t.Errorf("Unexpected failing case: %+v", failed)
+	}
+	if passed := suites.Suites[1].Cases[0]; passed.Failure != nil || !strings.HasPrefix(passed.SystemOut, "warning: PrimaryColor") {
+		t.Errorf("Expected the warning to pass with output, got %+v", passed)
+	}
+
+	// A clean result is still one passing test
+	buf.Reset()
+	if err := WriteReport(&buf, ReportJUnit, "My League", NewValidationResult()); err != nil {
+		t.Fatalf("WriteReport failed: %v", err)
+	}
+	suites = junitSuites{}
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil || suites.Tests != 1 || suites.Failures != 0 {
+		t.Errorf("Expected one passing test, got %+v (%v)", suites, err)
+	}
+}
+
+func TestReportFormatForFile(t *testing.T) {
+	tests := map[string]ReportFormat{
+		"report.json": ReportJSON, "README.md": ReportMarkdown, "report.HTML": ReportHTML, "junit.xml": ReportJUnit,
+	}
+	for path, want := range tests {
+		if got, err := ReportFormatForFile(path); err != nil || got != want {
+			t.Errorf("%s: expected %s, got %s (%v)", path, want, got, err)
+		}
+	}
+	if _, err := ReportFormatForFile("report.txt"); err == nil {
+		t.Error("Expected an error for an unknown extension")
+	}
+	if got, err := ParseReportFormat("JUnit"); err != nil || got != ReportJUnit {
+		t.Errorf("Expected junit, got %s (%v)", got, err)
+	}
+}
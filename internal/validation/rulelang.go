@@ -0,0 +1,680 @@
+// ABOUTME: Parser, checker and evaluator of the house rule language of custom validation rules
+// ABOUTME: Rules compare CSV columns of each record or aggregates of players per team or position
+
+package validation
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A house rule states what must hold, in one of two forms:
+//
+//	each player|coach|team [where CONDITION]: CONDITION
+//	per team|position [where CONDITION]: CONDITION
+//
+// An "each" rule is checked for every record of the file; its conditions
+// use the record's CSV columns, e.g. "each player: AGE <= 35". A "per" rule
+// groups the players, of the player and quarterback files, by team or
+// position after the where filter and checks the group with aggregates:
+// count(CONDITION) or count(), and sum, avg, min and max of a number, e.g.
+// "per team: count(POSITION = 'QB') >= 3".
+//
+// Conditions compare numbers with = != < <= > >= (≠ ≤ ≥ also work) and
+// strings with = and !=, combine with and, or, not and parentheses, and
+// compute with + - * /. Strings are quoted with ' or ". Column names are
+// case-insensitive. Players and coaches also have AGE, the league's base
+// year less BIRTHYEAR, and players POSITION, the abbreviation of
+// POSITION_KEY such as "QB".
+
+// RuleError is a malformed rule, located at a column of the rule text
+type RuleError struct {
+	Column  int // 1-based column in the rule text
+	Message string
+}
+
+// Error returns the column and message, e.g. "column 14: expected ':'"
+func (e *RuleError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
+
+// ruleType is the static type of a rule expression
+type ruleType int
+
+const (
+	ruleNumber ruleType = iota
+	ruleString
+	ruleBool
+)
+
+// String names the type in error messages
+func (t ruleType) String() string {
+	switch t {
+	case ruleString:
+		return "text"
+	case ruleBool:
+		return "a condition"
+	default:
+		return "a number"
+	}
+}
+
+// ruleValue is the value of an expression; its type is known statically
+type ruleValue struct {
+	num float64
+	str string
+	ok  bool
+}
+
+// ruleTokenKind classifies the tokens of a rule
+type ruleTokenKind int
+
+const (
+	tokenEOF ruleTokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOperator // = != < <= > >= + - * /
+	tokenLeftParen
+	tokenRightParen
+	tokenColon
+)
+
+// ruleToken is a token of the rule text at a 1-based column
+type ruleToken struct {
+	kind   ruleTokenKind
+	text   string
+	num    float64
+	column int
+	end    int // Column after the token
+}
+
+// describe names a token for error messages
+func (t ruleToken) describe() string {
+	if t.kind == tokenEOF {
+		return "the end of the rule"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// is returns true if the token is the identifier or operator given, ignoring case
+func (t ruleToken) is(text string) bool {
+	return (t.kind == tokenIdent || t.kind == tokenOperator) && strings.EqualFold(t.text, text)
+}
+
+// ruleOperators maps operator spellings to their canonical form
+var ruleOperators = map[string]string{
+	"=": "=", "==": "=", "!=": "!=", "<>": "!=", "≠": "!=",
+	"<": "<", "<=": "<=", "≤": "<=", ">": ">", ">=": ">=", "≥": ">=",
+	"+": "+", "-": "-", "*": "*", "/": "/",
+}
+
+// tokenizeRule splits a rule into tokens
+func tokenizeRule(text string) ([]ruleToken, error) {
+	runes := []rune(text)
+	tokens := make([]ruleToken, 0, len(runes)/2)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, ruleToken{kind: tokenIdent, text: string(runes[start:i])})
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			num, err := strconv.ParseFloat(string(runes[start:i]), 64)
+			if err != nil {
+				return nil, &RuleError{start + 1, fmt.Sprintf("%q is not a number", string(runes[start:i]))}
+			}
+			tokens = append(tokens, ruleToken{kind: tokenNumber, text: string(runes[start:i]), num: num})
+		case r == '\'' || r == '"':
+			i++
+			for i < len(runes) && runes[i] != r {
+				i++
+			}
+			if i == len(runes) {
+				return nil, &RuleError{start + 1, fmt.Sprintf("text starting here has no closing %c", r)}
+			}
+			i++
+			tokens = append(tokens, ruleToken{kind: tokenString, text: string(runes[start+1 : i-1])})
+		case r == '(':
+			i++
+			tokens = append(tokens, ruleToken{kind: tokenLeftParen, text: "("})
+		case r == ')':
+			i++
+			tokens = append(tokens, ruleToken{kind: tokenRightParen, text: ")"})
+		case r == ':':
+			i++
+			tokens = append(tokens, ruleToken{kind: tokenColon, text: ":"})
+		default:
+			op := ""
+			if i+1 < len(runes) {
+				if canonical, ok := ruleOperators[string(runes[i:i+2])]; ok {
+					op, i = canonical, i+2
+				}
+			}
+			if op == "" {
+				canonical, ok := ruleOperators[string(r)]
+				if !ok {
+					return nil, &RuleError{start + 1, fmt.Sprintf("unexpected character %q", string(r))}
+				}
+				op, i = canonical, i+1
+			}
+			tokens = append(tokens, ruleToken{kind: tokenOperator, text: op})
+		}
+		tokens[len(tokens)-1].column = start + 1
+		tokens[len(tokens)-1].end = i + 1
+	}
+
+	return append(tokens, ruleToken{kind: tokenEOF, column: len(runes) + 1, end: len(runes) + 1}), nil
+}
+
+// ruleExpr is a node of a parsed rule expression
+type ruleExpr struct {
+	op          string // "number", "string", "field", "aggregate", "not", "neg", "and", "or", or a comparison or arithmetic operator
+	num         float64
+	str         string // String literal, field name or aggregate function
+	left, right *ruleExpr
+	typ         ruleType
+	start, end  int // Columns of the expression in the rule text
+}
+
+// parsedRule is a rule checked against the fields of its records
+type parsedRule struct {
+	text   string
+	per    bool   // A per rule over groups of players
+	entity string // "player", "coach" or "team" for each rules; "team" or "position" for per rules
+	where  *ruleExpr
+	body   *ruleExpr
+	fields []string // Columns the body uses, in order of appearance
+}
+
+// source returns the rule text of an expression
+func (r *parsedRule) source(e *ruleExpr) string {
+	runes := []rune(r.text)
+	return strings.TrimSpace(string(runes[e.start-1 : e.end-1]))
+}
+
+// ruleParser is a recursive descent parser over the tokens of a rule
+type ruleParser struct {
+	tokens []ruleToken
+	pos    int
+}
+
+func (p *ruleParser) peek() ruleToken { return p.tokens[p.pos] }
+
+func (p *ruleParser) next() ruleToken {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// fail reports an unexpected token
+func (p *ruleParser) fail(expected string) error {
+	t := p.peek()
+	return &RuleError{t.column, fmt.Sprintf("expected %s, found %s", expected, t.describe())}
+}
+
+// ruleEntities maps the record words of each and per rules, singular or plural
+var ruleEntities = map[string]string{
+	"player": "player", "players": "player", "coach": "coach", "coaches": "coach",
+	"team": "team", "teams": "team", "position": "position", "positions": "position",
+}
+
+// ParseRule checks the syntax of a rule and the columns it uses, returning
+// a *RuleError that locates the first problem
+func ParseRule(text string) error {
+	_, err := parseRule(text)
+	return err
+}
+
+// parseRule parses and type-checks a rule
+func parseRule(text string) (*parsedRule, error) {
+	tokens, err := tokenizeRule(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &ruleParser{tokens: tokens}
+	rule := &parsedRule{text: text}
+
+	switch t := p.next(); {
+	case t.is("each"):
+	case t.is("per"):
+		rule.per = true
+	default:
+		return nil, &RuleError{t.column, fmt.Sprintf(`expected "each" or "per" to start the rule, found %s`, t.describe())}
+	}
+
+	word := p.next()
+	rule.entity = ruleEntities[strings.ToLower(word.text)]
+	if word.kind != tokenIdent || rule.entity == "" ||
+		(rule.per && rule.entity != "team" && rule.entity != "position") || (!rule.per && rule.entity == "position") {
+		expected := `"player", "coach" or "team" after "each"`
+		if rule.per {
+			expected = `"team" or "position" after "per"`
+		}
+		return nil, &RuleError{word.column, fmt.Sprintf("expected %s, found %s", expected, word.describe())}
+	}
+
+	if p.peek().is("where") {
+		p.next()
+		if rule.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek().kind != tokenColon {
+		if rule.where == nil {
+			return nil, p.fail(`":" or "where" after the record`)
+		}
+		return nil, p.fail(`":" after the where condition`)
+	}
+	p.next()
+	if rule.body, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, p.fail("an operator or the end of the rule")
+	}
+
+	// The where filter applies to single records; per rules filter players
+	recordEntity := rule.entity
+	if rule.per {
+		recordEntity = "player"
+	}
+	if rule.where != nil {
+		if err := rule.check(rule.where, ruleCheckScope{entity: recordEntity}); err != nil {
+			return nil, err
+		}
+		if rule.where.typ != ruleBool {
+			return nil, &RuleError{rule.where.start, "the where filter must be a condition such as TEAM != 0"}
+		}
+	}
+	rule.fields = nil // Findings point at the columns of the body
+	if err := rule.check(rule.body, ruleCheckScope{entity: recordEntity, aggregates: rule.per}); err != nil {
+		return nil, err
+	}
+	if rule.body.typ != ruleBool {
+		return nil, &RuleError{rule.body.start, "the rule must be a condition such as AGE <= 35"}
+	}
+	return rule, nil
+}
+
+// parseExpr parses: or := and { "or" and }
+func (p *ruleParser) parseExpr() (*ruleExpr, error) {
+	return p.parseBinary([]string{"or"}, p.parseAnd)
+}
+
+// parseAnd parses: and := not { "and" not }
+func (p *ruleParser) parseAnd() (*ruleExpr, error) {
+	return p.parseBinary([]string{"and"}, p.parseNot)
+}
+
+// parseNot parses: not := "not" not | comparison
+func (p *ruleParser) parseNot() (*ruleExpr, error) {
+	if t := p.peek(); t.is("not") {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &ruleExpr{op: "not", left: operand, start: t.column, end: operand.end}, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses: comparison := sum [ op sum ]
+func (p *ruleParser) parseComparison() (*ruleExpr, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	if t.kind != tokenOperator || !isComparison(t.text) {
+		return left, nil
+	}
+	p.next()
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind == tokenOperator && isComparison(next.text) {
+		return nil, &RuleError{next.column, "comparisons cannot be chained; join them with and"}
+	}
+	return &ruleExpr{op: t.text, left: left, right: right, start: left.start, end: right.end}, nil
+}
+
+// parseSum parses: sum := term { (+|-) term }
+func (p *ruleParser) parseSum() (*ruleExpr, error) {
+	return p.parseBinary([]string{"+", "-"}, p.parseTerm)
+}
+
+// parseTerm parses: term := unary { (*|/) unary }
+func (p *ruleParser) parseTerm() (*ruleExpr, error) {
+	return p.parseBinary([]string{"*", "/"}, p.parseUnary)
+}
+
+// parseBinary parses left-associative operators over operands
+func (p *ruleParser) parseBinary(ops []string, operand func() (*ruleExpr, error)) (*ruleExpr, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		op := ""
+		for _, candidate := range ops {
+			if t.is(candidate) {
+				op = candidate
+			}
+		}
+		if op == "" {
+			return left, nil
+		}
+		p.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &ruleExpr{op: op, left: left, right: right, start: left.start, end: right.end}
+	}
+}
+
+// parseUnary parses: unary := "-" unary | primary
+func (p *ruleParser) parseUnary() (*ruleExpr, error) {
+	if t := p.peek(); t.kind == tokenOperator && t.text == "-" {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &ruleExpr{op: "neg", left: operand, start: t.column, end: operand.end}, nil
+	}
+	return p.parsePrimary()
+}
+
+// ruleAggregates are the aggregate functions of per rules
+var ruleAggregates = map[string]bool{"count": true, "sum": true, "avg": true, "min": true, "max": true}
+
+// parsePrimary parses a number, text, column, aggregate or parenthesized condition
+func (p *ruleParser) parsePrimary() (*ruleExpr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		return &ruleExpr{op: "number", num: t.num, start: t.column, end: t.end}, nil
+	case tokenString:
+		return &ruleExpr{op: "string", str: t.text, start: t.column, end: t.end}, nil
+	case tokenLeftParen:
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRightParen {
+			return nil, p.fail(`")"`)
+		}
+		closing := p.next()
+		inner.start, inner.end = t.column, closing.end
+		return inner, nil
+	case tokenIdent:
+		name := strings.ToLower(t.text)
+		if ruleAggregates[name] && p.peek().kind == tokenLeftParen {
+			p.next()
+			aggregate := &ruleExpr{op: "aggregate", str: name, start: t.column}
+			if p.peek().kind != tokenRightParen || name != "count" {
+				arg, err := p.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				aggregate.left = arg
+			}
+			if p.peek().kind != tokenRightParen {
+				return nil, p.fail(`")" to close ` + name + "(")
+			}
+			aggregate.end = p.next().end
+			return aggregate, nil
+		}
+		if name != "and" && name != "or" && name != "not" && name != "where" {
+			return &ruleExpr{op: "field", str: strings.ToUpper(t.text), start: t.column, end: t.end}, nil
+		}
+	}
+	return nil, &RuleError{t.column, fmt.Sprintf("expected a column, number or text, found %s", t.describe())}
+}
+
+// isComparison returns true for the comparison operators
+func isComparison(op string) bool {
+	switch op {
+	case "=", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// ruleCheckScope is where an expression appears in a rule
+type ruleCheckScope struct {
+	entity      string // Record whose columns are used
+	aggregates  bool   // Aggregates are allowed: the body of a per rule
+	inAggregate bool
+}
+
+// check resolves the columns of an expression and sets and checks its types
+func (r *parsedRule) check(e *ruleExpr, scope ruleCheckScope) error {
+	fail := func(format string, args ...interface{}) error {
+		return &RuleError{e.start, fmt.Sprintf(format, args...)}
+	}
+	operands := func(want ruleType) error {
+		for _, operand := range []*ruleExpr{e.left, e.right} {
+			if operand == nil {
+				continue
+			}
+			if err := r.check(operand, scope); err != nil {
+				return err
+			}
+			if operand.typ != want {
+				return &RuleError{operand.start, fmt.Sprintf("%q is %s; %s needs %s", r.source(operand), operand.typ, e.op, want)}
+			}
+		}
+		return nil
+	}
+
+	switch e.op {
+	case "number":
+		e.typ = ruleNumber
+	case "string":
+		e.typ = ruleString
+	case "field":
+		typ, ok := ruleFieldTypes(scope.entity)[e.str]
+		if !ok {
+			return fail("%s has no column %s", scope.entity, e.str)
+		}
+		if scope.aggregates && !scope.inAggregate {
+			return fail("%s must be inside an aggregate such as count(...) in a per rule", e.str)
+		}
+		e.typ = typ
+		for _, field := range r.fields {
+			if field == e.str {
+				return nil
+			}
+		}
+		r.fields = append(r.fields, e.str)
+	case "aggregate":
+		if !scope.aggregates {
+			return fail("%s() is only allowed in per rules", e.str)
+		}
+		if scope.inAggregate {
+			return fail("%s() cannot be nested in another aggregate", e.str)
+		}
+		e.typ = ruleNumber
+		if e.left == nil {
+			return nil
+		}
+		inner := scope
+		inner.inAggregate = true
+		if err := r.check(e.left, inner); err != nil {
+			return err
+		}
+		if e.str == "count" && e.left.typ != ruleBool {
+			return fail("count() needs a condition such as POSITION = 'QB', not %s", e.left.typ)
+		}
+		if e.str != "count" && e.left.typ != ruleNumber {
+			return fail("%s() needs a number, not %s", e.str, e.left.typ)
+		}
+	case "not", "and", "or":
+		e.typ = ruleBool
+		return operands(ruleBool)
+	case "neg", "+", "-", "*", "/":
+		e.typ = ruleNumber
+		return operands(ruleNumber)
+	default:
+		e.typ = ruleBool
+		if err := r.check(e.left, scope); err != nil {
+			return err
+		}
+		if err := r.check(e.right, scope); err != nil {
+			return err
+		}
+		if e.left.typ == ruleBool || e.right.typ == ruleBool {
+			return fail("conditions cannot be compared with %s; join them with and or or", e.op)
+		}
+		if e.left.typ != e.right.typ {
+			return fail("cannot compare %q (%s) with %q (%s)", r.source(e.left), e.left.typ, r.source(e.right), e.right.typ)
+		}
+		if e.left.typ == ruleString && e.op != "=" && e.op != "!=" {
+			return fail("text can only be compared with = or !=")
+		}
+	}
+	return nil
+}
+
+// ruleRecord looks up the columns of a record; ok is false for a column the
+// record lacks, such as OVERALLRATING of a quarterback
+type ruleRecord func(column string) (ruleValue, bool)
+
+// eval evaluates an expression for a record, or for a group of records when
+// it has aggregates. ok is false if a column is missing or a value is
+// undefined, such as a division by zero or the average of no records.
+func (e *ruleExpr) eval(record ruleRecord, group []ruleRecord) (ruleValue, bool) {
+	switch e.op {
+	case "number":
+		return ruleValue{num: e.num}, true
+	case "string":
+		return ruleValue{str: e.str}, true
+	case "field":
+		return record(e.str)
+	case "aggregate":
+		return e.aggregate(group)
+	case "not":
+		v, ok := e.left.eval(record, group)
+		return ruleValue{ok: !v.ok}, ok
+	case "neg":
+		v, ok := e.left.eval(record, group)
+		return ruleValue{num: -v.num}, ok
+	case "and", "or":
+		left, ok := e.left.eval(record, group)
+		if !ok {
+			return left, false
+		}
+		if (e.op == "and" && !left.ok) || (e.op == "or" && left.ok) {
+			return left, true
+		}
+		return e.right.eval(record, group)
+	}
+
+	left, ok := e.left.eval(record, group)
+	if !ok {
+		return left, false
+	}
+	right, ok := e.right.eval(record, group)
+	if !ok {
+		return right, false
+	}
+	switch e.op {
+	case "+":
+		return ruleValue{num: left.num + right.num}, true
+	case "-":
+		return ruleValue{num: left.num - right.num}, true
+	case "*":
+		return ruleValue{num: left.num * right.num}, true
+	case "/":
+		if right.num == 0 {
+			return ruleValue{}, false
+		}
+		return ruleValue{num: left.num / right.num}, true
+	case "=":
+		return ruleValue{ok: left.num == right.num && strings.EqualFold(left.str, right.str)}, true
+	case "!=":
+		return ruleValue{ok: left.num != right.num || !strings.EqualFold(left.str, right.str)}, true
+	case "<":
+		return ruleValue{ok: left.num < right.num}, true
+	case "<=":
+		return ruleValue{ok: left.num <= right.num}, true
+	case ">":
+		return ruleValue{ok: left.num > right.num}, true
+	default: // ">="
+		return ruleValue{ok: left.num >= right.num}, true
+	}
+}
+
+// aggregate evaluates count, sum, avg, min or max over a group, leaving out
+// records that lack a column the argument uses
+func (e *ruleExpr) aggregate(group []ruleRecord) (ruleValue, bool) {
+	count, sum := 0, 0.0
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, record := range group {
+		if e.left == nil {
+			count++
+			continue
+		}
+		v, ok := e.left.eval(record, nil)
+		if !ok {
+			continue
+		}
+		if e.str == "count" {
+			if v.ok {
+				count++
+			}
+			continue
+		}
+		count++
+		sum += v.num
+		low, high = math.Min(low, v.num), math.Max(high, v.num)
+	}
+
+	switch e.str {
+	case "count":
+		return ruleValue{num: float64(count)}, true
+	case "sum":
+		return ruleValue{num: sum}, true
+	}
+	if count == 0 {
+		return ruleValue{}, false
+	}
+	switch e.str {
+	case "avg":
+		return ruleValue{num: sum / float64(count)}, true
+	case "min":
+		return ruleValue{num: low}, true
+	default:
+		return ruleValue{num: high}, true
+	}
+}
+
+// formatRuleValue formats a value for messages: numbers without trailing
+// zeros, text quoted
+func formatRuleValue(v ruleValue, typ ruleType) string {
+	if typ == ruleString {
+		return fmt.Sprintf("%q", v.str)
+	}
+	return strconv.FormatFloat(math.Round(v.num*100)/100, 'f', -1, 64)
+}
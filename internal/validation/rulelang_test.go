@@ -0,0 +1,116 @@
+// ABOUTME: Tests for the house rule language
+// ABOUTME: Covers parsing, type checking, located syntax errors and evaluation of records and groups
+
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRule_Valid(t *testing.T) {
+	rules := []string{
+		"each player: AGE <= 35",
+		"EACH Players where position = 'K' : weight < 250 or HEIGHT ≤ 76",
+		"per team: count(POSITION = \"QB\") >= 3",
+		"per team where TEAM != 0: count(OVERALLRATING ≥ 8) <= 2",
+		"per position: avg(OVERALLRATING) - 1 < max(OVERALLRATING) / 2 and count() > 0",
+		"each coach: not (AGE > 70 or AGE < 30)",
+		"each team: CAPACITY >= 100 * LUXURY",
+		"each player: -HEIGHT < -60",
+	}
+	for _, rule := range rules {
+		if err := ParseRule(rule); err != nil {
+			t.Errorf("%s: unexpected error %v", rule, err)
+		}
+	}
+}
+
+func TestParseRule_Errors(t *testing.T) {
+	tests := []struct {
+		rule    string
+		column  int
+		message string
+	}{
+		{"player: AGE <= 35", 1, `expected "each" or "per" to start the rule, found "player"`},
+		{"each quarterback: AGE <= 35", 6, `expected "player", "coach" or "team" after "each", found "quarterback"`},
+		{"per coach: count() > 1", 5, `expected "team" or "position" after "per", found "coach"`},
+		{"each player AGE <= 35", 13, `expected ":" or "where" after the record, found "AGE"`},
+		{"each player: AGE <=", 20, `expected a column, number or text, found the end of the rule`},
+		{"each player: AGE <= 35 35", 24, `expected an operator or the end of the rule, found "35"`},
+		{"each player: (AGE <= 35", 24, `expected ")", found the end of the rule`},
+		{"each player: AGEE <= 35", 14, "player has no column AGEE"},
+		{"each team: AGE <= 35", 12, "team has no column AGE"},
+		{"each player: AGE", 14, "the rule must be a condition such as AGE <= 35"},
+		{"each player: 30 < AGE < 35", 23, "comparisons cannot be chained; join them with and"},
+		{"each player: POSITION > 'QB'", 14, "text can only be compared with = or !="},
+		{"each player: POSITION = 1", 14, `cannot compare "POSITION" (text) with "1" (a number)`},
+		{"each player: AGE and HEIGHT > 70", 14, `"AGE" is a number; and needs a condition`},
+		{"each player: count(AGE > 35) = 0", 14, "count() is only allowed in per rules"},
+		{"per team: OVERALLRATING >= 8", 11, "OVERALLRATING must be inside an aggregate such as count(...) in a per rule"},
+		{"per team: sum(count()) > 1", 15, "count() cannot be nested in another aggregate"},
+		{"per team: count(AGE) > 1", 11, "count() needs a condition such as POSITION = 'QB', not a number"},
+		{"per team: sum() > 1", 15, "expected a column, number or text, found \")\""},
+		{"each player: FIRSTNAME = 'Tom", 26, "text starting here has no closing '"},
+		{"each player: AGE # 3", 18, `unexpected character "#"`},
+	}
+	for _, tt := range tests {
+		err := ParseRule(tt.rule)
+		var ruleErr *RuleError
+		if !errors.As(err, &ruleErr) {
+			t.Errorf("%s: expected a RuleError, got %v", tt.rule, err)
+			continue
+		}
+		if ruleErr.Column != tt.column || ruleErr.Message != tt.message {
+			t.Errorf("%s: expected column %d %q, got column %d %q", tt.rule, tt.column, tt.message, ruleErr.Column, ruleErr.Message)
+		}
+	}
+}
+
+func TestRuleExpr_Eval(t *testing.T) {
+	record := func(values map[string]ruleValue) ruleRecord {
+		return func(column string) (ruleValue, bool) {
+			v, ok := values[column]
+			return v, ok
+		}
+	}
+	tom := record(map[string]ruleValue{"AGE": {num: 37}, "POSITION": {str: "QB"}, "OVERALLRATING": {num: 9}})
+	rob := record(map[string]ruleValue{"AGE": {num: 29}, "POSITION": {str: "TE"}, "OVERALLRATING": {num: 8}})
+	qb := record(map[string]ruleValue{"AGE": {num: 24}, "POSITION": {str: "qb"}})
+	group := []ruleRecord{tom, rob, qb}
+
+	tests := []struct {
+		rule   string
+		record ruleRecord
+		want   bool
+		ok     bool
+	}{
+		{"each player: AGE <= 35", tom, false, true},
+		{"each player: AGE <= 35", rob, true, true},
+		{"each player: POSITION = 'QB' and AGE > 30", tom, true, true},
+		{"each player: not (POSITION != 'qb')", tom, true, true},
+		{"each player: POSITION = 'TE' or AGE * 2 = 74", tom, true, true},
+		{"each player: OVERALLRATING < 9", qb, false, false}, // Missing column
+		{"each player: AGE / (OVERALLRATING - 9) > 0", tom, false, false},
+		{"per team: count(POSITION = 'QB') >= 3", nil, false, true},
+		{"per team: count(OVERALLRATING >= 8) <= 2", nil, true, true},
+		{"per team: count() = 3 and sum(AGE) = 90", nil, true, true},
+		{"per team: avg(OVERALLRATING) = 8.5 and min(AGE) = 24 and max(AGE) = 37", nil, true, true},
+	}
+	for _, tt := range tests {
+		rule, err := parseRule(tt.rule)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.rule, err)
+		}
+		v, ok := rule.body.eval(tt.record, group)
+		if ok != tt.ok || (ok && v.ok != tt.want) {
+			t.Errorf("%s: expected %v (ok %v), got %v (ok %v)", tt.rule, tt.want, tt.ok, v.ok, ok)
+		}
+	}
+
+	// The average of no records is undefined
+	rule, _ := parseRule("per team: avg(AGE) < 30")
+	if _, ok := rule.body.eval(nil, nil); ok {
+		t.Error("Expected avg() of an empty group to be undefined")
+	}
+}
@@ -5,9 +5,39 @@ package validation
 
 import (
 	"fmt"
+	"math"
 	"strings"
 )
 
+// RangeError is an integer outside the range a validator allows. Range
+// findings suggest the nearest allowed value, which fixes can apply.
+type RangeError struct {
+	Min     int
+	Max     int
+	message string
+}
+
+// newRangeError returns a range error with a formatted message
+func newRangeError(min, max int, format string, args ...interface{}) *RangeError {
+	return &RangeError{Min: min, Max: max, message: fmt.Sprintf(format, args...)}
+}
+
+// Error returns the message, e.g. "must be between 0 and 100"
+func (e *RangeError) Error() string {
+	return e.message
+}
+
+// Clamp returns the allowed value nearest to a value
+func (e *RangeError) Clamp(value int) int {
+	if value < e.Min {
+		return e.Min
+	}
+	if value > e.Max {
+		return e.Max
+	}
+	return value
+}
+
 // Required validates that a string is not empty
 func Required(message string) FieldValidator {
 	return func(value interface{}) error {
@@ -61,7 +91,7 @@ func IntRange(min, max int) FieldValidator {
 			return fmt.Errorf("invalid type for range validation")
 		}
 		if num < min || num > max {
-			return fmt.Errorf("must be between %d and %d", min, max)
+			return newRangeError(min, max, "must be between %d and %d", min, max)
 		}
 		return nil
 	}
@@ -75,7 +105,7 @@ func IntMin(min int) FieldValidator {
 			return fmt.Errorf("invalid type for min validation")
 		}
 		if num < min {
-			return fmt.Errorf("must be at least %d", min)
+			return newRangeError(min, math.MaxInt, "must be at least %d", min)
 		}
 		return nil
 	}
@@ -89,7 +119,7 @@ func IntMax(max int) FieldValidator {
 			return fmt.Errorf("invalid type for max validation")
 		}
 		if num > max {
-			return fmt.Errorf("must be at most %d", max)
+			return newRangeError(math.MinInt, max, "must be at most %d", max)
 		}
 		return nil
 	}
@@ -103,7 +133,7 @@ func IntPositive() FieldValidator {
 			return fmt.Errorf("invalid type for positive validation")
 		}
 		if num <= 0 {
-			return fmt.Errorf("must be a positive number")
+			return newRangeError(1, math.MaxInt, "must be a positive number")
 		}
 		return nil
 	}
@@ -117,7 +147,7 @@ func IntNonNegative() FieldValidator {
 			return fmt.Errorf("invalid type for non-negative validation")
 		}
 		if num < 0 {
-			return fmt.Errorf("must be zero or greater")
+			return newRangeError(0, math.MaxInt, "must be zero or greater")
 		}
 		return nil
 	}
@@ -131,7 +161,7 @@ func YearRange(minYear, maxYear int) FieldValidator {
 			return fmt.Errorf("invalid type for year validation")
 		}
 		if year < minYear || year > maxYear {
-			return fmt.Errorf("year must be between %d and %d", minYear, maxYear)
+			return newRangeError(minYear, maxYear, "year must be between %d and %d", minYear, maxYear)
 		}
 		return nil
 	}
@@ -167,3 +197,55 @@ func OneOf(allowed ...int) FieldValidator {
 		return fmt.Errorf("must be one of: %v", allowed)
 	}
 }
+
+// Optional skips the validators when a value equals its "not set" marker,
+// such as -1 for ratings the game generates or 0 for measurements it chooses
+func Optional(unset int, validators ...FieldValidator) FieldValidator {
+	return func(value interface{}) error {
+		if num, ok := value.(int); ok && num == unset {
+			return nil
+		}
+		for _, validator := range validators {
+			if err := validator(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// EighthsOfInch validates a measurement in inches and eighths of an inch, as
+// used by the game's player files (93 is 9 3/8")
+func EighthsOfInch(minInches, maxInches int) FieldValidator {
+	return func(value interface{}) error {
+		num, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("invalid type for measurement validation")
+		}
+		if num%10 > 7 {
+			return fmt.Errorf("last digit must be eighths of an inch (0-7)")
+		}
+		if num < minInches*10 || num > maxInches*10+7 {
+			return fmt.Errorf("must be between %d and %d 7/8 inches", minInches, maxInches)
+		}
+		return nil
+	}
+}
+
+// HeightInches validates a height given either in inches (72 is six feet) or,
+// for values over 99, in inches and eighths of an inch (745 is 6' 2 5/8")
+func HeightInches(minInches, maxInches int) FieldValidator {
+	return func(value interface{}) error {
+		num, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("invalid type for height validation")
+		}
+		if num > 99 {
+			return EighthsOfInch(minInches, maxInches)(num)
+		}
+		if num < minInches || num > maxInches {
+			return newRangeError(minInches, maxInches, "must be between %d and %d inches", minInches, maxInches)
+		}
+		return nil
+	}
+}
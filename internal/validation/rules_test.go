@@ -3,7 +3,10 @@
 
 package validation
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestRequired(t *testing.T) {
 	validator := Required("Field is required")
@@ -105,6 +108,23 @@ func TestIntRange(t *testing.T) {
 	}
 }
 
+func TestRangeError_Clamp(t *testing.T) {
+	var rangeErr *RangeError
+	if !errors.As(IntRange(10, 20)(25), &rangeErr) || rangeErr.Error() != "must be between 10 and 20" {
+		t.Fatalf("Expected a range error, got %v", rangeErr)
+	}
+	if rangeErr.Clamp(25) != 20 || rangeErr.Clamp(3) != 10 || rangeErr.Clamp(15) != 15 {
+		t.Error("Expected values to be clamped into 10-20")
+	}
+
+	if !errors.As(IntNonNegative()(-4), &rangeErr) || rangeErr.Clamp(-4) != 0 {
+		t.Error("Expected a negative value to be clamped to 0")
+	}
+	if errors.As(OneOf(1, 3)(2), &rangeErr) {
+		t.Error("Expected OneOf not to report a range")
+	}
+}
+
 func TestIntMin(t *testing.T) {
 	validator := IntMin(10)
 
@@ -329,3 +349,49 @@ func TestOneOf(t *testing.T) {
 		}
 	}
 }
+
+func TestOptional(t *testing.T) {
+	validator := Optional(-1, IntRange(0, 250))
+
+	for _, val := range []int{-1, 0, 250} {
+		if err := validator(val); err != nil {
+			t.Errorf("Expected no error for value %d, got %v", val, err)
+		}
+	}
+	for _, val := range []int{-2, 251} {
+		if err := validator(val); err == nil {
+			t.Errorf("Expected error for value %d", val)
+		}
+	}
+}
+
+func TestEighthsOfInch(t *testing.T) {
+	validator := EighthsOfInch(7, 12)
+
+	for _, val := range []int{70, 93, 127} {
+		if err := validator(val); err != nil {
+			t.Errorf("Expected no error for value %d, got %v", val, err)
+		}
+	}
+	// 98 has no valid eighths digit; 9 is in whole inches; 130 is too large
+	for _, val := range []int{98, 9, 130} {
+		if err := validator(val); err == nil {
+			t.Errorf("Expected error for value %d", val)
+		}
+	}
+}
+
+func TestHeightInches(t *testing.T) {
+	validator := HeightInches(60, 90)
+
+	for _, val := range []int{72, 745, 600, 907} {
+		if err := validator(val); err != nil {
+			t.Errorf("Expected no error for height %d, got %v", val, err)
+		}
+	}
+	for _, val := range []int{50, 91, 100, 748, 910} {
+		if err := validator(val); err == nil {
+			t.Errorf("Expected error for height %d", val)
+		}
+	}
+}
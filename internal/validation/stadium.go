@@ -0,0 +1,96 @@
+// ABOUTME: Stadium and future stadium consistency validation for the teams file
+// ABOUTME: Ties the PLAN fields together and checks capacity and luxury boxes against each other and the home market
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// Stadium plausibility limits. The shipped team_info.csv peaks at about 6
+// luxury boxes per 1,000 seats and at a capacity of a quarter of the home
+// metro area (Green Bay).
+const (
+	maxSeatsPerLuxuryBox = 100 // A stadium has at most one luxury box per 100 seats
+	stadiumMarketDivisor = 3   // A stadium seats at most a third of its market
+)
+
+// ValidateStadiums checks the stadium and future stadium plan of every team:
+//   - TEAM_PLAN (error): a PLAN other than 0 or 1
+//   - TEAM_PLAN_COMPLETED (error): an active plan completing in or before the
+//     team's YEAR, which the game never builds
+//   - TEAM_PLAN_FUTURE, TEAM_PLAN_FUTURECAP (error): an active plan without a
+//     city or capacity for the new stadium
+//   - TEAM_PLAN_FIELDS (warning): future stadium fields set without a plan;
+//     the docs ask for zero or blank values
+//   - TEAM_LUXURY_CAPACITY (warning): more than one luxury box per 100 seats,
+//     in the current or the future stadium
+//   - TEAM_CAPACITY_MARKET (warning): a stadium seating more than a third of
+//     the population of its city's metro area, or of the city outside one
+//
+// The market check needs refs with cities; metro areas are used when loaded.
+func ValidateStadiums(file string, teams []models.Team, refs *models.ReferenceData) *ValidationResult {
+	result := NewValidationResult()
+
+	for i := range teams {
+		team := &teams[i]
+		found := NewValidationResult()
+
+		add := func(field, code, message string, severity Severity, suggestion string) {
+			found.AddFinding(ValidationError{
+				Field: field, Message: message, Severity: severity, Code: "TEAM_" + code, Suggestion: suggestion,
+			})
+		}
+
+		switch {
+		case team.Plan != 0 && team.Plan != 1:
+			add("Plan", "PLAN", "must be 0 (no plan) or 1 (new stadium planned)", SeverityError, "")
+		case team.HasFuturePlan():
+			if team.Year != 0 && team.Completed <= team.Year {
+				add("Completed", "PLAN_COMPLETED", fmt.Sprintf("new stadium completes in %d, not after the %d season", team.Completed, team.Year),
+					SeverityError, fmt.Sprintf("COMPLETED %d", team.Year+1))
+			}
+			if team.Future == 0 {
+				add("Future", "PLAN_FUTURE", "new stadium plan needs the CITYID of the new stadium", SeverityError, "")
+			}
+			if team.FutureCap == 0 {
+				add("FutureCap", "PLAN_FUTURECAP", "new stadium plan needs a capacity", SeverityError, "")
+			}
+		default:
+			if columns := team.FuturePlanColumns(); len(columns) > 0 {
+				add("Plan", "PLAN_FIELDS", fmt.Sprintf("%s set without a new stadium plan; they should be 0 or blank", strings.Join(columns, ", ")),
+					SeverityWarning, "")
+			}
+		}
+
+		if team.Luxury*maxSeatsPerLuxuryBox > team.Capacity {
+			add("Luxury", "LUXURY_CAPACITY", fmt.Sprintf("%d luxury boxes in a %d-seat stadium; expected at most one per %d seats",
+				team.Luxury, team.Capacity, maxSeatsPerLuxuryBox), SeverityWarning, "")
+		}
+		if team.HasFuturePlan() && team.FutureCap > 0 && team.FutureLuxury*maxSeatsPerLuxuryBox > team.FutureCap {
+			add("FutureLuxury", "LUXURY_CAPACITY", fmt.Sprintf("%d luxury boxes in a %d-seat new stadium; expected at most one per %d seats",
+				team.FutureLuxury, team.FutureCap, maxSeatsPerLuxuryBox), SeverityWarning, "")
+		}
+
+		if refs != nil && refs.HasCities() {
+			if market := refs.MarketPopulation(team.City); market > 0 && team.Capacity*stadiumMarketDivisor > market {
+				add("Capacity", "CAPACITY_MARKET", fmt.Sprintf("%d seats for a market of %d people in %s; expected at most a third of it",
+					team.Capacity, market, refs.GetCityNameByID(team.City)), SeverityWarning, "")
+			}
+			if team.HasFuturePlan() {
+				if market := refs.MarketPopulation(team.Future); market > 0 && team.FutureCap*stadiumMarketDivisor > market {
+					add("FutureCap", "CAPACITY_MARKET", fmt.Sprintf("%d seats in the new stadium for a market of %d people in %s; expected at most a third of it",
+						team.FutureCap, market, refs.GetCityNameByID(team.Future)), SeverityWarning, "")
+				}
+			}
+		}
+
+		found.locate(file, i+2, teamRecord(team), teamKey(team))
+		result.Merge(found)
+	}
+
+	return result
+}
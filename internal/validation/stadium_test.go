@@ -0,0 +1,93 @@
+// ABOUTME: Tests for stadium and future stadium validation
+// ABOUTME: Covers plan consistency, luxury boxes, market size and the shipped team_info.csv
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestValidateStadiums(t *testing.T) {
+	refs := models.NewReferenceData()
+	refs.SetCities([]models.City{
+		{CityID: 20173, Name: "Green Bay", Region: "WI", Population: 107000, MetroArea: 347},
+		{CityID: 500, Name: "Smallville", Region: "KS", Population: 60000},
+	})
+	refs.SetMetros([]models.MetroArea{{MetroID: 347, Name: "Green Bay", Population: 322906}})
+
+	teams := []models.Team{
+		{Year: 2024, TeamID: 1, City: 20173, Capacity: 110000, Luxury: 168},
+		{Year: 2024, TeamID: 2, City: 20173, Capacity: 65000, Luxury: 700, Plan: 1, Completed: 2024, FutureLuxury: 10},
+		{Year: 2024, TeamID: 3, City: 20173, Capacity: 65000, Luxury: 100, FutureCap: 70000, TeamContribution: 50},
+		{Year: 2024, TeamID: 4, City: 20173, Capacity: 65000, Luxury: 100, Plan: 1, Completed: 2026, Future: 500, FutureCap: 30000, FutureLuxury: 400},
+		{Year: 2024, TeamID: 5, City: 20173, Capacity: 65000, Luxury: 100, Plan: 2},
+	}
+
+	result := ValidateStadiums("team_info.csv", teams, refs)
+
+	expected := []struct {
+		line     int
+		field    string
+		code     string
+		severity Severity
+	}{
+		{2, "Capacity", "TEAM_CAPACITY_MARKET", SeverityWarning},
+		{3, "Completed", "TEAM_PLAN_COMPLETED", SeverityError},
+		{3, "Future", "TEAM_PLAN_FUTURE", SeverityError},
+		{3, "FutureCap", "TEAM_PLAN_FUTURECAP", SeverityError},
+		{3, "Luxury", "TEAM_LUXURY_CAPACITY", SeverityWarning},
+		{4, "Plan", "TEAM_PLAN_FIELDS", SeverityWarning},
+		{5, "FutureLuxury", "TEAM_LUXURY_CAPACITY", SeverityWarning},
+		{5, "FutureCap", "TEAM_CAPACITY_MARKET", SeverityWarning},
+		{6, "Plan", "TEAM_PLAN", SeverityError},
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), result.Errors)
+	}
+	for i, want := range expected {
+		got := result.Errors[i]
+		if got.Line != want.line || got.Field != want.field || got.Code != want.code || got.Severity != want.severity {
+			t.Errorf("Finding %d: expected line %d %s %s (%s), got line %d %s %s (%s)",
+				i, want.line, want.field, want.code, want.severity, got.Line, got.Field, got.Code, got.Severity)
+		}
+		if got.File != "team_info.csv" {
+			t.Errorf("Finding %d: expected team_info.csv, got %s", i, got.File)
+		}
+	}
+	if got := result.Errors[1].Suggestion; got != "COMPLETED 2025" {
+		t.Errorf("Expected suggestion COMPLETED 2025, got %q", got)
+	}
+	if got := result.Errors[5].Message; got != "FUTURECAP, TEAMCONTRIBUTION set without a new stadium plan; they should be 0 or blank" {
+		t.Errorf("Unexpected message for stray plan fields: %s", got)
+	}
+
+	// Without cities only the market checks are skipped
+	if result := ValidateStadiums("team_info.csv", teams, nil); len(result.Errors) != len(expected)-2 {
+		t.Errorf("Expected %d findings without cities, got %v", len(expected)-2, result.Errors)
+	}
+}
+
+func TestValidateStadiums_DefaultData(t *testing.T) {
+	teams, err := data.LoadTeams("../../default_data/team_info.csv")
+	if err != nil {
+		t.Fatalf("LoadTeams failed: %v", err)
+	}
+	cities, err := data.LoadCities("../../default_data/cities.csv")
+	if err != nil {
+		t.Fatalf("LoadCities failed: %v", err)
+	}
+	metros, err := data.LoadMetroAreas("../../default_data/metro_areas.csv")
+	if err != nil {
+		t.Fatalf("LoadMetroAreas failed: %v", err)
+	}
+	refs := models.NewReferenceData()
+	refs.SetCities(cities)
+	refs.SetMetros(metros)
+
+	if result := ValidateStadiums("team_info.csv", teams, refs); len(result.Errors) != 0 {
+		t.Errorf("Expected the shipped stadiums to pass, got %d findings: %v", len(result.Errors), result.Errors)
+	}
+}
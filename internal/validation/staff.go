@@ -0,0 +1,165 @@
+// ABOUTME: Coaching staff structure validation for the coaches file
+// ABOUTME: Checks each team's head coach and coordinators and each coach's style against their position group
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// staffPositions are the jobs every team needs exactly one coach for
+var staffPositions = []struct {
+	position int
+	code     string
+}{
+	{models.PositionHeadCoach, "HC"},
+	{models.PositionOffensiveCoordinator, "OC"},
+	{models.PositionDefensiveCoordinator, "DC"},
+}
+
+// ValidateCoachingStaff checks the staff of every team of the season and the
+// fit of each coach's styles to their position group:
+//   - COACH_STAFF_DUP (error): a second head coach, offensive coordinator or
+//     defensive coordinator on the same team
+//   - TEAM_STAFF_HC, TEAM_STAFF_OC, TEAM_STAFF_DC (warning): a team without
+//     that coach; the game creates new coaches to fill the job
+//   - COACH_UNEMPLOYED (warning): a coach with TEAM 0 and a job, or with
+//     POSITION 12 and a team
+//   - COACH_STYLE_GROUP (warning): offensive groups need an OFFENSIVESTYLE and
+//     DEFENSIVESTYLE 0, defensive groups the reverse, strength coaches neither
+//   - COACH_POSITION_GROUP (warning): a coordinator outside their side of the
+//     ball, or a strength coach and strength group that do not go together
+//
+// Team findings are located in the teams file. Without teams for the season
+// the staff of every team named in the coaches file is checked.
+func ValidateCoachingStaff(coachesFile string, coaches []models.Coach, teamsFile string, teams []models.Team, season int) *ValidationResult {
+	result := NewValidationResult()
+
+	teamIDs := make([]int, 0)
+	for _, team := range models.TeamsForYear(teams, season) {
+		teamIDs = append(teamIDs, team.TeamID)
+	}
+	if len(teamIDs) == 0 {
+		seen := make(map[int]bool)
+		for _, coach := range coaches {
+			if coach.Team > 0 && !seen[coach.Team] {
+				seen[coach.Team] = true
+				teamIDs = append(teamIDs, coach.Team)
+			}
+		}
+	}
+
+	// staff[team][position] is the index of the first coach in that job
+	staff := make(map[int]map[int]int)
+	for i := range coaches {
+		coach := &coaches[i]
+		found := NewValidationResult()
+
+		switch {
+		case coach.Team == 0 && coach.Position != models.PositionUnemployed:
+			found.AddFinding(ValidationError{
+				Field:      "Position",
+				Message:    fmt.Sprintf("coach without a team is listed as %s; use POSITION %d for unemployed coaches", coach.GetPositionName(), models.PositionUnemployed),
+				Severity:   SeverityWarning,
+				Code:       "COACH_UNEMPLOYED",
+				Suggestion: fmt.Sprintf("POSITION %d", models.PositionUnemployed),
+			})
+		case coach.Team != 0 && coach.Position == models.PositionUnemployed:
+			found.AddFinding(ValidationError{
+				Field:      "Team",
+				Message:    fmt.Sprintf("unemployed coach is assigned to team %d; use TEAM 0 for unemployed coaches", coach.Team),
+				Severity:   SeverityWarning,
+				Code:       "COACH_UNEMPLOYED",
+				Suggestion: "TEAM 0",
+			})
+		}
+
+		if !coach.IsUnemployed() {
+			if staff[coach.Team] == nil {
+				staff[coach.Team] = make(map[int]int)
+			}
+			if first, ok := staff[coach.Team][coach.Position]; ok && coach.Position <= models.PositionDefensiveCoordinator {
+				found.AddFinding(ValidationError{
+					Field: "Position",
+					Message: fmt.Sprintf("team %d already has a %s (%s)",
+						coach.Team, coach.GetPositionName(), coaches[first].GetDisplayName()),
+					Severity: SeverityError,
+					Code:     "COACH_STAFF_DUP",
+				})
+			} else if !ok {
+				staff[coach.Team][coach.Position] = i
+			}
+		}
+
+		checkCoachStyle(found, coach)
+		checkCoachGroup(found, coach)
+
+		found.locate(coachesFile, i+2, coachRecord(coach), coachKey(coach))
+		result.Merge(found)
+	}
+
+	for _, teamID := range teamIDs {
+		for _, job := range staffPositions {
+			if _, ok := staff[teamID][job.position]; ok {
+				continue
+			}
+			found := NewValidationResult()
+			found.AddFinding(ValidationError{
+				Field:    "Staff",
+				Message:  fmt.Sprintf("team %d has no %s; the game will create one", teamID, models.GetCoachPositionName(job.position)),
+				Severity: SeverityWarning,
+				Code:     "TEAM_STAFF_" + job.code,
+			})
+			line, record, key := teamLocation(teams, season, teamID)
+			found.locate(teamsFile, line, record, key)
+			result.Merge(found)
+		}
+	}
+
+	return result
+}
+
+// checkCoachStyle flags offensive and defensive styles that do not match the position group
+func checkCoachStyle(result *ValidationResult, coach *models.Coach) {
+	group := models.GetCoachGroupName(coach.PositionGroup)
+
+	var field, message string
+	switch {
+	case models.IsOffensiveCoachGroup(coach.PositionGroup) && coach.OffensiveStyle == 0:
+		field, message = "OffensiveStyle", fmt.Sprintf("%s coach has no offensive style", group)
+	case models.IsOffensiveCoachGroup(coach.PositionGroup) && coach.DefensiveStyle != 0:
+		field, message = "DefensiveStyle", fmt.Sprintf("%s coach should have DEFENSIVESTYLE 0", group)
+	case models.IsDefensiveCoachGroup(coach.PositionGroup) && coach.DefensiveStyle == 0:
+		field, message = "DefensiveStyle", fmt.Sprintf("%s coach has no defensive front", group)
+	case models.IsDefensiveCoachGroup(coach.PositionGroup) && coach.OffensiveStyle != 0:
+		field, message = "OffensiveStyle", fmt.Sprintf("%s coach should have OFFENSIVESTYLE 0", group)
+	case coach.PositionGroup == models.CoachGroupStrength && (coach.OffensiveStyle != 0 || coach.DefensiveStyle != 0):
+		field, message = "OffensiveStyle", "strength coach should have OFFENSIVESTYLE and DEFENSIVESTYLE 0"
+	default:
+		return
+	}
+
+	result.AddFinding(ValidationError{Field: field, Message: message, Severity: SeverityWarning, Code: "COACH_STYLE_GROUP"})
+}
+
+// checkCoachGroup flags coordinators and strength coaches outside their position groups
+func checkCoachGroup(result *ValidationResult, coach *models.Coach) {
+	var message string
+	switch {
+	case coach.Position == models.PositionOffensiveCoordinator && !models.IsOffensiveCoachGroup(coach.PositionGroup):
+		message = "offensive coordinator should coach an offensive position group (3-7)"
+	case coach.Position == models.PositionDefensiveCoordinator && !models.IsDefensiveCoachGroup(coach.PositionGroup):
+		message = "defensive coordinator should coach a defensive position group (8-10); the team uses their defensive front"
+	case coach.Position == models.PositionStrengthCoach && coach.PositionGroup != models.CoachGroupStrength:
+		message = fmt.Sprintf("strength coach should have POSITIONGROUP %d", models.CoachGroupStrength)
+	case coach.Position != models.PositionStrengthCoach && coach.Position != models.PositionUnemployed &&
+		coach.PositionGroup == models.CoachGroupStrength:
+		message = fmt.Sprintf("only strength coaches should have POSITIONGROUP %d", models.CoachGroupStrength)
+	default:
+		return
+	}
+
+	result.AddFinding(ValidationError{Field: "PositionGroup", Message: message, Severity: SeverityWarning, Code: "COACH_POSITION_GROUP"})
+}
@@ -0,0 +1,115 @@
+// ABOUTME: Tests for coaching staff structure validation
+// ABOUTME: Checks staff, unemployed and style findings on small sets and the shipped coaches files
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+// staffCoach returns a coach of a team in a job with matching group and styles
+func staffCoach(team, position, group int) models.Coach {
+	coach := models.Coach{FirstName: "Test", LastName: fmt.Sprintf("Coach%d", position), Team: team,
+		Position: position, PositionGroup: group, PayScale: 100}
+	switch {
+	case models.IsOffensiveCoachGroup(group):
+		coach.OffensiveStyle = 2
+	case models.IsDefensiveCoachGroup(group):
+		coach.DefensiveStyle = 3
+	}
+	return coach
+}
+
+func TestValidateCoachingStaff(t *testing.T) {
+	teams := []models.Team{{Year: 2024, TeamID: 1}, {Year: 2024, TeamID: 2}}
+	coaches := []models.Coach{
+		staffCoach(1, models.PositionHeadCoach, models.CoachGroupQuarterbacks),
+		staffCoach(1, models.PositionOffensiveCoordinator, models.CoachGroupWideReceivers),
+		staffCoach(1, models.PositionDefensiveCoordinator, models.CoachGroupLinebackers),
+		staffCoach(1, models.PositionHeadCoach, models.CoachGroupSecondary),
+		staffCoach(2, models.PositionHeadCoach, models.CoachGroupRunningBacks),
+		staffCoach(2, models.PositionOffensiveCoordinator, models.CoachGroupDefensiveLine),
+		staffCoach(2, models.PositionStrengthCoach, models.CoachGroupStrength),
+		staffCoach(0, models.PositionAssistantCoach, models.CoachGroupTightEnds),
+		staffCoach(0, models.PositionUnemployed, models.CoachGroupOffensiveLine),
+	}
+	coaches[4].DefensiveStyle = 1
+	coaches[6].OffensiveStyle = 4
+
+	result := ValidateCoachingStaff("2024_coaches.csv", coaches, "team_info.csv", teams, 2024)
+
+	expected := []struct {
+		file     string
+		line     int
+		code     string
+		severity Severity
+	}{
+		{"2024_coaches.csv", 5, "COACH_STAFF_DUP", SeverityError},
+		{"2024_coaches.csv", 6, "COACH_STYLE_GROUP", SeverityWarning},
+		{"2024_coaches.csv", 7, "COACH_POSITION_GROUP", SeverityWarning},
+		{"2024_coaches.csv", 8, "COACH_STYLE_GROUP", SeverityWarning},
+		{"2024_coaches.csv", 9, "COACH_UNEMPLOYED", SeverityWarning},
+		{"team_info.csv", 3, "TEAM_STAFF_DC", SeverityWarning},
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), result.Errors)
+	}
+	for i, want := range expected {
+		got := result.Errors[i]
+		if got.File != want.file || got.Line != want.line || got.Code != want.code || got.Severity != want.severity {
+			t.Errorf("Finding %d: expected %s:%d %s (%s), got %s:%d %s (%s)",
+				i, want.file, want.line, want.code, want.severity, got.File, got.Line, got.Code, got.Severity)
+		}
+	}
+
+	if got := result.Errors[0].Message; got != "team 1 already has a Head Coach (Test Coach0)" {
+		t.Errorf("Unexpected duplicate message: %s", got)
+	}
+	if got := result.Errors[4].Suggestion; got != "POSITION 12" {
+		t.Errorf("Expected unemployed suggestion POSITION 12, got %q", got)
+	}
+	if got := result.Errors[5].RecordKey; got != "team:2024:2" {
+		t.Errorf("Expected team record key team:2024:2, got %s", got)
+	}
+}
+
+func TestValidateCoachingStaff_TeamsFromCoaches(t *testing.T) {
+	coaches := []models.Coach{staffCoach(5, models.PositionHeadCoach, models.CoachGroupQuarterbacks)}
+	coaches = append(coaches, staffCoach(5, models.PositionUnemployed, models.CoachGroupQuarterbacks))
+
+	result := ValidateCoachingStaff("coaches", coaches, "teams", nil, 2024)
+
+	codes := make(map[string]int)
+	for _, err := range result.Errors {
+		codes[err.Code]++
+	}
+	if codes["TEAM_STAFF_OC"] != 1 || codes["TEAM_STAFF_DC"] != 1 || codes["COACH_UNEMPLOYED"] != 1 || len(result.Errors) != 3 {
+		t.Errorf("Expected missing OC and DC of team 5 and one unemployed finding, got %v", result.Errors)
+	}
+	if got := result.Errors[0].Suggestion; got != "TEAM 0" {
+		t.Errorf("Expected unemployed suggestion TEAM 0, got %q", got)
+	}
+}
+
+func TestValidateCoachingStaff_DefaultData(t *testing.T) {
+	teams, err := data.LoadTeams("../../default_data/team_info.csv")
+	if err != nil {
+		t.Fatalf("LoadTeams failed: %v", err)
+	}
+
+	for _, season := range []int{2023, 2024} {
+		coaches, err := data.LoadCoaches(fmt.Sprintf("../../default_data/%d_coaches.csv", season))
+		if err != nil {
+			t.Fatalf("LoadCoaches failed: %v", err)
+		}
+
+		result := ValidateCoachingStaff("coaches", coaches, "teams", teams, season)
+		if len(result.Errors) != 0 {
+			t.Errorf("Expected shipped %d staffs to pass, got %d findings, first %v", season, len(result.Errors), result.Errors[0])
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// ABOUTME: Suppression of validation findings by rule code and record
+// ABOUTME: Entries are "CODE" for every record or "CODE@recordKey" for one record
+
+package validation
+
+import "strings"
+
+// SuppressionEntry returns the suppression entry for a finding's code on its
+// record, e.g. "PLAYER_UNIFORM_DUP@player:1000", or the bare code if the
+// finding has no record key
+func SuppressionEntry(finding ValidationError) string {
+	if finding.RecordKey == "" {
+		return finding.Code
+	}
+	return finding.Code + "@" + finding.RecordKey
+}
+
+// ApplySuppressions removes the findings matched by the given entries and
+// counts them in Suppressed. An entry is a rule code, which suppresses it
+// for every record, or CODE@recordKey, which suppresses it for one record.
+// Valid is recomputed from the remaining errors.
+func (r *ValidationResult) ApplySuppressions(entries []string) {
+	if len(entries) == 0 {
+		return
+	}
+
+	codes := make(map[string]bool)
+	records := make(map[string]bool)
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if strings.Contains(entry, "@") {
+			records[entry] = true
+		} else if entry != "" {
+			codes[entry] = true
+		}
+	}
+
+	kept := make([]ValidationError, 0, len(r.Errors))
+	r.Valid = true
+	for _, finding := range r.Errors {
+		if codes[finding.Code] || (finding.RecordKey != "" && records[SuppressionEntry(finding)]) {
+			r.Suppressed++
+			continue
+		}
+		if finding.Severity == SeverityError {
+			r.Valid = false
+		}
+		kept = append(kept, finding)
+	}
+	r.Errors = kept
+}
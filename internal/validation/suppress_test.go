@@ -0,0 +1,83 @@
+// ABOUTME: Tests for suppressing validation findings
+// ABOUTME: Verifies code-wide and per-record suppressions and the recomputed validity
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestApplySuppressions(t *testing.T) {
+	newResult := func() *ValidationResult {
+		result := NewValidationResult()
+		result.AddFinding(ValidationError{Field: "PlayerID", Severity: SeverityWarning, Code: "PLAYER_ID_LOW", RecordKey: "player:1"})
+		result.AddFinding(ValidationError{Field: "PlayerID", Severity: SeverityWarning, Code: "PLAYER_ID_LOW", RecordKey: "player:2"})
+		result.AddFinding(ValidationError{Field: "PlayerID", Code: "PLAYER_ID_DUP", RecordKey: "player:2"})
+		return result
+	}
+
+	result := newResult()
+	result.ApplySuppressions([]string{"PLAYER_ID_LOW"})
+	if len(result.Errors) != 1 || result.Suppressed != 2 || result.Valid {
+		t.Errorf("Expected only the error to remain, got %v (%d suppressed)", result.Errors, result.Suppressed)
+	}
+
+	result = newResult()
+	result.ApplySuppressions([]string{"PLAYER_ID_DUP@player:2", "PLAYER_ID_LOW@player:1"})
+	if len(result.Errors) != 1 || result.Errors[0].RecordKey != "player:2" || result.Suppressed != 2 {
+		t.Errorf("Expected the player:2 warning to remain, got %v", result.Errors)
+	}
+	if !result.Valid {
+		t.Error("Expected the result to be valid once its error is suppressed")
+	}
+
+	if got := SuppressionEntry(result.Errors[0]); got != "PLAYER_ID_LOW@player:2" {
+		t.Errorf("Expected PLAYER_ID_LOW@player:2, got %s", got)
+	}
+}
+
+func TestValidateProject_Suppressions(t *testing.T) {
+	appState, refs := loadProjectFixtures(t)
+	project := models.NewProject("Test League", "test", "/tmp", 2024)
+	appState.SetProject(project)
+	appState.GetPlayers()[1].CollegeID = 1234
+	appState.GetCoaches()[0].BirthCityID = 99
+
+	project.AddValidationSuppression("PLAYER_COLLEGEID_REF@player:1001")
+	project.AddValidationSuppression("COACH_CITYID_REF")
+
+	result := ValidateProject(appState, refs)
+	if found := referenceFindings(result); len(found) != 0 {
+		t.Errorf("Expected the reference errors to be suppressed, got %v", found)
+	}
+	if result.Suppressed != 2 {
+		t.Errorf("Expected 2 suppressed findings, got %d", result.Suppressed)
+	}
+}
+
+func TestValidateProject_CoachSuppressionsFollowCoach(t *testing.T) {
+	appState, refs := loadProjectFixtures(t)
+	project := models.NewProject("Test League", "test", "/tmp", 2024)
+	appState.SetProject(project)
+	belichick := appState.GetCoaches()[0]
+	belichick.BirthCityID = 99
+	other := models.Coach{FirstName: "Other", LastName: "Coach", BirthYear: 1960, BirthMonth: 1, BirthDay: 2, BirthCityID: 99}
+	appState.SetCoaches([]models.Coach{other, belichick})
+	project.AddValidationSuppression("COACH_CITYID_REF@coach:Bill Belichick:1952-04-16")
+
+	// Deleting or reordering coaches keeps Belichick's finding suppressed and
+	// never suppresses the other coach's
+	for _, coaches := range [][]models.Coach{{other, belichick}, {belichick}, {belichick, other}} {
+		appState.SetCoaches(coaches)
+		var records []string
+		for _, finding := range referenceFindings(ValidateProject(appState, refs)) {
+			records = append(records, finding.Record)
+		}
+		want := len(coaches) - 1
+		if len(records) != want || (want == 1 && records[0] != "Coach Other Coach") {
+			t.Errorf("Expected %d findings for the other coach, got %v", want, records)
+		}
+	}
+}
@@ -33,20 +33,20 @@ func ValidateTeam(team *models.Team) *ValidationResult {
 		YearRange(1920, 2100),
 	))
 
-	// Team ID (0-31 for 32 teams)
+	// Team ID (1 up to the largest league, as TEAM is in person files)
 	result.Merge(ValidateField("TeamID", team.TeamID,
-		IntRange(0, 31),
+		IntRange(1, models.MaxTeams),
 	))
 
 	// League structure
-	// Conference (0-1 for AFC/NFC)
+	// Conference (1-2, as in the game's team files)
 	result.Merge(ValidateField("Conference", team.Conference,
-		IntRange(0, 1),
+		IntRange(1, 2),
 	))
 
-	// Division (0-3 for North/South/East/West)
+	// Division within the conference (1-based, up to 4 per conference)
 	result.Merge(ValidateField("Division", team.Division,
-		IntRange(0, 3),
+		IntRange(1, models.MaxDivisions/2),
 	))
 
 	// City ID validation
@@ -96,14 +96,10 @@ func ValidateTeam(team *models.Team) *ValidationResult {
 	))
 
 	// Financial data
-	// Attendance (0 - capacity)
-	if team.Attendance > team.Capacity {
-		result.AddError("Attendance", "cannot exceed stadium capacity")
-	} else {
-		result.Merge(ValidateField("Attendance", team.Attendance,
-			IntNonNegative(),
-		))
-	}
+	// Attendance (percentage of capacity filled, 0-100)
+	result.Merge(ValidateField("Attendance", team.Attendance,
+		IntRange(0, 100),
+	))
 
 	// Support level (0-100)
 	result.Merge(ValidateField("Support", team.Support,
@@ -143,6 +139,7 @@ func ValidateTeam(team *models.Team) *ValidationResult {
 		))
 	}
 
+	result.setDefaultCodes("TEAM")
 	return result
 }
 
@@ -176,7 +173,7 @@ func ValidateTeamField(fieldName string, value interface{}) *ValidationResult {
 
 	case "TeamID":
 		if num, ok := value.(int); ok {
-			result.Merge(ValidateField(fieldName, num, IntRange(0, 31)))
+			result.Merge(ValidateField(fieldName, num, IntRange(1, models.MaxTeams)))
 		}
 
 	case "Conference":
@@ -230,12 +227,7 @@ func ValidateTeamField(fieldName string, value interface{}) *ValidationResult {
 			result.Merge(ValidateField(fieldName, num, IntRange(1, 10)))
 		}
 
-	case "Attendance":
-		if num, ok := value.(int); ok {
-			result.Merge(ValidateField(fieldName, num, IntNonNegative()))
-		}
-
-	case "Support":
+	case "Attendance", "Support":
 		if num, ok := value.(int); ok {
 			result.Merge(ValidateField(fieldName, num, IntRange(0, 100)))
 		}
@@ -251,5 +243,6 @@ func ValidateTeamField(fieldName string, value interface{}) *ValidationResult {
 		}
 	}
 
+	result.setDefaultCodes("TEAM")
 	return result
 }
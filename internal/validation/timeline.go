@@ -0,0 +1,131 @@
+// ABOUTME: Age, experience and career-timeline plausibility checks for the player files
+// ABOUTME: Compares birth dates, YEARENTRY and YEARSIGNED with experience and the league's base year
+
+package validation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+)
+
+// Career timeline bounds. The docs say "a player with four years of
+// experience should probably have a birth year about 26-28 years earlier
+// than the year of the player file", a career start at about 22-24.
+const (
+	minStartingAge     = 20
+	maxStartingAge     = 30
+	typicalStartingAge = 23
+	// Seasons a player may have gone without accruing experience since
+	// entering the league, such as years on practice squads or injured
+	maxMissedSeasons = 5
+)
+
+// career is the timeline of a player or quarterback file entry
+type career struct {
+	birthYear, birthMonth, birthDay int
+	yearEntry, yearSigned           int
+	experience                      int
+	baseYear                        int // The player's own BASE_YEAR, 0 if not set
+}
+
+// ValidateTimeline checks that the birth date, experience, YEARENTRY and
+// YEARSIGNED of every player in both files agree with the league's base
+// year, the season a player enters the universe in. Players of a custom
+// file whose BASE_YEAR differs, such as future draft classes, are checked
+// against their own BASE_YEAR. The docs warn that otherwise "players may
+// retire earlier or later than players should" and "unusual values ...
+// could cause instability in areas where past draft results can be viewed".
+// Every check is a warning carrying a suggested correction:
+//   - PLAYER_BIRTHDATE: a birth date that is not in the calendar, e.g. Feb 30
+//   - PLAYER_BIRTHYEAR_EXPERIENCE: a career that started before 20 or after 30
+//   - PLAYER_YEARENTRY: a YEARENTRY after BASE_YEAR - EXPERIENCE, or more than
+//     5 seasons before it
+//   - PLAYER_YEARSIGNED: a YEARSIGNED after BASE_YEAR
+//
+// Quarterback findings use the QB prefix. A base year of 0 skips the file.
+func ValidateTimeline(playersFile string, players []models.Player, quarterbacksFile string, quarterbacks []models.Quarterback, baseYear int) *ValidationResult {
+	result := NewValidationResult()
+	if baseYear <= 0 {
+		return result
+	}
+
+	for i := range quarterbacks {
+		qb := &quarterbacks[i]
+		found := checkCareer("QB", career{
+			birthYear: qb.BirthYear, birthMonth: qb.BirthMonth, birthDay: qb.BirthDay,
+			yearEntry: qb.YearEntry, yearSigned: qb.YearSigned, experience: qb.Experience,
+			baseYear: qb.BaseYear,
+		}, baseYear)
+		found.locate(quarterbacksFile, i+2, fmt.Sprintf("Player %d (%s)", qb.PlayerID, qb.GetDisplayName()),
+			roster.PlayerKey(qb.PlayerID))
+		result.Merge(found)
+	}
+
+	for i := range players {
+		player := &players[i]
+		found := checkCareer("PLAYER", career{
+			birthYear: player.BirthYear, birthMonth: player.BirthMonth, birthDay: player.BirthDay,
+			yearEntry: player.YearEntry, yearSigned: player.YearSigned, experience: player.Experience,
+			baseYear: player.BaseYear,
+		}, baseYear)
+		found.locate(playersFile, i+2, playerRecord(player), playerKey(player))
+		result.Merge(found)
+	}
+
+	return result
+}
+
+// checkCareer returns the timeline findings of one entry
+func checkCareer(prefix string, c career, baseYear int) *ValidationResult {
+	found := NewValidationResult()
+	add := func(field, code, message, suggestion string) {
+		found.AddFinding(ValidationError{
+			Field: field, Message: message, Severity: SeverityWarning, Code: prefix + code, Suggestion: suggestion,
+		})
+	}
+
+	if c.baseYear > 0 {
+		baseYear = c.baseYear
+	}
+	firstSeason := baseYear - c.experience
+
+	if c.birthYear > 0 && c.birthMonth >= 1 && c.birthMonth <= 12 && c.birthDay >= 1 && c.birthDay <= 31 {
+		if last := daysInMonth(c.birthYear, c.birthMonth); c.birthDay > last {
+			add("BirthDay", "_BIRTHDATE",
+				fmt.Sprintf("%s %d, %d is not a date", time.Month(c.birthMonth), c.birthDay, c.birthYear),
+				fmt.Sprintf("BIRTHDAY %d", last))
+		}
+	}
+
+	if c.birthYear > 0 {
+		if age := firstSeason - c.birthYear; age < minStartingAge || age > maxStartingAge {
+			add("BirthYear", "_BIRTHYEAR_EXPERIENCE",
+				fmt.Sprintf("born in %d with %d years of experience in %d, so the career started at age %d",
+					c.birthYear, c.experience, baseYear, age),
+				fmt.Sprintf("BIRTHYEAR %d", firstSeason-typicalStartingAge))
+		}
+	}
+
+	if c.yearEntry > 0 && (c.yearEntry > firstSeason || c.yearEntry < firstSeason-maxMissedSeasons) {
+		add("YearEntry", "_YEARENTRY",
+			fmt.Sprintf("entered the league in %d but has %d years of experience in %d, a first season of %d",
+				c.yearEntry, c.experience, baseYear, firstSeason),
+			fmt.Sprintf("YEARENTRY %d", firstSeason))
+	}
+
+	if c.yearSigned > baseYear {
+		add("YearSigned", "_YEARSIGNED",
+			fmt.Sprintf("signed in %d, after the %d base year", c.yearSigned, baseYear),
+			fmt.Sprintf("YEARSIGNED %d", baseYear))
+	}
+
+	return found
+}
+
+// daysInMonth returns the number of days in a month, counting leap years
+func daysInMonth(year, month int) int {
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
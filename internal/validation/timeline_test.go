@@ -0,0 +1,100 @@
+// ABOUTME: Tests for the age, experience and career-timeline checks
+// ABOUTME: Covers each timeline rule with its suggested correction and the shipped default data
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestValidateTimeline(t *testing.T) {
+	valid := validPlayer()
+
+	oldRookie := validPlayer()
+	oldRookie.PlayerID = 1010
+	oldRookie.BirthYear = 1980
+
+	badDate := validPlayer()
+	badDate.PlayerID = 1011
+	badDate.BirthMonth, badDate.BirthDay, badDate.BirthYear = 2, 30, 1996
+
+	lateEntry := validPlayer()
+	lateEntry.PlayerID = 1012
+	lateEntry.YearEntry, lateEntry.YearSigned = 2021, 2025
+
+	// A future draft class is checked against its own BASE_YEAR
+	draftee := validPlayer()
+	draftee.PlayerID = 1013
+	draftee.Experience, draftee.BirthYear, draftee.YearEntry, draftee.BaseYear = 0, 2004, 2026, 2026
+
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 501, FirstName: "Aaron", LastName: "Rodgers", BirthMonth: 12, BirthDay: 2, BirthYear: 1983,
+			Experience: 18, YearEntry: 2010},
+	}
+	players := []models.Player{*valid, *oldRookie, *badDate, *lateEntry, *draftee}
+
+	result := ValidateTimeline("2024_players.csv", players, "2024_quarterbacks.csv", quarterbacks, 2024)
+
+	expected := []struct {
+		line       int
+		code       string
+		suggestion string
+	}{
+		{2, "QB_YEARENTRY", "YEARENTRY 2006"},
+		{3, "PLAYER_BIRTHYEAR_EXPERIENCE", "BIRTHYEAR 1996"},
+		{4, "PLAYER_BIRTHDATE", "BIRTHDAY 29"},
+		{5, "PLAYER_YEARENTRY", "YEARENTRY 2019"},
+		{5, "PLAYER_YEARSIGNED", "YEARSIGNED 2024"},
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), result.Errors)
+	}
+	for i, want := range expected {
+		got := result.Errors[i]
+		if got.Line != want.line || got.Code != want.code || got.Suggestion != want.suggestion {
+			t.Errorf("Finding %d: expected line %d %s (%s), got line %d %s (%s)",
+				i, want.line, want.code, want.suggestion, got.Line, got.Code, got.Suggestion)
+		}
+		if got.Severity != SeverityWarning {
+			t.Errorf("Finding %d: expected a warning, got %s", i, got.Severity)
+		}
+	}
+	if got := result.Errors[1].Message; got != "born in 1980 with 5 years of experience in 2024, so the career started at age 39" {
+		t.Errorf("Unexpected message: %s", got)
+	}
+	if got := result.Errors[2].Message; got != "February 30, 1996 is not a date" {
+		t.Errorf("Unexpected message: %s", got)
+	}
+
+	if result := ValidateTimeline("players.csv", players, "", nil, 0); len(result.Errors) != 0 {
+		t.Errorf("Expected no checks without a base year, got %v", result.Errors)
+	}
+}
+
+func TestValidateTimeline_DefaultData(t *testing.T) {
+	players, err := data.LoadPlayers("../../default_data/2024_players.csv")
+	if err != nil {
+		t.Fatalf("LoadPlayers failed: %v", err)
+	}
+	quarterbacks, err := data.LoadQuarterbacks("../../default_data/2024_quarterbacks.csv")
+	if err != nil {
+		t.Fatalf("LoadQuarterbacks failed: %v", err)
+	}
+
+	result := ValidateTimeline("2024_players.csv", players, "2024_quarterbacks.csv", quarterbacks, 2024)
+	counts := make(map[string]int)
+	for _, finding := range result.Errors {
+		counts[finding.Code]++
+	}
+	// The shipped birth dates and signing years are sound; a few careers
+	// start late or have a YEARENTRY outside the expected window
+	if counts["PLAYER_BIRTHDATE"] != 0 || counts["PLAYER_YEARSIGNED"] != 0 || counts["QB_YEARSIGNED"] != 0 {
+		t.Errorf("Expected valid birth dates and signing years, got %v", counts)
+	}
+	if counts["PLAYER_BIRTHYEAR_EXPERIENCE"] != 3 || counts["PLAYER_YEARENTRY"] != 6 || counts["QB_YEARENTRY"] != 2 {
+		t.Errorf("Unexpected timeline findings: %v", counts)
+	}
+}
@@ -0,0 +1,77 @@
+// ABOUTME: Team-scoped uniform number validation across the player and quarterback files
+// ABOUTME: Flags numbers shared by teammates and numbers unusual for a player's position
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/igorilic/fof9editor/internal/models"
+	"github.com/igorilic/fof9editor/internal/roster"
+)
+
+// ValidateUniforms checks the uniform numbers of every team across both
+// files. The docs say "a uniform number should be unique on this team, as no
+// checking is done when starting a new league", so a number worn by an
+// earlier teammate is a PLAYER_UNIFORM_DUP or QB_UNIFORM_DUP warning. A
+// number outside the customary ranges for the position, such as a lineman
+// in the 10s, is a PLAYER_UNIFORM_POSITION or QB_UNIFORM_POSITION warning.
+// Free agents and players without a uniform (-1) are not checked.
+func ValidateUniforms(playersFile string, players []models.Player, quarterbacksFile string, quarterbacks []models.Quarterback) *ValidationResult {
+	result := NewValidationResult()
+
+	worn := make(map[[2]int]roster.Member)
+	for _, member := range roster.Members(players, quarterbacks) {
+		if member.Team == 0 || member.Uniform < 0 {
+			continue
+		}
+
+		prefix, file := "PLAYER", playersFile
+		if member.Quarterback {
+			prefix, file = "QB", quarterbacksFile
+		}
+		found := NewValidationResult()
+
+		slot := [2]int{member.Team, member.Uniform}
+		if other, ok := worn[slot]; ok {
+			found.AddFinding(ValidationError{
+				Field:    "Uniform",
+				Message:  fmt.Sprintf("uniform %d is also worn by %s on team %d", member.Uniform, other.Name, member.Team),
+				Severity: SeverityWarning,
+				Code:     prefix + "_UNIFORM_DUP",
+			})
+		} else {
+			worn[slot] = member
+		}
+
+		if !models.IsConventionalUniform(member.PositionKey, member.Uniform) {
+			found.AddFinding(ValidationError{
+				Field: "Uniform",
+				Message: fmt.Sprintf("uniform %d is unusual for a %s; customary numbers are %s",
+					member.Uniform, models.GetPositionAbbr(member.PositionKey), formatUniformRanges(member.PositionKey)),
+				Severity: SeverityWarning,
+				Code:     prefix + "_UNIFORM_POSITION",
+			})
+		}
+
+		found.locate(file, member.Index+2, fmt.Sprintf("Player %d (%s)", member.PlayerID, member.Name),
+			roster.PlayerKey(member.PlayerID))
+		result.Merge(found)
+	}
+
+	return result
+}
+
+// formatUniformRanges lists a position's customary numbers, e.g. "60-79, 50-59"
+func formatUniformRanges(positionKey int) string {
+	var parts []string
+	for _, r := range models.UniformRanges(positionKey) {
+		if r.Min == r.Max {
+			parts = append(parts, fmt.Sprintf("%d", r.Min))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", r.Min, r.Max))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
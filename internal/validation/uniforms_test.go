@@ -0,0 +1,77 @@
+// ABOUTME: Tests for team-scoped uniform number validation
+// ABOUTME: Covers duplicates across the player and quarterback files and position conventions
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/igorilic/fof9editor/internal/data"
+	"github.com/igorilic/fof9editor/internal/models"
+)
+
+func TestValidateUniforms(t *testing.T) {
+	quarterbacks := []models.Quarterback{
+		{PlayerID: 501, FirstName: "Aaron", LastName: "Rodgers", Team: 20, Uniform: 8},
+	}
+	players := []models.Player{
+		{PlayerID: 1000, FirstName: "Davante", LastName: "Adams", Team: 20, PositionKey: models.PositionFL, Uniform: 8},
+		{PlayerID: 1001, FirstName: "Big", LastName: "Guard", Team: 20, PositionKey: models.PositionLG, Uniform: 14},
+		{PlayerID: 1002, FirstName: "Other", LastName: "Team", Team: 21, PositionKey: models.PositionFL, Uniform: 8},
+		{PlayerID: 1003, FirstName: "Free", LastName: "Agent", PositionKey: models.PositionLG, Uniform: -1},
+	}
+
+	result := ValidateUniforms("2024_players.csv", players, "2024_quarterbacks.csv", quarterbacks)
+
+	expected := []struct {
+		line int
+		code string
+		key  string
+	}{
+		{2, "PLAYER_UNIFORM_DUP", "player:1000"},
+		{3, "PLAYER_UNIFORM_POSITION", "player:1001"},
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Expected %d findings, got %v", len(expected), result.Errors)
+	}
+	for i, want := range expected {
+		got := result.Errors[i]
+		if got.File != "2024_players.csv" || got.Line != want.line || got.Code != want.code || got.RecordKey != want.key {
+			t.Errorf("Finding %d: expected line %d %s (%s), got %s:%d %s (%s)",
+				i, want.line, want.code, want.key, got.File, got.Line, got.Code, got.RecordKey)
+		}
+		if got.Severity != SeverityWarning {
+			t.Errorf("Finding %d: expected a warning, got %s", i, got.Severity)
+		}
+	}
+	if got := result.Errors[0].Message; got != "uniform 8 is also worn by Aaron Rodgers on team 20" {
+		t.Errorf("Unexpected message: %s", got)
+	}
+	if got := result.Errors[1].Message; got != "uniform 14 is unusual for a LG; customary numbers are 60-79, 50-59" {
+		t.Errorf("Unexpected message: %s", got)
+	}
+}
+
+func TestValidateUniforms_DefaultData(t *testing.T) {
+	players, err := data.LoadPlayers("../../default_data/2024_players.csv")
+	if err != nil {
+		t.Fatalf("LoadPlayers failed: %v", err)
+	}
+	quarterbacks, err := data.LoadQuarterbacks("../../default_data/2024_quarterbacks.csv")
+	if err != nil {
+		t.Fatalf("LoadQuarterbacks failed: %v", err)
+	}
+
+	result := ValidateUniforms("2024_players.csv", players, "2024_quarterbacks.csv", quarterbacks)
+	if result.Count(SeverityWarning) != len(result.Errors) {
+		t.Errorf("Expected only warnings, got %v", result.Errors)
+	}
+	for _, err := range result.Errors {
+		if err.Code == "PLAYER_UNIFORM_DUP" || err.Code == "QB_UNIFORM_DUP" {
+			t.Errorf("Expected no duplicate uniforms in the shipped data, got %v", err)
+		}
+	}
+	if len(result.Errors) > 5 {
+		t.Errorf("Expected the shipped numbers to follow the conventions, got %d findings", len(result.Errors))
+	}
+}
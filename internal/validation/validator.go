@@ -3,23 +3,64 @@
 
 package validation
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
-// ValidationError represents a validation error for a specific field
+// Severity is how serious a validation finding is. Only errors make a
+// result invalid; warnings and info flag advisory constraints of the game.
+type Severity int
+
+const (
+	SeverityError   Severity = iota // Breaks a hard game constraint and blocks export
+	SeverityWarning                 // Breaks an advisory constraint ("should")
+	SeverityInfo                    // Worth knowing, such as a BIRTHCITY outside its CITYID
+)
+
+// String returns the severity name
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	default:
+		return "error"
+	}
+}
+
+// ValidationError represents a validation finding for a specific field
 type ValidationError struct {
-	Field   string
-	Message string
+	Field    string
+	Message  string
+	Severity Severity
+	Code     string // Stable rule code, e.g. "PLAYER_UNIFORM_DUP"
+
+	// Suggested correction, when a check can propose one, e.g. "YEARENTRY 2019"
+	Suggestion string
+
+	// Record location, set by checks that span whole files
+	File      string // CSV file name, e.g. "2024_players.csv"
+	Line      int    // 1-based line in the file; the header is line 1
+	Record    string // Record identity, e.g. "Player 1000 (Tom Brady)"
+	RecordKey string // Stable record key for suppressions, e.g. "player:1000"
 }
 
 // Error implements the error interface
 func (e ValidationError) Error() string {
+	if e.File != "" {
+		return fmt.Sprintf("%s:%d: %s %s: %s", e.File, e.Line, e.Record, e.Field, e.Message)
+	}
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
-// ValidationResult holds the result of a validation operation
+// ValidationResult holds the result of a validation operation. Errors holds
+// every finding; Valid is false only if one of them has SeverityError.
 type ValidationResult struct {
-	Valid  bool
-	Errors []ValidationError
+	Valid      bool
+	Errors     []ValidationError
+	Suppressed int // Findings removed by project suppressions
 }
 
 // NewValidationResult creates a new empty validation result
@@ -39,6 +80,79 @@ func (r *ValidationResult) AddError(field, message string) {
 	})
 }
 
+// AddRecordError adds a validation error for a record at a line of a CSV file
+func (r *ValidationResult) AddRecordError(file string, line int, record, field, message string) {
+	r.Valid = false
+	r.Errors = append(r.Errors, ValidationError{
+		Field:   field,
+		Message: message,
+		File:    file,
+		Line:    line,
+		Record:  record,
+	})
+}
+
+// AddFinding adds a finding of any severity. Only errors mark the result as invalid.
+func (r *ValidationResult) AddFinding(finding ValidationError) {
+	if finding.Severity == SeverityError {
+		r.Valid = false
+	}
+	r.Errors = append(r.Errors, finding)
+}
+
+// Count returns the number of findings with the given severity
+func (r *ValidationResult) Count(severity Severity) int {
+	count := 0
+	for _, err := range r.Errors {
+		if err.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// ForFile returns the findings located in one CSV file
+func (r *ValidationResult) ForFile(file string) *ValidationResult {
+	result := NewValidationResult()
+	for _, err := range r.Errors {
+		if err.File == file {
+			result.AddFinding(err)
+		}
+	}
+	return result
+}
+
+// WithSeverity returns the findings with the given severity
+func (r *ValidationResult) WithSeverity(severity Severity) *ValidationResult {
+	result := NewValidationResult()
+	for _, err := range r.Errors {
+		if err.Severity == severity {
+			result.AddFinding(err)
+		}
+	}
+	return result
+}
+
+// setDefaultCodes gives findings without a rule code the code
+// PREFIX_FIELD, e.g. "TEAM_CAPACITY"
+func (r *ValidationResult) setDefaultCodes(prefix string) {
+	for i := range r.Errors {
+		if r.Errors[i].Code == "" {
+			r.Errors[i].Code = prefix + "_" + strings.ToUpper(r.Errors[i].Field)
+		}
+	}
+}
+
+// locate sets the file location and record identity of every finding
+func (r *ValidationResult) locate(file string, line int, record, recordKey string) {
+	for i := range r.Errors {
+		r.Errors[i].File = file
+		r.Errors[i].Line = line
+		r.Errors[i].Record = record
+		r.Errors[i].RecordKey = recordKey
+	}
+}
+
 // HasError checks if a specific field has an error
 func (r *ValidationResult) HasError(field string) bool {
 	for _, err := range r.Errors {
@@ -59,12 +173,13 @@ func (r *ValidationResult) GetError(field string) string {
 	return ""
 }
 
-// Merge combines two validation results
+// Merge combines two validation results, keeping warnings and info of valid results
 func (r *ValidationResult) Merge(other *ValidationResult) {
 	if !other.Valid {
 		r.Valid = false
-		r.Errors = append(r.Errors, other.Errors...)
 	}
+	r.Errors = append(r.Errors, other.Errors...)
+	r.Suppressed += other.Suppressed
 }
 
 // FieldValidator is a function that validates a field value
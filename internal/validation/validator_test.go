@@ -102,3 +102,46 @@ func TestValidationError(t *testing.T) {
 		t.Errorf("Expected '%s', got '%s'", expected, err.Error())
 	}
 }
+
+func TestValidationError_Record(t *testing.T) {
+	result := NewValidationResult()
+	result.AddRecordError("2024_players.csv", 12, "Player 1000 (Tom Brady)", "TEAM", "team 40 does not exist in 2024")
+
+	if result.Valid {
+		t.Error("Expected result to be invalid")
+	}
+
+	expected := "2024_players.csv:12: Player 1000 (Tom Brady) TEAM: team 40 does not exist in 2024"
+	if got := result.Errors[0].Error(); got != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, got)
+	}
+}
+
+func TestAddFinding_Severity(t *testing.T) {
+	result := NewValidationResult()
+	result.AddFinding(ValidationError{Field: "Uniform", Message: "duplicate", Severity: SeverityWarning, Code: "PLAYER_UNIFORM_DUP"})
+	result.AddFinding(ValidationError{Field: "Speed", Message: "high", Severity: SeverityInfo, Code: "PLAYER_RATING_HIGH"})
+	if !result.Valid {
+		t.Error("Expected warnings and info to keep the result valid")
+	}
+
+	other := NewValidationResult()
+	other.AddFinding(ValidationError{Field: "Team", Message: "missing", Code: "PLAYER_TEAM_REF", File: "players.csv"})
+	result.Merge(other)
+	if result.Valid {
+		t.Error("Expected an error to make the result invalid")
+	}
+
+	if result.Count(SeverityError) != 1 || result.Count(SeverityWarning) != 1 || result.Count(SeverityInfo) != 1 {
+		t.Errorf("Unexpected counts in %v", result.Errors)
+	}
+	if errs := result.WithSeverity(SeverityError); errs.Valid || len(errs.Errors) != 1 {
+		t.Errorf("Expected one error, got %v", errs.Errors)
+	}
+	if found := result.ForFile("players.csv"); len(found.Errors) != 1 || found.Errors[0].Code != "PLAYER_TEAM_REF" {
+		t.Errorf("Expected the players.csv finding, got %v", found.Errors)
+	}
+	if SeverityWarning.String() != "warning" {
+		t.Errorf("Expected warning, got %s", SeverityWarning)
+	}
+}